@@ -8,11 +8,13 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 
 	"github.com/ryichk/ai-agents-sdk-go/guardrail"
 	"github.com/ryichk/ai-agents-sdk-go/handoff"
 	"github.com/ryichk/ai-agents-sdk-go/interfaces"
 	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/prompt"
 	"github.com/ryichk/ai-agents-sdk-go/tool"
 )
 
@@ -22,6 +24,9 @@ type InstructionsFunc func(ctx context.Context) string
 // AsyncInstructionsFunc is a function type that generates dynamic instructions asynchronously
 type AsyncInstructionsFunc func(ctx context.Context) (string, error)
 
+// PromptFunc is a function type that selects the stored prompt, e.g. its variables, for each turn
+type PromptFunc func(ctx context.Context) (*model.Prompt, error)
+
 // An agent is an AI model configured with instructions, tools, guardrails, and handoffs and more.
 //
 // We strongly recommend passing `instructions`, which is the "system prompt" for the agent.
@@ -42,6 +47,10 @@ type Agent struct {
 	// asyncDynamicInstructions) to handle dynamic instruction generation.
 	Instructions string
 
+	// A prompt stored on the server, used with or instead of Instructions.
+	// It is resolved by RunConfig.PromptResolver, or by the provider when the run has none.
+	Prompt *model.Prompt
+
 	// A description of the agent.
 	// This is used when the agent is used as a handoff, so that an LLM knows what it does and when to invoke it.
 	HandoffDescription string
@@ -55,6 +64,10 @@ type Agent struct {
 	// By default, if not set, the agent will use the default model configured in `runner.DefaultRunConfig`.
 	Model string
 
+	// The provider to call this agent's model with, so one run can mix providers across the
+	// agents of a handoff graph. By default, the run's `RunConfig.ModelProvider` is used.
+	ModelProvider model.Provider
+
 	// Configures model-specific tuning parameters (e.g. Temperature, TopP).
 	ModelSettings model.Settings
 
@@ -69,9 +82,26 @@ type Agent struct {
 	// Runs only if the agent produces a final output.
 	OutputGuardrails []guardrail.OutputGuardrail
 
+	// A list of checks that run on the arguments of every tool call, before the tool is invoked.
+	ToolInputGuardrails []guardrail.ToolInputGuardrail
+
+	// A list of checks that run on the output of every tool call, before it is sent to the model.
+	ToolOutputGuardrails []guardrail.ToolOutputGuardrail
+
+	// A list of checks that run on every message the model returns, including the ones calling tools.
+	TurnGuardrails []guardrail.TurnGuardrail
+
 	// The type of the output object. If not provided, the output will be `string`.
 	OutputType reflect.Type
 
+	// How the model returns an OutputType: as a JSON response (the default) or by calling a
+	// final_output tool.
+	OutputMode OutputMode
+
+	// Whether the model must call a tool before the agent can give its final output, as RAG
+	// agents must retrieve before answering.
+	ToolUse ToolUsePolicy
+
 	// A interface that receives callbacks on various lifecycle events for this agent.
 	Hooks Hooks
 
@@ -84,6 +114,45 @@ type Agent struct {
 	// This is part of the Go implementation's approach to handle coroutine functions
 	// (async def) for instructions in the Python SDK.
 	asyncDynamicInstructions AsyncInstructionsFunc
+
+	// dynamicPrompt selects the stored prompt for each turn
+	dynamicPrompt PromptFunc
+
+	// instructionsTemplate renders the instructions with the data of instructionsData
+	instructionsTemplate *prompt.Template
+	instructionsData     prompt.DataFunc
+
+	// mu guards the fields changed by the agent's methods
+	mu sync.RWMutex
+
+	// origin is the agent a snapshot was taken from
+	origin *Agent
+
+	// frozen marks snapshots, which cannot be modified
+	frozen bool
+}
+
+// OutputMode selects how an agent with an OutputType produces its final output
+type OutputMode string
+
+const (
+	// OutputModeResponseFormat asks for a JSON response matching the output type's schema
+	OutputModeResponseFormat OutputMode = ""
+
+	// OutputModeTool makes the model call a final_output tool whose parameters are the output
+	// type's schema, which is more reliable on models without json_schema response formats
+	OutputModeTool OutputMode = "tool"
+)
+
+// ToolUsePolicy makes the runner enforce tool use. When the model answers without having called
+// a tool since the agent took over, the answer is rejected and the model is asked again with
+// tool_choice "required", up to MaxRetries times; then the run fails with runner.ErrToolUseRequired.
+type ToolUsePolicy struct {
+	// Required enables the policy
+	Required bool
+
+	// MaxRetries is the number of times the model is asked again
+	MaxRetries int
 }
 
 func New(name string, instructions string) *Agent {
@@ -97,38 +166,117 @@ func New(name string, instructions string) *Agent {
 }
 
 func (a *Agent) AddTool(tool tool.Tool) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.Tools = append(a.Tools, tool)
 }
 
+// AddTools adds several tools, such as the ones selected from a tool.Registry
+func (a *Agent) AddTools(tools ...tool.Tool) {
+	a.lock()
+	defer a.mu.Unlock()
+
+	a.Tools = append(a.Tools, tools...)
+}
+
 func (a *Agent) AddHandoff(handoff handoff.Handoff) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.Handoffs = append(a.Handoffs, handoff)
 }
 
 func (a *Agent) AddHandoffs(handoffs ...handoff.Handoff) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.Handoffs = append(a.Handoffs, handoffs...)
 }
 
 func (a *Agent) AddInputGuardrail(guardrail guardrail.InputGuardrail) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.InputGuardrails = append(a.InputGuardrails, guardrail)
 }
 
 func (a *Agent) AddOutputGuardrail(guardrail guardrail.OutputGuardrail) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.OutputGuardrails = append(a.OutputGuardrails, guardrail)
 }
 
+func (a *Agent) AddToolInputGuardrail(guardrail guardrail.ToolInputGuardrail) {
+	a.lock()
+	defer a.mu.Unlock()
+
+	a.ToolInputGuardrails = append(a.ToolInputGuardrails, guardrail)
+}
+
+func (a *Agent) AddToolOutputGuardrail(guardrail guardrail.ToolOutputGuardrail) {
+	a.lock()
+	defer a.mu.Unlock()
+
+	a.ToolOutputGuardrails = append(a.ToolOutputGuardrails, guardrail)
+}
+
+func (a *Agent) AddTurnGuardrail(guardrail guardrail.TurnGuardrail) {
+	a.lock()
+	defer a.mu.Unlock()
+
+	a.TurnGuardrails = append(a.TurnGuardrails, guardrail)
+}
+
 func (a *Agent) SetModel(model string) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.Model = model
 }
 
+func (a *Agent) SetModelProvider(provider model.Provider) {
+	a.lock()
+	defer a.mu.Unlock()
+
+	a.ModelProvider = provider
+}
+
 func (a *Agent) SetModelSettings(settings model.Settings) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.ModelSettings = settings
 }
 
 func (a *Agent) SetOutputType(outputType reflect.Type) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.OutputType = outputType
 }
 
+// SetOutputMode sets how the model returns the agent's OutputType
+func (a *Agent) SetOutputMode(mode OutputMode) {
+	a.lock()
+	defer a.mu.Unlock()
+
+	a.OutputMode = mode
+}
+
+// SetToolUse sets the tool use policy of the agent
+func (a *Agent) SetToolUse(policy ToolUsePolicy) {
+	a.lock()
+	defer a.mu.Unlock()
+
+	a.ToolUse = policy
+}
+
 func (a *Agent) SetHooks(hooks Hooks) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.Hooks = hooks
 }
 
@@ -136,6 +284,9 @@ func (a *Agent) SetHooks(hooks Hooks) {
 // In the Python SDK, this functionality is achieved by directly assigning
 // a callable to the instructions field.
 func (a *Agent) SetDynamicInstructions(f InstructionsFunc) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.dynamicInstructions = f
 }
 
@@ -143,18 +294,73 @@ func (a *Agent) SetDynamicInstructions(f InstructionsFunc) {
 // In the Python SDK, this functionality is achieved by directly assigning
 // an async callable (coroutine function) to the instructions field.
 func (a *Agent) SetAsyncDynamicInstructions(f AsyncInstructionsFunc) {
+	a.lock()
+	defer a.mu.Unlock()
+
 	a.asyncDynamicInstructions = f
 }
 
+// SetPrompt sets the stored prompt of the agent
+func (a *Agent) SetPrompt(prompt *model.Prompt) {
+	a.lock()
+	defer a.mu.Unlock()
+
+	a.Prompt = prompt
+}
+
+// SetDynamicPrompt sets a function that selects the stored prompt for each turn
+func (a *Agent) SetDynamicPrompt(f PromptFunc) {
+	a.lock()
+	defer a.mu.Unlock()
+
+	a.dynamicPrompt = f
+}
+
+// GetPrompt returns the stored prompt of the agent, or nil if it has none
+func (a *Agent) GetPrompt(ctx context.Context) (*model.Prompt, error) {
+	a.mu.RLock()
+	dynamicPrompt, stored := a.dynamicPrompt, a.Prompt
+	a.mu.RUnlock()
+
+	if dynamicPrompt != nil {
+		return dynamicPrompt(ctx)
+	}
+	return stored, nil
+}
+
+// HasPrompt reports whether the agent has a stored prompt or a function selecting one
+func (a *Agent) HasPrompt() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.Prompt != nil || a.dynamicPrompt != nil
+}
+
+// SetInstructionsTemplate sets a template that renders the instructions every time the system
+// prompt is built. data provides the template's .Data (optional).
+func (a *Agent) SetInstructionsTemplate(tmpl *prompt.Template, data prompt.DataFunc) {
+	a.lock()
+	defer a.mu.Unlock()
+
+	a.instructionsTemplate = tmpl
+	a.instructionsData = data
+}
+
 // GetName returns the agent name
 // Implements interfaces.Agent interface
 func (a *Agent) GetName() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
 	return a.Name
 }
 
 // GetDescription returns the agent description
 // Implements interfaces.Agent interface
 func (a *Agent) GetDescription() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
 	if a.HandoffDescription != "" {
 		return a.HandoffDescription
 	}
@@ -187,11 +393,41 @@ func (a *Agent) AsTool(runner any, options ...tool.AgentToolOption) (tool.Tool,
 // While the Python version can inspect the type of instructions at runtime,
 // the Go version uses predefined fields for different types of instruction sources.
 func (a *Agent) GetSystemPrompt(ctx context.Context) (string, error) {
+	// The instructions functions run on a snapshot, so they can modify the agent
+	if !a.frozen {
+		return a.Snapshot().GetSystemPrompt(ctx)
+	}
+
 	if a.asyncDynamicInstructions != nil {
 		return a.asyncDynamicInstructions(ctx)
 	}
 	if a.dynamicInstructions != nil {
 		return a.dynamicInstructions(ctx), nil
 	}
+	if a.instructionsTemplate != nil {
+		return a.renderInstructions(ctx)
+	}
 	return a.Instructions, nil
 }
+
+// renderInstructions renders the instructions template with the agent's tools, handoffs and data
+func (a *Agent) renderInstructions(ctx context.Context) (string, error) {
+	c := prompt.Context{
+		Agent: prompt.AgentInfo{Name: a.Name},
+	}
+	for _, t := range a.Tools {
+		c.Agent.Tools = append(c.Agent.Tools, prompt.ToolInfo{Name: t.Name(), Description: t.Description()})
+	}
+	for _, h := range a.Handoffs {
+		c.Agent.Handoffs = append(c.Agent.Handoffs, prompt.HandoffInfo{Name: h.ToolName(), Description: h.ToolDescription()})
+	}
+	if a.instructionsData != nil {
+		data, err := a.instructionsData(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get instructions data: %w", err)
+		}
+		c.Data = data
+	}
+
+	return a.instructionsTemplate.Render(c)
+}
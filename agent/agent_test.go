@@ -6,12 +6,14 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/ryichk/ai-agents-sdk-go/guardrail"
 	"github.com/ryichk/ai-agents-sdk-go/handoff"
 	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/prompt"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -238,3 +240,36 @@ func TestCompleteAgent(t *testing.T) {
 	assert.Equal(t, 0.7, agent.ModelSettings.Temperature)
 	assert.Equal(t, 1000, agent.ModelSettings.MaxTokens)
 }
+
+func TestInstructionsTemplate(t *testing.T) {
+	billing := New("billing", "Handles billing")
+	support := New("support", "")
+	support.AddTool(&MockTool{name: "lookup_order", description: "Looks up an order"})
+	support.AddHandoff(handoff.NewHandoff(billing, "Billing questions"))
+
+	tmpl := prompt.Must(prompt.New("support", `You are {{.Agent.Name}} for {{.Data}}.
+{{template "tool_guidance" .}}
+{{- range .Agent.Handoffs}}{{.Name}}{{end}}`))
+	support.SetInstructionsTemplate(tmpl, func(ctx context.Context) (any, error) {
+		return "Acme", nil
+	})
+
+	instructions, err := support.GetSystemPrompt(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, instructions, "You are support for Acme.")
+	assert.Contains(t, instructions, "- lookup_order: Looks up an order")
+	assert.Contains(t, instructions, support.Handoffs[0].ToolName())
+
+	// Clones keep the template
+	cloned := support.Clone(WithName("support-2"))
+	instructions, err = cloned.GetSystemPrompt(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, instructions, "You are support-2 for Acme.")
+
+	// Data errors fail the system prompt
+	failing := support.Clone(WithInstructionsTemplate(tmpl, func(ctx context.Context) (any, error) {
+		return nil, errors.New("customer not found")
+	}))
+	_, err = failing.GetSystemPrompt(context.Background())
+	assert.ErrorContains(t, err, "customer not found")
+}
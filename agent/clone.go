@@ -5,11 +5,14 @@
 package agent
 
 import (
+	"maps"
 	"reflect"
+	"slices"
 
 	"github.com/ryichk/ai-agents-sdk-go/guardrail"
 	"github.com/ryichk/ai-agents-sdk-go/handoff"
 	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/prompt"
 	"github.com/ryichk/ai-agents-sdk-go/tool"
 )
 
@@ -39,6 +42,13 @@ func WithModel(model string) CloneOption {
 	}
 }
 
+// WithModelProvider sets the provider of the agent's model
+func WithModelProvider(provider model.Provider) CloneOption {
+	return func(a *Agent) {
+		a.ModelProvider = provider
+	}
+}
+
 func WithModelSettings(settings model.Settings) CloneOption {
 	return func(a *Agent) {
 		a.ModelSettings = settings
@@ -65,6 +75,13 @@ func WithOutputType(outputType reflect.Type) CloneOption {
 	}
 }
 
+// WithToolUse sets the tool use policy of the agent
+func WithToolUse(policy ToolUsePolicy) CloneOption {
+	return func(a *Agent) {
+		a.ToolUse = policy
+	}
+}
+
 // WithHooks sets the hooks of the agent
 func WithHooks(hooks Hooks) CloneOption {
 	return func(a *Agent) {
@@ -85,6 +102,27 @@ func WithOutputGuardrails(guardrails []guardrail.OutputGuardrail) CloneOption {
 	}
 }
 
+func WithToolInputGuardrails(guardrails []guardrail.ToolInputGuardrail) CloneOption {
+	return func(a *Agent) {
+		a.ToolInputGuardrails = make([]guardrail.ToolInputGuardrail, len(guardrails))
+		copy(a.ToolInputGuardrails, guardrails)
+	}
+}
+
+func WithToolOutputGuardrails(guardrails []guardrail.ToolOutputGuardrail) CloneOption {
+	return func(a *Agent) {
+		a.ToolOutputGuardrails = make([]guardrail.ToolOutputGuardrail, len(guardrails))
+		copy(a.ToolOutputGuardrails, guardrails)
+	}
+}
+
+func WithTurnGuardrails(guardrails []guardrail.TurnGuardrail) CloneOption {
+	return func(a *Agent) {
+		a.TurnGuardrails = make([]guardrail.TurnGuardrail, len(guardrails))
+		copy(a.TurnGuardrails, guardrails)
+	}
+}
+
 func WithDynamicInstructions(fn InstructionsFunc) CloneOption {
 	return func(a *Agent) {
 		a.dynamicInstructions = fn
@@ -97,6 +135,28 @@ func WithAsyncDynamicInstructions(fn AsyncInstructionsFunc) CloneOption {
 	}
 }
 
+// WithPrompt sets the stored prompt of the agent
+func WithPrompt(prompt *model.Prompt) CloneOption {
+	return func(a *Agent) {
+		a.Prompt = prompt
+	}
+}
+
+// WithDynamicPrompt sets a function that selects the stored prompt for each turn
+func WithDynamicPrompt(fn PromptFunc) CloneOption {
+	return func(a *Agent) {
+		a.dynamicPrompt = fn
+	}
+}
+
+// WithInstructionsTemplate sets a template that renders the instructions with the data of data (optional)
+func WithInstructionsTemplate(tmpl *prompt.Template, data prompt.DataFunc) CloneOption {
+	return func(a *Agent) {
+		a.instructionsTemplate = tmpl
+		a.instructionsData = data
+	}
+}
+
 // Make a copy of the agent, with the given arguments changed.
 // For example, you could do:
 // ```
@@ -107,24 +167,9 @@ func WithAsyncDynamicInstructions(fn AsyncInstructionsFunc) CloneOption {
 // )
 // ```
 func (a *Agent) Clone(opts ...CloneOption) *Agent {
-	cloned := &Agent{
-		Name:               a.Name,
-		Instructions:       a.Instructions,
-		HandoffDescription: a.HandoffDescription,
-		Model:              a.Model,
-		ModelSettings:      a.ModelSettings,
-		Tools:              make([]tool.Tool, len(a.Tools)),
-		Handoffs:           make([]handoff.Handoff, len(a.Handoffs)),
-		InputGuardrails:    make([]guardrail.InputGuardrail, len(a.InputGuardrails)),
-		OutputGuardrails:   make([]guardrail.OutputGuardrail, len(a.OutputGuardrails)),
-		OutputType:         a.OutputType,
-		Hooks:              a.Hooks,
-	}
-
-	copy(cloned.Tools, a.Tools)
-	copy(cloned.Handoffs, a.Handoffs)
-	copy(cloned.InputGuardrails, a.InputGuardrails)
-	copy(cloned.OutputGuardrails, a.OutputGuardrails)
+	a.mu.RLock()
+	cloned := a.copyLocked()
+	a.mu.RUnlock()
 
 	// Apply any options to modify the cloned agent
 	for _, opt := range opts {
@@ -133,3 +178,39 @@ func (a *Agent) Clone(opts ...CloneOption) *Agent {
 
 	return cloned
 }
+
+// copyLocked copies the agent, with its own lists and settings maps. The caller holds a.mu.
+func (a *Agent) copyLocked() *Agent {
+	settings := a.ModelSettings
+	settings.StopSequences = slices.Clone(settings.StopSequences)
+	settings.Tools = slices.Clone(settings.Tools)
+	settings.LogitBias = maps.Clone(settings.LogitBias)
+	settings.Metadata = maps.Clone(settings.Metadata)
+	settings.Custom = maps.Clone(settings.Custom)
+
+	return &Agent{
+		Name:                     a.Name,
+		Instructions:             a.Instructions,
+		Prompt:                   a.Prompt,
+		HandoffDescription:       a.HandoffDescription,
+		Model:                    a.Model,
+		ModelProvider:            a.ModelProvider,
+		ModelSettings:            settings,
+		Tools:                    append(make([]tool.Tool, 0, len(a.Tools)), a.Tools...),
+		Handoffs:                 append(make([]handoff.Handoff, 0, len(a.Handoffs)), a.Handoffs...),
+		InputGuardrails:          append(make([]guardrail.InputGuardrail, 0, len(a.InputGuardrails)), a.InputGuardrails...),
+		OutputGuardrails:         append(make([]guardrail.OutputGuardrail, 0, len(a.OutputGuardrails)), a.OutputGuardrails...),
+		ToolInputGuardrails:      append(make([]guardrail.ToolInputGuardrail, 0, len(a.ToolInputGuardrails)), a.ToolInputGuardrails...),
+		ToolOutputGuardrails:     append(make([]guardrail.ToolOutputGuardrail, 0, len(a.ToolOutputGuardrails)), a.ToolOutputGuardrails...),
+		TurnGuardrails:           append(make([]guardrail.TurnGuardrail, 0, len(a.TurnGuardrails)), a.TurnGuardrails...),
+		OutputType:               a.OutputType,
+		OutputMode:               a.OutputMode,
+		ToolUse:                  a.ToolUse,
+		Hooks:                    a.Hooks,
+		dynamicInstructions:      a.dynamicInstructions,
+		asyncDynamicInstructions: a.asyncDynamicInstructions,
+		dynamicPrompt:            a.dynamicPrompt,
+		instructionsTemplate:     a.instructionsTemplate,
+		instructionsData:         a.instructionsData,
+	}
+}
@@ -7,6 +7,7 @@ package agent
 import (
 	"context"
 
+	"github.com/ryichk/ai-agents-sdk-go/model"
 	"github.com/ryichk/ai-agents-sdk-go/tool"
 )
 
@@ -28,6 +29,27 @@ type Hooks interface {
 	OnToolEnd(ctx context.Context, agent *Agent, tool tool.Tool, output string) error
 }
 
+// LLMHooks is an optional interface that Hooks implementations can satisfy
+// to receive callbacks immediately before and after each model provider call
+type LLMHooks interface {
+	// OnLLMStart is called before the model provider is invoked
+	OnLLMStart(ctx context.Context, agent *Agent, messages []model.Message, settings model.Settings) error
+
+	// OnLLMEnd is called after the model provider returns, with either the response or the error
+	OnLLMEnd(ctx context.Context, agent *Agent, response *model.Response, err error) error
+}
+
+// ToolCallHooks is an optional interface that Hooks implementations can satisfy to receive
+// the model's tool call, with its ID and raw arguments, around each tool execution.
+// The ID matches the tool_call_id of the tool_call span and the provider's records.
+type ToolCallHooks interface {
+	// OnToolCallStart is called after OnToolStart, before the tool is invoked
+	OnToolCallStart(ctx context.Context, agent *Agent, tool tool.Tool, call model.ToolCall) error
+
+	// OnToolCallEnd is called after OnToolEnd, with the tool's output
+	OnToolCallEnd(ctx context.Context, agent *Agent, tool tool.Tool, call model.ToolCall, output string) error
+}
+
 // BaseAgentHooks provides a basic implementation of the Hooks interface
 type BaseAgentHooks struct{}
 
@@ -50,3 +72,19 @@ func (h *BaseAgentHooks) OnToolStart(ctx context.Context, agent *Agent, tool too
 func (h *BaseAgentHooks) OnToolEnd(ctx context.Context, agent *Agent, tool tool.Tool, output string) error {
 	return nil
 }
+
+func (h *BaseAgentHooks) OnLLMStart(ctx context.Context, agent *Agent, messages []model.Message, settings model.Settings) error {
+	return nil
+}
+
+func (h *BaseAgentHooks) OnLLMEnd(ctx context.Context, agent *Agent, response *model.Response, err error) error {
+	return nil
+}
+
+func (h *BaseAgentHooks) OnToolCallStart(ctx context.Context, agent *Agent, tool tool.Tool, call model.ToolCall) error {
+	return nil
+}
+
+func (h *BaseAgentHooks) OnToolCallEnd(ctx context.Context, agent *Agent, tool tool.Tool, call model.ToolCall, output string) error {
+	return nil
+}
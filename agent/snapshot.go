@@ -0,0 +1,51 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package agent
+
+import "fmt"
+
+// Snapshot returns a frozen copy of the agent, which the runner works on so that an agent can be
+// modified while runs of it are in progress. The snapshot has its own lists and settings maps, and
+// panics if it is modified through its methods. Tools, handoffs, guardrails and hooks are shared
+// with the agent.
+//
+// Agents are safe for concurrent use as long as they are modified through their methods (AddTool,
+// SetModel, ...) rather than by assigning their fields. Changes made during a run apply from the
+// next run, or from the next handoff to the agent.
+func (a *Agent) Snapshot() *Agent {
+	if a.frozen {
+		return a
+	}
+
+	a.mu.RLock()
+	snapshot := a.copyLocked()
+	a.mu.RUnlock()
+
+	snapshot.origin = a
+	snapshot.frozen = true
+	return snapshot
+}
+
+// Origin returns the agent a snapshot was taken from, or the agent itself if it is not a snapshot
+func (a *Agent) Origin() *Agent {
+	if a.origin != nil {
+		return a.origin
+	}
+	return a
+}
+
+// IsSnapshot reports whether the agent is a frozen snapshot
+func (a *Agent) IsSnapshot() bool {
+	return a.frozen
+}
+
+// lock locks the agent to modify it, panicking if it is a snapshot
+func (a *Agent) lock() {
+	a.mu.Lock()
+	if a.frozen {
+		a.mu.Unlock()
+		panic(fmt.Sprintf("agent %q is a snapshot and cannot be modified; modify the original agent or a Clone", a.Name))
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package agent
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestSnapshot(t *testing.T) {
+	a := New("support", "Help the user")
+	a.SetModel("gpt-4o")
+	a.SetModelSettings(model.Settings{Metadata: map[string]string{"team": "support"}, LogitBias: map[string]int{"1734": -100}})
+	a.SetDynamicInstructions(func(ctx context.Context) string { return "Dynamic" })
+
+	snapshot := a.Snapshot()
+	assert.True(t, snapshot.IsSnapshot())
+	assert.False(t, a.IsSnapshot())
+	assert.Same(t, a, snapshot.Origin())
+	assert.Same(t, a, a.Origin())
+	assert.Same(t, snapshot, snapshot.Snapshot())
+
+	// The snapshot keeps the state of the agent when it was taken
+	a.SetModel("gpt-4o-mini")
+	a.ModelSettings.Metadata["team"] = "sales"
+	a.ModelSettings.LogitBias["1734"] = 100
+	assert.Equal(t, "gpt-4o", snapshot.Model)
+	assert.Equal(t, "support", snapshot.ModelSettings.Metadata["team"])
+	assert.Equal(t, -100, snapshot.ModelSettings.LogitBias["1734"])
+
+	instructions, err := snapshot.GetSystemPrompt(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Dynamic", instructions)
+
+	assert.PanicsWithValue(t, `agent "support" is a snapshot and cannot be modified; modify the original agent or a Clone`, func() {
+		snapshot.SetModel("o3")
+	})
+
+	// Clones of snapshots are regular agents
+	clone := snapshot.Clone()
+	assert.False(t, clone.IsSnapshot())
+	clone.SetModel("o3")
+	assert.Equal(t, "o3", clone.Model)
+}
+
+func TestAgentConcurrentUse(t *testing.T) {
+	a := New("support", "Help the user")
+
+	// Run with -race: snapshots and reads may happen while the agent is modified
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			a.SetModel("gpt-4o")
+			a.AddInputGuardrail(nil)
+			a.SetModelSettings(model.Settings{Temperature: 0.5})
+		}()
+		go func() {
+			defer wg.Done()
+			snapshot := a.Snapshot()
+			_ = snapshot.Model
+			_ = len(snapshot.InputGuardrails)
+			_, _ = a.GetSystemPrompt(context.Background())
+			_ = a.GetDescription()
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, a.InputGuardrails, 10)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package agenthttp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+// Names of the server-sent events of a streamed run
+const (
+	// EventRunStarted is sent first, with the session ID
+	EventRunStarted = "run_started"
+
+	// EventTextDelta carries text the model produced. The runner does not stream tokens, so each
+	// model response that contains text is sent as one delta.
+	EventTextDelta = "text_delta"
+
+	// EventToolCallStarted is sent before a tool is invoked, with its arguments
+	EventToolCallStarted = "tool_call_started"
+
+	// EventToolCallCompleted is sent after a tool returns, with its output
+	EventToolCallCompleted = "tool_call_completed"
+
+	// EventToolProgress carries a progress report of a running tool
+	EventToolProgress = "tool_progress"
+
+	// EventHandoff is sent when the conversation is transferred to another agent
+	EventHandoff = "handoff"
+
+	// EventRunCompleted is sent last, with the run's result in the runner's wire format
+	EventRunCompleted = "run_completed"
+
+	// EventError is sent last when the run fails
+	EventError = "error"
+)
+
+// TextDelta is the data of a text_delta event
+type TextDelta struct {
+	Agent   string `json:"agent"`
+	Content string `json:"content"`
+}
+
+// ToolCallEvent is the data of tool_call_started and tool_call_completed events
+type ToolCallEvent struct {
+	Agent     string `json:"agent"`
+	Tool      string `json:"tool"`
+	CallID    string `json:"call_id"`
+	Arguments string `json:"arguments"`
+	Output    string `json:"output,omitempty"`
+}
+
+// HandoffEvent is the data of a handoff event
+type HandoffEvent struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ErrorEvent is the data of an error event
+type ErrorEvent struct {
+	Message string `json:"message"`
+
+	// TraceID is the trace of the failed run, if it was traced
+	TraceID string `json:"trace_id,omitempty"`
+}
+
+// sseWriter writes server-sent events; it is safe for concurrent use since tools may run in parallel.
+// A tool abandoned by the run may still report progress after the handler returned, when the
+// ResponseWriter must no longer be used, so events sent after close are dropped.
+type sseWriter struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+	closed  bool
+}
+
+func newSSEWriter(w http.ResponseWriter) *sseWriter {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	s := &sseWriter{w: w, flusher: flusher}
+	s.flush()
+	return s
+}
+
+// send writes an event with its data encoded as JSON
+func (s *sseWriter) send(event string, data any) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		encoded, _ = json.Marshal(ErrorEvent{Message: err.Error()})
+		event = EventError
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, encoded)
+	s.flush()
+}
+
+// close drops the events sent afterwards; the handler calls it before returning
+func (s *sseWriter) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+func (s *sseWriter) flush() {
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+}
+
+// eventHooks sends the model's text and tool calls of a run as events, then calls the hooks it wraps
+type eventHooks struct {
+	events   *sseWriter
+	llm      agent.LLMHooks
+	toolCall agent.ToolCallHooks
+}
+
+func (h *eventHooks) OnLLMStart(ctx context.Context, a *agent.Agent, messages []model.Message, settings model.Settings) error {
+	if h.llm != nil {
+		return h.llm.OnLLMStart(ctx, a, messages, settings)
+	}
+	return nil
+}
+
+func (h *eventHooks) OnLLMEnd(ctx context.Context, a *agent.Agent, response *model.Response, err error) error {
+	if response != nil && response.Message.Content != "" {
+		h.events.send(EventTextDelta, TextDelta{Agent: a.Name, Content: response.Message.Content})
+	}
+	if h.llm != nil {
+		return h.llm.OnLLMEnd(ctx, a, response, err)
+	}
+	return nil
+}
+
+func (h *eventHooks) OnToolCallStart(ctx context.Context, a *agent.Agent, t tool.Tool, call model.ToolCall) error {
+	h.events.send(EventToolCallStarted, ToolCallEvent{
+		Agent:     a.Name,
+		Tool:      t.Name(),
+		CallID:    call.ID,
+		Arguments: call.Function.Arguments,
+	})
+	if h.toolCall != nil {
+		return h.toolCall.OnToolCallStart(ctx, a, t, call)
+	}
+	return nil
+}
+
+func (h *eventHooks) OnToolCallEnd(ctx context.Context, a *agent.Agent, t tool.Tool, call model.ToolCall, output string) error {
+	h.events.send(EventToolCallCompleted, ToolCallEvent{
+		Agent:     a.Name,
+		Tool:      t.Name(),
+		CallID:    call.ID,
+		Arguments: call.Function.Arguments,
+		Output:    output,
+	})
+	if h.toolCall != nil {
+		return h.toolCall.OnToolCallEnd(ctx, a, t, call, output)
+	}
+	return nil
+}
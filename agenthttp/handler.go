@@ -0,0 +1,309 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package agenthttp serves agents over HTTP. A Handler runs the agent for each posted message,
+// keeps the conversation of each chat session between requests, and can stream the run's
+// events to the client as server-sent events.
+package agenthttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// Errors returned by the handler
+var (
+	// ErrShuttingDown is reported to requests made after Shutdown was called
+	ErrShuttingDown = errors.New("server is shutting down")
+
+	// ErrSessionBusy is reported when a message is posted to a session that is still running
+	ErrSessionBusy = errors.New("session is already running")
+
+	// ErrEmptyInput is reported when the posted message has no input
+	ErrEmptyInput = errors.New("input is required")
+)
+
+// DefaultSessionTTL is how long the default session store keeps idle sessions
+const DefaultSessionTTL = 30 * time.Minute
+
+// RunRequest is the JSON body of a POST request
+type RunRequest struct {
+	// SessionID continues an existing session; a new session is created if it is empty
+	SessionID string `json:"session_id,omitempty"`
+
+	// Input is the user's message
+	Input string `json:"input"`
+}
+
+// RunResponse is the JSON body of the response to a non-streaming POST request
+type RunResponse struct {
+	// SessionID identifies the session to continue in the next request
+	SessionID string `json:"session_id"`
+
+	// Result is the run's result in the runner's wire format
+	Result *runner.Result `json:"result"`
+}
+
+// errorResponse is the JSON body of an error response
+type errorResponse struct {
+	Error string `json:"error"`
+
+	// TraceID is the trace of the failed run, if it was traced
+	TraceID string `json:"trace_id,omitempty"`
+}
+
+// Option configures a Handler
+type Option func(*Handler)
+
+// WithRunConfig sets the configuration of every run; its History is replaced by the session's
+func WithRunConfig(config runner.RunConfig) Option {
+	return func(h *Handler) {
+		h.config = config
+	}
+}
+
+// WithSessionStore sets where sessions are kept (defaults to a MemorySessionStore with DefaultSessionTTL)
+func WithSessionStore(store SessionStore) Option {
+	return func(h *Handler) {
+		h.sessions = store
+	}
+}
+
+// Handler is an http.Handler that runs an agent. It serves:
+//
+//	POST /                   run the agent with a RunRequest
+//	DELETE /sessions/{id}    delete a session
+//
+// A POST request whose Accept header includes text/event-stream receives the run's events as
+// server-sent events; other requests receive a RunResponse. A run is canceled when the client
+// disconnects. Mount the handler under a prefix with http.StripPrefix.
+type Handler struct {
+	agent    *agent.Agent
+	config   runner.RunConfig
+	sessions SessionStore
+	mux      *http.ServeMux
+
+	// baseCtx is canceled when Shutdown gives up waiting, which cancels the runs in flight
+	baseCtx    context.Context
+	cancelRuns context.CancelFunc
+	runs       sync.WaitGroup
+
+	mu       sync.Mutex
+	closing  bool
+	inFlight map[string]bool
+}
+
+// NewHandler creates a handler that runs the agent
+func NewHandler(a *agent.Agent, opts ...Option) *Handler {
+	h := &Handler{
+		agent:    a,
+		config:   runner.DefaultRunConfig(),
+		inFlight: map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.sessions == nil {
+		h.sessions = NewMemorySessionStore(DefaultSessionTTL)
+	}
+	h.baseCtx, h.cancelRuns = context.WithCancel(context.Background())
+
+	h.mux = http.NewServeMux()
+	h.mux.HandleFunc("POST /{$}", h.handleRun)
+	h.mux.HandleFunc("DELETE /sessions/{id}", h.handleDeleteSession)
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Shutdown stops accepting new runs and waits for the runs in flight to finish. If the
+// context is done first, the remaining runs are canceled and the context's error is returned.
+func (h *Handler) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	h.closing = true
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.runs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		h.cancelRuns()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (h *Handler) handleRun(w http.ResponseWriter, r *http.Request) {
+	var req RunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if strings.TrimSpace(req.Input) == "" {
+		writeError(w, http.StatusBadRequest, ErrEmptyInput)
+		return
+	}
+	if req.SessionID == "" {
+		req.SessionID = uuid.New().String()
+	}
+
+	if status, err := h.begin(req.SessionID); err != nil {
+		writeError(w, status, err)
+		return
+	}
+	defer h.end(req.SessionID)
+
+	// The run joins the caller's trace when the request has a traceparent header
+	ctx, cancel := context.WithCancel(tracing.ExtractTraceContext(r.Context(), r.Header))
+	defer cancel()
+	stop := context.AfterFunc(h.baseCtx, cancel)
+	defer stop()
+
+	session, err := h.sessions.Get(ctx, req.SessionID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if session == nil {
+		session = &Session{ID: req.SessionID}
+	}
+	current := h.agent
+	if session.Agent != nil {
+		current = session.Agent
+	}
+
+	config := h.config
+	config.History = session.History
+
+	var events *sseWriter
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		events = newSSEWriter(w)
+		defer events.close()
+		events.send(EventRunStarted, RunResponse{SessionID: session.ID})
+		h.streamEvents(&config, events)
+	}
+
+	result, err := runner.RunWithConfig(ctx, current, req.Input, config)
+	if err == nil {
+		session.History = result.History
+		session.Agent = result.LastAgent
+		session.UpdatedAt = time.Now()
+		err = h.sessions.Save(ctx, session)
+	}
+
+	if events != nil {
+		if err != nil {
+			traceID, _ := runner.TraceIDFromError(err)
+			events.send(EventError, ErrorEvent{Message: err.Error(), TraceID: traceID})
+			return
+		}
+		events.send(EventRunCompleted, RunResponse{SessionID: session.ID, Result: result})
+		return
+	}
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, RunResponse{SessionID: session.ID, Result: result})
+}
+
+func (h *Handler) handleDeleteSession(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	h.mu.Lock()
+	busy := h.inFlight[id]
+	h.mu.Unlock()
+	if busy {
+		writeError(w, http.StatusConflict, ErrSessionBusy)
+		return
+	}
+
+	if err := h.sessions.Delete(r.Context(), id); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// begin registers a run of the session, returning the status to respond with if it cannot start
+func (h *Handler) begin(sessionID string) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closing {
+		return http.StatusServiceUnavailable, ErrShuttingDown
+	}
+	if h.inFlight[sessionID] {
+		return http.StatusConflict, ErrSessionBusy
+	}
+	h.inFlight[sessionID] = true
+	h.runs.Add(1)
+	return 0, nil
+}
+
+// end unregisters a run started by begin
+func (h *Handler) end(sessionID string) {
+	h.mu.Lock()
+	delete(h.inFlight, sessionID)
+	h.mu.Unlock()
+	h.runs.Done()
+}
+
+// streamEvents wraps the callbacks of the run's configuration to also send them as events
+func (h *Handler) streamEvents(config *runner.RunConfig, events *sseWriter) {
+	hooks := &eventHooks{events: events, llm: config.LLMHooks, toolCall: config.ToolCallHooks}
+	config.LLMHooks = hooks
+	config.ToolCallHooks = hooks
+
+	progressHandler := config.ToolProgressHandler
+	config.ToolProgressHandler = func(ctx context.Context, event tool.ProgressEvent) {
+		events.send(EventToolProgress, event)
+		if progressHandler != nil {
+			progressHandler(ctx, event)
+		}
+	}
+
+	handoffCallback := config.HandoffCallback
+	config.HandoffCallback = func(ctx context.Context, target *agent.Agent, source *agent.Agent, inputJSON string) error {
+		if handoffCallback != nil {
+			if err := handoffCallback(ctx, target, source, inputJSON); err != nil {
+				return err
+			}
+		}
+		events.send(EventHandoff, HandoffEvent{From: source.Name, To: target.Name})
+		return nil
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	traceID, _ := runner.TraceIDFromError(err)
+	writeJSON(w, status, errorResponse{Error: err.Error(), TraceID: traceID})
+}
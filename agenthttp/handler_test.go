@@ -0,0 +1,274 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package agenthttp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/agentstest"
+	"github.com/ryichk/ai-agents-sdk-go/jsonschema"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+func readEvents(t *testing.T, body string) []sseEvent {
+	t.Helper()
+	var events []sseEvent
+	var current sseEvent
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			current.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		case line == "":
+			events = append(events, current)
+			current = sseEvent{}
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func postRun(t *testing.T, h http.Handler, body string, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerRunContinuesSession(t *testing.T) {
+	fakeModel := agentstest.NewFakeModel()
+	fakeModel.AddTurn(agentstest.GetTextMessage("Hello!"))
+	fakeModel.AddTurn(agentstest.GetTextMessage("You said hi"))
+
+	h := NewHandler(agent.New("assistant", "Test agent"),
+		WithRunConfig(runner.RunConfig{ModelProvider: fakeModel, MaxTurns: 3}))
+
+	rec := postRun(t, h, `{"input":"hi"}`, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var first struct {
+		SessionID string `json:"session_id"`
+		Result    struct {
+			FinalOutput string `json:"final_output"`
+			LastAgent   string `json:"last_agent"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &first))
+	assert.NotEmpty(t, first.SessionID)
+	assert.Equal(t, "Hello!", first.Result.FinalOutput)
+	assert.Equal(t, "assistant", first.Result.LastAgent)
+
+	rec = postRun(t, h, `{"session_id":"`+first.SessionID+`","input":"what did I say?"}`, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// The second run continues the conversation of the first
+	calls := fakeModel.Calls()
+	require.Len(t, calls, 2)
+	messages := calls[1].Messages
+	require.Len(t, messages, 4)
+	assert.Equal(t, "hi", messages[1].Content)
+	assert.Equal(t, "Hello!", messages[2].Content)
+	assert.Equal(t, "what did I say?", messages[3].Content)
+}
+
+func TestHandlerStreamsEvents(t *testing.T) {
+	fakeModel := agentstest.NewFakeModel()
+	fakeModel.AddTurn(agentstest.GetFunctionToolCall("weather", `{"a":"Tokyo"}`))
+	fakeModel.AddTurn(agentstest.GetTextMessage("It is sunny"))
+
+	testAgent := agent.New("assistant", "Test agent")
+	testAgent.AddTool(agentstest.NewFunctionTool("weather", "sunny"))
+	h := NewHandler(testAgent, WithRunConfig(runner.RunConfig{ModelProvider: fakeModel, MaxTurns: 3}))
+
+	rec := postRun(t, h, `{"session_id":"s1","input":"Weather in Tokyo?"}`, "text/event-stream")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+
+	events := readEvents(t, rec.Body.String())
+	var names []string
+	for _, event := range events {
+		names = append(names, event.name)
+	}
+	assert.Equal(t, []string{
+		EventRunStarted,
+		EventToolCallStarted,
+		EventToolCallCompleted,
+		EventTextDelta,
+		EventRunCompleted,
+	}, names)
+
+	assert.JSONEq(t, `{"session_id":"s1","result":null}`, events[0].data)
+	assert.JSONEq(t, `{"agent":"assistant","tool":"weather","call_id":"call_weather","arguments":"{\"a\":\"Tokyo\"}","output":"sunny"}`, events[2].data)
+	assert.JSONEq(t, `{"agent":"assistant","content":"It is sunny"}`, events[3].data)
+
+	var completed struct {
+		Result struct {
+			FinalOutput string `json:"final_output"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(events[4].data), &completed))
+	assert.Equal(t, "It is sunny", completed.Result.FinalOutput)
+
+	// The events match the wire schema
+	var wireSchema map[string]any
+	require.NoError(t, json.Unmarshal(runner.JSONSchema(), &wireSchema))
+	schema := map[string]any{"$ref": "#/$defs/stream_event", "$defs": wireSchema["$defs"]}
+	for _, event := range events {
+		_, err := jsonschema.ValidateJSON(schema, `{"event":"`+event.name+`","data":`+event.data+`}`)
+		assert.NoError(t, err, "%s event does not match the schema", event.name)
+	}
+}
+
+func TestHandlerDropsEventsAfterReturning(t *testing.T) {
+	fakeModel := agentstest.NewFakeModel()
+	fakeModel.AddTurn(agentstest.GetFunctionToolCall("work", `{}`))
+	fakeModel.AddTurn(agentstest.GetTextMessage("Done"))
+
+	// The tool keeps its context, as a tool abandoned by the run would
+	var toolCtx context.Context
+	work, err := tool.NewFunctionTool(func(ctx context.Context) string {
+		toolCtx = ctx
+		tool.ReportProgress(ctx, "working", 0.5)
+		return "done"
+	}, tool.FunctionToolOption{NameOverride: "work"})
+	require.NoError(t, err)
+
+	testAgent := agent.New("assistant", "Test agent")
+	testAgent.AddTool(work)
+	h := NewHandler(testAgent, WithRunConfig(runner.RunConfig{ModelProvider: fakeModel, MaxTurns: 3}))
+
+	rec := postRun(t, h, `{"input":"Work"}`, "text/event-stream")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "event: "+EventToolProgress)
+
+	require.NotNil(t, toolCtx)
+	tool.ReportProgress(toolCtx, "too late", 1)
+	assert.Equal(t, body, rec.Body.String(), "No event is written after the handler returned")
+}
+
+func TestHandlerStreamsErrors(t *testing.T) {
+	fakeModel := agentstest.NewFakeModel()
+	fakeModel.AddError(assert.AnError)
+	h := NewHandler(agent.New("assistant", "Test agent"),
+		WithRunConfig(runner.RunConfig{ModelProvider: fakeModel, MaxTurns: 3}))
+
+	events := readEvents(t, postRun(t, h, `{"input":"hi"}`, "text/event-stream").Body.String())
+	require.Len(t, events, 2)
+	assert.Equal(t, EventError, events[1].name)
+	assert.Contains(t, events[1].data, assert.AnError.Error())
+
+	fakeModel.AddError(assert.AnError)
+	rec := postRun(t, h, `{"input":"hi"}`, "")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), assert.AnError.Error())
+}
+
+func TestHandlerRejectsInvalidRequests(t *testing.T) {
+	h := NewHandler(agent.New("assistant", "Test agent"),
+		WithRunConfig(runner.RunConfig{ModelProvider: agentstest.NewFakeModel(), MaxTurns: 3}))
+
+	assert.Equal(t, http.StatusBadRequest, postRun(t, h, `not json`, "").Code)
+	assert.Equal(t, http.StatusBadRequest, postRun(t, h, `{"input":"  "}`, "").Code)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestHandlerBusySessionAndShutdown(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	provider := model.ProviderFuncs{
+		Completion: func(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+			close(started)
+			select {
+			case <-release:
+				return &model.Response{Message: model.Message{Role: "assistant", Content: "done"}}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	}
+	h := NewHandler(agent.New("assistant", "Test agent"),
+		WithRunConfig(runner.RunConfig{ModelProvider: provider, MaxTurns: 3}))
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- postRun(t, h, `{"session_id":"s1","input":"hi"}`, "")
+	}()
+	<-started
+
+	assert.Equal(t, http.StatusConflict, postRun(t, h, `{"session_id":"s1","input":"again"}`, "").Code)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/sessions/s1", nil))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	// Shutdown gives up waiting and cancels the run in flight
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, h.Shutdown(ctx), context.DeadlineExceeded)
+	close(release)
+	assert.Equal(t, http.StatusInternalServerError, (<-done).Code)
+
+	assert.Equal(t, http.StatusServiceUnavailable, postRun(t, h, `{"input":"hi"}`, "").Code)
+}
+
+func TestHandlerDeleteSession(t *testing.T) {
+	store := NewMemorySessionStore(0)
+	require.NoError(t, store.Save(context.Background(), &Session{ID: "s1"}))
+	h := NewHandler(agent.New("assistant", "Test agent"), WithSessionStore(store))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/sessions/s1", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	session, err := store.Get(context.Background(), "s1")
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestMemorySessionStoreExpires(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemorySessionStore(time.Minute)
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, store.Save(context.Background(), &Session{ID: "s1", UpdatedAt: now}))
+	session, err := store.Get(context.Background(), "s1")
+	require.NoError(t, err)
+	require.NotNil(t, session)
+
+	now = now.Add(2 * time.Minute)
+	session, err = store.Get(context.Background(), "s1")
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
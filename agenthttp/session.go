@@ -0,0 +1,108 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package agenthttp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+)
+
+// Session is the conversation of a chat session
+type Session struct {
+	// ID identifies the session
+	ID string
+
+	// History is the conversation so far, passed to the next run as RunConfig.History
+	History []runner.Message
+
+	// Agent is the agent that answered last, which handles the next message (nil for the handler's agent)
+	Agent *agent.Agent
+
+	// UpdatedAt is the time of the last run
+	UpdatedAt time.Time
+}
+
+// SessionStore stores chat sessions between requests
+type SessionStore interface {
+	// Get returns the session, or nil if it does not exist
+	Get(ctx context.Context, id string) (*Session, error)
+
+	// Save stores the session
+	Save(ctx context.Context, session *Session) error
+
+	// Delete removes the session
+	Delete(ctx context.Context, id string) error
+}
+
+// MemorySessionStore keeps sessions in memory. Sessions idle for longer than the TTL are
+// removed. A MemorySessionStore is safe for concurrent use.
+type MemorySessionStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewMemorySessionStore creates an in-memory session store; ttl 0 keeps sessions forever
+func NewMemorySessionStore(ttl time.Duration) *MemorySessionStore {
+	return &MemorySessionStore{
+		ttl:      ttl,
+		now:      time.Now,
+		sessions: map[string]*Session{},
+	}
+}
+
+// Get returns the session, or nil if it does not exist or has expired
+func (s *MemorySessionStore) Get(ctx context.Context, id string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *session
+	copied.History = append([]runner.Message(nil), session.History...)
+	return &copied, nil
+}
+
+// Save stores the session
+func (s *MemorySessionStore) Save(ctx context.Context, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	copied := *session
+	copied.History = append([]runner.Message(nil), session.History...)
+	s.sessions[session.ID] = &copied
+	return nil
+}
+
+// Delete removes the session
+func (s *MemorySessionStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
+
+// expire removes the sessions idle for longer than the TTL; s.mu must be held
+func (s *MemorySessionStore) expire() {
+	if s.ttl <= 0 {
+		return
+	}
+	cutoff := s.now().Add(-s.ttl)
+	for id, session := range s.sessions {
+		if session.UpdatedAt.Before(cutoff) {
+			delete(s.sessions, id)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package agentstest provides a scripted model provider and helpers for unit testing agents
+// without calling a real model:
+//
+//	fake := agentstest.NewFakeModel()
+//	fake.AddMultipleTurnOutputs([][]model.Message{
+//		{agentstest.GetFunctionToolCall("get_weather", `{"city":"Tokyo"}`)},
+//		{agentstest.GetTextMessage("It is sunny in Tokyo.")},
+//	})
+//	result, err := runner.RunWithConfig(ctx, myAgent, "weather?", runner.RunConfig{ModelProvider: fake, MaxTurns: 5})
+package agentstest
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// DefaultOutput is the content the fake model answers with when no output is scripted
+const DefaultOutput = "default response"
+
+// Call is a request received by a FakeModel
+type Call struct {
+	// Messages are the messages sent to the model
+	Messages []model.Message
+
+	// Settings are the settings of the request
+	Settings model.Settings
+
+	// Stream is true for CreateChatCompletionStream calls
+	Stream bool
+}
+
+// turn is a scripted answer: the messages combined into the response, or an error
+type turn struct {
+	output []model.Message
+	err    error
+}
+
+// FakeModel is a model.Provider that answers with scripted outputs, one turn per call.
+// Scripted turns are used first, then the output set with SetNextOutput, then DefaultOutput.
+// The messages of a turn are combined into one response: their contents joined with spaces and
+// their tool calls concatenated. A FakeModel is safe for concurrent use.
+type FakeModel struct {
+	mu         sync.Mutex
+	turns      []turn
+	nextOutput []model.Message
+	usage      model.Usage
+	calls      []Call
+}
+
+// NewFakeModel creates a fake model that reports 100 prompt and 50 completion tokens per call
+func NewFakeModel() *FakeModel {
+	return &FakeModel{
+		usage: model.Usage{
+			PromptTokens:     100,
+			CompletionTokens: 50,
+			TotalTokens:      150,
+		},
+	}
+}
+
+// SetNextOutput sets the output of the next call made after the scripted turns are used up
+func (m *FakeModel) SetNextOutput(output []model.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextOutput = output
+}
+
+// AddMultipleTurnOutputs replaces the scripted turns with one turn per output
+func (m *FakeModel) AddMultipleTurnOutputs(outputs [][]model.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.turns = make([]turn, 0, len(outputs))
+	for _, output := range outputs {
+		m.turns = append(m.turns, turn{output: output})
+	}
+}
+
+// AddTurn appends a scripted turn that answers with the messages
+func (m *FakeModel) AddTurn(output ...model.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.turns = append(m.turns, turn{output: output})
+}
+
+// AddError appends a scripted turn that fails with err, to test error handling
+func (m *FakeModel) AddError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.turns = append(m.turns, turn{err: err})
+}
+
+// SetUsage sets the usage reported by every response
+func (m *FakeModel) SetUsage(usage model.Usage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usage = usage
+}
+
+// Calls returns the requests the fake model has received
+func (m *FakeModel) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// LastCall returns the last request the fake model received, and false if there was none
+func (m *FakeModel) LastCall() (Call, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.calls) == 0 {
+		return Call{}, false
+	}
+	return m.calls[len(m.calls)-1], true
+}
+
+// CreateChatCompletion answers with the next scripted turn
+func (m *FakeModel) CreateChatCompletion(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.next(messages, settings, false)
+}
+
+// CreateChatCompletionStream streams the next scripted turn: its content word by word, then its
+// tool calls, then the finish reason
+func (m *FakeModel) CreateChatCompletionStream(ctx context.Context, messages []model.Message, settings model.Settings) (model.Stream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	response, err := m.next(messages, settings, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var chunks []*model.StreamChunk
+	for _, word := range strings.SplitAfter(response.Message.Content, " ") {
+		if word != "" {
+			chunks = append(chunks, &model.StreamChunk{Delta: model.Message{Role: "assistant", Content: word}})
+		}
+	}
+	finishReason := "stop"
+	if len(response.Message.ToolCalls) > 0 {
+		chunks = append(chunks, &model.StreamChunk{Delta: model.Message{Role: "assistant", ToolCalls: response.Message.ToolCalls}})
+		finishReason = "tool_calls"
+	}
+	chunks = append(chunks, &model.StreamChunk{Delta: model.Message{Role: "assistant"}, FinishReason: finishReason})
+
+	return &FakeStream{chunks: chunks}, nil
+}
+
+// next records the call and returns the response of the next turn
+func (m *FakeModel) next(messages []model.Message, settings model.Settings, stream bool) (*model.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, Call{
+		Messages: append([]model.Message(nil), messages...),
+		Settings: settings,
+		Stream:   stream,
+	})
+
+	var output []model.Message
+	switch {
+	case len(m.turns) > 0:
+		t := m.turns[0]
+		m.turns = m.turns[1:]
+		if t.err != nil {
+			return nil, t.err
+		}
+		output = t.output
+	case m.nextOutput != nil:
+		output = m.nextOutput
+		m.nextOutput = nil
+	default:
+		output = []model.Message{GetTextMessage(DefaultOutput)}
+	}
+
+	return &model.Response{
+		Message: combineMessages(output),
+		Usage:   m.usage,
+	}, nil
+}
+
+// combineMessages combines the assistant messages of a turn into a single response message
+func combineMessages(messages []model.Message) model.Message {
+	result := model.Message{Role: "assistant"}
+	var contents []string
+	for _, msg := range messages {
+		if msg.Role != "assistant" {
+			continue
+		}
+		if msg.Content != "" {
+			contents = append(contents, msg.Content)
+		}
+		result.ToolCalls = append(result.ToolCalls, msg.ToolCalls...)
+		if result.Refusal == "" {
+			result.Refusal = msg.Refusal
+		}
+		if result.Reasoning == "" {
+			result.Reasoning = msg.Reasoning
+		}
+	}
+	result.Content = strings.Join(contents, " ")
+	return result
+}
+
+// FakeStream is the stream returned by FakeModel
+type FakeStream struct {
+	mu     sync.Mutex
+	chunks []*model.StreamChunk
+	index  int
+	closed bool
+}
+
+// Recv receives the next chunk, or io.EOF after the last one
+func (s *FakeStream) Recv() (*model.StreamChunk, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.index >= len(s.chunks) {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[s.index]
+	s.index++
+	return chunk, nil
+}
+
+// Close closes the stream
+func (s *FakeStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
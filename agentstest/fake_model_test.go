@@ -0,0 +1,105 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package agentstest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestFakeModelScriptedTurns(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeModel()
+	fake.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("thinking"), GetFunctionToolCall("lookup", `{"a":"b"}`)},
+	})
+	fake.AddTurn(GetTextMessage("done"))
+	rateLimited := errors.New("rate limited")
+	fake.AddError(rateLimited)
+	fake.SetNextOutput([]model.Message{GetTextMessage("next")})
+
+	response, err := fake.CreateChatCompletion(ctx, []model.Message{GetTextInputItem("hi")}, model.DefaultSettings())
+	require.NoError(t, err)
+	assert.Equal(t, "thinking", response.Message.Content)
+	require.Len(t, response.Message.ToolCalls, 1)
+	assert.Equal(t, "call_lookup", response.Message.ToolCalls[0].ID)
+	assert.Equal(t, 150, response.Usage.TotalTokens)
+
+	response, err = fake.CreateChatCompletion(ctx, nil, model.Settings{})
+	require.NoError(t, err)
+	assert.Equal(t, "done", response.Message.Content)
+
+	_, err = fake.CreateChatCompletion(ctx, nil, model.Settings{})
+	assert.ErrorIs(t, err, rateLimited)
+
+	response, err = fake.CreateChatCompletion(ctx, nil, model.Settings{})
+	require.NoError(t, err)
+	assert.Equal(t, "next", response.Message.Content)
+
+	response, err = fake.CreateChatCompletion(ctx, nil, model.Settings{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultOutput, response.Message.Content)
+
+	calls := fake.Calls()
+	require.Len(t, calls, 5)
+	assert.Equal(t, "hi", calls[0].Messages[0].Content)
+	assert.Equal(t, model.DefaultSettings().MaxTokens, calls[0].Settings.MaxTokens)
+}
+
+func TestFakeModelStream(t *testing.T) {
+	fake := NewFakeModel()
+	fake.AddTurn(GetTextMessage("hello there"))
+	fake.AddTurn(GetFunctionToolCall("lookup", "{}"))
+
+	stream, err := fake.CreateChatCompletionStream(context.Background(), nil, model.Settings{})
+	require.NoError(t, err)
+	content, finishReason := drain(t, stream)
+	assert.Equal(t, "hello there", content)
+	assert.Equal(t, "stop", finishReason)
+
+	stream, err = fake.CreateChatCompletionStream(context.Background(), nil, model.Settings{})
+	require.NoError(t, err)
+	_, finishReason = drain(t, stream)
+	assert.Equal(t, "tool_calls", finishReason)
+
+	last, ok := fake.LastCall()
+	require.True(t, ok)
+	assert.True(t, last.Stream)
+}
+
+func TestFunctionTool(t *testing.T) {
+	lookup := NewFunctionTool("lookup", "found")
+
+	output, err := lookup.Invoke(context.Background(), `{"a":"b"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "found", output)
+	assert.Equal(t, []string{`{"a":"b"}`}, lookup.Inputs())
+}
+
+// drain reads a stream to the end and returns its content and finish reason
+func drain(t *testing.T, stream model.Stream) (string, string) {
+	t.Helper()
+	defer stream.Close()
+
+	var content, finishReason string
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return content, finishReason
+		}
+		require.NoError(t, err)
+		content += chunk.Delta.Content
+		if chunk.FinishReason != "" {
+			finishReason = chunk.FinishReason
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package agentstest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// GetTextMessage returns an assistant message with the content
+func GetTextMessage(content string) model.Message {
+	return model.Message{
+		Role:    "assistant",
+		Content: content,
+	}
+}
+
+// GetTextInputItem returns a user message with the content
+func GetTextInputItem(content string) model.Message {
+	return model.Message{
+		Role:    "user",
+		Content: content,
+	}
+}
+
+// GetFinalOutputMessage returns an assistant message with the final output.
+// The runner treats a message without tool calls as the final output.
+func GetFinalOutputMessage(content string) model.Message {
+	return GetTextMessage(content)
+}
+
+// GetFunctionToolCall returns an assistant message that calls the tool with the arguments.
+// The ID of the call is "call_" followed by the tool's name.
+func GetFunctionToolCall(name string, arguments string) model.Message {
+	return model.Message{
+		Role:    "assistant",
+		Content: "",
+		ToolCalls: []model.ToolCall{
+			{
+				ID:   "call_" + name,
+				Type: "function",
+				Function: model.FunctionCall{
+					Name:      name,
+					Arguments: arguments,
+				},
+			},
+		},
+	}
+}
+
+// GetHandoffToolCall returns an assistant message that hands off to the agent with the input
+// (optional, defaults to "{}")
+func GetHandoffToolCall(targetAgent *agent.Agent, inputJSON string) model.Message {
+	if inputJSON == "" {
+		inputJSON = "{}"
+	}
+
+	agentName := targetAgent.Name
+
+	return model.Message{
+		Role:    "assistant",
+		Content: "",
+		ToolCalls: []model.ToolCall{
+			{
+				ID:   "handoff_" + agentName,
+				Type: "function",
+				Function: model.FunctionCall{
+					Name:      fmt.Sprintf("handoff_%s", agentName),
+					Arguments: inputJSON,
+				},
+			},
+		},
+	}
+}
+
+// FunctionTool is a tool that returns a fixed result and records its inputs
+type FunctionTool struct {
+	name   string
+	result string
+
+	mu     sync.Mutex
+	inputs []string
+}
+
+// NewFunctionTool creates a tool that returns result whatever its input
+func NewFunctionTool(name string, result string) *FunctionTool {
+	return &FunctionTool{
+		name:   name,
+		result: result,
+	}
+}
+
+func (t *FunctionTool) Name() string {
+	return t.name
+}
+
+func (t *FunctionTool) Description() string {
+	return "test tool"
+}
+
+func (t *FunctionTool) ParamsJSONSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"a": map[string]any{
+				"type": "string",
+			},
+		},
+	}
+}
+
+func (t *FunctionTool) Invoke(ctx context.Context, input string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inputs = append(t.inputs, input)
+	return t.result, nil
+}
+
+// Inputs returns the inputs the tool has been invoked with
+func (t *FunctionTool) Inputs() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.inputs...)
+}
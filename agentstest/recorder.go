@@ -0,0 +1,376 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package agentstest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// RecordMode selects whether a Recorder calls the real provider
+type RecordMode int
+
+const (
+	// ModeAuto replays the fixture if it exists, and records a new one otherwise
+	ModeAuto RecordMode = iota
+
+	// ModeReplay only replays the fixture; requests that were not recorded fail
+	ModeReplay
+
+	// ModeRecord calls the provider for every request and overwrites the fixture on Save
+	ModeRecord
+)
+
+// RecordEnv is the environment variable that forces Record to re-record fixtures when set to "1"
+const RecordEnv = "AGENTS_RECORD"
+
+// Redacted replaces the secrets removed from fixtures
+const Redacted = "[REDACTED]"
+
+var (
+	// ErrNotRecorded is returned in replay mode for a request the fixture does not contain
+	ErrNotRecorded = errors.New("request not recorded in fixture")
+
+	// ErrNoProvider is returned when a request must be recorded but the recorder has no provider
+	ErrNoProvider = errors.New("recorder has no provider to record with")
+)
+
+// defaultRedactions match OpenAI API keys and bearer tokens
+var defaultRedactions = []*regexp.Regexp{
+	regexp.MustCompile(`sk-[A-Za-z0-9_\-]{16,}`),
+	regexp.MustCompile(`(?i)bearer [A-Za-z0-9_\-.=]+`),
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	// Request is the recorded request
+	Request RecordedRequest `json:"request"`
+
+	// Response is the response of a completion
+	Response *model.Response `json:"response,omitempty"`
+
+	// Chunks are the chunks of a stream
+	Chunks []*model.StreamChunk `json:"chunks,omitempty"`
+
+	// Error is the error the provider returned
+	Error string `json:"error,omitempty"`
+}
+
+// RecordedRequest is the part of a request replays are matched on
+type RecordedRequest struct {
+	Model    string                 `json:"model,omitempty"`
+	Messages []model.Message        `json:"messages"`
+	Tools    []model.ToolDefinition `json:"tools,omitempty"`
+	Stream   bool                   `json:"stream,omitempty"`
+}
+
+// fixture is the file format of a recording
+type fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is a model.Provider that records the requests and responses of a real provider to a
+// JSON fixture, and replays them without calling the provider. Requests are matched on the
+// model, the messages and the tools, so replays are deterministic even when calls run
+// concurrently; identical requests replay in the order they were recorded.
+// Secrets such as API keys are redacted from the fixture.
+// A Recorder is safe for concurrent use.
+type Recorder struct {
+	path       string
+	provider   model.Provider
+	mode       RecordMode
+	redactions []*regexp.Regexp
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+	recorded     bool
+}
+
+// RecorderOption configures a Recorder
+type RecorderOption func(*Recorder)
+
+// WithMode sets the record mode (defaults to ModeAuto)
+func WithMode(mode RecordMode) RecorderOption {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// WithRedactions adds patterns whose matches are replaced with Redacted in the fixture,
+// in addition to API keys and bearer tokens
+func WithRedactions(patterns ...*regexp.Regexp) RecorderOption {
+	return func(r *Recorder) {
+		r.redactions = append(r.redactions, patterns...)
+	}
+}
+
+// NewRecorder creates a recorder for the fixture at path. The provider is called in record mode,
+// and may be nil when the fixture is only replayed.
+func NewRecorder(path string, provider model.Provider, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:       path,
+		provider:   provider,
+		redactions: append([]*regexp.Regexp(nil), defaultRedactions...),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && r.mode == ModeAuto {
+		r.mode = ModeRecord
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	r.mode = ModeReplay
+	r.interactions = f.Interactions
+	r.used = make([]bool, len(f.Interactions))
+	return r, nil
+}
+
+// Record creates a recorder for the fixture at testdata/<name>.json that records when the
+// fixture does not exist or the AGENTS_RECORD environment variable is "1", and replays it
+// otherwise. The fixture is saved when the test ends.
+func Record(t testing.TB, name string, provider model.Provider, opts ...RecorderOption) *Recorder {
+	t.Helper()
+
+	if os.Getenv(RecordEnv) == "1" {
+		opts = append(opts, WithMode(ModeRecord))
+	}
+	r, err := NewRecorder(filepath.Join("testdata", name+".json"), provider, opts...)
+	if err != nil {
+		t.Fatalf("agentstest: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := r.Save(); err != nil {
+			t.Errorf("agentstest: %v", err)
+		}
+	})
+	return r
+}
+
+// Mode returns the mode the recorder runs in; ModeAuto is resolved when the recorder is created
+func (r *Recorder) Mode() RecordMode {
+	return r.mode
+}
+
+// Interactions returns the recorded or loaded interactions
+func (r *Recorder) Interactions() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to the fixture. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode != ModeRecord || !r.recorded {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(fixture{Interactions: r.interactions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0750); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// CreateChatCompletion replays the recorded response of the request, or records the provider's
+func (r *Recorder) CreateChatCompletion(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+	request, err := r.request(messages, settings, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		interaction, err := r.replay(request)
+		if err != nil {
+			return nil, err
+		}
+		if interaction.Error != "" {
+			return nil, errors.New(interaction.Error)
+		}
+		return interaction.Response, nil
+	}
+
+	if r.provider == nil {
+		return nil, ErrNoProvider
+	}
+	response, err := r.provider.CreateChatCompletion(ctx, messages, settings)
+	interaction := &Interaction{Request: request, Response: response}
+	if err != nil {
+		interaction.Error = err.Error()
+	}
+	if recordErr := r.record(interaction); recordErr != nil {
+		return nil, recordErr
+	}
+	return response, err
+}
+
+// CreateChatCompletionStream replays the recorded chunks of the request, or records the
+// provider's stream as it is read
+func (r *Recorder) CreateChatCompletionStream(ctx context.Context, messages []model.Message, settings model.Settings) (model.Stream, error) {
+	request, err := r.request(messages, settings, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		interaction, err := r.replay(request)
+		if err != nil {
+			return nil, err
+		}
+		if interaction.Error != "" {
+			return nil, errors.New(interaction.Error)
+		}
+		return &FakeStream{chunks: interaction.Chunks}, nil
+	}
+
+	if r.provider == nil {
+		return nil, ErrNoProvider
+	}
+	stream, err := r.provider.CreateChatCompletionStream(ctx, messages, settings)
+	if err != nil {
+		if recordErr := r.record(&Interaction{Request: request, Error: err.Error()}); recordErr != nil {
+			return nil, recordErr
+		}
+		return nil, err
+	}
+	return &recordingStream{Stream: stream, recorder: r, interaction: &Interaction{Request: request}}, nil
+}
+
+// request builds the redacted request that is recorded and matched
+func (r *Recorder) request(messages []model.Message, settings model.Settings, stream bool) (RecordedRequest, error) {
+	request := RecordedRequest{
+		Model:    settings.EffectiveModel(),
+		Messages: messages,
+		Tools:    settings.EffectiveTools(),
+		Stream:   stream,
+	}
+
+	// Round-trip through the redacted JSON so live requests match the redacted fixture
+	var redacted RecordedRequest
+	if err := r.redactInto(request, &redacted); err != nil {
+		return RecordedRequest{}, err
+	}
+	return redacted, nil
+}
+
+// replay returns the first unused interaction recorded for the request
+func (r *Recorder) replay(request RecordedRequest) (*Interaction, error) {
+	key, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		if r.used[i] {
+			continue
+		}
+		recorded, err := json.Marshal(interaction.Request)
+		if err != nil {
+			return nil, err
+		}
+		if string(recorded) == string(key) {
+			r.used[i] = true
+			return interaction, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotRecorded, r.path)
+}
+
+// record adds a redacted interaction
+func (r *Recorder) record(interaction *Interaction) error {
+	var redacted Interaction
+	if err := r.redactInto(interaction, &redacted); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, &redacted)
+	r.recorded = true
+	return nil
+}
+
+// redactInto encodes v, redacts the secrets from the JSON and decodes it into out
+func (r *Recorder) redactInto(v any, out any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode interaction: %w", err)
+	}
+	for _, pattern := range r.redactions {
+		data = pattern.ReplaceAll(data, []byte(Redacted))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode redacted interaction: %w", err)
+	}
+	return nil
+}
+
+// recordingStream records the chunks of a stream as they are read
+type recordingStream struct {
+	model.Stream
+	recorder    *Recorder
+	interaction *Interaction
+	once        sync.Once
+}
+
+func (s *recordingStream) Recv() (*model.StreamChunk, error) {
+	chunk, err := s.Stream.Recv()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			s.interaction.Error = err.Error()
+		}
+		s.finish()
+		return chunk, err
+	}
+	s.interaction.Chunks = append(s.interaction.Chunks, chunk)
+	return chunk, nil
+}
+
+func (s *recordingStream) Close() error {
+	s.finish()
+	return s.Stream.Close()
+}
+
+// finish records the interaction once, when the stream ends or is closed
+func (s *recordingStream) finish() {
+	s.once.Do(func() {
+		_ = s.recorder.record(s.interaction)
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package agentstest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "fixtures", "session.json")
+	settings := model.Settings{Custom: map[string]any{"model": "gpt-4o"}}
+	first := []model.Message{GetTextInputItem("my key is sk-abcdefghijklmnopqrstuvwxyz, customer 4242")}
+	second := []model.Message{GetTextInputItem("second")}
+
+	fake := NewFakeModel()
+	fake.AddTurn(GetTextMessage("first answer"))
+	fake.AddError(errors.New("overloaded"))
+
+	recorder, err := NewRecorder(path, fake, WithRedactions(regexp.MustCompile(`customer \d+`)))
+	require.NoError(t, err)
+	assert.Equal(t, ModeRecord, recorder.Mode())
+
+	response, err := recorder.CreateChatCompletion(ctx, first, settings)
+	require.NoError(t, err)
+	assert.Equal(t, "first answer", response.Message.Content)
+	_, err = recorder.CreateChatCompletion(ctx, second, settings)
+	require.EqualError(t, err, "overloaded")
+	require.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "sk-abcdefghijklmnopqrstuvwxyz")
+	assert.NotContains(t, string(data), "4242")
+	assert.Contains(t, string(data), Redacted)
+
+	// The replay does not need the provider, and matches requests in any order
+	replay, err := NewRecorder(path, nil, WithRedactions(regexp.MustCompile(`customer \d+`)))
+	require.NoError(t, err)
+	assert.Equal(t, ModeReplay, replay.Mode())
+
+	_, err = replay.CreateChatCompletion(ctx, second, settings)
+	assert.EqualError(t, err, "overloaded")
+	response, err = replay.CreateChatCompletion(ctx, first, settings)
+	require.NoError(t, err)
+	assert.Equal(t, "first answer", response.Message.Content)
+	assert.Equal(t, 150, response.Usage.TotalTokens)
+
+	_, err = replay.CreateChatCompletion(ctx, first, settings)
+	assert.ErrorIs(t, err, ErrNotRecorded)
+	_, err = replay.CreateChatCompletion(ctx, first, model.Settings{Custom: map[string]any{"model": "gpt-4o-mini"}})
+	assert.ErrorIs(t, err, ErrNotRecorded)
+}
+
+func TestRecorderStream(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "stream.json")
+
+	fake := NewFakeModel()
+	fake.AddTurn(GetTextMessage("hello there"))
+
+	recorder, err := NewRecorder(path, fake)
+	require.NoError(t, err)
+	stream, err := recorder.CreateChatCompletionStream(ctx, nil, model.Settings{})
+	require.NoError(t, err)
+	content, _ := drain(t, stream)
+	assert.Equal(t, "hello there", content)
+	require.NoError(t, recorder.Save())
+
+	replay, err := NewRecorder(path, nil, WithMode(ModeReplay))
+	require.NoError(t, err)
+	stream, err = replay.CreateChatCompletionStream(ctx, nil, model.Settings{})
+	require.NoError(t, err)
+	content, finishReason := drain(t, stream)
+	assert.Equal(t, "hello there", content)
+	assert.Equal(t, "stop", finishReason)
+
+	// A completion does not replay a recorded stream
+	_, err = replay.CreateChatCompletion(ctx, nil, model.Settings{})
+	assert.ErrorIs(t, err, ErrNotRecorded)
+}
+
+func TestRecordHelper(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	t.Run("record", func(t *testing.T) {
+		fake := NewFakeModel()
+		fake.AddTurn(GetTextMessage("recorded"))
+		recorder := Record(t, "helper", fake)
+		_, err := recorder.CreateChatCompletion(context.Background(), nil, model.Settings{})
+		require.NoError(t, err)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		recorder := Record(t, "helper", nil)
+		assert.Equal(t, ModeReplay, recorder.Mode())
+		response, err := recorder.CreateChatCompletion(context.Background(), nil, model.Settings{})
+		require.NoError(t, err)
+		assert.Equal(t, "recorded", response.Message.Content)
+	})
+
+	_, err := NewRecorder(filepath.Join("testdata", "missing.json"), nil, WithMode(ModeReplay))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
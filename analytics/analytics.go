@@ -0,0 +1,245 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package analytics aggregates the outcomes of many agent runs into health metrics:
+// per-agent success rates, average turns, tool failure rates and cost over time.
+package analytics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// DefaultRetention is the default period for which records are kept
+const DefaultRetention = 24 * time.Hour
+
+// CostFunc returns the cost of the given token usage of a model
+type CostFunc func(model string, promptTokens int, completionTokens int) float64
+
+// RunRecord describes the outcome of a single agent run
+type RunRecord struct {
+	// Time is when the run finished
+	Time time.Time
+
+	// AgentName is the name of the agent the run started with
+	AgentName string
+
+	// Success indicates whether the run produced a final output
+	Success bool
+
+	// Turns is the number of turns used by the run
+	Turns int
+
+	// Duration is the wall-clock duration of the run
+	Duration time.Duration
+}
+
+// ToolRecord describes a single tool invocation
+type ToolRecord struct {
+	// Time is when the invocation finished
+	Time time.Time
+
+	// ToolName is the name of the tool
+	ToolName string
+
+	// Success indicates whether the tool returned without error
+	Success bool
+}
+
+// UsageRecord describes the token usage of a single model call
+type UsageRecord struct {
+	// Time is when the call finished
+	Time time.Time
+
+	// AgentName is the name of the agent that made the call
+	AgentName string
+
+	// Model is the name of the model
+	Model string
+
+	// PromptTokens is the number of tokens in the prompt
+	PromptTokens int
+
+	// CompletionTokens is the number of tokens in the completion
+	CompletionTokens int
+}
+
+// Option is a function that configures a Collector
+type Option func(*Collector)
+
+// WithRetention sets how long records are kept (0 keeps them forever)
+func WithRetention(retention time.Duration) Option {
+	return func(c *Collector) {
+		c.retention = retention
+	}
+}
+
+// WithCostFunc sets the function used to compute the cost of model calls
+func WithCostFunc(costFunc CostFunc) Option {
+	return func(c *Collector) {
+		c.costFunc = costFunc
+	}
+}
+
+// Collector records run, tool and usage events and computes aggregated reports.
+// It implements tracing.SpanProcessor, so it can be registered with tracing.AddTraceProcessor
+// to collect events from every run automatically.
+type Collector struct {
+	mu        sync.Mutex
+	runs      []RunRecord
+	tools     []ToolRecord
+	usage     []UsageRecord
+	retention time.Duration
+	costFunc  CostFunc
+	now       func() time.Time
+}
+
+// NewCollector creates a new Collector
+func NewCollector(options ...Option) *Collector {
+	c := &Collector{
+		retention: DefaultRetention,
+		now:       time.Now,
+	}
+
+	for _, o := range options {
+		o(c)
+	}
+
+	return c
+}
+
+// RecordRun records the outcome of a run
+func (c *Collector) RecordRun(record RunRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.runs = append(c.runs, record)
+	c.prune()
+}
+
+// RecordTool records a tool invocation
+func (c *Collector) RecordTool(record ToolRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tools = append(c.tools, record)
+	c.prune()
+}
+
+// RecordUsage records the token usage of a model call
+func (c *Collector) RecordUsage(record UsageRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.usage = append(c.usage, record)
+	c.prune()
+}
+
+// RecordResult records the outcome of a run returned by runner.Run, including its per-call usage.
+// Use it instead of registering the Collector as a span processor, not in addition to it.
+func (c *Collector) RecordResult(agentName string, result *runner.Result, err error, duration time.Duration) {
+	now := c.now()
+
+	record := RunRecord{
+		Time:      now,
+		AgentName: agentName,
+		Success:   err == nil && result != nil,
+		Duration:  duration,
+	}
+
+	if result != nil {
+		record.Turns = len(result.UsageReport.Steps)
+		for _, step := range result.UsageReport.Steps {
+			c.RecordUsage(UsageRecord{
+				Time:             now,
+				AgentName:        step.AgentName,
+				Model:            step.Model,
+				PromptTokens:     step.Usage.PromptTokens,
+				CompletionTokens: step.Usage.CompletionTokens,
+			})
+		}
+	}
+
+	c.RecordRun(record)
+}
+
+// prune removes records older than the retention period. The caller must hold c.mu.
+func (c *Collector) prune() {
+	if c.retention <= 0 {
+		return
+	}
+
+	cutoff := c.now().Add(-c.retention)
+	c.runs = pruneBefore(c.runs, cutoff, func(r RunRecord) time.Time { return r.Time })
+	c.tools = pruneBefore(c.tools, cutoff, func(r ToolRecord) time.Time { return r.Time })
+	c.usage = pruneBefore(c.usage, cutoff, func(r UsageRecord) time.Time { return r.Time })
+}
+
+// pruneBefore drops the leading records older than cutoff (records are appended in time order)
+func pruneBefore[T any](records []T, cutoff time.Time, timeOf func(T) time.Time) []T {
+	i := 0
+	for i < len(records) && timeOf(records[i]).Before(cutoff) {
+		i++
+	}
+	if i == 0 {
+		return records
+	}
+	return append([]T(nil), records[i:]...)
+}
+
+// OnStart is called when a span starts
+func (c *Collector) OnStart(span *tracing.StandardSpan) {}
+
+// OnEnd records agent runs, tool calls and model calls from ended spans
+func (c *Collector) OnEnd(span *tracing.StandardSpan) {
+	ctx := span.Context()
+	attributes := ctx.Attributes
+
+	_, failed := attributes["error"]
+	success, _ := attributes["success"].(bool)
+
+	switch ctx.Name {
+	case "agent_run":
+		agentName, _ := attributes["agent_name"].(string)
+		turns, _ := attributes["turns_used"].(int)
+		c.RecordRun(RunRecord{
+			Time:      ctx.EndTime,
+			AgentName: agentName,
+			Success:   success && !failed,
+			Turns:     turns,
+			Duration:  ctx.EndTime.Sub(ctx.StartTime),
+		})
+	case "tool_call":
+		toolName, _ := attributes["tool_name"].(string)
+		c.RecordTool(ToolRecord{
+			Time:     ctx.EndTime,
+			ToolName: toolName,
+			Success:  success && !failed,
+		})
+	case "llm_call":
+		if failed {
+			return
+		}
+		agentName, _ := attributes["agent"].(string)
+		modelName, _ := attributes["model"].(string)
+		promptTokens, _ := attributes["prompt_tokens"].(int)
+		completionTokens, _ := attributes["completion_tokens"].(int)
+		c.RecordUsage(UsageRecord{
+			Time:             ctx.EndTime,
+			AgentName:        agentName,
+			Model:            modelName,
+			PromptTokens:     promptTokens,
+			CompletionTokens: completionTokens,
+		})
+	}
+}
+
+// ForceFlush does nothing for Collector
+func (c *Collector) ForceFlush() {}
+
+// Shutdown does nothing for Collector
+func (c *Collector) Shutdown(ctx context.Context) error {
+	return nil
+}
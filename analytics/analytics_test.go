@@ -0,0 +1,123 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+func TestCollectorReport(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewCollector(
+		WithRetention(0),
+		WithCostFunc(func(model string, promptTokens int, completionTokens int) float64 {
+			return float64(promptTokens+completionTokens) / 1000
+		}),
+	)
+
+	c.RecordRun(RunRecord{Time: base, AgentName: "triage", Success: true, Turns: 2, Duration: 2 * time.Second})
+	c.RecordRun(RunRecord{Time: base.Add(time.Minute), AgentName: "triage", Success: false, Turns: 4, Duration: 4 * time.Second})
+	c.RecordRun(RunRecord{Time: base.Add(2 * time.Minute), AgentName: "billing", Success: true, Turns: 1, Duration: time.Second})
+	c.RecordTool(ToolRecord{Time: base, ToolName: "search", Success: true})
+	c.RecordTool(ToolRecord{Time: base, ToolName: "search", Success: false})
+	c.RecordUsage(UsageRecord{Time: base, AgentName: "triage", Model: "gpt-4o", PromptTokens: 1000, CompletionTokens: 1000})
+	c.RecordUsage(UsageRecord{Time: base.Add(2 * time.Minute), AgentName: "billing", Model: "gpt-4o", PromptTokens: 500, CompletionTokens: 500})
+
+	report := c.Report(base, base.Add(time.Hour))
+
+	triage := report.Agents["triage"]
+	assert.Equal(t, 2, triage.Runs)
+	assert.Equal(t, 0.5, triage.SuccessRate)
+	assert.Equal(t, 3.0, triage.AverageTurns)
+	assert.Equal(t, 3*time.Second, triage.AverageDuration)
+	assert.Equal(t, 2.0, triage.Cost)
+
+	assert.Equal(t, 3, report.Total.Runs)
+	assert.Equal(t, 3.0, report.Total.Cost)
+	assert.Equal(t, 0.5, report.Tools["search"].FailureRate)
+
+	// Records outside the window are ignored
+	report = c.Report(base.Add(90*time.Second), base.Add(time.Hour))
+	assert.Equal(t, 1, report.Total.Runs)
+	assert.NotContains(t, report.Agents, "triage")
+
+	points := c.CostOverTime(base, base.Add(3*time.Minute), time.Minute)
+	require.Len(t, points, 3)
+	assert.Equal(t, 1, points[0].Runs)
+	assert.Equal(t, 2.0, points[0].Cost)
+	assert.Equal(t, 0.0, points[1].Cost)
+	assert.Equal(t, 1.0, points[2].Cost)
+}
+
+func TestCollectorRetention(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewCollector(WithRetention(time.Hour))
+	c.now = func() time.Time { return now }
+
+	c.RecordRun(RunRecord{Time: now.Add(-2 * time.Hour), AgentName: "old"})
+	c.RecordRun(RunRecord{Time: now, AgentName: "new"})
+
+	report := c.ReportSince(24 * time.Hour)
+	assert.Equal(t, 1, report.Total.Runs)
+	assert.Contains(t, report.Agents, "new")
+}
+
+// stubProvider returns a fixed response or error
+type stubProvider struct {
+	err error
+}
+
+func (p *stubProvider) CreateChatCompletion(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &model.Response{
+		Message: model.Message{Role: "assistant", Content: "done"},
+		Usage:   model.Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+	}, nil
+}
+
+func (p *stubProvider) CreateChatCompletionStream(ctx context.Context, messages []model.Message, settings model.Settings) (model.Stream, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestCollectorAsSpanProcessor(t *testing.T) {
+	originalTracer := tracing.GetTracer()
+	c := NewCollector()
+	tracing.SetTracer(tracing.NewStandardTracer(c))
+	defer tracing.SetTracer(originalTracer)
+
+	testAgent := agent.New("assistant", "instructions")
+
+	_, err := runner.RunWithConfig(context.Background(), testAgent, "hello", runner.RunConfig{
+		Model:         "gpt-4o",
+		ModelProvider: &stubProvider{},
+	})
+	require.NoError(t, err)
+
+	_, err = runner.RunWithConfig(context.Background(), testAgent, "hello", runner.RunConfig{
+		Model:         "gpt-4o",
+		ModelProvider: &stubProvider{err: errors.New("boom")},
+	})
+	require.Error(t, err)
+
+	report := c.ReportSince(time.Hour)
+	stats := report.Agents["assistant"]
+	assert.Equal(t, 2, stats.Runs)
+	assert.Equal(t, 1, stats.Successes)
+	assert.Equal(t, 10, stats.PromptTokens)
+	assert.Equal(t, 5, stats.CompletionTokens)
+}
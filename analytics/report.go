@@ -0,0 +1,228 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package analytics
+
+import (
+	"time"
+)
+
+// AgentStats aggregates the runs and model usage of an agent
+type AgentStats struct {
+	// Runs is the number of runs
+	Runs int
+
+	// Successes is the number of runs that produced a final output
+	Successes int
+
+	// SuccessRate is Successes divided by Runs
+	SuccessRate float64
+
+	// AverageTurns is the average number of turns per run
+	AverageTurns float64
+
+	// AverageDuration is the average duration of a run
+	AverageDuration time.Duration
+
+	// PromptTokens is the total number of prompt tokens
+	PromptTokens int
+
+	// CompletionTokens is the total number of completion tokens
+	CompletionTokens int
+
+	// Cost is the total cost computed with the collector's CostFunc (0 if none is configured)
+	Cost float64
+}
+
+// ToolStats aggregates the invocations of a tool
+type ToolStats struct {
+	// Calls is the number of invocations
+	Calls int
+
+	// Failures is the number of invocations that returned an error
+	Failures int
+
+	// FailureRate is Failures divided by Calls
+	FailureRate float64
+}
+
+// Report aggregates all records within a time window
+type Report struct {
+	// Start is the inclusive start of the window
+	Start time.Time
+
+	// End is the exclusive end of the window
+	End time.Time
+
+	// Total aggregates all agents
+	Total AgentStats
+
+	// Agents aggregates per agent name
+	Agents map[string]AgentStats
+
+	// Tools aggregates per tool name
+	Tools map[string]ToolStats
+}
+
+// CostPoint is the usage and cost within one interval of a time series
+type CostPoint struct {
+	// Start is the start of the interval
+	Start time.Time
+
+	// Runs is the number of runs finished in the interval
+	Runs int
+
+	// PromptTokens is the number of prompt tokens used in the interval
+	PromptTokens int
+
+	// CompletionTokens is the number of completion tokens used in the interval
+	CompletionTokens int
+
+	// Cost is the cost of the model calls in the interval
+	Cost float64
+}
+
+// Report aggregates the records in [start, end)
+func (c *Collector) Report(start, end time.Time) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := Report{
+		Start:  start,
+		End:    end,
+		Agents: make(map[string]AgentStats),
+		Tools:  make(map[string]ToolStats),
+	}
+
+	turns := make(map[string]int)
+	durations := make(map[string]time.Duration)
+	var totalTurns int
+	var totalDuration time.Duration
+
+	for _, run := range c.runs {
+		if !inWindow(run.Time, start, end) {
+			continue
+		}
+
+		stats := report.Agents[run.AgentName]
+		addRun(&stats, run)
+		report.Agents[run.AgentName] = stats
+		addRun(&report.Total, run)
+
+		turns[run.AgentName] += run.Turns
+		durations[run.AgentName] += run.Duration
+		totalTurns += run.Turns
+		totalDuration += run.Duration
+	}
+
+	for _, usage := range c.usage {
+		if !inWindow(usage.Time, start, end) {
+			continue
+		}
+
+		cost := c.cost(usage)
+
+		stats := report.Agents[usage.AgentName]
+		stats.PromptTokens += usage.PromptTokens
+		stats.CompletionTokens += usage.CompletionTokens
+		stats.Cost += cost
+		report.Agents[usage.AgentName] = stats
+
+		report.Total.PromptTokens += usage.PromptTokens
+		report.Total.CompletionTokens += usage.CompletionTokens
+		report.Total.Cost += cost
+	}
+
+	for name, stats := range report.Agents {
+		finalizeAgentStats(&stats, turns[name], durations[name])
+		report.Agents[name] = stats
+	}
+	finalizeAgentStats(&report.Total, totalTurns, totalDuration)
+
+	for _, tool := range c.tools {
+		if !inWindow(tool.Time, start, end) {
+			continue
+		}
+
+		stats := report.Tools[tool.ToolName]
+		stats.Calls++
+		if !tool.Success {
+			stats.Failures++
+		}
+		stats.FailureRate = float64(stats.Failures) / float64(stats.Calls)
+		report.Tools[tool.ToolName] = stats
+	}
+
+	return report
+}
+
+// ReportSince aggregates the records of the last period
+func (c *Collector) ReportSince(period time.Duration) Report {
+	now := c.now()
+	return c.Report(now.Add(-period), now.Add(time.Nanosecond))
+}
+
+// CostOverTime returns the usage and cost in [start, end) split into intervals of the given length
+func (c *Collector) CostOverTime(start, end time.Time, interval time.Duration) []CostPoint {
+	if interval <= 0 || !end.After(start) {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := int((end.Sub(start) + interval - 1) / interval)
+	points := make([]CostPoint, count)
+	for i := range points {
+		points[i].Start = start.Add(time.Duration(i) * interval)
+	}
+
+	for _, run := range c.runs {
+		if inWindow(run.Time, start, end) {
+			points[int(run.Time.Sub(start)/interval)].Runs++
+		}
+	}
+
+	for _, usage := range c.usage {
+		if inWindow(usage.Time, start, end) {
+			point := &points[int(usage.Time.Sub(start)/interval)]
+			point.PromptTokens += usage.PromptTokens
+			point.CompletionTokens += usage.CompletionTokens
+			point.Cost += c.cost(usage)
+		}
+	}
+
+	return points
+}
+
+// cost returns the cost of a model call
+func (c *Collector) cost(usage UsageRecord) float64 {
+	if c.costFunc == nil {
+		return 0
+	}
+	return c.costFunc(usage.Model, usage.PromptTokens, usage.CompletionTokens)
+}
+
+// addRun counts a run in the stats
+func addRun(stats *AgentStats, run RunRecord) {
+	stats.Runs++
+	if run.Success {
+		stats.Successes++
+	}
+}
+
+// finalizeAgentStats computes the rates and averages of the stats
+func finalizeAgentStats(stats *AgentStats, turns int, duration time.Duration) {
+	if stats.Runs == 0 {
+		return
+	}
+	stats.SuccessRate = float64(stats.Successes) / float64(stats.Runs)
+	stats.AverageTurns = float64(turns) / float64(stats.Runs)
+	stats.AverageDuration = duration / time.Duration(stats.Runs)
+}
+
+// inWindow reports whether t is in [start, end)
+func inWindow(t, start, end time.Time) bool {
+	return !t.Before(start) && t.Before(end)
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Tooldoc generates the documentation of Go functions used as function tools from their doc
+// comments, so their JSON schemas describe the tool and each parameter without hand-written maps.
+// It is meant to be run by go generate, in the package of the functions:
+//
+//	//go:generate go run github.com/ryichk/ai-agents-sdk-go/cmd/tooldoc -func getWeather -type Forecast
+//
+// The doc comment of a function is the description of the tool. Its parameters are described in
+// an "Args:" (or "Parameters:") section, one "- name: description" item per parameter; they are
+// named after the function's parameters instead of param0, param1, ... The doc comments of the
+// fields of the listed struct types describe the fields:
+//
+//	// getWeather returns the weather forecast of a city.
+//	//
+//	// Args:
+//	//   - city: the name of the city, e.g. "Tokyo"
+//	//   - days: the number of days to forecast
+//	func getWeather(ctx context.Context, city string, days int) (Forecast, error)
+//
+// The generated file registers the documentation with tool.RegisterFunctionDoc and
+// tool.RegisterTypeDoc when the package is initialized.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// toolPackage is the import path of the tool package
+const toolPackage = "github.com/ryichk/ai-agents-sdk-go/tool"
+
+func main() {
+	funcs := flag.String("func", "", "comma-separated names of the functions to document")
+	types := flag.String("type", "", "comma-separated names of the struct types to document")
+	output := flag.String("output", "tooldoc_gen.go", "name of the generated file")
+	dir := flag.String("dir", ".", "directory of the package")
+	flag.Parse()
+
+	if *funcs == "" && *types == "" {
+		log.Fatal("tooldoc: -func or -type is required")
+	}
+
+	source, err := generate(*dir, splitNames(*funcs), splitNames(*types), *output)
+	if err != nil {
+		log.Fatalf("tooldoc: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *output), source, 0o644); err != nil {
+		log.Fatalf("tooldoc: %v", err)
+	}
+}
+
+// splitNames splits a comma-separated list of names
+func splitNames(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// functionDoc is the documentation of a function found in the package
+type functionDoc struct {
+	name        string
+	description string
+	params      []paramDoc
+}
+
+type paramDoc struct {
+	name        string
+	description string
+}
+
+// typeDoc is the documentation of the fields of a struct type, by JSON name
+type typeDoc struct {
+	name   string
+	fields map[string]string
+}
+
+// generate parses the package in dir and returns the source of the file documenting the
+// functions and types. The file named output is skipped, as it is being regenerated.
+func generate(dir string, funcs []string, types []string, output string) ([]byte, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == output {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	functions := make(map[string]functionDoc)
+	structs := make(map[string]typeDoc)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					functions[decl.Name.Name] = parseFunction(decl)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+						structs[typeSpec.Name.Name] = typeDoc{name: typeSpec.Name.Name, fields: parseFields(structType)}
+					}
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by tooldoc; DO NOT EDIT.\n\npackage %s\n\n", files[0].Name.Name)
+	fmt.Fprintf(&buf, "import %q\n\nfunc init() {\n", toolPackage)
+	for _, name := range funcs {
+		function, ok := functions[name]
+		if !ok {
+			return nil, fmt.Errorf("function %s not found in %s", name, dir)
+		}
+		fmt.Fprintf(&buf, "\ttool.RegisterFunctionDoc(%s, tool.FunctionDoc{\n", function.name)
+		fmt.Fprintf(&buf, "\t\tDescription: %s,\n\t\tParams: []tool.ParamDoc{\n", strconv.Quote(function.description))
+		for _, param := range function.params {
+			fmt.Fprintf(&buf, "\t\t\t{Name: %s, Description: %s},\n", strconv.Quote(param.name), strconv.Quote(param.description))
+		}
+		buf.WriteString("\t\t},\n\t})\n")
+	}
+	for _, name := range types {
+		structType, ok := structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct type %s not found in %s", name, dir)
+		}
+		fmt.Fprintf(&buf, "\ttool.RegisterTypeDoc(%s{}, tool.TypeDoc{\n", structType.name)
+		fields := make([]string, 0, len(structType.fields))
+		for field := range structType.fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			fmt.Fprintf(&buf, "\t\t%s: %s,\n", strconv.Quote(field), strconv.Quote(structType.fields[field]))
+		}
+		buf.WriteString("\t})\n")
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// parseFunction reads the description of a function and of its parameters from its doc comment
+func parseFunction(decl *ast.FuncDecl) functionDoc {
+	description, args := parseDocComment(decl.Doc.Text())
+	function := functionDoc{name: decl.Name.Name, description: description}
+
+	i := 0
+	for _, field := range decl.Type.Params.List {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{{Name: "_"}}
+		}
+		for _, ident := range names {
+			name := ident.Name
+			if name == "_" {
+				name = fmt.Sprintf("param%d", i)
+			}
+			function.params = append(function.params, paramDoc{name: name, description: args[name]})
+			i++
+		}
+	}
+	return function
+}
+
+// parseFields reads the descriptions of the exported fields of a struct, by JSON name
+func parseFields(structType *ast.StructType) map[string]string {
+	fields := make(map[string]string)
+	for _, field := range structType.Fields.List {
+		text := field.Doc.Text()
+		if text == "" {
+			text = field.Comment.Text()
+		}
+		description, _ := parseDocComment(text)
+		if description == "" {
+			continue
+		}
+
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			if value, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(value)
+			}
+		}
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			name := ident.Name
+			if jsonName, _, _ := strings.Cut(tag.Get("json"), ","); jsonName == "-" {
+				continue
+			} else if jsonName != "" {
+				name = jsonName
+			}
+			fields[name] = description
+		}
+	}
+	return fields
+}
+
+// parseDocComment splits a doc comment into its description and the descriptions of the items of
+// its "Args:" or "Parameters:" section. Other sections, such as "Returns:", are left out. Lines of
+// a paragraph are joined, so the description reads as the doc comment renders.
+func parseDocComment(text string) (string, map[string]string) {
+	args := make(map[string]string)
+	var paragraphs []string
+	var paragraph []string
+	section := ""
+	lastArg := ""
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			paragraphs = append(paragraphs, strings.Join(paragraph, " "))
+			paragraph = nil
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if isSectionHeader(trimmed) {
+			flush()
+			section = strings.ToLower(strings.TrimSuffix(trimmed, ":"))
+			lastArg = ""
+			continue
+		}
+
+		switch section {
+		case "":
+			if trimmed == "" {
+				flush()
+			} else {
+				paragraph = append(paragraph, trimmed)
+			}
+		case "args", "arguments", "parameters", "params":
+			item := strings.TrimSpace(strings.TrimLeft(trimmed, "-*"))
+			name, description, ok := strings.Cut(item, ":")
+			name = strings.TrimSpace(name)
+			if ok && name != "" && !strings.Contains(name, " ") {
+				lastArg = name
+				args[name] = strings.TrimSpace(description)
+			} else if lastArg != "" && trimmed != "" {
+				args[lastArg] += " " + trimmed
+			}
+		}
+	}
+	flush()
+	return strings.Join(paragraphs, "\n\n"), args
+}
+
+// isSectionHeader reports whether a line starts a section such as "Args:" or "Returns:"
+func isSectionHeader(line string) bool {
+	name, ok := strings.CutSuffix(line, ":")
+	if !ok || name == "" || strings.ContainsAny(name, " -*") {
+		return false
+	}
+	return name[0] >= 'A' && name[0] <= 'Z'
+}
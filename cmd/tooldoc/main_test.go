@@ -0,0 +1,94 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const weatherSource = `package weather
+
+import "context"
+
+// Forecast is the weather forecast of a city
+type Forecast struct {
+	// City is the name of the city
+	City string ` + "`json:\"city\"`" + `
+
+	Days    int    ` + "`json:\"days\"`" + ` // the number of days
+	Summary string // a short summary
+	Secret  string ` + "`json:\"-\"`" + ` // never sent
+}
+
+// getWeather returns the weather forecast of a city,
+// for up to a week.
+//
+// Args:
+//   - city: the name of the city,
+//     e.g. "Tokyo"
+//   - days: the number of days to forecast
+//
+// Returns:
+//   - the forecast
+func getWeather(ctx context.Context, city string, days int, _ bool) (Forecast, error) {
+	return Forecast{City: city, Days: days}, nil
+}
+`
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "weather.go"), []byte(weatherSource), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tooldoc_gen.go"), []byte("not go"), 0o644))
+
+	source, err := generate(dir, []string{"getWeather"}, []string{"Forecast"}, "tooldoc_gen.go")
+	require.NoError(t, err)
+
+	expected := `// Code generated by tooldoc; DO NOT EDIT.
+
+package weather
+
+import "github.com/ryichk/ai-agents-sdk-go/tool"
+
+func init() {
+	tool.RegisterFunctionDoc(getWeather, tool.FunctionDoc{
+		Description: "getWeather returns the weather forecast of a city, for up to a week.",
+		Params: []tool.ParamDoc{
+			{Name: "ctx", Description: ""},
+			{Name: "city", Description: "the name of the city, e.g. \"Tokyo\""},
+			{Name: "days", Description: "the number of days to forecast"},
+			{Name: "param3", Description: ""},
+		},
+	})
+	tool.RegisterTypeDoc(Forecast{}, tool.TypeDoc{
+		"Summary": "a short summary",
+		"city":    "City is the name of the city",
+		"days":    "the number of days",
+	})
+}
+`
+	assert.Equal(t, expected, string(source))
+}
+
+func TestGenerateNotFound(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "weather.go"), []byte(weatherSource), 0o644))
+
+	_, err := generate(dir, []string{"getForecast"}, nil, "tooldoc_gen.go")
+	assert.ErrorContains(t, err, "function getForecast not found")
+
+	_, err = generate(dir, nil, []string{"Weather"}, "tooldoc_gen.go")
+	assert.ErrorContains(t, err, "struct type Weather not found")
+}
+
+func TestParseDocComment(t *testing.T) {
+	description, args := parseDocComment("Adds two numbers.\n\nThe sum may overflow.\n\nParameters:\n  a: the first number\n  b: the second number\n")
+	assert.Equal(t, "Adds two numbers.\n\nThe sum may overflow.", description)
+	assert.Equal(t, map[string]string{"a": "the first number", "b": "the second number"}, args)
+}
@@ -26,29 +26,6 @@ After the translations are completed, a synthesizer agent inspects, corrects if
 and produces a final concatenated response.
 */
 
-// CustomRunnerImpl is a custom implementation of the RunnerIF interface
-type CustomRunnerImpl struct {
-	Config runner.RunConfig
-}
-
-// Run runs the agent with the custom configuration
-func (r *CustomRunnerImpl) Run(ctx context.Context, agentIF any, input string) (any, error) {
-	// Check if the agent is of the correct type
-	a, ok := agentIF.(*agent.Agent)
-	if !ok {
-		return nil, fmt.Errorf("agent must be of type *agent.Agent")
-	}
-
-	// Run with the custom configuration
-	result, err := runner.RunWithConfig(ctx, a, input, r.Config)
-	if err != nil {
-		return nil, err
-	}
-
-	// Wrap the result to implement the RunResult interface
-	return &runner.GetResult{Result: result}, nil
-}
-
 func main() {
 	apiKey := os.Getenv("OPENAI_API_KEY")
 	if apiKey == "" {
@@ -70,11 +47,6 @@ func main() {
 	runConfig := runner.DefaultRunConfig()
 	runConfig.ModelProvider = provider
 
-	// Create a custom runner implementation with the config
-	customRunner := &CustomRunnerImpl{
-		Config: runConfig,
-	}
-
 	// Create translation agents
 	spanishAgent := agent.New(
 		"spanish_agent",
@@ -100,8 +72,8 @@ func main() {
 	)
 	japaneseAgent.HandoffDescription = "An English to Japanese translator"
 
-	// Create agent tools using the custom runner
-	spanishTool, err := spanishAgent.AsTool(customRunner, tool.AgentToolOption{
+	// Create agent tools that run with the same config as the orchestrator
+	spanishTool, err := runner.AsToolWithConfig(spanishAgent, runConfig, tool.AgentToolOption{
 		Name:        "translate_to_spanish",
 		Description: "Translate the user's message to Spanish",
 	})
@@ -110,7 +82,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	frenchTool, err := frenchAgent.AsTool(customRunner, tool.AgentToolOption{
+	frenchTool, err := runner.AsToolWithConfig(frenchAgent, runConfig, tool.AgentToolOption{
 		Name:        "translate_to_french",
 		Description: "Translate the user's message to French",
 	})
@@ -119,7 +91,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	italianTool, err := italianAgent.AsTool(customRunner, tool.AgentToolOption{
+	italianTool, err := runner.AsToolWithConfig(italianAgent, runConfig, tool.AgentToolOption{
 		Name:        "translate_to_italian",
 		Description: "Translate the user's message to Italian",
 	})
@@ -128,7 +100,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	japaneseTool, err := japaneseAgent.AsTool(customRunner, tool.AgentToolOption{
+	japaneseTool, err := runner.AsToolWithConfig(japaneseAgent, runConfig, tool.AgentToolOption{
 		Name:        "translate_to_japanese",
 		Description: "Translate the user's message to Japanese",
 	})
@@ -0,0 +1,25 @@
+module github.com/ryichk/ai-agents-sdk-go/examples/grpc-server
+
+go 1.24.1
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/ryichk/ai-agents-sdk-go v0.0.0
+	github.com/ryichk/ai-agents-sdk-go/proto v0.0.0
+	google.golang.org/grpc v1.72.0
+)
+
+require (
+	github.com/abadojack/whatlanggo v1.0.1 // indirect
+	github.com/sashabaranov/go-openai v1.38.0 // indirect
+	golang.org/x/net v0.35.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250218202821-56aae31c358a // indirect
+	google.golang.org/protobuf v1.36.6 // indirect
+)
+
+replace (
+	github.com/ryichk/ai-agents-sdk-go => ../..
+	github.com/ryichk/ai-agents-sdk-go/proto => ../../proto
+)
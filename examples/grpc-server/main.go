@@ -0,0 +1,85 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// This example serves agents over gRPC with the agents.v1 AgentService
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	agentsv1 "github.com/ryichk/ai-agents-sdk-go/proto/agents/v1"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+// currentTime returns the current time in a time zone
+func currentTime(ctx context.Context, zone string) (string, error) {
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return "", err
+	}
+	return time.Now().In(location).Format(time.RFC1123), nil
+}
+
+func main() {
+	addr := flag.String("addr", ":50051", "address to listen on")
+	flag.Parse()
+
+	if os.Getenv("OPENAI_API_KEY") == "" {
+		log.Fatal("OPENAI_API_KEY environment variable is not set")
+	}
+
+	provider, err := model.NewDefaultOpenAIProvider()
+	if err != nil {
+		log.Fatalf("Failed to create OpenAI provider: %v", err)
+	}
+
+	timeTool, err := tool.NewFunctionTool(currentTime, tool.FunctionToolOption{
+		NameOverride:        "current_time",
+		DescriptionOverride: "Returns the current time in an IANA time zone such as Asia/Tokyo",
+	})
+	if err != nil {
+		log.Fatalf("Failed to create tool: %v", err)
+	}
+
+	assistant := agent.New("Assistant", "You are a helpful assistant. Answer the user's questions briefly.")
+	assistant.AddTool(timeTool)
+
+	translator := agent.New("Translator", "Translate the user's message into English.")
+
+	config := runner.DefaultRunConfig()
+	config.ModelProvider = provider
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	agentsv1.RegisterAgentServiceServer(grpcServer, newServer(config, assistant, translator))
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+		log.Println("Shutting down")
+		grpcServer.GracefulStop()
+	}()
+
+	log.Printf("AgentService listening on %s", listener.Addr())
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
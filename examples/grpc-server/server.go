@@ -0,0 +1,342 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/agenthttp"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	agentsv1 "github.com/ryichk/ai-agents-sdk-go/proto/agents/v1"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+// server implements agentsv1.AgentServiceServer on top of the runner. Sessions are kept in an
+// agenthttp.SessionStore, so the gRPC and HTTP transports can share them.
+type server struct {
+	agentsv1.UnimplementedAgentServiceServer
+
+	// agents are the agents a request can name; defaultAgent runs when it names none
+	agents       map[string]*agent.Agent
+	defaultAgent *agent.Agent
+	config       runner.RunConfig
+	sessions     agenthttp.SessionStore
+}
+
+// newServer creates a server running defaultAgent and the other agents by name
+func newServer(config runner.RunConfig, defaultAgent *agent.Agent, others ...*agent.Agent) *server {
+	s := &server{
+		agents:       map[string]*agent.Agent{defaultAgent.Name: defaultAgent},
+		defaultAgent: defaultAgent,
+		config:       config,
+		sessions:     agenthttp.NewMemorySessionStore(agenthttp.DefaultSessionTTL),
+	}
+	for _, a := range others {
+		s.agents[a.Name] = a
+	}
+	return s
+}
+
+// Run runs an agent and returns its result
+func (s *server) Run(ctx context.Context, req *agentsv1.RunRequest) (*agentsv1.RunResult, error) {
+	session, current, config, err := s.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.run(ctx, session, current, req.GetInput(), config)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return toRunResult(session.ID, result), nil
+}
+
+// RunStream runs an agent and streams its events, ending with run_completed or error
+func (s *server) RunStream(req *agentsv1.RunRequest, stream grpc.ServerStreamingServer[agentsv1.RunEvent]) error {
+	ctx := stream.Context()
+	session, current, config, err := s.prepare(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	events := &eventSender{stream: stream}
+	defer events.close()
+	events.send(&agentsv1.RunEvent{Event: &agentsv1.RunEvent_RunStarted{
+		RunStarted: &agentsv1.RunStarted{SessionId: session.ID},
+	}})
+	streamEvents(&config, events)
+
+	result, err := s.run(ctx, session, current, req.GetInput(), config)
+	if err != nil {
+		traceID, _ := runner.TraceIDFromError(err)
+		events.send(&agentsv1.RunEvent{Event: &agentsv1.RunEvent_Error{
+			Error: &agentsv1.Error{Message: err.Error(), TraceId: traceID},
+		}})
+		return nil
+	}
+	events.send(&agentsv1.RunEvent{Event: &agentsv1.RunEvent_RunCompleted{
+		RunCompleted: toRunResult(session.ID, result),
+	}})
+	return nil
+}
+
+// DeleteSession removes a session and its history
+func (s *server) DeleteSession(ctx context.Context, req *agentsv1.DeleteSessionRequest) (*agentsv1.DeleteSessionResponse, error) {
+	if err := s.sessions.Delete(ctx, req.GetSessionId()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &agentsv1.DeleteSessionResponse{}, nil
+}
+
+// prepare validates the request and returns its session, the agent to run and the run's configuration
+func (s *server) prepare(ctx context.Context, req *agentsv1.RunRequest) (*agenthttp.Session, *agent.Agent, runner.RunConfig, error) {
+	config := s.config
+	if strings.TrimSpace(req.GetInput()) == "" {
+		return nil, nil, config, status.Error(codes.InvalidArgument, agenthttp.ErrEmptyInput.Error())
+	}
+
+	current := s.defaultAgent
+	if name := req.GetAgent(); name != "" {
+		named, ok := s.agents[name]
+		if !ok {
+			return nil, nil, config, status.Errorf(codes.NotFound, "agent %q not found", name)
+		}
+		current = named
+	}
+
+	sessionID := req.GetSessionId()
+	if sessionID == "" {
+		sessionID = uuid.New().String()
+	}
+	session, err := s.sessions.Get(ctx, sessionID)
+	if err != nil {
+		return nil, nil, config, status.Error(codes.Internal, err.Error())
+	}
+	if session == nil {
+		session = &agenthttp.Session{ID: sessionID}
+	}
+	// A session continues with the agent that answered last
+	if session.Agent != nil {
+		current = session.Agent
+	}
+
+	config.History = session.History
+	if history := req.GetHistory(); len(history) > 0 {
+		config.History = fromMessages(history)
+	}
+	if req.GetMaxTurns() > 0 {
+		config.MaxTurns = int(req.GetMaxTurns())
+	}
+	if len(req.GetMetadata()) > 0 {
+		metadata := make(map[string]any, len(config.TraceMetadata)+len(req.GetMetadata()))
+		for k, v := range config.TraceMetadata {
+			metadata[k] = v
+		}
+		for k, v := range req.GetMetadata() {
+			metadata[k] = v
+		}
+		config.TraceMetadata = metadata
+	}
+	return session, current, config, nil
+}
+
+// run runs the agent with runner.RunWithConfig and saves the session
+func (s *server) run(ctx context.Context, session *agenthttp.Session, current *agent.Agent, input string, config runner.RunConfig) (*runner.Result, error) {
+	result, err := runner.RunWithConfig(ctx, current, input, config)
+	if err != nil {
+		return nil, err
+	}
+
+	session.History = result.History
+	session.Agent = result.LastAgent
+	session.UpdatedAt = time.Now()
+	if err := s.sessions.Save(ctx, session); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// eventSender sends the events of a run on its stream. Tools abandoned by the runner may still
+// report progress after the stream's handler returned, so sends after close are dropped.
+type eventSender struct {
+	mu     sync.Mutex
+	stream grpc.ServerStreamingServer[agentsv1.RunEvent]
+	closed bool
+}
+
+func (e *eventSender) send(event *agentsv1.RunEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	// A failed send means the client is gone; the run is canceled with the stream's context
+	_ = e.stream.Send(event)
+}
+
+func (e *eventSender) close() {
+	e.mu.Lock()
+	e.closed = true
+	e.mu.Unlock()
+}
+
+// streamEvents wraps the callbacks of the run's configuration to also send them as events
+func streamEvents(config *runner.RunConfig, events *eventSender) {
+	hooks := &eventHooks{events: events, llm: config.LLMHooks, toolCall: config.ToolCallHooks}
+	config.LLMHooks = hooks
+	config.ToolCallHooks = hooks
+
+	progressHandler := config.ToolProgressHandler
+	config.ToolProgressHandler = func(ctx context.Context, event tool.ProgressEvent) {
+		events.send(&agentsv1.RunEvent{Event: &agentsv1.RunEvent_ToolProgress{ToolProgress: &agentsv1.ToolProgress{
+			ToolName:  event.ToolName,
+			Message:   event.Message,
+			Progress:  event.Progress,
+			Timestamp: event.Timestamp.UnixMilli(),
+		}}})
+		if progressHandler != nil {
+			progressHandler(ctx, event)
+		}
+	}
+
+	handoffCallback := config.HandoffCallback
+	config.HandoffCallback = func(ctx context.Context, target *agent.Agent, source *agent.Agent, inputJSON string) error {
+		if handoffCallback != nil {
+			if err := handoffCallback(ctx, target, source, inputJSON); err != nil {
+				return err
+			}
+		}
+		events.send(&agentsv1.RunEvent{Event: &agentsv1.RunEvent_Handoff{
+			Handoff: &agentsv1.Handoff{From: source.Name, To: target.Name},
+		}})
+		return nil
+	}
+}
+
+// eventHooks sends the model's text and the tool calls as events, then calls the configured hooks
+type eventHooks struct {
+	events   *eventSender
+	llm      agent.LLMHooks
+	toolCall agent.ToolCallHooks
+}
+
+func (h *eventHooks) OnLLMStart(ctx context.Context, a *agent.Agent, messages []model.Message, settings model.Settings) error {
+	if h.llm != nil {
+		return h.llm.OnLLMStart(ctx, a, messages, settings)
+	}
+	return nil
+}
+
+func (h *eventHooks) OnLLMEnd(ctx context.Context, a *agent.Agent, response *model.Response, err error) error {
+	if response != nil && response.Message.Content != "" {
+		h.events.send(&agentsv1.RunEvent{Event: &agentsv1.RunEvent_TextDelta{
+			TextDelta: &agentsv1.TextDelta{Agent: a.Name, Content: response.Message.Content},
+		}})
+	}
+	if h.llm != nil {
+		return h.llm.OnLLMEnd(ctx, a, response, err)
+	}
+	return nil
+}
+
+func (h *eventHooks) OnToolCallStart(ctx context.Context, a *agent.Agent, t tool.Tool, call model.ToolCall) error {
+	h.events.send(&agentsv1.RunEvent{Event: &agentsv1.RunEvent_ToolCallStarted{
+		ToolCallStarted: &agentsv1.ToolCallEvent{Agent: a.Name, Tool: t.Name(), CallId: call.ID, Arguments: call.Function.Arguments},
+	}})
+	if h.toolCall != nil {
+		return h.toolCall.OnToolCallStart(ctx, a, t, call)
+	}
+	return nil
+}
+
+func (h *eventHooks) OnToolCallEnd(ctx context.Context, a *agent.Agent, t tool.Tool, call model.ToolCall, output string) error {
+	h.events.send(&agentsv1.RunEvent{Event: &agentsv1.RunEvent_ToolCallCompleted{
+		ToolCallCompleted: &agentsv1.ToolCallEvent{Agent: a.Name, Tool: t.Name(), CallId: call.ID, Arguments: call.Function.Arguments, Output: output},
+	}})
+	if h.toolCall != nil {
+		return h.toolCall.OnToolCallEnd(ctx, a, t, call, output)
+	}
+	return nil
+}
+
+// toRunResult converts a result to its protobuf message
+func toRunResult(sessionID string, result *runner.Result) *agentsv1.RunResult {
+	lastAgent := ""
+	if result.LastAgent != nil {
+		lastAgent = result.LastAgent.Name
+	}
+	return &agentsv1.RunResult{
+		SchemaVersion: runner.WireFormatVersion,
+		SessionId:     sessionID,
+		FinalOutput:   result.FinalOutput,
+		LastAgent:     lastAgent,
+		History:       toMessages(result.History),
+		Usage: &agentsv1.Usage{
+			PromptTokens:       int64(result.Usage.PromptTokens),
+			CompletionTokens:   int64(result.Usage.CompletionTokens),
+			TotalTokens:        int64(result.Usage.TotalTokens),
+			CachedPromptTokens: int64(result.Usage.CachedPromptTokens),
+		},
+		LastResponseId: result.LastResponseID,
+		TraceId:        result.TraceID,
+	}
+}
+
+// toMessages converts runner messages to protobuf messages
+func toMessages(history []runner.Message) []*agentsv1.Message {
+	messages := make([]*agentsv1.Message, 0, len(history))
+	for _, msg := range history {
+		converted := &agentsv1.Message{
+			Role:       msg.Role,
+			Content:    msg.Content,
+			ToolCallId: msg.ToolCallID,
+			Name:       msg.Name,
+			Reasoning:  msg.Reasoning,
+		}
+		for _, call := range msg.ToolCalls {
+			converted.ToolCalls = append(converted.ToolCalls, &agentsv1.ToolCall{
+				Id:        call.ID,
+				Type:      call.Type,
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			})
+		}
+		messages = append(messages, converted)
+	}
+	return messages
+}
+
+// fromMessages converts protobuf messages to runner messages
+func fromMessages(messages []*agentsv1.Message) []runner.Message {
+	history := make([]runner.Message, 0, len(messages))
+	for _, msg := range messages {
+		converted := runner.Message{
+			Role:       msg.GetRole(),
+			Content:    msg.GetContent(),
+			ToolCallID: msg.GetToolCallId(),
+			Name:       msg.GetName(),
+			Reasoning:  msg.GetReasoning(),
+		}
+		for _, call := range msg.GetToolCalls() {
+			converted.ToolCalls = append(converted.ToolCalls, model.ToolCall{
+				ID:       call.GetId(),
+				Type:     call.GetType(),
+				Function: model.FunctionCall{Name: call.GetName(), Arguments: call.GetArguments()},
+			})
+		}
+		history = append(history, converted)
+	}
+	return history
+}
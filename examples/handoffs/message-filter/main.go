@@ -55,92 +55,6 @@ func (t *RandomNumberTool) Invoke(ctx context.Context, input string) (string, er
 	return strconv.Itoa(result), nil
 }
 
-type MessageFilterHandoff struct {
-	targetAgent  *agent.Agent
-	lastHandoff  time.Time
-	filterPrefix string
-}
-
-func NewMessageFilterHandoff(target *agent.Agent, filterPrefix string) *MessageFilterHandoff {
-	return &MessageFilterHandoff{
-		targetAgent:  target,
-		filterPrefix: filterPrefix,
-	}
-}
-
-func (h *MessageFilterHandoff) TargetAgent() any {
-	return h.targetAgent
-}
-
-func (h *MessageFilterHandoff) Description() string {
-	return fmt.Sprintf("Handoff to %s with filtered messages", h.targetAgent.Name)
-}
-
-func (h *MessageFilterHandoff) ShouldHandoff(ctx context.Context, input string) (bool, error) {
-	return true, nil
-}
-
-func (h *MessageFilterHandoff) Name() string {
-	return "handoff_to_" + h.targetAgent.Name
-}
-
-func (h *MessageFilterHandoff) FilterInput(ctx context.Context, inputData *handoff.InputData) (*handoff.InputData, error) {
-	// Message filtering process
-	for i, msg := range inputData.InputHistory {
-		// Filter user messages only
-		if role, ok := msg["role"].(string); ok && role == "user" {
-			if content, ok := msg["content"].(string); ok {
-				// Add filter prefix
-				msg["content"] = h.filterPrefix + ": " + content
-				inputData.InputHistory[i] = msg
-			}
-		}
-	}
-
-	fmt.Println("Messages filtered:")
-	for _, msg := range inputData.InputHistory {
-		if role, ok := msg["role"].(string); ok && role == "user" {
-			if content, ok := msg["content"].(string); ok {
-				fmt.Printf("- %s\n", content)
-			}
-		}
-	}
-
-	return inputData, nil
-}
-
-func (h *MessageFilterHandoff) GetLastHandoffTime() time.Time {
-	return h.lastHandoff
-}
-
-func (h *MessageFilterHandoff) UpdateLastHandoffTime() {
-	h.lastHandoff = time.Now()
-}
-
-func (h *MessageFilterHandoff) ToolName() string {
-	return "handoff_to_" + h.targetAgent.Name
-}
-
-func (h *MessageFilterHandoff) ToolDescription() string {
-	return fmt.Sprintf("Handoff to %s with filtered messages", h.targetAgent.Name)
-}
-
-func (h *MessageFilterHandoff) InputJSONSchema() handoff.JSONSchema {
-	return handoff.JSONSchema{
-		"type": "object",
-		"properties": map[string]any{
-			"input": map[string]any{
-				"type": "string",
-			},
-		},
-	}
-}
-
-func (h *MessageFilterHandoff) OnHandoff(ctx context.Context, inputData *handoff.InputData, inputJSON string) error {
-	fmt.Printf("Handoff started: %s -> %s\n", "Source Agent", h.targetAgent.Name)
-	return nil
-}
-
 type ExampleHooks struct {
 	agent.BaseAgentHooks
 }
@@ -191,8 +105,10 @@ func main() {
 	intermediateAgent.AddTool(&RandomNumberTool{})
 	intermediateAgent.SetHooks(hooks)
 
-	filterHandoff := NewMessageFilterHandoff(finalAgent, "Random number")
-	intermediateAgent.AddHandoff(filterHandoff)
+	// The final agent only sees the conversation: no tool calls, and only the last few messages
+	intermediateAgent.AddHandoff(handoff.NewHandoffWithOptions(finalAgent, "Handoff to the final agent", handoff.Options{
+		InputFilter: handoff.Chain(handoff.RemoveAllTools, handoff.KeepLastN(4)),
+	}))
 
 	startAgent := agent.New("Start Agent", "Generate a random number between 1 and 5, then hand off to the intermediate agent.")
 	startAgent.AddTool(&RandomNumberTool{})
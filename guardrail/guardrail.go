@@ -6,6 +6,8 @@ package guardrail
 
 import (
 	"context"
+
+	"github.com/ryichk/ai-agents-sdk-go/interfaces"
 )
 
 type InputGuardrailResult struct {
@@ -14,6 +16,10 @@ type InputGuardrailResult struct {
 
 	// Message is the message when the guardrail is tripped
 	Message string
+
+	// Metadata is extra information about the check, such as scores or matched rules,
+	// reported in the run's result (optional)
+	Metadata map[string]any
 }
 
 type OutputGuardrailResult struct {
@@ -25,6 +31,18 @@ type OutputGuardrailResult struct {
 
 	// ModifiedOutput is the modified output (if the guardrail modifies the output)
 	ModifiedOutput string
+
+	// ModifiedStructuredOutput replaces the structured output (StructuredOutputGuardrail only)
+	ModifiedStructuredOutput any
+
+	// Reask asks the runner to send Message back to the model as a correction and let it answer
+	// once more before the run fails. Each guardrail is re-asked at most once per run, unless it
+	// implements ReaskLimiter (see WithOutputGuardrailRetry).
+	Reask bool
+
+	// Metadata is extra information about the check, such as scores or matched rules,
+	// reported in the run's result (optional)
+	Metadata map[string]any
 }
 
 type InputGuardrail interface {
@@ -39,6 +57,13 @@ type OutputGuardrail interface {
 	Check(ctx context.Context, output string) (OutputGuardrailResult, error)
 }
 
+// StructuredOutputGuardrail is an optional interface that OutputGuardrail implementations can satisfy
+// to receive the parsed output of agents with an OutputType. For those agents CheckStructured is
+// called instead of Check, with the agent and the value of the agent's OutputType.
+type StructuredOutputGuardrail interface {
+	CheckStructured(ctx context.Context, agent interfaces.Agent, output any) (OutputGuardrailResult, error)
+}
+
 type FunctionInputGuardrail struct {
 	name        string
 	description string
@@ -75,6 +100,29 @@ func (g *FunctionOutputGuardrail) Check(ctx context.Context, output string) (Out
 	return g.checkFunc(ctx, output)
 }
 
+type FunctionStructuredOutputGuardrail struct {
+	name        string
+	description string
+	checkFunc   func(ctx context.Context, agent interfaces.Agent, output any) (OutputGuardrailResult, error)
+}
+
+func (g *FunctionStructuredOutputGuardrail) Name() string {
+	return g.name
+}
+
+func (g *FunctionStructuredOutputGuardrail) Description() string {
+	return g.description
+}
+
+// Check passes the text output of agents without an OutputType to the check function, with a nil agent
+func (g *FunctionStructuredOutputGuardrail) Check(ctx context.Context, output string) (OutputGuardrailResult, error) {
+	return g.checkFunc(ctx, nil, output)
+}
+
+func (g *FunctionStructuredOutputGuardrail) CheckStructured(ctx context.Context, agent interfaces.Agent, output any) (OutputGuardrailResult, error) {
+	return g.checkFunc(ctx, agent, output)
+}
+
 func NewInputGuardrail(name string, description string, checkFunc func(ctx context.Context, input string) (InputGuardrailResult, error)) InputGuardrail {
 	return &FunctionInputGuardrail{
 		name:        name,
@@ -90,3 +138,14 @@ func NewOutputGuardrail(name string, description string, checkFunc func(ctx cont
 		checkFunc:   checkFunc,
 	}
 }
+
+// NewStructuredOutputGuardrail creates an output guardrail that receives the parsed structured output.
+// checkFunc can type-assert output to the agent's OutputType and return a replacement value in
+// ModifiedStructuredOutput.
+func NewStructuredOutputGuardrail(name string, description string, checkFunc func(ctx context.Context, agent interfaces.Agent, output any) (OutputGuardrailResult, error)) OutputGuardrail {
+	return &FunctionStructuredOutputGuardrail{
+		name:        name,
+		description: description,
+		checkFunc:   checkFunc,
+	}
+}
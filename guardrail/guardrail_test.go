@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ryichk/ai-agents-sdk-go/interfaces"
 )
 
 func TestInputGuardrail(t *testing.T) {
@@ -162,3 +164,24 @@ func TestOutputGuardrail(t *testing.T) {
 	assert.Error(t, err, "Error should occur")
 	assert.Equal(t, expectedError, err, "Returned error does not match")
 }
+
+func TestStructuredOutputGuardrail(t *testing.T) {
+	outputGuardrail := NewStructuredOutputGuardrail("Structured", "Checks structured outputs", func(ctx context.Context, agent interfaces.Agent, output any) (OutputGuardrailResult, error) {
+		_, isString := output.(string)
+		return OutputGuardrailResult{Allowed: !isString, Message: "expected a structured output"}, nil
+	})
+	assert.Equal(t, "Structured", outputGuardrail.Name())
+	assert.Equal(t, "Checks structured outputs", outputGuardrail.Description())
+
+	structured, ok := outputGuardrail.(StructuredOutputGuardrail)
+	assert.True(t, ok, "Guardrail should implement StructuredOutputGuardrail")
+
+	result, err := structured.CheckStructured(context.Background(), nil, map[string]any{"answer": 42})
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// Text outputs are passed to the same function
+	result, err = outputGuardrail.Check(context.Background(), "plain text")
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+}
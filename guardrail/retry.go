@@ -0,0 +1,65 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package guardrail
+
+import (
+	"context"
+
+	"github.com/ryichk/ai-agents-sdk-go/interfaces"
+)
+
+// ReaskLimiter is an optional interface for output guardrails that let the model correct its
+// answer more than once. MaxReasks returns how many times per run the runner sends the
+// guardrail's message back to the model before the run fails with the tripwire error.
+type ReaskLimiter interface {
+	MaxReasks() int
+}
+
+// WithOutputGuardrailRetry wraps an output guardrail so that when it trips, its message is sent
+// back to the model as a correction, up to maxRetries times per run, before the run fails.
+// This suits failures the model can fix by itself, such as formatting or banned phrases.
+// The wrapped guardrail keeps its name, and its structured checks if it has any.
+func WithOutputGuardrailRetry(g OutputGuardrail, maxRetries int) OutputGuardrail {
+	retry := &retryOutputGuardrail{OutputGuardrail: g, maxRetries: maxRetries}
+	if structured, ok := g.(StructuredOutputGuardrail); ok {
+		return &retryStructuredOutputGuardrail{retryOutputGuardrail: retry, structured: structured}
+	}
+	return retry
+}
+
+// retryOutputGuardrail asks for a re-ask whenever the wrapped guardrail trips
+type retryOutputGuardrail struct {
+	OutputGuardrail
+	maxRetries int
+}
+
+func (g *retryOutputGuardrail) Check(ctx context.Context, output string) (OutputGuardrailResult, error) {
+	result, err := g.OutputGuardrail.Check(ctx, output)
+	return g.reask(result), err
+}
+
+// MaxReasks returns the number of retries of the guardrail
+func (g *retryOutputGuardrail) MaxReasks() int {
+	return g.maxRetries
+}
+
+// reask marks a tripped result for a re-ask
+func (g *retryOutputGuardrail) reask(result OutputGuardrailResult) OutputGuardrailResult {
+	if !result.Allowed && g.maxRetries > 0 {
+		result.Reask = true
+	}
+	return result
+}
+
+// retryStructuredOutputGuardrail is a retryOutputGuardrail wrapping a StructuredOutputGuardrail
+type retryStructuredOutputGuardrail struct {
+	*retryOutputGuardrail
+	structured StructuredOutputGuardrail
+}
+
+func (g *retryStructuredOutputGuardrail) CheckStructured(ctx context.Context, agent interfaces.Agent, output any) (OutputGuardrailResult, error) {
+	result, err := g.structured.CheckStructured(ctx, agent, output)
+	return g.reask(result), err
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package guardrail
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/interfaces"
+)
+
+func TestWithOutputGuardrailRetry(t *testing.T) {
+	ctx := context.Background()
+	banned := NewOutputGuardrail("banned_phrases", "Blocks banned phrases",
+		func(ctx context.Context, output string) (OutputGuardrailResult, error) {
+			if strings.Contains(output, "guarantee") {
+				return OutputGuardrailResult{Message: "do not promise a guarantee"}, nil
+			}
+			return OutputGuardrailResult{Allowed: true}, nil
+		})
+
+	g := WithOutputGuardrailRetry(banned, 3)
+	assert.Equal(t, "banned_phrases", g.Name())
+	assert.Equal(t, 3, g.(ReaskLimiter).MaxReasks())
+	_, structured := g.(StructuredOutputGuardrail)
+	assert.False(t, structured)
+
+	result, err := g.Check(ctx, "We guarantee delivery tomorrow")
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.True(t, result.Reask)
+	assert.Equal(t, "do not promise a guarantee", result.Message)
+
+	result, err = g.Check(ctx, "Delivery is planned for tomorrow")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.False(t, result.Reask)
+
+	// Structured guardrails keep their structured check
+	g = WithOutputGuardrailRetry(NewStructuredOutputGuardrail("positive", "Checks the total",
+		func(ctx context.Context, agent interfaces.Agent, output any) (OutputGuardrailResult, error) {
+			return OutputGuardrailResult{Message: "the total must be positive"}, nil
+		}), 1)
+	result, err = g.(StructuredOutputGuardrail).CheckStructured(ctx, nil, -1)
+	require.NoError(t, err)
+	assert.True(t, result.Reask)
+}
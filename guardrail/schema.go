@@ -0,0 +1,55 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package guardrail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/jsonschema"
+)
+
+// JSONSchemaGuardrailName is the name of the guardrail returned by NewJSONSchemaOutputGuardrail
+const JSONSchemaGuardrailName = "json_schema"
+
+// JSONSchemaOutputGuardrail is an output guardrail that validates the final output against a
+// JSON schema. For agents with an OutputType, the output is the JSON of the structured output.
+// Any JSON value can be validated, not only objects.
+type JSONSchemaOutputGuardrail struct {
+	// Schema is the JSON schema the output must match
+	Schema map[string]any
+
+	// Reask has the runner send the validation error back to the model once, so it can fix
+	// its answer, before the run fails
+	Reask bool
+}
+
+// NewJSONSchemaOutputGuardrail creates an output guardrail that validates the final output
+// against the schema
+func NewJSONSchemaOutputGuardrail(schema map[string]any) *JSONSchemaOutputGuardrail {
+	return &JSONSchemaOutputGuardrail{Schema: schema}
+}
+
+// Name returns the name of the guardrail
+func (g *JSONSchemaOutputGuardrail) Name() string {
+	return JSONSchemaGuardrailName
+}
+
+// Description returns the description of the guardrail
+func (g *JSONSchemaOutputGuardrail) Description() string {
+	return "Validates the output against a JSON schema"
+}
+
+// Check blocks output that is not valid JSON or does not match the schema
+func (g *JSONSchemaOutputGuardrail) Check(ctx context.Context, output string) (OutputGuardrailResult, error) {
+	if _, err := jsonschema.ValidateJSON(g.Schema, output); err != nil {
+		return OutputGuardrailResult{
+			Message:  fmt.Sprintf("The output does not match the JSON schema: %v", err),
+			Reask:    g.Reask,
+			Metadata: map[string]any{"error": err.Error()},
+		}, nil
+	}
+	return OutputGuardrailResult{Allowed: true}, nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package guardrail
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchemaOutputGuardrail(t *testing.T) {
+	ctx := context.Background()
+	g := NewJSONSchemaOutputGuardrail(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"city":       map[string]any{"type": "string"},
+			"population": map[string]any{"type": "integer"},
+		},
+		"required": []string{"city"},
+	})
+	assert.Equal(t, JSONSchemaGuardrailName, g.Name())
+
+	result, err := g.Check(ctx, `{"city":"Paris","population":2100000}`)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = g.Check(ctx, `{"population":2100000}`)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.False(t, result.Reask)
+	assert.Equal(t, "missing required field: city", result.Metadata["error"])
+
+	g.Reask = true
+	result, err = g.Check(ctx, `{"city":75}`)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.True(t, result.Reask)
+	assert.Contains(t, result.Message, "invalid type for field city")
+
+	result, err = g.Check(ctx, "Paris")
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package guardrail
+
+import (
+	"context"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// ToolGuardrailResult is the result of a tool input or output guardrail
+type ToolGuardrailResult struct {
+	// Allowed indicates whether the tool call or its output is allowed
+	Allowed bool
+
+	// Message is the message when the guardrail is tripped
+	Message string
+
+	// Reject, when the guardrail is tripped, sends Message to the model as the tool's output
+	// instead of stopping the run, so the model can continue without the tool's result
+	Reject bool
+
+	// ModifiedOutput replaces the tool's output (output guardrails only)
+	ModifiedOutput string
+}
+
+// TurnGuardrailResult is the result of a turn guardrail
+type TurnGuardrailResult struct {
+	// Allowed indicates whether the assistant message is allowed
+	Allowed bool
+
+	// Message is the message when the guardrail is tripped
+	Message string
+}
+
+// ToolInputGuardrail checks the arguments of every tool call before the tool is invoked
+type ToolInputGuardrail interface {
+	Name() string
+	Description() string
+	Check(ctx context.Context, toolName string, arguments string) (ToolGuardrailResult, error)
+}
+
+// ToolOutputGuardrail checks the output of every tool call before it is sent to the model
+type ToolOutputGuardrail interface {
+	Name() string
+	Description() string
+	Check(ctx context.Context, toolName string, arguments string, output string) (ToolGuardrailResult, error)
+}
+
+// TurnGuardrail checks every assistant message returned by the model, including intermediate
+// messages that call tools
+type TurnGuardrail interface {
+	Name() string
+	Description() string
+	Check(ctx context.Context, message model.Message) (TurnGuardrailResult, error)
+}
+
+type FunctionToolInputGuardrail struct {
+	name        string
+	description string
+	checkFunc   func(ctx context.Context, toolName string, arguments string) (ToolGuardrailResult, error)
+}
+
+func (g *FunctionToolInputGuardrail) Name() string {
+	return g.name
+}
+
+func (g *FunctionToolInputGuardrail) Description() string {
+	return g.description
+}
+
+func (g *FunctionToolInputGuardrail) Check(ctx context.Context, toolName string, arguments string) (ToolGuardrailResult, error) {
+	return g.checkFunc(ctx, toolName, arguments)
+}
+
+type FunctionToolOutputGuardrail struct {
+	name        string
+	description string
+	checkFunc   func(ctx context.Context, toolName string, arguments string, output string) (ToolGuardrailResult, error)
+}
+
+func (g *FunctionToolOutputGuardrail) Name() string {
+	return g.name
+}
+
+func (g *FunctionToolOutputGuardrail) Description() string {
+	return g.description
+}
+
+func (g *FunctionToolOutputGuardrail) Check(ctx context.Context, toolName string, arguments string, output string) (ToolGuardrailResult, error) {
+	return g.checkFunc(ctx, toolName, arguments, output)
+}
+
+type FunctionTurnGuardrail struct {
+	name        string
+	description string
+	checkFunc   func(ctx context.Context, message model.Message) (TurnGuardrailResult, error)
+}
+
+func (g *FunctionTurnGuardrail) Name() string {
+	return g.name
+}
+
+func (g *FunctionTurnGuardrail) Description() string {
+	return g.description
+}
+
+func (g *FunctionTurnGuardrail) Check(ctx context.Context, message model.Message) (TurnGuardrailResult, error) {
+	return g.checkFunc(ctx, message)
+}
+
+func NewToolInputGuardrail(name string, description string, checkFunc func(ctx context.Context, toolName string, arguments string) (ToolGuardrailResult, error)) ToolInputGuardrail {
+	return &FunctionToolInputGuardrail{
+		name:        name,
+		description: description,
+		checkFunc:   checkFunc,
+	}
+}
+
+func NewToolOutputGuardrail(name string, description string, checkFunc func(ctx context.Context, toolName string, arguments string, output string) (ToolGuardrailResult, error)) ToolOutputGuardrail {
+	return &FunctionToolOutputGuardrail{
+		name:        name,
+		description: description,
+		checkFunc:   checkFunc,
+	}
+}
+
+func NewTurnGuardrail(name string, description string, checkFunc func(ctx context.Context, message model.Message) (TurnGuardrailResult, error)) TurnGuardrail {
+	return &FunctionTurnGuardrail{
+		name:        name,
+		description: description,
+		checkFunc:   checkFunc,
+	}
+}
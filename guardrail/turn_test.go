@@ -0,0 +1,45 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package guardrail
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestToolGuardrails(t *testing.T) {
+	inputGuardrail := NewToolInputGuardrail("Input", "Checks tool arguments", func(ctx context.Context, toolName string, arguments string) (ToolGuardrailResult, error) {
+		return ToolGuardrailResult{Allowed: toolName != "shell", Message: "shell is disabled", Reject: true}, nil
+	})
+	assert.Equal(t, "Input", inputGuardrail.Name())
+	assert.Equal(t, "Checks tool arguments", inputGuardrail.Description())
+
+	result, err := inputGuardrail.Check(context.Background(), "shell", `{"cmd": "ls"}`)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.True(t, result.Reject)
+
+	outputGuardrail := NewToolOutputGuardrail("Output", "Checks tool outputs", func(ctx context.Context, toolName string, arguments string, output string) (ToolGuardrailResult, error) {
+		return ToolGuardrailResult{Allowed: true, ModifiedOutput: output + "!"}, nil
+	})
+	result, err = outputGuardrail.Check(context.Background(), "echo", "{}", "hi")
+	assert.NoError(t, err)
+	assert.Equal(t, "hi!", result.ModifiedOutput)
+}
+
+func TestTurnGuardrail(t *testing.T) {
+	turnGuardrail := NewTurnGuardrail("Turn", "Checks assistant messages", func(ctx context.Context, message model.Message) (TurnGuardrailResult, error) {
+		return TurnGuardrailResult{Allowed: len(message.ToolCalls) == 0, Message: "no tools"}, nil
+	})
+	assert.Equal(t, "Turn", turnGuardrail.Name())
+
+	result, err := turnGuardrail.Check(context.Background(), model.Message{Role: "assistant", Content: "hi"})
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
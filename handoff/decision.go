@@ -0,0 +1,24 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import "context"
+
+// Explainer is implemented by handoffs that can tell why they did or did not hand off, such as
+// the keyword that matched. The runner records the reason with each decision, for debugging.
+type Explainer interface {
+	// ExplainHandoff decides like ShouldHandoff, and returns a short reason for the decision
+	ExplainHandoff(ctx context.Context, input string) (bool, string, error)
+}
+
+// Evaluate decides whether the handoff accepts the input, with the reason given by handoffs
+// implementing Explainer (empty for the others)
+func Evaluate(ctx context.Context, h Handoff, input string) (bool, string, error) {
+	if explainer, ok := h.(Explainer); ok {
+		return explainer.ExplainHandoff(ctx, input)
+	}
+	shouldHandoff, err := h.ShouldHandoff(ctx, input)
+	return shouldHandoff, "", err
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	ctx := context.Background()
+	target := newMockAgent("Target Agent", "This is a target agent")
+
+	keyword := NewKeywordHandoff(target, "Keyword", []string{"refund", "invoice"})
+	decision, reason, err := Evaluate(ctx, keyword, "Where is my INVOICE?")
+	require.NoError(t, err)
+	assert.True(t, decision)
+	assert.Equal(t, `input contains keyword "invoice"`, reason)
+
+	decision, reason, err = Evaluate(ctx, keyword, "hello")
+	require.NoError(t, err)
+	assert.False(t, decision)
+	assert.Equal(t, "input contains none of the keywords", reason)
+
+	pattern, err := NewPatternHandoff(target, "Pattern", `order #\d+`)
+	require.NoError(t, err)
+	decision, reason, err = Evaluate(ctx, pattern, "about order #42 please")
+	require.NoError(t, err)
+	assert.True(t, decision)
+	assert.Equal(t, `input matches order #\d+ at "order #42"`, reason)
+
+	// Filtered handoffs explain with their base handoff
+	filtered := NewFilteredHandoff(keyword, func(ctx context.Context, inputData *InputData) (*InputData, error) {
+		return inputData, nil
+	})
+	_, reason, err = Evaluate(ctx, filtered, "refund")
+	require.NoError(t, err)
+	assert.Equal(t, `input contains keyword "refund"`, reason)
+
+	language := NewLanguageHandoff(target, "Japanese", "ja")
+	decision, reason, err = Evaluate(ctx, language, `{"detected_language":"ja"}`)
+	require.NoError(t, err)
+	assert.True(t, decision)
+	assert.Equal(t, "the model reported Japanese", reason)
+
+	// Handoffs without an explanation are evaluated with ShouldHandoff
+	failure := errors.New("lookup failed")
+	function := NewFunctionHandoff(target, "Function", func(ctx context.Context, input string) (bool, error) {
+		return false, failure
+	})
+	decision, reason, err = Evaluate(ctx, function, "{}")
+	assert.ErrorIs(t, err, failure)
+	assert.False(t, decision)
+	assert.Empty(t, reason)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import "context"
+
+// Chain returns an input filter applying the filters in order, each to the output of the previous one
+func Chain(filters ...InputFilter) InputFilter {
+	return func(ctx context.Context, inputData *InputData) (*InputData, error) {
+		for _, filter := range filters {
+			var err error
+			inputData, err = filter(ctx, inputData)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return inputData, nil
+	}
+}
+
+// RemoveAllTools is an input filter removing the tool calls and tool outputs from the history,
+// so the next agent only sees the conversation. Messages that only called tools are dropped.
+func RemoveAllTools(ctx context.Context, inputData *InputData) (*InputData, error) {
+	return mapItems(inputData, func(item map[string]any) map[string]any {
+		if item["role"] == "tool" {
+			return nil
+		}
+		if _, ok := item["tool_calls"]; !ok {
+			return item
+		}
+		if content, _ := item["content"].(string); content == "" && item["content_parts"] == nil {
+			return nil
+		}
+		stripped := make(map[string]any, len(item))
+		for key, value := range item {
+			if key != "tool_calls" {
+				stripped[key] = value
+			}
+		}
+		return stripped
+	}), nil
+}
+
+// RemoveSystemMessages is an input filter removing the system and developer messages from the history
+func RemoveSystemMessages(ctx context.Context, inputData *InputData) (*InputData, error) {
+	return mapItems(inputData, func(item map[string]any) map[string]any {
+		if role := item["role"]; role == "system" || role == "developer" {
+			return nil
+		}
+		return item
+	}), nil
+}
+
+// KeepLastN returns an input filter keeping the last n messages of the history. The oldest
+// messages go first: the input history, then the items before the handoff, then the new items.
+// Tool results are kept with the message that called the tools, so more than n messages are
+// kept when the cut would split them.
+func KeepLastN(n int) InputFilter {
+	return func(ctx context.Context, inputData *InputData) (*InputData, error) {
+		items := make([]map[string]any, 0, len(inputData.InputHistory)+len(inputData.PreHandoffItems)+len(inputData.NewItems))
+		items = append(items, inputData.InputHistory...)
+		items = append(items, inputData.PreHandoffItems...)
+		items = append(items, inputData.NewItems...)
+
+		// Move the cut back to the tool call of the tool results it would split
+		drop := len(items) - n
+		for drop > 0 && drop < len(items) && items[drop]["role"] == "tool" {
+			drop--
+		}
+
+		filtered := *inputData
+		filtered.InputHistory, drop = dropItems(inputData.InputHistory, drop)
+		filtered.PreHandoffItems, drop = dropItems(inputData.PreHandoffItems, drop)
+		filtered.NewItems, _ = dropItems(inputData.NewItems, drop)
+		return &filtered, nil
+	}
+}
+
+// mapItems applies fn to every item of the input data, dropping the items for which it returns nil
+func mapItems(inputData *InputData, fn func(item map[string]any) map[string]any) *InputData {
+	apply := func(items []map[string]any) []map[string]any {
+		result := make([]map[string]any, 0, len(items))
+		for _, item := range items {
+			if mapped := fn(item); mapped != nil {
+				result = append(result, mapped)
+			}
+		}
+		return result
+	}
+
+	filtered := *inputData
+	filtered.InputHistory = apply(inputData.InputHistory)
+	filtered.PreHandoffItems = apply(inputData.PreHandoffItems)
+	filtered.NewItems = apply(inputData.NewItems)
+	return &filtered
+}
+
+// dropItems drops up to n items from the start of items, and returns how many are left to drop
+func dropItems(items []map[string]any, n int) ([]map[string]any, int) {
+	if n <= 0 {
+		return items, 0
+	}
+	if n >= len(items) {
+		return []map[string]any{}, n - len(items)
+	}
+	return items[n:], 0
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func filterTestData() *InputData {
+	return &InputData{
+		InputHistory: []map[string]any{
+			{"role": "system", "content": "You are helpful"},
+			{"role": "user", "content": "What is 2+2?"},
+		},
+		PreHandoffItems: []map[string]any{
+			{"role": "assistant", "content": "", "tool_calls": []any{map[string]any{"id": "call_1"}}},
+			{"role": "tool", "content": "4", "tool_call_id": "call_1"},
+			{"role": "assistant", "content": "It is 4", "tool_calls": []any{map[string]any{"id": "call_2"}}},
+		},
+		NewItems: []map[string]any{
+			{"role": "tool", "content": `{"assistant": "math"}`, "tool_call_id": "call_2"},
+		},
+		Metadata: map[string]any{"source_agent": "triage"},
+	}
+}
+
+func TestRemoveAllTools(t *testing.T) {
+	data := filterTestData()
+	filtered, err := RemoveAllTools(context.Background(), data)
+	require.NoError(t, err)
+
+	assert.Len(t, filtered.InputHistory, 2)
+	assert.Equal(t, []map[string]any{{"role": "assistant", "content": "It is 4"}}, filtered.PreHandoffItems)
+	assert.Empty(t, filtered.NewItems)
+	assert.Equal(t, data.Metadata, filtered.Metadata)
+
+	// The input data is not modified
+	assert.Len(t, data.PreHandoffItems, 3)
+	assert.Contains(t, data.PreHandoffItems[2], "tool_calls")
+}
+
+func TestRemoveSystemMessages(t *testing.T) {
+	filtered, err := RemoveSystemMessages(context.Background(), filterTestData())
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"role": "user", "content": "What is 2+2?"}}, filtered.InputHistory)
+	assert.Len(t, filtered.PreHandoffItems, 3)
+}
+
+func TestKeepLastN(t *testing.T) {
+	filtered, err := KeepLastN(2)(context.Background(), filterTestData())
+	require.NoError(t, err)
+	assert.Empty(t, filtered.InputHistory)
+	assert.Len(t, filtered.PreHandoffItems, 1)
+	assert.Len(t, filtered.NewItems, 1)
+
+	filtered, err = KeepLastN(10)(context.Background(), filterTestData())
+	require.NoError(t, err)
+	assert.Len(t, filtered.InputHistory, 2)
+
+	// A cut inside a tool batch keeps the message that called the tools
+	filtered, err = KeepLastN(1)(context.Background(), filterTestData())
+	require.NoError(t, err)
+	assert.Empty(t, filtered.InputHistory)
+	assert.Equal(t, "It is 4", filtered.PreHandoffItems[0]["content"])
+	assert.Len(t, filtered.NewItems, 1)
+
+	batch := &InputData{
+		PreHandoffItems: []map[string]any{
+			{"role": "user", "content": "Weather in Tokyo and Paris?"},
+			{"role": "assistant", "content": "", "tool_calls": []any{map[string]any{"id": "call_1"}, map[string]any{"id": "call_2"}}},
+			{"role": "tool", "content": "sunny", "tool_call_id": "call_1"},
+		},
+		NewItems: []map[string]any{
+			{"role": "tool", "content": "rainy", "tool_call_id": "call_2"},
+		},
+	}
+	filtered, err = KeepLastN(2)(context.Background(), batch)
+	require.NoError(t, err)
+	require.Len(t, filtered.PreHandoffItems, 2)
+	assert.Contains(t, filtered.PreHandoffItems[0], "tool_calls")
+	assert.Len(t, filtered.NewItems, 1)
+}
+
+func TestChain(t *testing.T) {
+	filtered, err := Chain(RemoveSystemMessages, RemoveAllTools, KeepLastN(1))(context.Background(), filterTestData())
+	require.NoError(t, err)
+	assert.Empty(t, filtered.InputHistory)
+	assert.Equal(t, []map[string]any{{"role": "assistant", "content": "It is 4"}}, filtered.PreHandoffItems)
+	assert.Empty(t, filtered.NewItems)
+}
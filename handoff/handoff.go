@@ -14,7 +14,7 @@ import (
 	"sync"
 	"time"
 
-	"github.com/abadojack/whatlanggo"
+	"github.com/ryichk/ai-agents-sdk-go/jsonschema"
 )
 
 // InputData represents the data being passed during a handoff
@@ -59,7 +59,7 @@ type Options struct {
 	Callback        Callback
 	InputJSONSchema JSONSchema
 	OnHandoff       Callback
-	InputFilter     func(ctx context.Context, inputData *InputData) (*InputData, error)
+	InputFilter     InputFilter
 }
 
 // JSONSchema represents a JSON schema for validating handoff inputs
@@ -111,6 +111,7 @@ type BaseHandoff struct {
 	toolDescription string
 	inputJSONSchema JSONSchema
 	onHandoffCB     Callback
+	inputFilter     InputFilter
 }
 
 // DefaultToolName generates a default tool name for an agent
@@ -120,6 +121,12 @@ func DefaultToolName(agentName string) string {
 	return fmt.Sprintf("transfer_to_%s", name)
 }
 
+// TransferMessage returns the tool output that acknowledges a handoff to the agent
+func TransferMessage(agentName string) string {
+	data, _ := json.Marshal(map[string]string{"assistant": agentName})
+	return string(data)
+}
+
 // DefaultToolDescription generates a default tool description for an agent
 func DefaultToolDescription(agentName, handoffDescription string) string {
 	desc := fmt.Sprintf("Handoff to the %s agent to handle the request.", agentName)
@@ -144,9 +151,12 @@ func (h *BaseHandoff) Name() string {
 	return h.name
 }
 
-// FilterInput provides a default implementation for filtering input
+// FilterInput applies the InputFilter of the handoff's options, if any
 func (h *BaseHandoff) FilterInput(ctx context.Context, inputData *InputData) (*InputData, error) {
-	return inputData, nil
+	if h.inputFilter == nil {
+		return inputData, nil
+	}
+	return h.inputFilter(ctx, inputData)
 }
 
 // GetLastHandoffTime returns the last time this handoff was invoked
@@ -235,6 +245,14 @@ func (h *PatternHandoff) ShouldHandoff(ctx context.Context, input string) (bool,
 	return h.pattern.MatchString(input), nil
 }
 
+// ExplainHandoff checks if the input matches the pattern, and says which part matched
+func (h *PatternHandoff) ExplainHandoff(ctx context.Context, input string) (bool, string, error) {
+	if loc := h.pattern.FindStringIndex(input); loc != nil {
+		return true, fmt.Sprintf("input matches %s at %q", h.pattern, input[loc[0]:loc[1]]), nil
+	}
+	return false, fmt.Sprintf("input does not match %s", h.pattern), nil
+}
+
 // KeywordHandoff handles handoffs based on keyword presence
 type KeywordHandoff struct {
 	BaseHandoff
@@ -243,166 +261,19 @@ type KeywordHandoff struct {
 
 // ShouldHandoff checks if any keyword is in the input
 func (h *KeywordHandoff) ShouldHandoff(ctx context.Context, input string) (bool, error) {
+	shouldHandoff, _, err := h.ExplainHandoff(ctx, input)
+	return shouldHandoff, err
+}
+
+// ExplainHandoff checks if any keyword is in the input, and says which one
+func (h *KeywordHandoff) ExplainHandoff(ctx context.Context, input string) (bool, string, error) {
 	lowerInput := strings.ToLower(input)
 	for _, keyword := range h.keywords {
 		if strings.Contains(lowerInput, strings.ToLower(keyword)) {
-			return true, nil
+			return true, fmt.Sprintf("input contains keyword %q", keyword), nil
 		}
 	}
-	return false, nil
-}
-
-// LanguageHandoff defines a handoff that occurs when the input is detected to be in a specified language
-type LanguageHandoff struct {
-	targetAgent any
-	description string
-	langCode    string
-	targetLang  whatlanggo.Lang
-}
-
-// NewLanguageHandoff creates a handoff that triggers when the input text is detected to be in the specified language
-func NewLanguageHandoff(targetAgent any, description, langCode string) *LanguageHandoff {
-	var targetLang whatlanggo.Lang
-	switch langCode {
-	case "es":
-		targetLang = whatlanggo.Spa
-	case "fr":
-		targetLang = whatlanggo.Fra
-	case "de":
-		targetLang = whatlanggo.Deu
-	case "it":
-		targetLang = whatlanggo.Ita
-	case "ja":
-		targetLang = whatlanggo.Jpn
-	default:
-		targetLang = whatlanggo.Eng
-	}
-
-	return &LanguageHandoff{
-		targetAgent: targetAgent,
-		description: description,
-		langCode:    langCode,
-		targetLang:  targetLang,
-	}
-}
-
-// Name returns the name of the handoff
-func (h *LanguageHandoff) Name() string {
-	return "language_handoff"
-}
-
-// Description returns the description of the handoff
-func (h *LanguageHandoff) Description() string {
-	return h.description
-}
-
-// TargetAgent returns the target agent for this handoff
-func (h *LanguageHandoff) TargetAgent() any {
-	return h.targetAgent
-}
-
-// ShouldHandoff determines if a handoff should occur based on language detection
-func (h *LanguageHandoff) ShouldHandoff(ctx context.Context, input string) (bool, error) {
-	if input == "" {
-		return false, nil
-	}
-
-	// Simple check based on special characters and common words first
-	// For Spanish detection
-	if h.langCode == "es" {
-		// Check for Spanish special characters
-		if strings.Contains(input, "¿") || strings.Contains(input, "¡") ||
-			strings.Contains(input, "ñ") || strings.Contains(input, "ó") ||
-			strings.Contains(input, "á") || strings.Contains(input, "é") ||
-			strings.Contains(input, "í") || strings.Contains(input, "ú") {
-			return true, nil
-		}
-
-		// Check for common Spanish words
-		spanishWords := []string{"hola", "como", "estas", "gracias", "buenos", "dias", "adios", "por favor", "ayuda"}
-		for _, word := range spanishWords {
-			if strings.Contains(strings.ToLower(input), word) {
-				return true, nil
-			}
-		}
-	}
-
-	// Fall back to the language detection library
-	info := whatlanggo.Detect(input)
-	return info.Lang == h.targetLang && info.Confidence > 0.5, nil
-}
-
-// FilterInput filters the input before handing off
-func (h *LanguageHandoff) FilterInput(ctx context.Context, inputData *InputData) (*InputData, error) {
-	// Just pass through the input data by default
-	return inputData, nil
-}
-
-// ToolName returns the name of the tool that represents the handoff
-func (h *LanguageHandoff) ToolName() string {
-	if agent, ok := h.targetAgent.(interface{ Name() string }); ok {
-		langName := ""
-		switch h.langCode {
-		case "es":
-			langName = "Spanish"
-		case "fr":
-			langName = "French"
-		case "de":
-			langName = "German"
-		case "it":
-			langName = "Italian"
-		case "ja":
-			langName = "Japanese"
-		default:
-			langName = h.langCode
-		}
-		return DefaultToolName(fmt.Sprintf("%s_%s", langName, agent.Name()))
-	}
-	return DefaultToolName(fmt.Sprintf("language_%s", h.langCode))
-}
-
-// ToolDescription returns the description of the tool that represents the handoff
-func (h *LanguageHandoff) ToolDescription() string {
-	langName := ""
-	switch h.langCode {
-	case "es":
-		langName = "Spanish"
-	case "fr":
-		langName = "French"
-	case "de":
-		langName = "German"
-	case "it":
-		langName = "Italian"
-	case "ja":
-		langName = "Japanese"
-	default:
-		langName = h.langCode
-	}
-
-	if agent, ok := h.targetAgent.(interface{ Name() string }); ok {
-		return fmt.Sprintf("Handoff to the %s agent to handle %s language requests.", agent.Name(), langName)
-	}
-	return fmt.Sprintf("Handoff to handle %s language requests.", langName)
-}
-
-// InputJSONSchema returns an empty JSON schema
-func (h *LanguageHandoff) InputJSONSchema() JSONSchema {
-	return JSONSchema{}
-}
-
-// OnHandoff does nothing for LanguageHandoff
-func (h *LanguageHandoff) OnHandoff(ctx context.Context, inputData *InputData, inputJSON string) error {
-	return nil
-}
-
-// GetLastHandoffTime returns zero time for LanguageHandoff
-func (h *LanguageHandoff) GetLastHandoffTime() time.Time {
-	return time.Time{}
-}
-
-// UpdateLastHandoffTime does nothing for LanguageHandoff
-func (h *LanguageHandoff) UpdateLastHandoffTime() {
-	// No-op
+	return false, "input contains none of the keywords", nil
 }
 
 // FilteredHandoff wraps another handoff and applies input filtering
@@ -417,6 +288,11 @@ func (h *FilteredHandoff) ShouldHandoff(ctx context.Context, input string) (bool
 	return h.baseHandoff.ShouldHandoff(ctx, input)
 }
 
+// ExplainHandoff delegates to the base handoff
+func (h *FilteredHandoff) ExplainHandoff(ctx context.Context, input string) (bool, string, error) {
+	return Evaluate(ctx, h.baseHandoff, input)
+}
+
 // FilterInput applies the filter function
 func (h *FilteredHandoff) FilterInput(ctx context.Context, inputData *InputData) (*InputData, error) {
 	return h.filterFunc(ctx, inputData)
@@ -442,7 +318,9 @@ func (h *FilteredHandoff) OnHandoff(ctx context.Context, inputData *InputData, i
 	return h.baseHandoff.OnHandoff(ctx, inputData, inputJSON)
 }
 
-// ValidateJSON validates JSON data against a schema
+// ValidateJSON decodes JSON object data and validates it against a schema with the jsonschema
+// package: nested objects and arrays, enums, patterns, numeric ranges and $ref are supported.
+// Tool arguments are validated against the tools' ParamsJSONSchema with it too.
 func ValidateJSON(jsonData string, schema JSONSchema) (map[string]any, error) {
 	if len(schema) == 0 {
 		return nil, nil
@@ -453,95 +331,12 @@ func ValidateJSON(jsonData string, schema JSONSchema) (map[string]any, error) {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	return validateJSONData(data, schema)
-}
-
-// validateJSONData validates the parsed JSON data against the schema
-func validateJSONData(data map[string]any, schema JSONSchema) (map[string]any, error) {
-	if err := validateRequiredFields(data, schema); err != nil {
-		return nil, err
-	}
-
-	if err := validateFieldTypes(data, schema); err != nil {
+	if err := jsonschema.Validate(schema, data); err != nil {
 		return nil, err
 	}
-
 	return data, nil
 }
 
-// validateRequiredFields checks if all required fields are present
-func validateRequiredFields(data map[string]any, schema JSONSchema) error {
-	if required, ok := schema["required"].([]string); ok {
-		for _, field := range required {
-			if _, exists := data[field]; !exists {
-				return fmt.Errorf("missing required field: %s", field)
-			}
-		}
-	}
-	return nil
-}
-
-// validateFieldTypes validates the types of fields according to the schema
-func validateFieldTypes(data map[string]any, schema JSONSchema) error {
-	properties, ok := schema["properties"].(map[string]any)
-	if !ok {
-		return nil
-	}
-
-	for field, value := range data {
-		if err := validateFieldType(field, value, properties); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-// validateFieldType validates a single field's type
-func validateFieldType(field string, value any, properties map[string]any) error {
-	fieldSchema, ok := properties[field].(map[string]any)
-	if !ok {
-		return nil
-	}
-
-	expectedType, ok := fieldSchema["type"].(string)
-	if !ok {
-		return nil
-	}
-
-	if !isValidType(value, expectedType) {
-		return fmt.Errorf("invalid type for field %s: expected %s", field, expectedType)
-	}
-
-	return nil
-}
-
-// isValidType checks if a value matches the expected type
-func isValidType(value any, expectedType string) bool {
-	switch expectedType {
-	case "string":
-		_, ok := value.(string)
-		return ok
-	case "number", "integer":
-		switch value.(type) {
-		case float64, int, int64:
-			return true
-		default:
-			return false
-		}
-	case "boolean":
-		_, ok := value.(bool)
-		return ok
-	case "array":
-		_, ok := value.([]any)
-		return ok
-	case "object":
-		_, ok := value.(map[string]any)
-		return ok
-	default:
-		return true
-	}
-}
-
 // NewHandoffRegistry creates a new handoff registry
 func NewHandoffRegistry(minTimeBetween time.Duration) *Registry {
 	return &Registry{
@@ -589,6 +384,7 @@ func NewHandoffWithOptions(targetAgent any, description string, options Options)
 			toolDescription: options.ToolDescription,
 			inputJSONSchema: options.InputJSONSchema,
 			onHandoffCB:     options.OnHandoff,
+			inputFilter:     options.InputFilter,
 		},
 	}
 }
@@ -627,6 +423,7 @@ func NewFunctionHandoffWithOptions(targetAgent any, description string, handoffF
 			toolDescription: options.ToolDescription,
 			inputJSONSchema: options.InputJSONSchema,
 			onHandoffCB:     options.OnHandoff,
+			inputFilter:     options.InputFilter,
 		},
 		handoffFunc: handoffFunc,
 	}
@@ -665,6 +462,7 @@ func NewPatternHandoffWithOptions(targetAgent any, description string, pattern s
 			toolDescription: options.ToolDescription,
 			inputJSONSchema: options.InputJSONSchema,
 			onHandoffCB:     options.OnHandoff,
+			inputFilter:     options.InputFilter,
 		},
 		pattern: re,
 	}, nil
@@ -693,6 +491,7 @@ func NewKeywordHandoffWithOptions(targetAgent any, description string, keywords
 			toolDescription: options.ToolDescription,
 			inputJSONSchema: options.InputJSONSchema,
 			onHandoffCB:     options.OnHandoff,
+			inputFilter:     options.InputFilter,
 		},
 		keywords: keywords,
 	}
@@ -711,93 +510,30 @@ func NewFilteredHandoff(baseHandoff Handoff, filterFunc func(ctx context.Context
 	}
 }
 
-// CreateJSONSchema creates a JSON schema for a struct type or an interface with required fields
+// CreateJSONSchema creates a JSON schema for a struct type (see jsonschema.Reflector for the
+// supported types and tags). The required fields default to the fields that are neither pointers
+// nor tagged omitempty; a non-nil required list replaces them.
 func CreateJSONSchema(structType any, required []string) JSONSchema {
-	schema := JSONSchema{
-		"type":       "object",
-		"properties": map[string]any{},
-	}
-
-	if len(required) > 0 {
-		schema["required"] = required
-	}
-
 	t := reflect.TypeOf(structType)
-	if t.Kind() == reflect.Ptr {
+	for t != nil && t.Kind() == reflect.Pointer {
 		t = t.Elem()
 	}
-
-	if t.Kind() != reflect.Struct {
-		return schema
-	}
-
-	properties := schema["properties"].(map[string]any)
-
-	for i := range make([]int, t.NumField()) {
-		field := t.Field(i)
-		jsonTag := field.Tag.Get("json")
-		if jsonTag == "" || jsonTag == "-" {
-			continue
+	if t == nil || t.Kind() != reflect.Struct {
+		schema := JSONSchema{
+			"type":       "object",
+			"properties": map[string]any{},
 		}
-
-		// Split the json tag to get the field name
-		parts := strings.Split(jsonTag, ",")
-		fieldName := parts[0]
-
-		fieldType := field.Type
-		propSchema := map[string]any{}
-
-		switch fieldType.Kind() {
-		case reflect.Bool:
-			propSchema["type"] = "boolean"
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			propSchema["type"] = "integer"
-		case reflect.Float32, reflect.Float64:
-			propSchema["type"] = "number"
-		case reflect.String:
-			propSchema["type"] = "string"
-		case reflect.Slice, reflect.Array:
-			propSchema["type"] = "array"
-			if fieldType.Elem().Kind() == reflect.Struct {
-				propSchema["items"] = CreateJSONSchema(reflect.New(fieldType.Elem()).Interface(), nil)
-			} else {
-				propSchema["items"] = map[string]any{
-					"type": getJSONType(fieldType.Elem().Kind()),
-				}
-			}
-		case reflect.Map:
-			propSchema["type"] = "object"
-		case reflect.Struct:
-			propSchema["type"] = "object"
-			propSchema["properties"] = CreateJSONSchema(reflect.New(fieldType).Interface(), nil)["properties"]
+		if len(required) > 0 {
+			schema["required"] = required
 		}
-
-		properties[fieldName] = propSchema
+		return schema
 	}
 
-	return schema
-}
-
-// getJSONType returns the JSON schema type for a Go reflect.Kind
-func getJSONType(kind reflect.Kind) string {
-	switch kind {
-	case reflect.Bool:
-		return "boolean"
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return "integer"
-	case reflect.Float32, reflect.Float64:
-		return "number"
-	case reflect.String:
-		return "string"
-	case reflect.Slice, reflect.Array:
-		return "array"
-	case reflect.Map, reflect.Struct:
-		return "object"
-	default:
-		return "string"
+	schema := JSONSchema(jsonschema.Reflect(t))
+	if required != nil {
+		schema["required"] = required
 	}
+	return schema
 }
 
 // InputFilter is a function that filters the input data during a handoff
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock agent for testing
@@ -481,3 +482,70 @@ func TestHandoffWithStructJSONSchema(t *testing.T) {
 	_, err = ValidateJSON(invalidJSON, schema)
 	assert.Error(t, err, "Invalid JSON should cause an error")
 }
+
+func TestTransferMessage(t *testing.T) {
+	assert.Equal(t, `{"assistant":"Billing \"EU\""}`, TransferMessage(`Billing "EU"`))
+}
+
+func TestValidateJSONSchemaDecodedFromJSON(t *testing.T) {
+	var schema JSONSchema
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"priority": {"type": "string", "enum": ["low", "high"]},
+			"count": {"type": "integer"},
+			"customer": {
+				"type": "object",
+				"properties": {"id": {"type": "integer"}},
+				"required": ["id"]
+			}
+		},
+		"required": ["priority"]
+	}`), &schema))
+
+	_, err := ValidateJSON(`{"priority":"high","count":3,"customer":{"id":7}}`, schema)
+	assert.NoError(t, err)
+
+	_, err = ValidateJSON(`{"count":3}`, schema)
+	assert.EqualError(t, err, "missing required field: priority")
+
+	_, err = ValidateJSON(`{"priority":"urgent"}`, schema)
+	assert.EqualError(t, err, "invalid value for field priority: must be one of [low high]")
+
+	_, err = ValidateJSON(`{"priority":"low","count":2.5}`, schema)
+	assert.EqualError(t, err, "invalid type for field count: expected integer")
+
+	_, err = ValidateJSON(`{"priority":"low","customer":{}}`, schema)
+	assert.EqualError(t, err, "missing required field: customer.id")
+}
+
+// EscalationInput is a handoff input with optional fields, a timestamp and tagged fields
+type EscalationInput struct {
+	Reason   string    `json:"reason" description:"Why the conversation is escalated"`
+	Severity string    `json:"severity" enum:"low,high"`
+	Due      time.Time `json:"due"`
+	Ticket   *string   `json:"ticket"`
+	Notes    []string  `json:"notes,omitempty"`
+	Internal string    `json:"-"`
+}
+
+func TestCreateJSONSchemaFromTags(t *testing.T) {
+	schema := CreateJSONSchema(EscalationInput{}, nil)
+	assert.Equal(t, []string{"reason", "severity", "due"}, schema["required"])
+
+	properties := schema["properties"].(map[string]any)
+	assert.NotContains(t, properties, "Internal")
+	assert.Equal(t, map[string]any{"type": "string", "description": "Why the conversation is escalated"}, properties["reason"])
+	assert.Equal(t, map[string]any{"type": "string", "enum": []any{"low", "high"}}, properties["severity"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, properties["due"])
+	assert.Equal(t, map[string]any{"type": "string"}, properties["ticket"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, properties["notes"])
+
+	_, err := ValidateJSON(`{"reason":"refund","severity":"high","due":"2025-06-01T09:00:00Z"}`, schema)
+	assert.NoError(t, err)
+	_, err = ValidateJSON(`{"reason":"refund","severity":"urgent","due":"2025-06-01T09:00:00Z"}`, schema)
+	assert.EqualError(t, err, "invalid value for field severity: must be one of [low high]")
+
+	// An explicit list replaces the computed required fields
+	assert.Equal(t, []string{"reason"}, CreateJSONSchema(&EscalationInput{}, []string{"reason"})["required"])
+}
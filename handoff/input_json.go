@@ -0,0 +1,428 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedItem is returned when decoding input data with an item that has no equivalent
+// in the message history, such as a computer call
+var ErrUnsupportedItem = errors.New("unsupported input item")
+
+// Types of the Responses API items of the JSON representation of InputData
+const (
+	ItemTypeMessage            = "message"
+	ItemTypeFunctionCall       = "function_call"
+	ItemTypeFunctionCallOutput = "function_call_output"
+	ItemTypeReasoning          = "reasoning"
+)
+
+// Types of the run items wrapping the pre-handoff and new items in the JSON representation of
+// InputData, as in the RunItem classes of the Python SDK
+const (
+	RunItemMessageOutput  = "message_output_item"
+	RunItemToolCall       = "tool_call_item"
+	RunItemToolCallOutput = "tool_call_output_item"
+	RunItemHandoffCall    = "handoff_call_item"
+	RunItemHandoffOutput  = "handoff_output_item"
+	RunItemReasoning      = "reasoning_item"
+)
+
+// inputDataJSON is the JSON representation of InputData, matching HandoffInputData of the Python
+// SDK. The input history holds Responses API items, and the other lists hold run items of the
+// form {"type": "tool_call_item", "raw_item": {...}}. Metadata has no Python equivalent.
+type inputDataJSON struct {
+	InputHistory    json.RawMessage  `json:"input_history"`
+	PreHandoffItems []map[string]any `json:"pre_handoff_items"`
+	NewItems        []map[string]any `json:"new_items"`
+	Metadata        map[string]any   `json:"metadata,omitempty"`
+}
+
+// historyMessage mirrors the JSON of the messages held by InputData
+type historyMessage struct {
+	Role         string            `json:"role"`
+	Content      string            `json:"content"`
+	ToolCalls    []historyToolCall `json:"tool_calls,omitempty"`
+	ToolCallID   string            `json:"tool_call_id,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	ContentParts []map[string]any  `json:"content_parts,omitempty"`
+	Reasoning    string            `json:"reasoning,omitempty"`
+	Refusal      string            `json:"refusal,omitempty"`
+}
+
+type historyToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// MetadataHandoffToolNames is the InputData.Metadata key listing the tool names of the handoffs
+// of the conversation, as a []string. The runner sets it; EncodeInputData uses it to tell handoff
+// calls from tool calls.
+const MetadataHandoffToolNames = "handoff_tool_names"
+
+// EncodeInputData encodes input data in the JSON representation of the Python SDK's
+// HandoffInputData, so the handoffs of a workflow can be inspected and filtered by the same
+// tooling in both implementations. Function calls named in Metadata[MetadataHandoffToolNames]
+// are handoff calls; without that list, calls whose name starts with "transfer_to_" are.
+func EncodeInputData(inputData *InputData) ([]byte, error) {
+	return json.Marshal(inputData)
+}
+
+// DecodeInputData decodes input data encoded by EncodeInputData or by the Python SDK. The items
+// are converted back to messages: function calls join the assistant message before them and
+// reasoning items the assistant message after them.
+func DecodeInputData(data []byte) (*InputData, error) {
+	var inputData InputData
+	if err := json.Unmarshal(data, &inputData); err != nil {
+		return nil, err
+	}
+	return &inputData, nil
+}
+
+// MarshalJSON encodes the input data in the Python-compatible representation, see EncodeInputData
+func (d InputData) MarshalJSON() ([]byte, error) {
+	toolNames, named := d.handoffToolNames()
+	handoffCalls := make(map[string]bool)
+	for _, items := range [][]map[string]any{d.InputHistory, d.PreHandoffItems, d.NewItems} {
+		messages, err := toHistoryMessages(items)
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range messages {
+			for _, call := range message.ToolCalls {
+				if named && toolNames[call.Function.Name] || !named && strings.HasPrefix(call.Function.Name, "transfer_to_") {
+					handoffCalls[call.ID] = true
+				}
+			}
+		}
+	}
+
+	history, err := encodeItems(d.InputHistory, handoffCalls, false)
+	if err != nil {
+		return nil, err
+	}
+	historyJSON, err := json.Marshal(history)
+	if err != nil {
+		return nil, err
+	}
+	encoded := inputDataJSON{InputHistory: historyJSON, Metadata: d.Metadata}
+	if encoded.PreHandoffItems, err = encodeItems(d.PreHandoffItems, handoffCalls, true); err != nil {
+		return nil, err
+	}
+	if encoded.NewItems, err = encodeItems(d.NewItems, handoffCalls, true); err != nil {
+		return nil, err
+	}
+	return json.Marshal(encoded)
+}
+
+// handoffToolNames returns the names listed in Metadata[MetadataHandoffToolNames], which is a
+// []any once decoded from JSON, and whether there is such a list
+func (d InputData) handoffToolNames() (map[string]bool, bool) {
+	var list []string
+	switch names := d.Metadata[MetadataHandoffToolNames].(type) {
+	case []string:
+		list = names
+	case []any:
+		for _, name := range names {
+			if name, ok := name.(string); ok {
+				list = append(list, name)
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	names := make(map[string]bool, len(list))
+	for _, name := range list {
+		names[name] = true
+	}
+	return names, true
+}
+
+// UnmarshalJSON decodes input data in the Python-compatible representation, see DecodeInputData
+func (d *InputData) UnmarshalJSON(data []byte) error {
+	var encoded inputDataJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	// The Python SDK allows the input history to be a plain string
+	var history []map[string]any
+	var text string
+	if err := json.Unmarshal(encoded.InputHistory, &text); err == nil {
+		history = []map[string]any{{"type": ItemTypeMessage, "role": "user", "content": text}}
+	} else if len(encoded.InputHistory) > 0 {
+		if err := json.Unmarshal(encoded.InputHistory, &history); err != nil {
+			return fmt.Errorf("invalid input_history: %w", err)
+		}
+	}
+
+	var err error
+	decoded := InputData{Metadata: encoded.Metadata}
+	if decoded.InputHistory, err = decodeItems(history); err != nil {
+		return err
+	}
+	if decoded.PreHandoffItems, err = decodeItems(encoded.PreHandoffItems); err != nil {
+		return err
+	}
+	if decoded.NewItems, err = decodeItems(encoded.NewItems); err != nil {
+		return err
+	}
+	*d = decoded
+	return nil
+}
+
+// toHistoryMessages reads the messages of InputData, which filters may have built with any Go types
+func toHistoryMessages(items []map[string]any) ([]historyMessage, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var messages []historyMessage
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("invalid history item: %w", err)
+	}
+	return messages, nil
+}
+
+// fromHistoryMessages converts messages to the JSON objects of InputData
+func fromHistoryMessages(messages []historyMessage) ([]map[string]any, error) {
+	items := make([]map[string]any, 0, len(messages))
+	if len(messages) == 0 {
+		return items, nil
+	}
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// encodeItems converts messages to Responses API items, wrapped in run items if asked to
+func encodeItems(items []map[string]any, handoffCalls map[string]bool, runItems bool) ([]map[string]any, error) {
+	messages, err := toHistoryMessages(items)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := make([]map[string]any, 0, len(messages))
+	add := func(runItemType string, item map[string]any) {
+		if runItems {
+			item = map[string]any{"type": runItemType, "raw_item": item}
+		}
+		encoded = append(encoded, item)
+	}
+
+	for _, message := range messages {
+		switch message.Role {
+		case "tool":
+			itemType := RunItemToolCallOutput
+			if handoffCalls[message.ToolCallID] {
+				itemType = RunItemHandoffOutput
+			}
+			add(itemType, map[string]any{
+				"type":    ItemTypeFunctionCallOutput,
+				"call_id": message.ToolCallID,
+				"output":  message.Content,
+			})
+
+		case "assistant":
+			if message.Reasoning != "" {
+				add(RunItemReasoning, map[string]any{
+					"type":    ItemTypeReasoning,
+					"summary": []map[string]any{{"type": "summary_text", "text": message.Reasoning}},
+				})
+			}
+			if message.Content != "" || message.Refusal != "" {
+				var content []map[string]any
+				if message.Content != "" {
+					content = append(content, map[string]any{"type": "output_text", "text": message.Content, "annotations": []any{}})
+				}
+				if message.Refusal != "" {
+					content = append(content, map[string]any{"type": "refusal", "refusal": message.Refusal})
+				}
+				add(RunItemMessageOutput, map[string]any{
+					"type":    ItemTypeMessage,
+					"role":    "assistant",
+					"status":  "completed",
+					"content": content,
+				})
+			}
+			for _, call := range message.ToolCalls {
+				itemType := RunItemToolCall
+				if handoffCalls[call.ID] {
+					itemType = RunItemHandoffCall
+				}
+				add(itemType, map[string]any{
+					"type":      ItemTypeFunctionCall,
+					"call_id":   call.ID,
+					"name":      call.Function.Name,
+					"arguments": call.Function.Arguments,
+				})
+			}
+
+		default:
+			item := map[string]any{"type": ItemTypeMessage, "role": message.Role, "content": message.Content}
+			if len(message.ContentParts) > 0 {
+				var content []map[string]any
+				if message.Content != "" {
+					content = append(content, map[string]any{"type": "input_text", "text": message.Content})
+				}
+				for _, part := range message.ContentParts {
+					content = append(content, encodeContentPart(part))
+				}
+				item["content"] = content
+			}
+			add(RunItemMessageOutput, item)
+		}
+	}
+	return encoded, nil
+}
+
+// encodeContentPart converts a Chat Completions content part to a Responses API input part
+func encodeContentPart(part map[string]any) map[string]any {
+	switch part["type"] {
+	case "text":
+		return map[string]any{"type": "input_text", "text": part["text"]}
+	case "image_url":
+		image, _ := part["image_url"].(map[string]any)
+		encoded := map[string]any{"type": "input_image", "image_url": image["url"], "detail": "auto"}
+		if detail, _ := image["detail"].(string); detail != "" {
+			encoded["detail"] = detail
+		}
+		return encoded
+	case "file":
+		encoded := map[string]any{"type": "input_file"}
+		file, _ := part["file"].(map[string]any)
+		for key, value := range file {
+			encoded[key] = value
+		}
+		return encoded
+	}
+	return part
+}
+
+// decodeContentPart converts a Responses API input part to a Chat Completions content part
+func decodeContentPart(part map[string]any) map[string]any {
+	switch part["type"] {
+	case "input_text", "output_text":
+		return map[string]any{"type": "text", "text": part["text"]}
+	case "input_image":
+		image := map[string]any{"url": part["image_url"]}
+		if detail, _ := part["detail"].(string); detail != "" && detail != "auto" {
+			image["detail"] = detail
+		}
+		return map[string]any{"type": "image_url", "image_url": image}
+	case "input_file":
+		file := make(map[string]any)
+		for _, key := range []string{"file_id", "file_data", "filename"} {
+			if value, ok := part[key]; ok {
+				file[key] = value
+			}
+		}
+		return map[string]any{"type": "file", "file": file}
+	}
+	return part
+}
+
+// decodeItems converts Responses API items, or run items wrapping them, to messages
+func decodeItems(items []map[string]any) ([]map[string]any, error) {
+	var messages []historyMessage
+	var reasoning string
+
+	// lastAssistant returns the assistant message that function calls join
+	lastAssistant := func() *historyMessage {
+		if n := len(messages); n > 0 && messages[n-1].Role == "assistant" {
+			return &messages[n-1]
+		}
+		messages = append(messages, historyMessage{Role: "assistant", Reasoning: reasoning})
+		reasoning = ""
+		return &messages[len(messages)-1]
+	}
+
+	for _, item := range items {
+		if raw, ok := item["raw_item"].(map[string]any); ok {
+			item = raw
+		}
+		itemType, _ := item["type"].(string)
+		if itemType == "" && item["role"] != nil {
+			itemType = ItemTypeMessage
+		}
+
+		switch itemType {
+		case ItemTypeMessage:
+			message := historyMessage{}
+			message.Role, _ = item["role"].(string)
+			switch content := item["content"].(type) {
+			case string:
+				message.Content = content
+			case []any:
+				for i, value := range content {
+					part, _ := value.(map[string]any)
+					switch {
+					case part["type"] == "refusal":
+						message.Refusal, _ = part["refusal"].(string)
+					case part["type"] == "output_text" || (i == 0 && part["type"] == "input_text"):
+						text, _ := part["text"].(string)
+						message.Content += text
+					default:
+						message.ContentParts = append(message.ContentParts, decodeContentPart(part))
+					}
+				}
+			}
+			if message.Role == "assistant" {
+				message.Reasoning = reasoning
+				reasoning = ""
+			}
+			messages = append(messages, message)
+
+		case ItemTypeReasoning:
+			summary, _ := item["summary"].([]any)
+			for _, value := range summary {
+				part, _ := value.(map[string]any)
+				if text, _ := part["text"].(string); text != "" {
+					if reasoning != "" {
+						reasoning += "\n"
+					}
+					reasoning += text
+				}
+			}
+
+		case ItemTypeFunctionCall:
+			call := historyToolCall{Type: "function"}
+			call.ID, _ = item["call_id"].(string)
+			call.Function.Name, _ = item["name"].(string)
+			call.Function.Arguments, _ = item["arguments"].(string)
+			assistant := lastAssistant()
+			assistant.ToolCalls = append(assistant.ToolCalls, call)
+
+		case ItemTypeFunctionCallOutput:
+			message := historyMessage{Role: "tool"}
+			message.ToolCallID, _ = item["call_id"].(string)
+			if output, ok := item["output"].(string); ok {
+				message.Content = output
+			} else if item["output"] != nil {
+				data, _ := json.Marshal(item["output"])
+				message.Content = string(data)
+			}
+			messages = append(messages, message)
+
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedItem, itemType)
+		}
+	}
+	return fromHistoryMessages(messages)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeInputData(t *testing.T) {
+	inputData := &InputData{
+		InputHistory: []map[string]any{
+			{"role": "user", "content": "What is 2+2?"},
+		},
+		PreHandoffItems: []map[string]any{
+			{"role": "assistant", "content": "", "reasoning": "Use the calculator", "tool_calls": []any{
+				map[string]any{"id": "call_1", "type": "function", "function": map[string]any{"name": "add", "arguments": `{"a":2,"b":2}`}},
+			}},
+			{"role": "tool", "content": "4", "tool_call_id": "call_1"},
+		},
+		NewItems: []map[string]any{
+			{"role": "assistant", "content": "It is 4", "tool_calls": []any{
+				map[string]any{"id": "call_2", "type": "function", "function": map[string]any{"name": "transfer_to_math", "arguments": "{}"}},
+			}},
+			{"role": "tool", "content": `{"assistant": "math"}`, "tool_call_id": "call_2"},
+		},
+		Metadata: map[string]any{"source_agent": "triage"},
+	}
+
+	data, err := EncodeInputData(inputData)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"input_history": [{"type": "message", "role": "user", "content": "What is 2+2?"}],
+		"pre_handoff_items": [
+			{"type": "reasoning_item", "raw_item": {"type": "reasoning", "summary": [{"type": "summary_text", "text": "Use the calculator"}]}},
+			{"type": "tool_call_item", "raw_item": {"type": "function_call", "call_id": "call_1", "name": "add", "arguments": "{\"a\":2,\"b\":2}"}},
+			{"type": "tool_call_output_item", "raw_item": {"type": "function_call_output", "call_id": "call_1", "output": "4"}}
+		],
+		"new_items": [
+			{"type": "message_output_item", "raw_item": {"type": "message", "role": "assistant", "status": "completed",
+				"content": [{"type": "output_text", "text": "It is 4", "annotations": []}]}},
+			{"type": "handoff_call_item", "raw_item": {"type": "function_call", "call_id": "call_2", "name": "transfer_to_math", "arguments": "{}"}},
+			{"type": "handoff_output_item", "raw_item": {"type": "function_call_output", "call_id": "call_2", "output": "{\"assistant\": \"math\"}"}}
+		],
+		"metadata": {"source_agent": "triage"}
+	}`, string(data))
+
+	// Decoding restores the messages
+	decoded, err := DecodeInputData(data)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"role": "user", "content": "What is 2+2?"}}, decoded.InputHistory)
+	assert.Equal(t, []map[string]any{
+		{"role": "assistant", "content": "", "reasoning": "Use the calculator", "tool_calls": []any{
+			map[string]any{"id": "call_1", "type": "function", "function": map[string]any{"name": "add", "arguments": `{"a":2,"b":2}`}},
+		}},
+		{"role": "tool", "content": "4", "tool_call_id": "call_1"},
+	}, decoded.PreHandoffItems)
+	assert.Equal(t, inputData.NewItems, decoded.NewItems)
+	assert.Equal(t, inputData.Metadata, decoded.Metadata)
+}
+
+func TestEncodeInputDataHandoffToolNames(t *testing.T) {
+	call := func(id string, name string) map[string]any {
+		return map[string]any{"role": "assistant", "content": "", "tool_calls": []any{
+			map[string]any{"id": id, "type": "function", "function": map[string]any{"name": name, "arguments": "{}"}},
+		}}
+	}
+	inputData := &InputData{
+		NewItems: []map[string]any{
+			call("call_1", "transfer_to_archive"),
+			{"role": "tool", "content": "archived", "tool_call_id": "call_1"},
+			call("call_2", "escalate"),
+			{"role": "tool", "content": `{"assistant": "supervisor"}`, "tool_call_id": "call_2"},
+		},
+		Metadata: map[string]any{MetadataHandoffToolNames: []string{"escalate"}},
+	}
+	types := func(data []byte) []string {
+		var encoded struct {
+			NewItems []struct {
+				Type string `json:"type"`
+			} `json:"new_items"`
+		}
+		require.NoError(t, json.Unmarshal(data, &encoded))
+		var list []string
+		for _, item := range encoded.NewItems {
+			list = append(list, item.Type)
+		}
+		return list
+	}
+
+	// A handoff with a custom tool name is recognized, and a tool named like a handoff is not
+	data, err := EncodeInputData(inputData)
+	require.NoError(t, err)
+	assert.Equal(t, []string{RunItemToolCall, RunItemToolCallOutput, RunItemHandoffCall, RunItemHandoffOutput}, types(data))
+
+	// The names survive a round trip
+	decoded, err := DecodeInputData(data)
+	require.NoError(t, err)
+	data, err = EncodeInputData(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, []string{RunItemToolCall, RunItemToolCallOutput, RunItemHandoffCall, RunItemHandoffOutput}, types(data))
+
+	// Without the names, the tool name prefix is used
+	inputData.Metadata = nil
+	data, err = EncodeInputData(inputData)
+	require.NoError(t, err)
+	assert.Equal(t, []string{RunItemHandoffCall, RunItemHandoffOutput, RunItemToolCall, RunItemToolCallOutput}, types(data))
+}
+
+func TestDecodeInputDataFromPython(t *testing.T) {
+	// input_history may be a string, and content parts use the Responses API types
+	data := `{
+		"input_history": "Describe this",
+		"pre_handoff_items": [],
+		"new_items": [
+			{"type": "message_output_item", "raw_item": {"type": "message", "role": "user", "content": [
+				{"type": "input_text", "text": "And this"},
+				{"type": "input_image", "image_url": "https://example.com/cat.png", "detail": "auto"}
+			]}}
+		]
+	}`
+	var inputData InputData
+	require.NoError(t, json.Unmarshal([]byte(data), &inputData))
+	assert.Equal(t, []map[string]any{{"role": "user", "content": "Describe this"}}, inputData.InputHistory)
+	assert.Empty(t, inputData.PreHandoffItems)
+	assert.Equal(t, []map[string]any{{
+		"role":    "user",
+		"content": "And this",
+		"content_parts": []any{
+			map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/cat.png"}},
+		},
+	}}, inputData.NewItems)
+
+	// Filters work on the decoded data
+	filtered, err := KeepLastN(1)(context.Background(), &inputData)
+	require.NoError(t, err)
+	assert.Empty(t, filtered.InputHistory)
+
+	_, err = DecodeInputData([]byte(`{"input_history": [{"type": "computer_call"}]}`))
+	assert.ErrorIs(t, err, ErrUnsupportedItem)
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/abadojack/whatlanggo"
+)
+
+// DefaultLanguageConfidence is the detection confidence a LanguageHandoff requires by default
+const DefaultLanguageConfidence = 0.5
+
+// ErrUnknownLanguage is returned for language codes that cannot be detected
+var ErrUnknownLanguage = errors.New("unknown language")
+
+// LanguageHeuristic recognizes the language of inputs that detection handles poorly, such as
+// short greetings. It returns the language's ISO 639-1 or 639-3 code and true when it recognizes it.
+type LanguageHeuristic func(input string) (lang string, ok bool)
+
+// WordListHeuristic recognizes the language of inputs containing one of the characters, such as
+// "¿", or one of the words, matched case-insensitively
+func WordListHeuristic(lang string, characters []string, words []string) LanguageHeuristic {
+	return func(input string) (string, bool) {
+		for _, c := range characters {
+			if strings.Contains(input, c) {
+				return lang, true
+			}
+		}
+		lower := strings.ToLower(input)
+		for _, word := range words {
+			if strings.Contains(lower, word) {
+				return lang, true
+			}
+		}
+		return "", false
+	}
+}
+
+// SpanishHeuristic recognizes Spanish by its punctuation, accents and common words
+var SpanishHeuristic = WordListHeuristic("es",
+	[]string{"¿", "¡", "ñ", "ó", "á", "é", "í", "ú"},
+	[]string{"hola", "como", "estas", "gracias", "buenos", "dias", "adios", "por favor", "ayuda"})
+
+// DefaultLanguageHeuristics are used when LanguageOptions.Heuristics is nil
+var DefaultLanguageHeuristics = []LanguageHeuristic{SpanishHeuristic}
+
+// LanguageOptions configures a LanguageHandoff
+type LanguageOptions struct {
+	Options
+
+	// Languages are the ISO 639-1 ("es") or 639-3 ("spa") codes, or English names ("Spanish"),
+	// of the languages the target agent handles
+	Languages []string
+
+	// MinConfidence is the detection confidence required to hand off (defaults to DefaultLanguageConfidence)
+	MinConfidence float64
+
+	// Heuristics are tried before detection (defaults to DefaultLanguageHeuristics; use an
+	// empty slice to disable them). Only languages of the handoff are accepted from them.
+	Heuristics []LanguageHeuristic
+
+	// DetectedLanguageSchema sets LanguageInputSchema as the input schema when InputJSONSchema
+	// is not set, so the model reports the language it detected and why it hands off
+	DetectedLanguageSchema bool
+}
+
+// LanguageInputSchema is the input schema of a language handoff asking the model for the
+// reason of the handoff and the detected language
+func LanguageInputSchema() JSONSchema {
+	return JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"reason": map[string]any{
+				"type":        "string",
+				"description": "Why the conversation is handed off",
+			},
+			"detected_language": map[string]any{
+				"type":        "string",
+				"description": "The ISO 639-1 code of the user's language, such as \"es\"",
+			},
+		},
+		"required": []string{"detected_language"},
+	}
+}
+
+// LanguageHandoff defines a handoff that occurs when the input is detected to be in one of its languages
+type LanguageHandoff struct {
+	BaseHandoff
+	languages     []whatlanggo.Lang
+	minConfidence float64
+	heuristics    []LanguageHeuristic
+}
+
+// NewLanguageHandoff creates a handoff that triggers when the input text is detected to be in
+// the language. Unknown codes never trigger; NewLanguageHandoffWithOptions reports them.
+func NewLanguageHandoff(targetAgent any, description, langCode string) *LanguageHandoff {
+	h, err := NewLanguageHandoffWithOptions(targetAgent, description, LanguageOptions{Languages: []string{langCode}})
+	if err != nil {
+		h, _ = NewLanguageHandoffWithOptions(targetAgent, description, LanguageOptions{})
+	}
+	return h
+}
+
+// NewLanguageHandoffWithOptions creates a handoff that triggers when the input text is
+// detected to be in one of the languages of the options
+func NewLanguageHandoffWithOptions(targetAgent any, description string, options LanguageOptions) (*LanguageHandoff, error) {
+	h := &LanguageHandoff{
+		BaseHandoff: BaseHandoff{
+			targetAgent:     targetAgent,
+			description:     description,
+			name:            "language_handoff",
+			toolName:        options.ToolName,
+			toolDescription: options.ToolDescription,
+			inputJSONSchema: options.InputJSONSchema,
+			onHandoffCB:     options.OnHandoff,
+			inputFilter:     options.InputFilter,
+		},
+		minConfidence: options.MinConfidence,
+		heuristics:    options.Heuristics,
+	}
+	if h.minConfidence == 0 {
+		h.minConfidence = DefaultLanguageConfidence
+	}
+	if h.heuristics == nil {
+		h.heuristics = DefaultLanguageHeuristics
+	}
+	if h.inputJSONSchema == nil && options.DetectedLanguageSchema {
+		h.inputJSONSchema = LanguageInputSchema()
+	}
+
+	for _, code := range options.Languages {
+		lang, err := parseLanguage(code)
+		if err != nil {
+			return nil, err
+		}
+		h.languages = append(h.languages, lang)
+	}
+	return h, nil
+}
+
+// parseLanguage returns the language of an ISO 639-1 or 639-3 code or an English name
+func parseLanguage(code string) (whatlanggo.Lang, error) {
+	code = strings.TrimSpace(code)
+	if lang := whatlanggo.CodeToLang(strings.ToLower(code)); lang >= 0 {
+		return lang, nil
+	}
+	for lang, name := range whatlanggo.Langs {
+		if strings.EqualFold(lang.Iso6391(), code) || strings.EqualFold(name, code) {
+			return lang, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: %q", ErrUnknownLanguage, code)
+}
+
+// Languages returns the ISO 639-1 codes of the handoff's languages (639-3 for languages without one)
+func (h *LanguageHandoff) Languages() []string {
+	codes := make([]string, 0, len(h.languages))
+	for _, lang := range h.languages {
+		code := lang.Iso6391()
+		if code == "" {
+			code = lang.Iso6393()
+		}
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// ShouldHandoff reports whether the input is in one of the handoff's languages. When the input
+// is a JSON object with a "detected_language", the model's detection is used; otherwise the
+// heuristics and the language detection run on the text of the input.
+func (h *LanguageHandoff) ShouldHandoff(ctx context.Context, input string) (bool, error) {
+	shouldHandoff, _, err := h.ExplainHandoff(ctx, input)
+	return shouldHandoff, err
+}
+
+// ExplainHandoff decides like ShouldHandoff, and says which language was detected and how
+func (h *LanguageHandoff) ExplainHandoff(ctx context.Context, input string) (bool, string, error) {
+	var reported struct {
+		DetectedLanguage string `json:"detected_language"`
+	}
+	if strings.HasPrefix(strings.TrimSpace(input), "{") && json.Unmarshal([]byte(input), &reported) == nil && reported.DetectedLanguage != "" {
+		lang, err := parseLanguage(reported.DetectedLanguage)
+		if err != nil {
+			return false, fmt.Sprintf("the model reported an unknown language %q", reported.DetectedLanguage), nil
+		}
+		return h.handles(lang), fmt.Sprintf("the model reported %s", lang), nil
+	}
+
+	text := inputText(input)
+	if text == "" {
+		return false, "the input has no text", nil
+	}
+
+	for _, heuristic := range h.heuristics {
+		if code, ok := heuristic(text); ok {
+			if lang, err := parseLanguage(code); err == nil && h.handles(lang) {
+				return true, fmt.Sprintf("a heuristic recognized %s", lang), nil
+			}
+		}
+	}
+
+	info := whatlanggo.Detect(text)
+	shouldHandoff := h.handles(info.Lang) && info.Confidence > h.minConfidence
+	return shouldHandoff, fmt.Sprintf("detected %s with confidence %.2f (minimum %.2f)", info.Lang, info.Confidence, h.minConfidence), nil
+}
+
+func (h *LanguageHandoff) handles(lang whatlanggo.Lang) bool {
+	for _, l := range h.languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// languageNames returns the English names of the handoff's languages
+func (h *LanguageHandoff) languageNames() []string {
+	names := make([]string, 0, len(h.languages))
+	for _, lang := range h.languages {
+		names = append(names, lang.String())
+	}
+	return names
+}
+
+// ToolName returns the name of the tool that represents the handoff
+func (h *LanguageHandoff) ToolName() string {
+	if h.toolName != "" {
+		return h.toolName
+	}
+	langNames := strings.Join(h.languageNames(), "_")
+	if agent, ok := h.targetAgent.(interface{ Name() string }); ok {
+		return DefaultToolName(fmt.Sprintf("%s_%s", langNames, agent.Name()))
+	}
+	return DefaultToolName(fmt.Sprintf("language_%s", strings.Join(h.Languages(), "_")))
+}
+
+// ToolDescription returns the description of the tool that represents the handoff
+func (h *LanguageHandoff) ToolDescription() string {
+	if h.toolDescription != "" {
+		return h.toolDescription
+	}
+	langNames := strings.Join(h.languageNames(), " or ")
+	if agent, ok := h.targetAgent.(interface{ Name() string }); ok {
+		return fmt.Sprintf("Handoff to the %s agent to handle %s language requests.", agent.Name(), langNames)
+	}
+	return fmt.Sprintf("Handoff to handle %s language requests.", langNames)
+}
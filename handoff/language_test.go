@@ -0,0 +1,91 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguageHandoffOptions(t *testing.T) {
+	targetAgent := newMockAgent("Europe Agent", "This is an agent for European languages")
+
+	handoff, err := NewLanguageHandoffWithOptions(targetAgent, "European Language Handoff", LanguageOptions{
+		Languages:     []string{"pt", "nld", "Swedish"},
+		MinConfidence: 0.05,
+	})
+	require.NoError(t, err, "Valid languages should not return an error")
+	assert.Equal(t, []string{"pt", "nl", "sv"}, handoff.Languages())
+	assert.Equal(t, "transfer_to_portuguese_dutch_swedish_europe_agent", handoff.ToolName())
+	assert.Equal(t, "Handoff to the Europe Agent agent to handle Portuguese or Dutch or Swedish language requests.", handoff.ToolDescription())
+
+	shouldHandoff, err := handoff.ShouldHandoff(context.Background(), "Olá, preciso de ajuda com a minha encomenda, por favor.")
+	assert.NoError(t, err, "ShouldHandoff should not return an error")
+	assert.True(t, shouldHandoff, "Should handoff for Portuguese input")
+
+	shouldHandoff, err = handoff.ShouldHandoff(context.Background(), "Hallo, ik heb hulp nodig met mijn bestelling.")
+	assert.NoError(t, err, "ShouldHandoff should not return an error")
+	assert.True(t, shouldHandoff, "Should handoff for Dutch input")
+
+	shouldHandoff, err = handoff.ShouldHandoff(context.Background(), "Hello, I need help with my order please.")
+	assert.NoError(t, err, "ShouldHandoff should not return an error")
+	assert.False(t, shouldHandoff, "Should not handoff for English input")
+
+	_, err = NewLanguageHandoffWithOptions(targetAgent, "Unknown", LanguageOptions{Languages: []string{"klingon"}})
+	assert.ErrorIs(t, err, ErrUnknownLanguage)
+	assert.False(t, mustShouldHandoff(t, NewLanguageHandoff(targetAgent, "Unknown", "klingon"), "Hello there, how are you?"))
+}
+
+func TestLanguageHandoffHeuristics(t *testing.T) {
+	targetAgent := newMockAgent("Italian Agent", "This is an Italian agent")
+
+	// Short greetings are recognized by custom heuristics
+	handoff, err := NewLanguageHandoffWithOptions(targetAgent, "Italian Language Handoff", LanguageOptions{
+		Languages:  []string{"it"},
+		Heuristics: []LanguageHeuristic{WordListHeuristic("it", nil, []string{"ciao", "grazie"})},
+	})
+	require.NoError(t, err)
+	assert.True(t, mustShouldHandoff(t, handoff, "ciao!"))
+
+	// Heuristics for other languages are ignored
+	assert.False(t, mustShouldHandoff(t, handoff, "¿hola?"))
+
+	// A high confidence threshold rejects uncertain detections
+	strict, err := NewLanguageHandoffWithOptions(targetAgent, "Italian Language Handoff", LanguageOptions{
+		Languages:     []string{"it"},
+		Heuristics:    []LanguageHeuristic{},
+		MinConfidence: 1.1,
+	})
+	require.NoError(t, err)
+	assert.False(t, mustShouldHandoff(t, strict, "Buongiorno, ho bisogno di aiuto con il mio ordine."))
+}
+
+func TestLanguageHandoffDetectedLanguageSchema(t *testing.T) {
+	targetAgent := newMockAgent("Japanese Agent", "This is a Japanese agent")
+
+	handoff, err := NewLanguageHandoffWithOptions(targetAgent, "Japanese Language Handoff", LanguageOptions{
+		Languages:              []string{"ja"},
+		DetectedLanguageSchema: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, LanguageInputSchema(), handoff.InputJSONSchema())
+
+	// The language reported by the model is used
+	assert.True(t, mustShouldHandoff(t, handoff, `{"reason": "the user writes in Japanese", "detected_language": "ja"}`))
+	assert.False(t, mustShouldHandoff(t, handoff, `{"reason": "the user writes in Korean", "detected_language": "ko"}`))
+
+	// Without a reported language, the text of the arguments is detected
+	assert.True(t, mustShouldHandoff(t, handoff, `{"reason": "こんにちは、注文について助けが必要です"}`))
+}
+
+func mustShouldHandoff(t *testing.T, h Handoff, input string) bool {
+	t.Helper()
+	shouldHandoff, err := h.ShouldHandoff(context.Background(), input)
+	require.NoError(t, err)
+	return shouldHandoff
+}
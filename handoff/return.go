@@ -0,0 +1,80 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import "context"
+
+const (
+	// ReturnToolName is the default tool name of return handoffs
+	ReturnToolName = "return_to_previous_agent"
+
+	// ReturnToolDescription is the default tool description of return handoffs
+	ReturnToolDescription = "Hand the conversation back to the agent that transferred it to you, " +
+		"when the request is outside your scope or your part of it is done."
+)
+
+// ReturnHandoff hands the conversation back to the agent that handed it off to the current agent,
+// as in triage → specialist → triage flows, without wiring a reverse handoff to every parent.
+// It has no target agent: the runner keeps a stack of the run's handoffs and returns to the top
+// of it. The runner only offers the handoff to agents that were reached by a handoff.
+type ReturnHandoff struct {
+	BaseHandoff
+}
+
+// ShouldHandoff for ReturnHandoff always returns true (delegating to the LLM)
+func (h *ReturnHandoff) ShouldHandoff(ctx context.Context, input string) (bool, error) {
+	return true, nil
+}
+
+// ToolName returns the name of the tool that represents the handoff
+func (h *ReturnHandoff) ToolName() string {
+	if h.toolName != "" {
+		return h.toolName
+	}
+	return ReturnToolName
+}
+
+// ToolDescription returns the description of the tool that represents the handoff
+func (h *ReturnHandoff) ToolDescription() string {
+	if h.toolDescription != "" {
+		return h.toolDescription
+	}
+	if h.description != "" {
+		return ReturnToolDescription + " " + h.description
+	}
+	return ReturnToolDescription
+}
+
+// NewReturnHandoff creates a handoff back to the agent that handed off to the current agent
+func NewReturnHandoff() Handoff {
+	return NewReturnHandoffWithOptions(Options{})
+}
+
+// NewReturnHandoffWithOptions creates a return handoff with customizable options
+func NewReturnHandoffWithOptions(options Options) Handoff {
+	return &ReturnHandoff{
+		BaseHandoff: BaseHandoff{
+			description:     options.Description,
+			name:            "return_handoff",
+			toolName:        options.ToolName,
+			toolDescription: options.ToolDescription,
+			inputJSONSchema: options.InputJSONSchema,
+			onHandoffCB:     options.OnHandoff,
+			inputFilter:     options.InputFilter,
+		},
+	}
+}
+
+// IsReturnHandoff reports whether the handoff returns to the previous agent, including return
+// handoffs wrapped by NewFilteredHandoff
+func IsReturnHandoff(h Handoff) bool {
+	switch h := h.(type) {
+	case *ReturnHandoff:
+		return true
+	case *FilteredHandoff:
+		return IsReturnHandoff(h.baseHandoff)
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReturnHandoff(t *testing.T) {
+	h := NewReturnHandoff()
+	assert.True(t, IsReturnHandoff(h))
+	assert.Nil(t, h.TargetAgent())
+	assert.Equal(t, ReturnToolName, h.ToolName())
+	assert.Equal(t, ReturnToolDescription, h.ToolDescription())
+
+	shouldHandoff, err := h.ShouldHandoff(context.Background(), "{}")
+	assert.NoError(t, err)
+	assert.True(t, shouldHandoff)
+
+	custom := NewReturnHandoffWithOptions(Options{Description: "Return once the refund is processed.", ToolName: "back_to_triage"})
+	assert.Equal(t, "back_to_triage", custom.ToolName())
+	assert.Equal(t, ReturnToolDescription+" Return once the refund is processed.", custom.ToolDescription())
+
+	filtered := NewFilteredHandoff(custom, func(ctx context.Context, inputData *InputData) (*InputData, error) {
+		return inputData, nil
+	})
+	assert.True(t, IsReturnHandoff(filtered))
+	assert.False(t, IsReturnHandoff(NewHandoff(nil, "")))
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ryichk/ai-agents-sdk-go/retrieval"
+)
+
+// DefaultSemanticThreshold is a similarity threshold that suits OpenAI's text-embedding-3 models
+const DefaultSemanticThreshold = 0.5
+
+// SemanticHandoff hands off when the input means the same as one of its example utterances.
+// The input and the examples are embedded, and the handoff happens when the cosine similarity
+// with the closest example reaches the threshold. It matches paraphrases that KeywordHandoff
+// and PatternHandoff miss.
+type SemanticHandoff struct {
+	BaseHandoff
+	examples  []string
+	embedder  retrieval.Embedder
+	threshold float64
+
+	// The examples are embedded once, on first use
+	mu                sync.Mutex
+	exampleEmbeddings [][]float32
+}
+
+// NewSemanticHandoff creates a handoff that triggers when the input is at least threshold
+// similar to one of the example utterances
+func NewSemanticHandoff(targetAgent any, description string, examples []string, embedder retrieval.Embedder, threshold float64) *SemanticHandoff {
+	return NewSemanticHandoffWithOptions(targetAgent, description, examples, embedder, threshold, Options{})
+}
+
+// SemanticInputSchema is the default input schema of a semantic handoff. It asks the model for
+// the user's request, since the arguments of the handoff tool call are what is compared with the
+// examples and a call without text never hands off.
+func SemanticInputSchema() JSONSchema {
+	return JSONSchema{
+		"type": "object",
+		"properties": map[string]any{
+			"message": map[string]any{
+				"type":        "string",
+				"description": "The user's request, in the user's words",
+			},
+		},
+		"required": []string{"message"},
+	}
+}
+
+// NewSemanticHandoffWithOptions creates a semantic handoff with options. Without an
+// InputJSONSchema, the handoff uses SemanticInputSchema; a custom schema needs a string field
+// describing the request.
+func NewSemanticHandoffWithOptions(targetAgent any, description string, examples []string, embedder retrieval.Embedder, threshold float64, options Options) *SemanticHandoff {
+	if options.InputJSONSchema == nil {
+		options.InputJSONSchema = SemanticInputSchema()
+	}
+	return &SemanticHandoff{
+		BaseHandoff: BaseHandoff{
+			targetAgent:     targetAgent,
+			description:     description,
+			name:            "semantic_handoff",
+			toolName:        options.ToolName,
+			toolDescription: options.ToolDescription,
+			inputJSONSchema: options.InputJSONSchema,
+			onHandoffCB:     options.OnHandoff,
+			inputFilter:     options.InputFilter,
+		},
+		examples:  examples,
+		embedder:  embedder,
+		threshold: threshold,
+	}
+}
+
+// ShouldHandoff reports whether the input is similar enough to one of the examples.
+// When the input is a JSON object, such as the arguments of the handoff tool call, its string
+// values are compared; an input without text never hands off.
+func (h *SemanticHandoff) ShouldHandoff(ctx context.Context, input string) (bool, error) {
+	score, err := h.Similarity(ctx, input)
+	if err != nil {
+		return false, err
+	}
+	return score >= h.threshold, nil
+}
+
+// ExplainHandoff decides like ShouldHandoff, and gives the similarity with the closest example
+func (h *SemanticHandoff) ExplainHandoff(ctx context.Context, input string) (bool, string, error) {
+	score, err := h.Similarity(ctx, input)
+	if err != nil {
+		return false, "", err
+	}
+	return score >= h.threshold, fmt.Sprintf("similarity %.2f with the closest example (threshold %.2f)", score, h.threshold), nil
+}
+
+// Similarity returns the cosine similarity between the input and its closest example
+func (h *SemanticHandoff) Similarity(ctx context.Context, input string) (float64, error) {
+	text := inputText(input)
+	if text == "" || len(h.examples) == 0 {
+		return 0, nil
+	}
+
+	examples, err := h.embedExamples(ctx)
+	if err != nil {
+		return 0, err
+	}
+	embeddings, err := h.embedder.Embed(ctx, []string{text})
+	if err != nil {
+		return 0, fmt.Errorf("failed to embed handoff input: %w", err)
+	}
+	if len(embeddings) != 1 {
+		return 0, fmt.Errorf("embedder returned %d embeddings for 1 text", len(embeddings))
+	}
+
+	best := 0.0
+	for _, example := range examples {
+		score, err := retrieval.CosineSimilarity(embeddings[0], example)
+		if err != nil {
+			return 0, err
+		}
+		best = max(best, score)
+	}
+	return best, nil
+}
+
+// embedExamples returns the embeddings of the examples, embedding them on first use
+func (h *SemanticHandoff) embedExamples(ctx context.Context) ([][]float32, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.exampleEmbeddings != nil {
+		return h.exampleEmbeddings, nil
+	}
+	embeddings, err := h.embedder.Embed(ctx, h.examples)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed handoff examples: %w", err)
+	}
+	if len(embeddings) != len(h.examples) {
+		return nil, fmt.Errorf("embedder returned %d embeddings for %d examples", len(embeddings), len(h.examples))
+	}
+	h.exampleEmbeddings = embeddings
+	return embeddings, nil
+}
+
+// inputText returns the text of the input, joining the string values of a JSON object
+func inputText(input string) string {
+	input = strings.TrimSpace(input)
+	var object map[string]any
+	if !strings.HasPrefix(input, "{") || json.Unmarshal([]byte(input), &object) != nil {
+		return input
+	}
+
+	var texts []string
+	for _, value := range object {
+		if s, ok := value.(string); ok && strings.TrimSpace(s) != "" {
+			texts = append(texts, s)
+		}
+	}
+	// Map order is random; keep the text stable across calls
+	sort.Strings(texts)
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package handoff
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ryichk/ai-agents-sdk-go/retrieval"
+)
+
+// topicEmbedder embeds texts by the topics they mention, so paraphrases share a vector
+func topicEmbedder(calls *int) retrieval.Embedder {
+	topics := [][]string{
+		{"refund", "money back", "reimburse"},
+		{"password", "log in", "login"},
+	}
+	return retrieval.EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+		*calls++
+		embeddings := make([][]float32, len(texts))
+		for i, text := range texts {
+			embeddings[i] = make([]float32, len(topics)+1)
+			embeddings[i][len(topics)] = 0.1
+			for j, words := range topics {
+				for _, word := range words {
+					if strings.Contains(strings.ToLower(text), word) {
+						embeddings[i][j]++
+					}
+				}
+			}
+		}
+		return embeddings, nil
+	})
+}
+
+func TestNewSemanticHandoff(t *testing.T) {
+	targetAgent := newMockAgent("Billing Agent", "This is a billing agent")
+	calls := 0
+	handoff := NewSemanticHandoff(targetAgent, "Billing Semantic Handoff",
+		[]string{"I want a refund", "Can I get my money back?"}, topicEmbedder(&calls), 0.8)
+
+	assert.Equal(t, targetAgent, handoff.TargetAgent(), "Target agent of handoff is incorrect")
+	assert.Equal(t, "semantic_handoff", handoff.Name(), "Handoff name is incorrect")
+
+	// A paraphrase without the examples' keywords hands off
+	shouldHandoff, err := handoff.ShouldHandoff(context.Background(), "Please reimburse me for the broken item")
+	assert.NoError(t, err, "ShouldHandoff should not return an error")
+	assert.True(t, shouldHandoff, "Should handoff for a paraphrase of an example")
+
+	shouldHandoff, err = handoff.ShouldHandoff(context.Background(), "I can't log in to my account")
+	assert.NoError(t, err, "ShouldHandoff should not return an error")
+	assert.False(t, shouldHandoff, "Should not handoff for an unrelated input")
+
+	// The string values of the handoff tool call's arguments are compared
+	shouldHandoff, err = handoff.ShouldHandoff(context.Background(), `{"reason": "customer asks for a refund", "priority": 1}`)
+	assert.NoError(t, err, "ShouldHandoff should not return an error")
+	assert.True(t, shouldHandoff, "Should handoff for arguments mentioning a refund")
+
+	shouldHandoff, err = handoff.ShouldHandoff(context.Background(), `{}`)
+	assert.NoError(t, err, "ShouldHandoff should not return an error")
+	assert.False(t, shouldHandoff, "Should not handoff without text")
+
+	// The examples are embedded once
+	assert.Equal(t, 4, calls)
+}
+
+func TestSemanticHandoffEmbedderError(t *testing.T) {
+	failing := retrieval.EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+		return nil, assert.AnError
+	})
+	handoff := NewSemanticHandoffWithOptions(newMockAgent("Billing Agent", ""), "Billing", []string{"refund"}, failing,
+		DefaultSemanticThreshold, Options{ToolName: "transfer_to_billing"})
+	assert.Equal(t, "transfer_to_billing", handoff.ToolName())
+	assert.Equal(t, SemanticInputSchema(), handoff.InputJSONSchema(), "The model is asked for the request by default")
+
+	_, err := handoff.ShouldHandoff(context.Background(), "refund please")
+	assert.ErrorIs(t, err, assert.AnError)
+}
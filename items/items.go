@@ -0,0 +1,292 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package items defines the typed items of a conversation, aligned with the item types of the
+// OpenAI Responses API and the items of the Python SDK. A conversation is a list of items that
+// can be converted to and from the chat messages used by model providers, so code can switch on
+// item types instead of parsing roles and tool call fields.
+package items
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// ErrUnknownItemType is returned when decoding an item of an unsupported type
+var ErrUnknownItemType = errors.New("unknown item type")
+
+// Type is the type of an item, as named by the Responses API
+type Type string
+
+const (
+	// TypeMessage is a message from the system, developer, user or assistant
+	TypeMessage Type = "message"
+
+	// TypeFunctionCall is a call of a function tool made by the model
+	TypeFunctionCall Type = "function_call"
+
+	// TypeFunctionCallOutput is the output of a function tool call
+	TypeFunctionCallOutput Type = "function_call_output"
+
+	// TypeReasoning is the reasoning or reasoning summary of the model
+	TypeReasoning Type = "reasoning"
+
+	// TypeFileSearchCall is a call of the hosted file search tool
+	TypeFileSearchCall Type = "file_search_call"
+
+	// TypeWebSearchCall is a call of the hosted web search tool
+	TypeWebSearchCall Type = "web_search_call"
+
+	// TypeModelBehaviorError records an unexpected action of the model, such as a call of an
+	// unknown tool. It is not part of the Responses API.
+	TypeModelBehaviorError Type = "model_behavior_error"
+)
+
+// Item is an item of a conversation. It is one of *Message, *FunctionCall, *FunctionCallOutput,
+// *Reasoning, *FileSearchCall, *WebSearchCall or *ModelBehaviorError.
+type Item interface {
+	// ItemType returns the type of the item
+	ItemType() Type
+}
+
+// Message is a message from the system, developer, user or assistant
+type Message struct {
+	// Role is the role of the author (system, developer, user or assistant)
+	Role string
+
+	// Content is the text of the message
+	Content string
+
+	// Parts are the images, files, audio or additional text of the message
+	Parts []model.ContentPart
+
+	// Refusal is the model's explanation when it refuses to answer
+	Refusal string
+}
+
+// ItemType returns TypeMessage
+func (m *Message) ItemType() Type { return TypeMessage }
+
+// FunctionCall is a call of a function tool made by the model
+type FunctionCall struct {
+	// CallID identifies the call and its output
+	CallID string
+
+	// Name is the name of the tool
+	Name string
+
+	// Arguments are the JSON arguments of the call
+	Arguments string
+}
+
+// ItemType returns TypeFunctionCall
+func (c *FunctionCall) ItemType() Type { return TypeFunctionCall }
+
+// FunctionCallOutput is the output of a function tool call
+type FunctionCallOutput struct {
+	// CallID is the ID of the FunctionCall
+	CallID string
+
+	// Output is the output of the tool
+	Output string
+
+	// NestedResult is the result of the run made by the tool, such as the run of an agent used as
+	// a tool (a *runner.Result), when the run keeps nested results. It is not encoded.
+	NestedResult any
+}
+
+// ItemType returns TypeFunctionCallOutput
+func (o *FunctionCallOutput) ItemType() Type { return TypeFunctionCallOutput }
+
+// Reasoning is the reasoning or reasoning summary the model returned before its answer
+type Reasoning struct {
+	// Summary is the text of the reasoning
+	Summary string
+}
+
+// ItemType returns TypeReasoning
+func (r *Reasoning) ItemType() Type { return TypeReasoning }
+
+// FileSearchCall is a call of the hosted file search tool. Chat completion providers never
+// return it; it is decoded from Responses API items.
+type FileSearchCall struct {
+	ID      string
+	Status  string
+	Queries []string
+}
+
+// ItemType returns TypeFileSearchCall
+func (c *FileSearchCall) ItemType() Type { return TypeFileSearchCall }
+
+// WebSearchCall is a call of the hosted web search tool. Chat completion providers never
+// return it; it is decoded from Responses API items.
+type WebSearchCall struct {
+	ID     string
+	Status string
+}
+
+// ItemType returns TypeWebSearchCall
+func (c *WebSearchCall) ItemType() Type { return TypeWebSearchCall }
+
+// ModelBehaviorError records an unexpected action of the model, such as a call of a tool the agent
+// does not have. It is added by the runner and has no chat message.
+type ModelBehaviorError struct {
+	// CallID is the ID of the tool call at fault, if any
+	CallID string
+
+	// Message describes the error
+	Message string
+}
+
+// ItemType returns TypeModelBehaviorError
+func (e *ModelBehaviorError) ItemType() Type { return TypeModelBehaviorError }
+
+// FromMessages converts chat messages to items. An assistant message becomes its reasoning,
+// its message and one FunctionCall per tool call, and a tool message becomes a FunctionCallOutput.
+func FromMessages(messages []model.Message) []Item {
+	result := make([]Item, 0, len(messages))
+	for _, msg := range messages {
+		switch msg.Role {
+		case "assistant":
+			if msg.Reasoning != "" {
+				result = append(result, &Reasoning{Summary: msg.Reasoning})
+			}
+			if msg.Content != "" || msg.Refusal != "" || len(msg.ContentParts) > 0 {
+				result = append(result, &Message{Role: msg.Role, Content: msg.Content, Parts: msg.ContentParts, Refusal: msg.Refusal})
+			}
+			for _, tc := range msg.ToolCalls {
+				result = append(result, &FunctionCall{CallID: tc.ID, Name: tc.Function.Name, Arguments: tc.Function.Arguments})
+			}
+		case "tool":
+			result = append(result, &FunctionCallOutput{CallID: msg.ToolCallID, Output: msg.Content})
+		default:
+			result = append(result, &Message{Role: msg.Role, Content: msg.Content, Parts: msg.ContentParts})
+		}
+	}
+	return result
+}
+
+// ToMessages converts items to chat messages. Reasoning and function calls are merged into the
+// assistant message they follow, or into a new one. Hosted tool calls and model behavior errors
+// have no chat message and are skipped.
+func ToMessages(list []Item) []model.Message {
+	var result []model.Message
+	// assistant is the index of the assistant message the next calls are added to, or -1
+	assistant := -1
+	reasoning := ""
+
+	openAssistant := func() *model.Message {
+		if assistant < 0 {
+			result = append(result, model.Message{Role: "assistant"})
+			assistant = len(result) - 1
+		}
+		return &result[assistant]
+	}
+
+	for _, item := range list {
+		switch it := item.(type) {
+		case *Reasoning:
+			reasoning = it.Summary
+			assistant = -1
+		case *Message:
+			if it.Role != "assistant" {
+				result = append(result, model.Message{Role: it.Role, Content: it.Content, ContentParts: it.Parts})
+				assistant = -1
+				continue
+			}
+			result = append(result, model.Message{Role: it.Role, Content: it.Content, ContentParts: it.Parts, Refusal: it.Refusal, Reasoning: reasoning})
+			assistant = len(result) - 1
+			reasoning = ""
+		case *FunctionCall:
+			msg := openAssistant()
+			if reasoning != "" {
+				msg.Reasoning = reasoning
+				reasoning = ""
+			}
+			msg.ToolCalls = append(msg.ToolCalls, model.ToolCall{
+				ID:       it.CallID,
+				Type:     "function",
+				Function: model.FunctionCall{Name: it.Name, Arguments: it.Arguments},
+			})
+		case *FunctionCallOutput:
+			result = append(result, model.Message{Role: "tool", ToolCallID: it.CallID, Content: it.Output})
+			assistant = -1
+		}
+	}
+	return result
+}
+
+// Marshal encodes the items as a JSON array in the Responses API input format
+func Marshal(list []Item) ([]byte, error) {
+	raw := make([]json.RawMessage, len(list))
+	for i, item := range list {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		raw[i] = data
+	}
+	return json.Marshal(raw)
+}
+
+// Unmarshal decodes a JSON array of items in the Responses API format.
+// It returns an error wrapping ErrUnknownItemType for unsupported item types.
+func Unmarshal(data []byte) ([]Item, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	result := make([]Item, 0, len(raw))
+	for i, itemData := range raw {
+		item, err := UnmarshalItem(itemData)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// UnmarshalItem decodes a single item in the Responses API format.
+// Items without a type but with a role are messages, as in the Responses API.
+func UnmarshalItem(data []byte) (Item, error) {
+	var header struct {
+		Type Type   `json:"type"`
+		Role string `json:"role"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	if header.Type == "" && header.Role != "" {
+		header.Type = TypeMessage
+	}
+
+	var item Item
+	switch header.Type {
+	case TypeMessage:
+		item = &Message{}
+	case TypeFunctionCall:
+		item = &FunctionCall{}
+	case TypeFunctionCallOutput:
+		item = &FunctionCallOutput{}
+	case TypeReasoning:
+		item = &Reasoning{}
+	case TypeFileSearchCall:
+		item = &FileSearchCall{}
+	case TypeWebSearchCall:
+		item = &WebSearchCall{}
+	case TypeModelBehaviorError:
+		item = &ModelBehaviorError{}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownItemType, header.Type)
+	}
+	if err := json.Unmarshal(data, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
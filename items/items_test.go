@@ -0,0 +1,117 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package items
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func conversation() []model.Message {
+	return []model.Message{
+		{Role: "system", Content: "instructions"},
+		{Role: "user", Content: "look at this", ContentParts: []model.ContentPart{model.NewImagePart("https://example.com/a.png", "low")}},
+		{Role: "assistant", Reasoning: "I should look it up", ToolCalls: []model.ToolCall{
+			{ID: "call_1", Type: "function", Function: model.FunctionCall{Name: "lookup", Arguments: `{"q": "a"}`}},
+			{ID: "call_2", Type: "function", Function: model.FunctionCall{Name: "lookup", Arguments: `{"q": "b"}`}},
+		}},
+		{Role: "tool", ToolCallID: "call_1", Content: "found a"},
+		{Role: "tool", ToolCallID: "call_2", Content: "found b"},
+		{Role: "assistant", Content: "Both were found"},
+	}
+}
+
+func TestFromMessages(t *testing.T) {
+	list := FromMessages(conversation())
+
+	types := make([]Type, len(list))
+	for i, item := range list {
+		types[i] = item.ItemType()
+	}
+	assert.Equal(t, []Type{
+		TypeMessage, TypeMessage, TypeReasoning, TypeFunctionCall, TypeFunctionCall,
+		TypeFunctionCallOutput, TypeFunctionCallOutput, TypeMessage,
+	}, types)
+
+	assert.Equal(t, &Reasoning{Summary: "I should look it up"}, list[2])
+	assert.Equal(t, &FunctionCall{CallID: "call_2", Name: "lookup", Arguments: `{"q": "b"}`}, list[4])
+	assert.Equal(t, &FunctionCallOutput{CallID: "call_1", Output: "found a"}, list[5])
+
+	var answer string
+	for _, item := range list {
+		if msg, ok := item.(*Message); ok && msg.Role == "assistant" {
+			answer = msg.Content
+		}
+	}
+	assert.Equal(t, "Both were found", answer)
+}
+
+func TestToMessagesRoundTrip(t *testing.T) {
+	messages := conversation()
+	assert.Equal(t, messages, ToMessages(FromMessages(messages)))
+}
+
+func TestToMessagesMergesCallsIntoAssistantMessage(t *testing.T) {
+	messages := ToMessages([]Item{
+		&Message{Role: "user", Content: "hi"},
+		&Message{Role: "assistant", Content: "Let me check"},
+		&FunctionCall{CallID: "call_1", Name: "lookup", Arguments: "{}"},
+		&WebSearchCall{ID: "ws_1", Status: "completed"},
+		&FunctionCallOutput{CallID: "call_1", Output: "found"},
+	})
+
+	require.Len(t, messages, 3)
+	assert.Equal(t, "Let me check", messages[1].Content)
+	require.Len(t, messages[1].ToolCalls, 1)
+	assert.Equal(t, "lookup", messages[1].ToolCalls[0].Function.Name)
+	assert.Equal(t, "tool", messages[2].Role)
+}
+
+func TestToMessagesSkipsModelBehaviorErrors(t *testing.T) {
+	list := append(FromMessages(conversation()), &ModelBehaviorError{CallID: "call_2", Message: "unknown tool"})
+	assert.Equal(t, conversation(), ToMessages(list))
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	list := append(FromMessages(conversation()),
+		&Message{Role: "assistant", Refusal: "I can't help with that."},
+		&FileSearchCall{ID: "fs_1", Status: "completed", Queries: []string{"refunds"}},
+		&WebSearchCall{ID: "ws_1", Status: "completed"},
+		&ModelBehaviorError{CallID: "call_3", Message: `tool "search" not found`},
+	)
+
+	data, err := Marshal(list)
+	require.NoError(t, err)
+
+	decoded, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, list, decoded)
+}
+
+func TestUnmarshalResponsesItems(t *testing.T) {
+	decoded, err := Unmarshal([]byte(`[
+		{"role": "user", "content": "hello"},
+		{"type": "message", "role": "assistant", "content": [{"type": "output_text", "text": "hi", "annotations": []}]},
+		{"type": "function_call", "id": "fc_1", "call_id": "call_1", "name": "lookup", "arguments": "{}"},
+		{"type": "function_call_output", "call_id": "call_1", "output": "found"},
+		{"type": "reasoning", "id": "rs_1", "summary": [{"type": "summary_text", "text": "first"}, {"type": "summary_text", "text": "second"}]}
+	]`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Item{
+		&Message{Role: "user", Content: "hello"},
+		&Message{Role: "assistant", Content: "hi"},
+		&FunctionCall{CallID: "call_1", Name: "lookup", Arguments: "{}"},
+		&FunctionCallOutput{CallID: "call_1", Output: "found"},
+		&Reasoning{Summary: "first\n\nsecond"},
+	}, decoded)
+
+	_, err = Unmarshal([]byte(`[{"type": "computer_call"}]`))
+	assert.ErrorIs(t, err, ErrUnknownItemType)
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package items
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// contentPart is a message content part in the Responses API format
+type contentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	Refusal  string `json:"refusal,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	FileID   string `json:"file_id,omitempty"`
+	FileData string `json:"file_data,omitempty"`
+	FileName string `json:"filename,omitempty"`
+
+	InputAudio *model.InputAudio `json:"input_audio,omitempty"`
+}
+
+// MarshalJSON encodes the message in the Responses API format. The content is a string for
+// text-only messages and a list of content parts otherwise.
+func (m *Message) MarshalJSON() ([]byte, error) {
+	type message struct {
+		Type    Type   `json:"type"`
+		Role    string `json:"role"`
+		Content any    `json:"content"`
+	}
+	if len(m.Parts) == 0 && m.Refusal == "" {
+		return json.Marshal(message{Type: TypeMessage, Role: m.Role, Content: m.Content})
+	}
+
+	textType := "input_text"
+	if m.Role == "assistant" {
+		textType = "output_text"
+	}
+	parts := make([]contentPart, 0, len(m.Parts)+2)
+	if m.Content != "" {
+		parts = append(parts, contentPart{Type: textType, Text: m.Content})
+	}
+	for _, part := range m.Parts {
+		switch part.Type {
+		case model.ContentPartText:
+			parts = append(parts, contentPart{Type: textType, Text: part.Text})
+		case model.ContentPartImageURL:
+			if part.ImageURL != nil {
+				parts = append(parts, contentPart{Type: "input_image", ImageURL: part.ImageURL.URL, Detail: part.ImageURL.Detail})
+			}
+		case model.ContentPartFile:
+			if part.File != nil {
+				parts = append(parts, contentPart{Type: "input_file", FileID: part.File.FileID, FileData: part.File.FileData, FileName: part.File.FileName})
+			}
+		case model.ContentPartInputAudio:
+			parts = append(parts, contentPart{Type: "input_audio", InputAudio: part.InputAudio})
+		}
+	}
+	if m.Refusal != "" {
+		parts = append(parts, contentPart{Type: "refusal", Refusal: m.Refusal})
+	}
+	return json.Marshal(message{Type: TypeMessage, Role: m.Role, Content: parts})
+}
+
+// UnmarshalJSON decodes a message in the Responses API format, with a string or a list of
+// content parts as content. The first text part becomes Content.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var message struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &message); err != nil {
+		return err
+	}
+	*m = Message{Role: message.Role}
+	if len(message.Content) == 0 || string(message.Content) == "null" {
+		return nil
+	}
+
+	if err := json.Unmarshal(message.Content, &m.Content); err == nil {
+		return nil
+	}
+	var parts []contentPart
+	if err := json.Unmarshal(message.Content, &parts); err != nil {
+		return fmt.Errorf("invalid message content: %w", err)
+	}
+	for _, part := range parts {
+		switch part.Type {
+		case "input_text", "output_text", "text":
+			if m.Content == "" && len(m.Parts) == 0 {
+				m.Content = part.Text
+			} else {
+				m.Parts = append(m.Parts, model.NewTextPart(part.Text))
+			}
+		case "refusal":
+			m.Refusal = part.Refusal
+		case "input_image":
+			m.Parts = append(m.Parts, model.NewImagePart(part.ImageURL, part.Detail))
+		case "input_file":
+			m.Parts = append(m.Parts, model.ContentPart{
+				Type: model.ContentPartFile,
+				File: &model.InputFile{FileID: part.FileID, FileData: part.FileData, FileName: part.FileName},
+			})
+		case "input_audio":
+			m.Parts = append(m.Parts, model.ContentPart{Type: model.ContentPartInputAudio, InputAudio: part.InputAudio})
+		}
+	}
+	return nil
+}
+
+// functionCallJSON is a function call in the Responses API format
+type functionCallJSON struct {
+	Type      Type   `json:"type"`
+	CallID    string `json:"call_id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// MarshalJSON encodes the call in the Responses API format
+func (c *FunctionCall) MarshalJSON() ([]byte, error) {
+	return json.Marshal(functionCallJSON{Type: TypeFunctionCall, CallID: c.CallID, Name: c.Name, Arguments: c.Arguments})
+}
+
+// UnmarshalJSON decodes a call in the Responses API format
+func (c *FunctionCall) UnmarshalJSON(data []byte) error {
+	var call functionCallJSON
+	if err := json.Unmarshal(data, &call); err != nil {
+		return err
+	}
+	*c = FunctionCall{CallID: call.CallID, Name: call.Name, Arguments: call.Arguments}
+	return nil
+}
+
+// functionCallOutputJSON is a function call output in the Responses API format
+type functionCallOutputJSON struct {
+	Type   Type   `json:"type"`
+	CallID string `json:"call_id"`
+	Output string `json:"output"`
+}
+
+// MarshalJSON encodes the output in the Responses API format
+func (o *FunctionCallOutput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(functionCallOutputJSON{Type: TypeFunctionCallOutput, CallID: o.CallID, Output: o.Output})
+}
+
+// UnmarshalJSON decodes an output in the Responses API format
+func (o *FunctionCallOutput) UnmarshalJSON(data []byte) error {
+	var output functionCallOutputJSON
+	if err := json.Unmarshal(data, &output); err != nil {
+		return err
+	}
+	*o = FunctionCallOutput{CallID: output.CallID, Output: output.Output}
+	return nil
+}
+
+// summaryPart is a reasoning summary part in the Responses API format
+type summaryPart struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// MarshalJSON encodes the reasoning in the Responses API format, with a single summary part
+func (r *Reasoning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type    Type          `json:"type"`
+		Summary []summaryPart `json:"summary"`
+	}{Type: TypeReasoning, Summary: []summaryPart{{Type: "summary_text", Text: r.Summary}}})
+}
+
+// UnmarshalJSON decodes reasoning in the Responses API format, joining its summary parts
+func (r *Reasoning) UnmarshalJSON(data []byte) error {
+	var reasoning struct {
+		Summary []summaryPart `json:"summary"`
+	}
+	if err := json.Unmarshal(data, &reasoning); err != nil {
+		return err
+	}
+	*r = Reasoning{}
+	for i, part := range reasoning.Summary {
+		if i > 0 {
+			r.Summary += "\n\n"
+		}
+		r.Summary += part.Text
+	}
+	return nil
+}
+
+// fileSearchCallJSON is a file search call in the Responses API format
+type fileSearchCallJSON struct {
+	Type    Type     `json:"type"`
+	ID      string   `json:"id"`
+	Status  string   `json:"status,omitempty"`
+	Queries []string `json:"queries,omitempty"`
+}
+
+// MarshalJSON encodes the call in the Responses API format
+func (c *FileSearchCall) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fileSearchCallJSON{Type: TypeFileSearchCall, ID: c.ID, Status: c.Status, Queries: c.Queries})
+}
+
+// UnmarshalJSON decodes a call in the Responses API format
+func (c *FileSearchCall) UnmarshalJSON(data []byte) error {
+	var call fileSearchCallJSON
+	if err := json.Unmarshal(data, &call); err != nil {
+		return err
+	}
+	*c = FileSearchCall{ID: call.ID, Status: call.Status, Queries: call.Queries}
+	return nil
+}
+
+// webSearchCallJSON is a web search call in the Responses API format
+type webSearchCallJSON struct {
+	Type   Type   `json:"type"`
+	ID     string `json:"id"`
+	Status string `json:"status,omitempty"`
+}
+
+// MarshalJSON encodes the call in the Responses API format
+func (c *WebSearchCall) MarshalJSON() ([]byte, error) {
+	return json.Marshal(webSearchCallJSON{Type: TypeWebSearchCall, ID: c.ID, Status: c.Status})
+}
+
+// UnmarshalJSON decodes a call in the Responses API format
+func (c *WebSearchCall) UnmarshalJSON(data []byte) error {
+	var call webSearchCallJSON
+	if err := json.Unmarshal(data, &call); err != nil {
+		return err
+	}
+	*c = WebSearchCall{ID: call.ID, Status: call.Status}
+	return nil
+}
+
+// modelBehaviorErrorJSON is a model behavior error in the format of the other items
+type modelBehaviorErrorJSON struct {
+	Type    Type   `json:"type"`
+	CallID  string `json:"call_id,omitempty"`
+	Message string `json:"message"`
+}
+
+// MarshalJSON encodes the error as an item with the type "model_behavior_error"
+func (e *ModelBehaviorError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(modelBehaviorErrorJSON{Type: TypeModelBehaviorError, CallID: e.CallID, Message: e.Message})
+}
+
+// UnmarshalJSON decodes an error encoded by MarshalJSON
+func (e *ModelBehaviorError) UnmarshalJSON(data []byte) error {
+	var behaviorErr modelBehaviorErrorJSON
+	if err := json.Unmarshal(data, &behaviorErr); err != nil {
+		return err
+	}
+	*e = ModelBehaviorError{CallID: behaviorErr.CallID, Message: behaviorErr.Message}
+	return nil
+}
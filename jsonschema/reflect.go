@@ -0,0 +1,191 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package jsonschema
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reflector generates JSON schemas from Go types, for the values encoding/json produces:
+//
+//   - fields are named after their json tags, fields tagged "-" are skipped and the fields of
+//     embedded structs are flattened
+//   - fields are required unless they are pointers or tagged omitempty or omitzero
+//   - time.Time is a string in date-time format and []byte a (base64) string
+//   - a description tag becomes the field's description
+//   - an enum tag lists the allowed values, separated by commas, e.g. `enum:"low,medium,high"`;
+//     the values of a slice field are the allowed values of its items
+//
+// Unlike model.StrictJSONSchema, every Go type is accepted: maps become objects whose values
+// follow the map's element type, and interfaces accept any value.
+type Reflector struct {
+	// FieldDescription describes the fields without a description tag (optional).
+	// It receives the struct type and the JSON name of the field.
+	FieldDescription func(structType reflect.Type, name string) string
+}
+
+// Reflect generates the JSON schema of values of type t with the default Reflector
+func Reflect(t reflect.Type) map[string]any {
+	return (&Reflector{}).Reflect(t)
+}
+
+// Reflect generates the JSON schema of values of type t
+func (r *Reflector) Reflect(t reflect.Type) map[string]any {
+	return r.typeSchema(t, map[reflect.Type]bool{})
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// typeSchema generates the schema of a type; visiting guards against recursive types
+func (r *Reflector) typeSchema(t reflect.Type, visiting map[reflect.Type]bool) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes byte slices as base64 strings
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "array", "items": r.typeSchema(t.Elem(), visiting)}
+	case reflect.Map:
+		schema := map[string]any{"type": "object"}
+		if t.Key().Kind() == reflect.String {
+			schema["additionalProperties"] = r.typeSchema(t.Elem(), visiting)
+		}
+		return schema
+	case reflect.Struct:
+		// A recursive type is described down to its first repetition
+		if visiting[t] {
+			return map[string]any{"type": "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		properties := map[string]any{}
+		required := []string{}
+		r.addFields(t, t, properties, &required, visiting)
+		return map[string]any{
+			"type":       "object",
+			"properties": properties,
+			"required":   required,
+		}
+	case reflect.Interface:
+		return map[string]any{}
+	default:
+		// Channels and functions cannot be encoded; they are described as strings
+		return map[string]any{"type": "string"}
+	}
+}
+
+// addFields adds the schemas of the fields of t, an embedded struct of owner or owner itself
+func (r *Reflector) addFields(owner reflect.Type, t reflect.Type, properties map[string]any, required *[]string, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			embedded := fieldType
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && embedded != timeType {
+				r.addFields(owner, embedded, properties, required, visiting)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := r.typeSchema(fieldType, visiting)
+		if description := field.Tag.Get("description"); description != "" {
+			schema["description"] = description
+		} else if r.FieldDescription != nil {
+			if description := r.FieldDescription(owner, name); description != "" {
+				schema["description"] = description
+			}
+		}
+		if enum, ok := field.Tag.Lookup("enum"); ok {
+			addEnum(schema, fieldType, enum)
+		}
+
+		properties[name] = schema
+		if fieldType.Kind() != reflect.Pointer && !hasOption(options, "omitempty") && !hasOption(options, "omitzero") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// addEnum adds the values of an enum tag to the schema of a field of type t, or to the schema of
+// its items for slices. Values are converted to the field's type; values that do not convert are skipped.
+func addEnum(schema map[string]any, t reflect.Type, tag string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		if items, ok := schema["items"].(map[string]any); ok {
+			addEnum(items, t.Elem(), tag)
+		}
+		return
+	}
+
+	values := []any{}
+	for _, value := range strings.Split(tag, ",") {
+		value = strings.TrimSpace(value)
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				values = append(values, n)
+			}
+		case reflect.Float32, reflect.Float64:
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				values = append(values, n)
+			}
+		case reflect.Bool:
+			if b, err := strconv.ParseBool(value); err == nil {
+				values = append(values, b)
+			}
+		default:
+			values = append(values, value)
+		}
+	}
+	schema["enum"] = values
+}
+
+// hasOption reports whether the options of a json tag include option
+func hasOption(options string, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type audit struct {
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type ticket struct {
+	audit
+	ID       string            `json:"id" description:"The ticket ID"`
+	Priority string            `json:"priority" enum:"low, medium,high"`
+	Level    int               `json:"level,omitempty" enum:"1,2,3"`
+	Labels   []string          `json:"labels,omitempty" enum:"bug,feature"`
+	Assignee *string           `json:"assignee"`
+	Parent   *ticket           `json:"parent,omitempty"`
+	Meta     map[string]int    `json:"meta,omitzero"`
+	Extra    any               `json:"extra,omitempty"`
+	Raw      []byte            `json:"raw,omitempty"`
+	Notes    string            // untagged fields keep their Go name
+	Secret   string            `json:"-"`
+	internal string            // unexported fields are skipped
+	Headers  map[string]string `json:"-,"`
+}
+
+func TestReflect(t *testing.T) {
+	schema := Reflect(reflect.TypeOf(&ticket{}))
+
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []string{"created_at", "id", "priority", "Notes", "-"}, schema["required"])
+
+	properties := schema["properties"].(map[string]any)
+	assert.NotContains(t, properties, "Secret")
+	assert.NotContains(t, properties, "internal")
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, properties["created_at"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, properties["deleted_at"])
+	assert.Equal(t, map[string]any{"type": "string", "description": "The ticket ID"}, properties["id"])
+	assert.Equal(t, map[string]any{"type": "string", "enum": []any{"low", "medium", "high"}}, properties["priority"])
+	assert.Equal(t, map[string]any{"type": "integer", "enum": []any{int64(1), int64(2), int64(3)}}, properties["level"])
+	assert.Equal(t, map[string]any{
+		"type":  "array",
+		"items": map[string]any{"type": "string", "enum": []any{"bug", "feature"}},
+	}, properties["labels"])
+	assert.Equal(t, map[string]any{"type": "string"}, properties["assignee"])
+	assert.Equal(t, map[string]any{"type": "object"}, properties["parent"], "recursion stops at the repeated type")
+	assert.Equal(t, map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "integer"}}, properties["meta"])
+	assert.Equal(t, map[string]any{}, properties["extra"])
+	assert.Equal(t, map[string]any{"type": "string"}, properties["raw"])
+	assert.Equal(t, map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}}, properties["-"])
+}
+
+func TestReflectorFieldDescription(t *testing.T) {
+	r := &Reflector{FieldDescription: func(structType reflect.Type, name string) string {
+		if structType == reflect.TypeOf(ticket{}) {
+			return "documented " + name
+		}
+		return ""
+	}}
+	properties := r.Reflect(reflect.TypeOf(ticket{}))["properties"].(map[string]any)
+
+	assert.Equal(t, "The ticket ID", properties["id"].(map[string]any)["description"], "the description tag wins")
+	assert.Equal(t, "documented priority", properties["priority"].(map[string]any)["description"])
+	assert.Equal(t, "documented created_at", properties["created_at"].(map[string]any)["description"], "embedded fields belong to the outer struct")
+}
+
+func TestReflectedSchemaValidates(t *testing.T) {
+	schema := Reflect(reflect.TypeOf(ticket{}))
+
+	_, err := ValidateJSON(schema, `{"created_at":"2025-01-02T03:04:05Z","id":"T-1","priority":"high","Notes":"","-":{}}`)
+	assert.NoError(t, err)
+
+	_, err = ValidateJSON(schema, `{"created_at":"2025-01-02T03:04:05Z","id":"T-1","priority":"urgent","Notes":"","-":{}}`)
+	assert.EqualError(t, err, "invalid value for field priority: must be one of [low medium high]")
+
+	_, err = ValidateJSON(schema, `{"created_at":"2025-01-02T03:04:05Z","id":"T-1","priority":"low","Notes":"","-":{},"level":4}`)
+	assert.EqualError(t, err, "invalid value for field level: must be one of [1 2 3]")
+}
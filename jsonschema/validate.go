@@ -0,0 +1,540 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package jsonschema generates JSON schemas from Go types (see Reflector) and validates JSON
+// values against JSON schemas. Validation implements the subset of
+// draft 2020-12 used by tool parameters, handoff inputs and structured outputs:
+//
+//   - type (a name or a list of names), enum and const
+//   - properties, required, additionalProperties, minProperties and maxProperties
+//   - items, prefixItems, minItems, maxItems and uniqueItems
+//   - minLength, maxLength and pattern
+//   - minimum, maximum, exclusiveMinimum, exclusiveMaximum and multipleOf
+//   - allOf, anyOf, oneOf and not
+//   - $ref to the root ("#") and to its $defs or definitions ("#/$defs/address")
+//
+// Other keywords, such as format and description, are annotations and are ignored. Schemas can
+// be written in Go, with []string lists and int bounds, or decoded from JSON.
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ErrInvalidSchema is returned for schemas that cannot be used, such as an unresolvable $ref or an
+// invalid pattern
+var ErrInvalidSchema = errors.New("invalid JSON schema")
+
+// ValidationError reports a value that does not match the schema
+type ValidationError struct {
+	// Path locates the value in the validated document, such as "customer.emails[1]"
+	// (empty for the document itself)
+	Path string
+
+	// Message describes the mismatch, with the path
+	Message string
+}
+
+// Error returns the message of the error
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidateJSON decodes the JSON data and validates it against the schema. It returns the decoded
+// value, with objects as map[string]any, arrays as []any and numbers as float64.
+func ValidateJSON(schema map[string]any, data string) (any, error) {
+	var value any
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if err := Validate(schema, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Validate validates a decoded JSON value against the schema. It returns a *ValidationError for
+// the first mismatch found, or an error wrapping ErrInvalidSchema. An empty schema accepts every value.
+func Validate(schema map[string]any, value any) error {
+	v := &validator{root: schema}
+	return v.validate(schema, value, "")
+}
+
+// validator validates a value against the schema root, which $ref resolves against
+type validator struct {
+	root  map[string]any
+	depth int
+}
+
+// maxRefDepth bounds the $ref resolutions of a validation, against schemas referencing themselves
+// without consuming the value
+const maxRefDepth = 256
+
+func (v *validator) validate(schema any, value any, path string) error {
+	// Boolean schemas accept or reject everything
+	if accept, ok := schema.(bool); ok {
+		if !accept {
+			return invalid(path, "no value is allowed")
+		}
+		return nil
+	}
+	s, ok := asObject(schema)
+	if !ok {
+		return nil
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			return err
+		}
+		if v.depth++; v.depth > maxRefDepth {
+			return fmt.Errorf("%w: $ref %s nests too deeply", ErrInvalidSchema, ref)
+		}
+		err = v.validate(target, value, path)
+		v.depth--
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := v.validateType(s, value, path); err != nil {
+		return err
+	}
+	if err := v.validateEnum(s, value, path); err != nil {
+		return err
+	}
+	if err := v.validateCombinators(s, value, path); err != nil {
+		return err
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		return v.validateObject(s, typed, path)
+	case []any:
+		return v.validateArray(s, typed, path)
+	case string:
+		return v.validateString(s, typed, path)
+	case float64:
+		return v.validateNumber(s, typed, path)
+	}
+	return nil
+}
+
+// validateType checks the type keyword, a name or a list of names
+func (v *validator) validateType(s map[string]any, value any, path string) error {
+	typeValue, ok := s["type"]
+	if !ok {
+		return nil
+	}
+	types := asStrings(typeValue)
+	for _, t := range types {
+		if hasType(value, t) {
+			return nil
+		}
+	}
+	expected := strings.Join(types, " or ")
+	if path == "" {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("invalid type: expected %s", expected)}
+	}
+	return &ValidationError{Path: path, Message: fmt.Sprintf("invalid type for field %s: expected %s", path, expected)}
+}
+
+// validateEnum checks the enum and const keywords
+func (v *validator) validateEnum(s map[string]any, value any, path string) error {
+	if enum, ok := s["enum"]; ok {
+		values := asList(enum)
+		found := false
+		for _, allowed := range values {
+			if equal(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return invalid(path, fmt.Sprintf("must be one of %v", values))
+		}
+	}
+	if constant, ok := s["const"]; ok && !equal(constant, value) {
+		return invalid(path, fmt.Sprintf("must be %v", constant))
+	}
+	return nil
+}
+
+// validateCombinators checks the allOf, anyOf, oneOf and not keywords
+func (v *validator) validateCombinators(s map[string]any, value any, path string) error {
+	for _, sub := range asList(s["allOf"]) {
+		if err := v.validate(sub, value, path); err != nil {
+			return err
+		}
+	}
+
+	if anyOf, ok := s["anyOf"]; ok {
+		var firstErr error
+		matched := false
+		for _, sub := range asList(anyOf) {
+			err := v.validate(sub, value, path)
+			if err == nil {
+				matched = true
+				break
+			}
+			if errors.Is(err, ErrInvalidSchema) {
+				return err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		if !matched {
+			return invalid(path, fmt.Sprintf("must match one of the allowed schemas (%v)", firstErr))
+		}
+	}
+
+	if oneOf, ok := s["oneOf"]; ok {
+		matches := 0
+		for _, sub := range asList(oneOf) {
+			err := v.validate(sub, value, path)
+			if errors.Is(err, ErrInvalidSchema) {
+				return err
+			}
+			if err == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return invalid(path, fmt.Sprintf("must match exactly one of the schemas, matches %d", matches))
+		}
+	}
+
+	if not, ok := s["not"]; ok {
+		err := v.validate(not, value, path)
+		if errors.Is(err, ErrInvalidSchema) {
+			return err
+		}
+		if err == nil {
+			return invalid(path, "must not match the schema of not")
+		}
+	}
+	return nil
+}
+
+// validateObject checks the keywords of objects and validates the properties
+func (v *validator) validateObject(s map[string]any, object map[string]any, path string) error {
+	for _, field := range asStrings(s["required"]) {
+		if _, ok := object[field]; !ok {
+			return &ValidationError{Path: join(path, field), Message: "missing required field: " + join(path, field)}
+		}
+	}
+
+	if limit, ok := asNumber(s["minProperties"]); ok && float64(len(object)) < limit {
+		return invalid(path, fmt.Sprintf("must have at least %v properties", limit))
+	}
+	if limit, ok := asNumber(s["maxProperties"]); ok && float64(len(object)) > limit {
+		return invalid(path, fmt.Sprintf("must have at most %v properties", limit))
+	}
+
+	properties, _ := asObject(s["properties"])
+	additional, hasAdditional := s["additionalProperties"]
+
+	// Sorted, so the first error found does not depend on map order
+	fields := make([]string, 0, len(object))
+	for field := range object {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		if property, ok := properties[field]; ok {
+			if err := v.validate(property, object[field], join(path, field)); err != nil {
+				return err
+			}
+			continue
+		}
+		if !hasAdditional {
+			continue
+		}
+		if accept, ok := additional.(bool); ok && !accept {
+			return &ValidationError{Path: join(path, field), Message: "unexpected field: " + join(path, field)}
+		}
+		if err := v.validate(additional, object[field], join(path, field)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateArray checks the keywords of arrays and validates the items
+func (v *validator) validateArray(s map[string]any, array []any, path string) error {
+	if limit, ok := asNumber(s["minItems"]); ok && float64(len(array)) < limit {
+		return invalid(path, fmt.Sprintf("must have at least %v items", limit))
+	}
+	if limit, ok := asNumber(s["maxItems"]); ok && float64(len(array)) > limit {
+		return invalid(path, fmt.Sprintf("must have at most %v items", limit))
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		for i := range array {
+			for j := i + 1; j < len(array); j++ {
+				if equal(array[i], array[j]) {
+					return invalid(path, fmt.Sprintf("items %d and %d must be unique", i, j))
+				}
+			}
+		}
+	}
+
+	prefix := asList(s["prefixItems"])
+	for i, item := range array {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if i < len(prefix) {
+			if err := v.validate(prefix[i], item, itemPath); err != nil {
+				return err
+			}
+			continue
+		}
+		if items, ok := s["items"]; ok {
+			if err := v.validate(items, item, itemPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateString checks the length and pattern of strings
+func (v *validator) validateString(s map[string]any, str string, path string) error {
+	length := float64(utf8.RuneCountInString(str))
+	if limit, ok := asNumber(s["minLength"]); ok && length < limit {
+		return invalid(path, fmt.Sprintf("must be at least %v characters long", limit))
+	}
+	if limit, ok := asNumber(s["maxLength"]); ok && length > limit {
+		return invalid(path, fmt.Sprintf("must be at most %v characters long", limit))
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(str) {
+			return invalid(path, fmt.Sprintf("must match the pattern %s", pattern))
+		}
+	}
+	return nil
+}
+
+// validateNumber checks the ranges of numbers
+func (v *validator) validateNumber(s map[string]any, number float64, path string) error {
+	if limit, ok := asNumber(s["minimum"]); ok && number < limit {
+		return invalid(path, fmt.Sprintf("must be at least %v", limit))
+	}
+	if limit, ok := asNumber(s["maximum"]); ok && number > limit {
+		return invalid(path, fmt.Sprintf("must be at most %v", limit))
+	}
+	if limit, ok := asNumber(s["exclusiveMinimum"]); ok && number <= limit {
+		return invalid(path, fmt.Sprintf("must be greater than %v", limit))
+	}
+	if limit, ok := asNumber(s["exclusiveMaximum"]); ok && number >= limit {
+		return invalid(path, fmt.Sprintf("must be less than %v", limit))
+	}
+	if divisor, ok := asNumber(s["multipleOf"]); ok && divisor > 0 {
+		if quotient := number / divisor; math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			return invalid(path, fmt.Sprintf("must be a multiple of %v", divisor))
+		}
+	}
+	return nil
+}
+
+// resolve returns the schema a $ref points to, in the root schema
+func (v *validator) resolve(ref string) (any, error) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("%w: only local references are supported, not %s", ErrInvalidSchema, ref)
+	}
+
+	var current any = v.root
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := asObject(current)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot resolve $ref %s", ErrInvalidSchema, ref)
+		}
+		if current, ok = object[token]; !ok {
+			return nil, fmt.Errorf("%w: cannot resolve $ref %s", ErrInvalidSchema, ref)
+		}
+	}
+	return current, nil
+}
+
+// patterns caches the compiled patterns of schemas
+var patterns sync.Map
+
+// compilePattern compiles a pattern once. Go's regular expressions cover the ECMA-262 patterns
+// commonly used in schemas, without lookarounds and backreferences.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: pattern %s: %v", ErrInvalidSchema, pattern, err)
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+// invalid returns a validation error for the value at path
+func invalid(path string, reason string) error {
+	if path == "" {
+		return &ValidationError{Message: "invalid value: " + reason}
+	}
+	return &ValidationError{Path: path, Message: fmt.Sprintf("invalid value for field %s: %s", path, reason)}
+}
+
+// join appends a field name to a path
+func join(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// hasType reports whether a decoded JSON value has the JSON schema type
+func hasType(value any, t string) bool {
+	switch t {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number) && !math.IsInf(number, 0)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return true
+	}
+}
+
+// equal compares decoded JSON values, numbers by value whatever their Go type
+func equal(a any, b any) bool {
+	if x, ok := asNumber(a); ok {
+		y, ok := asNumber(b)
+		return ok && x == y
+	}
+	if x, ok := asObject(a); ok {
+		y, ok := asObject(b)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	if x := asList(a); x != nil {
+		y := asList(b)
+		if y == nil || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// asObject returns a schema or value as a map, whatever its map type (e.g. handoff.JSONSchema)
+func asObject(value any) (map[string]any, bool) {
+	if object, ok := value.(map[string]any); ok {
+		return object, true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	object := make(map[string]any, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		object[iter.Key().String()] = iter.Value().Interface()
+	}
+	return object, true
+}
+
+// asList returns a list of a schema, such as a []string or a []any, as a []any
+func asList(value any) []any {
+	if list, ok := value.([]any); ok {
+		return list
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil
+	}
+	list := make([]any, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+	return list
+}
+
+// asStrings returns a string or a list of strings of a schema as a []string
+func asStrings(value any) []string {
+	if s, ok := value.(string); ok {
+		return []string{s}
+	}
+	var strs []string
+	for _, item := range asList(value) {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// asNumber returns a number of a schema or a value, whatever its Go type
+func asNumber(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orderSchema is a schema with nested objects, arrays and references, as decoded from JSON
+const orderSchema = `{
+	"type": "object",
+	"required": ["id", "customer", "items"],
+	"additionalProperties": false,
+	"properties": {
+		"id": {"type": "string", "pattern": "^ord_[0-9]+$"},
+		"status": {"enum": ["pending", "shipped"]},
+		"customer": {"$ref": "#/$defs/customer"},
+		"items": {
+			"type": "array",
+			"minItems": 1,
+			"items": {
+				"type": "object",
+				"required": ["sku", "quantity"],
+				"properties": {
+					"sku": {"type": "string", "minLength": 3},
+					"quantity": {"type": "integer", "minimum": 1, "maximum": 10}
+				}
+			}
+		},
+		"discount": {"type": ["number", "null"], "exclusiveMinimum": 0, "exclusiveMaximum": 1}
+	},
+	"$defs": {
+		"customer": {
+			"type": "object",
+			"required": ["email"],
+			"properties": {
+				"email": {"type": "string", "pattern": "^[^@]+@[^@]+$"},
+				"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true}
+			}
+		}
+	}
+}`
+
+func TestValidateJSON(t *testing.T) {
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal([]byte(orderSchema), &schema))
+
+	valid := `{"id":"ord_42","status":"pending","customer":{"email":"a@example.com","tags":["vip"]},
+		"items":[{"sku":"abc","quantity":2}],"discount":null}`
+	value, err := ValidateJSON(schema, valid)
+	require.NoError(t, err)
+	assert.Equal(t, "ord_42", value.(map[string]any)["id"])
+
+	tests := []struct {
+		name string
+		data string
+		err  string
+		path string
+	}{
+		{"missing field", `{"id":"ord_1","items":[]}`, "missing required field: customer", "customer"},
+		{"pattern", `{"id":"42","customer":{"email":"a@b"},"items":[{"sku":"abc","quantity":1}]}`,
+			"invalid value for field id: must match the pattern ^ord_[0-9]+$", "id"},
+		{"enum", `{"id":"ord_1","status":"lost","customer":{"email":"a@b"},"items":[{"sku":"abc","quantity":1}]}`,
+			"invalid value for field status: must be one of [pending shipped]", "status"},
+		{"reference", `{"id":"ord_1","customer":{},"items":[{"sku":"abc","quantity":1}]}`,
+			"missing required field: customer.email", "customer.email"},
+		{"unique items", `{"id":"ord_1","customer":{"email":"a@b","tags":["x","x"]},"items":[{"sku":"abc","quantity":1}]}`,
+			"invalid value for field customer.tags: items 0 and 1 must be unique", "customer.tags"},
+		{"min items", `{"id":"ord_1","customer":{"email":"a@b"},"items":[]}`,
+			"invalid value for field items: must have at least 1 items", "items"},
+		{"nested array item", `{"id":"ord_1","customer":{"email":"a@b"},"items":[{"sku":"abc","quantity":1},{"sku":"ab","quantity":1}]}`,
+			"invalid value for field items[1].sku: must be at least 3 characters long", "items[1].sku"},
+		{"maximum", `{"id":"ord_1","customer":{"email":"a@b"},"items":[{"sku":"abc","quantity":11}]}`,
+			"invalid value for field items[0].quantity: must be at most 10", "items[0].quantity"},
+		{"integer", `{"id":"ord_1","customer":{"email":"a@b"},"items":[{"sku":"abc","quantity":1.5}]}`,
+			"invalid type for field items[0].quantity: expected integer", "items[0].quantity"},
+		{"exclusive range", `{"id":"ord_1","customer":{"email":"a@b"},"items":[{"sku":"abc","quantity":1}],"discount":1}`,
+			"invalid value for field discount: must be less than 1", "discount"},
+		{"type list", `{"id":"ord_1","customer":{"email":"a@b"},"items":[{"sku":"abc","quantity":1}],"discount":"10%"}`,
+			"invalid type for field discount: expected number or null", "discount"},
+		{"additional properties", `{"id":"ord_1","customer":{"email":"a@b"},"items":[{"sku":"abc","quantity":1}],"note":"x"}`,
+			"unexpected field: note", "note"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateJSON(schema, tt.data)
+			require.EqualError(t, err, tt.err)
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.path, validationErr.Path)
+		})
+	}
+
+	_, err = ValidateJSON(schema, `{"id":`)
+	assert.ErrorContains(t, err, "failed to parse JSON")
+}
+
+func TestValidateGoSchema(t *testing.T) {
+	// Schemas written in Go use []string lists, int bounds and named map types
+	type schemaMap map[string]any
+	schema := schemaMap{
+		"type":     "object",
+		"required": []string{"city", "days"},
+		"properties": map[string]any{
+			"city":  schemaMap{"type": "string"},
+			"days":  map[string]any{"type": "integer", "minimum": 1, "maximum": 7, "multipleOf": 1},
+			"units": map[string]any{"type": "string", "enum": []string{"metric", "imperial"}},
+		},
+	}
+
+	assert.NoError(t, Validate(schema, map[string]any{"city": "Tokyo", "days": float64(3), "units": "metric"}))
+	assert.EqualError(t, Validate(schema, map[string]any{"city": "Tokyo"}), "missing required field: days")
+	assert.EqualError(t, Validate(schema, map[string]any{"city": "Tokyo", "days": float64(0)}),
+		"invalid value for field days: must be at least 1")
+	assert.EqualError(t, Validate(schema, map[string]any{"city": "Tokyo", "days": float64(2), "units": "kelvin"}),
+		"invalid value for field units: must be one of [metric imperial]")
+	assert.EqualError(t, Validate(schema, map[string]any{"city": float64(1), "days": float64(2)}),
+		"invalid type for field city: expected string")
+	assert.EqualError(t, Validate(schema, "Tokyo"), "invalid type: expected object")
+}
+
+func TestValidateCombinators(t *testing.T) {
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"oneOf": [
+			{"type": "string", "maxLength": 5},
+			{"type": "integer", "multipleOf": 5}
+		],
+		"not": {"const": "stop"}
+	}`), &schema))
+
+	assert.NoError(t, Validate(schema, "go"))
+	assert.NoError(t, Validate(schema, float64(15)))
+	assert.EqualError(t, Validate(schema, float64(7)), "invalid value: must match exactly one of the schemas, matches 0")
+	assert.EqualError(t, Validate(schema, "stop"), "invalid value: must not match the schema of not")
+
+	anyOf := map[string]any{"anyOf": []any{
+		map[string]any{"type": "boolean"},
+		map[string]any{"type": "array", "prefixItems": []any{map[string]any{"type": "string"}}, "maxItems": 2},
+	}}
+	assert.NoError(t, Validate(anyOf, true))
+	assert.NoError(t, Validate(anyOf, []any{"a", float64(1)}))
+	assert.ErrorContains(t, Validate(anyOf, []any{float64(1)}), "must match one of the allowed schemas")
+}
+
+func TestValidateRecursiveReference(t *testing.T) {
+	schema := map[string]any{
+		"type":     "object",
+		"required": []string{"name"},
+		"properties": map[string]any{
+			"name":     map[string]any{"type": "string"},
+			"children": map[string]any{"type": "array", "items": map[string]any{"$ref": "#"}},
+		},
+	}
+
+	tree := map[string]any{"name": "root", "children": []any{
+		map[string]any{"name": "child", "children": []any{map[string]any{}}},
+	}}
+	assert.EqualError(t, Validate(schema, tree), "missing required field: children[0].children[0].name")
+}
+
+func TestValidateInvalidSchema(t *testing.T) {
+	err := Validate(map[string]any{"$ref": "#/$defs/missing"}, "x")
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+
+	err = Validate(map[string]any{"pattern": "("}, "x")
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+
+	err = Validate(map[string]any{"$ref": "https://example.com/schema.json"}, "x")
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+// CloseProvider releases the resources held by a provider, such as idle HTTP connections.
+// It looks through middleware and closes every provider of a MultiProvider. Providers that do
+// not implement io.Closer hold nothing to release and are skipped.
+func CloseProvider(provider Provider) error {
+	for {
+		switch p := provider.(type) {
+		case nil:
+			return nil
+		case io.Closer:
+			return p.Close()
+		case *wrappedProvider:
+			provider = p.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// Close closes the registered providers and the fallback provider
+func (m *MultiProvider) Close() error {
+	m.mu.RLock()
+	providers := make([]Provider, 0, len(m.providers)+1)
+	for _, p := range m.providers {
+		providers = append(providers, p)
+	}
+	providers = append(providers, m.fallback)
+	m.mu.RUnlock()
+
+	var errs []error
+	for _, p := range providers {
+		if err := CloseProvider(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the idle connections of the provider's HTTP client
+func (p *OpenAIProvider) Close() error {
+	client := p.config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	client.CloseIdleConnections()
+	return nil
+}
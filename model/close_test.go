@@ -0,0 +1,48 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// closingProvider is a provider counting its Close calls
+type closingProvider struct {
+	ProviderFuncs
+	closed int
+	err    error
+}
+
+func (p *closingProvider) Close() error {
+	p.closed++
+	return p.err
+}
+
+func TestCloseProvider(t *testing.T) {
+	assert.NoError(t, CloseProvider(nil))
+	assert.NoError(t, CloseProvider(ProviderFuncs{}))
+
+	// Middleware is looked through
+	wrapped := &closingProvider{}
+	require.NoError(t, CloseProvider(WithMiddleware(wrapped, CompletionMiddleware(nil))))
+	assert.Equal(t, 1, wrapped.closed)
+
+	// Every provider of a MultiProvider is closed, and the errors are joined
+	fallback := &closingProvider{err: errors.New("fallback failed")}
+	groq := &closingProvider{err: errors.New("groq failed")}
+	openai, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key"})
+	require.NoError(t, err)
+	multi := NewMultiProvider(fallback).Register("groq", groq).Register("openai", openai)
+
+	err = CloseProvider(multi)
+	assert.ErrorContains(t, err, "fallback failed")
+	assert.ErrorContains(t, err, "groq failed")
+	assert.Equal(t, 1, fallback.closed)
+	assert.Equal(t, 1, groq.closed)
+}
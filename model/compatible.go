@@ -0,0 +1,53 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"errors"
+	"net/http"
+)
+
+// OpenAICompatibleOptions configures a provider for a third-party OpenAI-compatible API
+type OpenAICompatibleOptions struct {
+	// DefaultModel is the model used when the settings don't name one (optional)
+	DefaultModel string
+
+	// Organization is sent as the OpenAI-Organization header (optional)
+	Organization string
+
+	// HTTPClient is the HTTP client used for API calls (optional)
+	HTTPClient *http.Client
+
+	// UserAgent overrides the User-Agent header sent with API calls (optional)
+	UserAgent string
+
+	// RateLimiter makes API calls wait for rate limit capacity (optional)
+	RateLimiter *RateLimiter
+}
+
+// NewOpenAICompatibleProvider creates a provider for any server that implements the
+// OpenAI Chat Completions API, such as Groq, Together, OpenRouter, vLLM, Ollama or a LiteLLM proxy.
+// Unlike NewOpenAIProvider, an empty apiKey is sent as is rather than read from OPENAI_API_KEY,
+// so the OpenAI key is never sent to another host; local servers usually accept any key.
+func NewOpenAICompatibleProvider(baseURL, apiKey string, opts OpenAICompatibleOptions) (*OpenAIProvider, error) {
+	if baseURL == "" {
+		return nil, errors.New("base URL is required")
+	}
+
+	config := OpenAIConfig{
+		APIKey:       apiKey,
+		BaseURL:      baseURL,
+		Organization: opts.Organization,
+		HTTPClient:   opts.HTTPClient,
+		UserAgent:    opts.UserAgent,
+		DefaultModel: opts.DefaultModel,
+		RateLimiter:  opts.RateLimiter,
+	}
+
+	return &OpenAIProvider{
+		config: config,
+		client: newOpenAIClient(config),
+	}, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// ContentPartType is the type of a message content part
+type ContentPartType string
+
+const (
+	// ContentPartText is a text part
+	ContentPartText ContentPartType = "text"
+
+	// ContentPartImageURL is an image given by URL or data URL
+	ContentPartImageURL ContentPartType = "image_url"
+
+	// ContentPartFile is a file such as a PDF, given inline or by uploaded file ID
+	ContentPartFile ContentPartType = "file"
+
+	// ContentPartInputAudio is base64-encoded audio
+	ContentPartInputAudio ContentPartType = "input_audio"
+)
+
+// ContentPart is one part of a multimodal message, in the Chat Completions wire format
+type ContentPart struct {
+	// Type is the type of the part
+	Type ContentPartType `json:"type"`
+
+	// Text is the text of a ContentPartText part
+	Text string `json:"text,omitempty"`
+
+	// ImageURL is the image of a ContentPartImageURL part
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+
+	// File is the file of a ContentPartFile part
+	File *InputFile `json:"file,omitempty"`
+
+	// InputAudio is the audio of a ContentPartInputAudio part
+	InputAudio *InputAudio `json:"input_audio,omitempty"`
+}
+
+// ImageURL is an image referenced by URL
+type ImageURL struct {
+	// URL is an http(s) URL or a base64 data URL
+	URL string `json:"url"`
+
+	// Detail is the image detail level: "auto", "low" or "high" (optional)
+	Detail string `json:"detail,omitempty"`
+}
+
+// InputFile is a file sent with a message
+type InputFile struct {
+	// FileID is the ID of an uploaded file
+	FileID string `json:"file_id,omitempty"`
+
+	// FileData is the file content as a base64 data URL
+	FileData string `json:"file_data,omitempty"`
+
+	// FileName is the name of the file
+	FileName string `json:"filename,omitempty"`
+}
+
+// InputAudio is audio sent with a message
+type InputAudio struct {
+	// Data is the base64-encoded audio
+	Data string `json:"data"`
+
+	// Format is the audio format, such as "wav" or "mp3"
+	Format string `json:"format"`
+}
+
+// NewTextPart creates a text content part
+func NewTextPart(text string) ContentPart {
+	return ContentPart{Type: ContentPartText, Text: text}
+}
+
+// NewImagePart creates an image content part from an http(s) or data URL
+func NewImagePart(url string, detail string) ContentPart {
+	return ContentPart{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: url, Detail: detail}}
+}
+
+// NewImageDataPart creates an image content part from raw image bytes (e.g. mimeType "image/png")
+func NewImageDataPart(data []byte, mimeType string, detail string) ContentPart {
+	return NewImagePart(dataURL(data, mimeType), detail)
+}
+
+// NewFilePart creates a file content part from raw file bytes (e.g. mimeType "application/pdf")
+func NewFilePart(fileName string, data []byte, mimeType string) ContentPart {
+	return ContentPart{Type: ContentPartFile, File: &InputFile{FileData: dataURL(data, mimeType), FileName: fileName}}
+}
+
+// NewFileIDPart creates a file content part from the ID of an uploaded file
+func NewFileIDPart(fileID string) ContentPart {
+	return ContentPart{Type: ContentPartFile, File: &InputFile{FileID: fileID}}
+}
+
+// NewAudioPart creates an audio content part from raw audio bytes in the given format (e.g. "wav")
+func NewAudioPart(data []byte, format string) ContentPart {
+	return ContentPart{Type: ContentPartInputAudio, InputAudio: &InputAudio{Data: base64.StdEncoding.EncodeToString(data), Format: format}}
+}
+
+// dataURL encodes data as a base64 data URL
+func dataURL(data []byte, mimeType string) string {
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data))
+}
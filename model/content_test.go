@@ -0,0 +1,49 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultimodalMessages(t *testing.T) {
+	server, _, lastBody := newTestServer(t, defaultChatResponse())
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	messages := []Message{{
+		Role:         "user",
+		Content:      "What is in this image?",
+		ContentParts: []ContentPart{NewImageDataPart([]byte("png"), "image/png", "low")},
+	}}
+	_, err = provider.CreateChatCompletion(context.Background(), messages, DefaultSettings())
+	require.NoError(t, err)
+
+	content := (*lastBody)["messages"].([]any)[0].(map[string]any)["content"]
+	assert.Equal(t, []any{
+		map[string]any{"type": "text", "text": "What is in this image?"},
+		map[string]any{"type": "image_url", "image_url": map[string]any{"url": "data:image/png;base64,cG5n", "detail": "low"}},
+	}, content)
+
+	// Files and audio are not supported by the API client and are sent by editing the request body
+	messages[0].ContentParts = []ContentPart{
+		NewFilePart("report.pdf", []byte("pdf"), "application/pdf"),
+		NewAudioPart([]byte("wav"), "wav"),
+	}
+	_, err = provider.CreateChatCompletion(context.Background(), messages, DefaultSettings())
+	require.NoError(t, err)
+
+	content = (*lastBody)["messages"].([]any)[0].(map[string]any)["content"]
+	assert.Equal(t, []any{
+		map[string]any{"type": "text", "text": "What is in this image?"},
+		map[string]any{"type": "file", "file": map[string]any{"file_data": "data:application/pdf;base64,cGRm", "filename": "report.pdf"}},
+		map[string]any{"type": "input_audio", "input_audio": map[string]any{"data": "d2F2", "format": "wav"}},
+	}, content)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// ErrContextWindowExceeded is wrapped by the errors of model calls whose prompt does not fit in
+// the model's context window
+var ErrContextWindowExceeded = errors.New("context window exceeded")
+
+// contextWindowMessages are fragments of the error messages providers return when the prompt is
+// longer than the context window
+var contextWindowMessages = []string{
+	"context_length_exceeded",
+	"maximum context length",
+	"context window",
+	"context length",
+	"prompt is too long",
+	"input is too long",
+	"too many tokens",
+}
+
+// IsContextWindowExceeded reports whether a provider error means that the prompt does not fit in
+// the model's context window. It recognizes ErrContextWindowExceeded, the context_length_exceeded
+// code of OpenAI-compatible APIs and the error messages of common providers.
+func IsContextWindowExceeded(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrContextWindowExceeded) {
+		return true
+	}
+
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		if code, ok := apiErr.Code.(string); ok && code == "context_length_exceeded" {
+			return true
+		}
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range contextWindowMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsContextWindowExceeded(t *testing.T) {
+	assert.True(t, IsContextWindowExceeded(fmt.Errorf("call failed: %w", ErrContextWindowExceeded)))
+	assert.True(t, IsContextWindowExceeded(errors.New("prompt is too long: 210000 tokens > 200000 maximum")))
+	assert.False(t, IsContextWindowExceeded(errors.New("rate limit exceeded")))
+	assert.False(t, IsContextWindowExceeded(nil))
+}
+
+func TestOpenAIProviderContextWindowExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": {"message": "Input exceeds the limit.", "type": "invalid_request_error", "code": "context_length_exceeded"}}`)
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, DefaultSettings())
+	assert.ErrorIs(t, err, ErrContextWindowExceeded)
+}
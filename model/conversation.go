@@ -0,0 +1,51 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import "errors"
+
+// Conversation state settings, set by the runner:
+//   - Settings.PreviousResponseID continues from a response stored by the provider
+//   - Settings.ConversationID appends to a conversation stored by the provider
+//
+// Only providers implementing ServerStateProvider act on them; the Chat Completions
+// provider keeps no state, so the runner refuses to chain runs with it.
+
+// ErrServerStateUnsupported is returned when a run continues a stored response or conversation
+// with a provider that does not keep conversation state
+var ErrServerStateUnsupported = errors.New("provider does not keep conversation state")
+
+// ServerStateProvider is implemented by providers that keep conversation state on the server,
+// such as the OpenAI Responses API. When a run chains responses, the runner sends such a
+// provider the system messages and only the messages added since its previous response.
+type ServerStateProvider interface {
+	Provider
+
+	// SupportsServerState reports whether previous_response_id and conversation_id are honored
+	SupportsServerState() bool
+}
+
+// SupportsServerState reports whether the provider that serves modelName keeps
+// conversation state on the server. Middlewares are looked through and a MultiProvider is resolved.
+func SupportsServerState(provider Provider, modelName string) bool {
+	for {
+		if stateful, ok := provider.(ServerStateProvider); ok {
+			return stateful.SupportsServerState()
+		}
+
+		switch p := provider.(type) {
+		case *wrappedProvider:
+			provider = p.Unwrap()
+		case *MultiProvider:
+			resolved, name, err := p.Resolve(modelName)
+			if err != nil {
+				return false
+			}
+			provider, modelName = resolved, name
+		default:
+			return false
+		}
+	}
+}
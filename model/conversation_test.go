@@ -0,0 +1,34 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type statefulProvider struct {
+	Provider
+}
+
+func (p *statefulProvider) SupportsServerState() bool {
+	return true
+}
+
+func TestSupportsServerState(t *testing.T) {
+	openaiProvider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key"})
+	require.NoError(t, err)
+	assert.False(t, SupportsServerState(openaiProvider, "gpt-4o"))
+
+	stateful := &statefulProvider{}
+	assert.True(t, SupportsServerState(stateful, "gpt-4o"))
+
+	multi := NewMultiProvider(openaiProvider).Register("responses", stateful)
+	assert.True(t, SupportsServerState(multi, "responses/gpt-4o"))
+	assert.False(t, SupportsServerState(multi, "gpt-4o"))
+	assert.False(t, SupportsServerState(NewMultiProvider(nil), "gpt-4o"))
+}
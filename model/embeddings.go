@@ -0,0 +1,104 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// DefaultEmbeddingModel is the embedding model used when the settings don't name one
+const DefaultEmbeddingModel = "text-embedding-3-small"
+
+// ErrEmbeddingsUnsupported is returned when the provider cannot create embeddings
+var ErrEmbeddingsUnsupported = errors.New("provider does not support embeddings")
+
+// EmbeddingSettings configures an embeddings request
+type EmbeddingSettings struct {
+	// Model is the embedding model (optional, defaults to DefaultEmbeddingModel)
+	Model string
+
+	// Dimensions shortens the embeddings to the number of dimensions, if the model supports it (optional)
+	Dimensions int
+
+	// User identifies the end user to the provider (optional)
+	User string
+}
+
+// EmbeddingsResponse holds the embeddings of the texts, in the order of the texts
+type EmbeddingsResponse struct {
+	Embeddings [][]float32
+	Usage      Usage
+}
+
+// Embeddings is an optional interface of providers that can turn texts into embeddings, so
+// retrieval tools, semantic routers and similarity guardrails can use the run's provider
+type Embeddings interface {
+	CreateEmbeddings(ctx context.Context, texts []string, settings EmbeddingSettings) (*EmbeddingsResponse, error)
+}
+
+// CreateEmbeddings creates embeddings with the provider that serves settings.Model.
+// Middlewares are looked through and a MultiProvider is resolved; ErrEmbeddingsUnsupported is
+// returned when the provider does not implement Embeddings.
+func CreateEmbeddings(ctx context.Context, provider Provider, texts []string, settings EmbeddingSettings) (*EmbeddingsResponse, error) {
+	for {
+		if embeddings, ok := provider.(Embeddings); ok {
+			return embeddings.CreateEmbeddings(ctx, texts, settings)
+		}
+
+		switch p := provider.(type) {
+		case *wrappedProvider:
+			provider = p.Unwrap()
+		case *MultiProvider:
+			resolved, name, err := p.Resolve(settings.Model)
+			if err != nil {
+				return nil, err
+			}
+			provider, settings.Model = resolved, name
+		default:
+			return nil, fmt.Errorf("%w: %T", ErrEmbeddingsUnsupported, provider)
+		}
+	}
+}
+
+// CreateEmbeddings calls the Embeddings API
+func (p *OpenAIProvider) CreateEmbeddings(ctx context.Context, texts []string, settings EmbeddingSettings) (*EmbeddingsResponse, error) {
+	if len(texts) == 0 {
+		return &EmbeddingsResponse{}, nil
+	}
+
+	modelName := settings.Model
+	if modelName == "" {
+		modelName = DefaultEmbeddingModel
+	}
+
+	result, err := p.client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Input:      texts,
+		Model:      openai.EmbeddingModel(modelName),
+		User:       settings.User,
+		Dimensions: settings.Dimensions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI API call failed: %w", err)
+	}
+	if len(result.Data) != len(texts) {
+		return nil, fmt.Errorf("OpenAI returned %d embeddings for %d texts", len(result.Data), len(texts))
+	}
+
+	response := &EmbeddingsResponse{
+		Embeddings: make([][]float32, len(texts)),
+		Usage:      convertAPIUsage(result.Usage),
+	}
+	for _, data := range result.Data {
+		if data.Index < 0 || data.Index >= len(texts) {
+			return nil, fmt.Errorf("OpenAI returned an embedding for unknown input %d", data.Index)
+		}
+		response.Embeddings[data.Index] = data.Embedding
+	}
+	return response, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIProviderCreateEmbeddings(t *testing.T) {
+	server, lastRequest, lastBody := newTestServer(t, map[string]any{
+		"object": "list",
+		"model":  "text-embedding-3-small",
+		"data": []any{
+			map[string]any{"object": "embedding", "index": 1, "embedding": []float32{0, 1}},
+			map[string]any{"object": "embedding", "index": 0, "embedding": []float32{1, 0}},
+		},
+		"usage": map[string]any{"prompt_tokens": 4, "total_tokens": 4},
+	})
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	// Embeddings are found through middlewares and multi providers
+	passthrough := func(next Provider) Provider { return ProviderFuncs{Next: next} }
+	wrapped := WithMiddleware(NewMultiProvider(nil).Register("openai", provider), passthrough)
+	result, err := CreateEmbeddings(context.Background(), wrapped, []string{"refund", "shipping"}, EmbeddingSettings{
+		Model:      "openai/text-embedding-3-large",
+		Dimensions: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}, {0, 1}}, result.Embeddings)
+	assert.Equal(t, 4, result.Usage.PromptTokens)
+
+	assert.Equal(t, "/embeddings", lastRequest.URL.Path)
+	assert.Equal(t, "text-embedding-3-large", (*lastBody)["model"])
+	assert.Equal(t, []any{"refund", "shipping"}, (*lastBody)["input"])
+	assert.Equal(t, float64(2), (*lastBody)["dimensions"])
+
+	// The default model is used when the settings don't name one
+	_, err = provider.CreateEmbeddings(context.Background(), []string{"a", "b"}, EmbeddingSettings{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultEmbeddingModel, (*lastBody)["model"])
+}
+
+func TestCreateEmbeddingsUnsupported(t *testing.T) {
+	_, err := CreateEmbeddings(context.Background(), ProviderFuncs{}, []string{"a"}, EmbeddingSettings{})
+	assert.ErrorIs(t, err, ErrEmbeddingsUnsupported)
+
+	_, err = CreateEmbeddings(context.Background(), NewMultiProvider(nil), []string{"a"}, EmbeddingSettings{Model: "x/y"})
+	assert.ErrorIs(t, err, ErrUnknownModelPrefix)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import "strings"
+
+// InstructionPlacement is how the system messages holding the agent's instructions are sent
+type InstructionPlacement string
+
+const (
+	// InstructionsSystem sends the instructions as system messages (the default)
+	InstructionsSystem InstructionPlacement = "system"
+
+	// InstructionsDeveloper sends the instructions as developer messages, the role o-series
+	// models expect
+	InstructionsDeveloper InstructionPlacement = "developer"
+
+	// InstructionsUserMessage prepends the instructions to the first user message, for
+	// providers and models without a system role
+	InstructionsUserMessage InstructionPlacement = "user_message"
+)
+
+// PlaceInstructions rewrites the system messages of a request according to placement.
+// Providers call it before converting the messages to their API format; messages is not modified.
+func PlaceInstructions(messages []Message, placement InstructionPlacement) []Message {
+	switch placement {
+	case InstructionsDeveloper:
+		placed := make([]Message, len(messages))
+		for i, message := range messages {
+			if message.Role == "system" {
+				message.Role = "developer"
+			}
+			placed[i] = message
+		}
+		return placed
+
+	case InstructionsUserMessage:
+		var instructions []string
+		placed := make([]Message, 0, len(messages))
+		insertAt := -1
+		for _, message := range messages {
+			if message.Role != "system" {
+				placed = append(placed, message)
+				continue
+			}
+			if insertAt < 0 {
+				insertAt = len(placed)
+			}
+			if message.Content != "" {
+				instructions = append(instructions, message.Content)
+			}
+		}
+		if len(instructions) == 0 {
+			return placed
+		}
+
+		text := strings.Join(instructions, "\n\n")
+		for i := insertAt; i < len(placed); i++ {
+			if placed[i].Role == "user" {
+				if placed[i].Content != "" {
+					text += "\n\n" + placed[i].Content
+				}
+				placed[i].Content = text
+				return placed
+			}
+		}
+		// Without a user message after the instructions, they become one
+		placed = append(placed[:insertAt], append([]Message{{Role: "user", Content: text}}, placed[insertAt:]...)...)
+		return placed
+	}
+	return messages
+}
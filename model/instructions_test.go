@@ -0,0 +1,45 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceInstructions(t *testing.T) {
+	messages := []Message{
+		{Role: "system", Content: "Be brief"},
+		{Role: "user", Content: "hi"},
+		{Role: "assistant", Content: "hello"},
+		{Role: "user", Content: "bye"},
+	}
+
+	assert.Equal(t, messages, PlaceInstructions(messages, ""))
+	assert.Equal(t, messages, PlaceInstructions(messages, InstructionsSystem))
+
+	developer := PlaceInstructions(messages, InstructionsDeveloper)
+	assert.Equal(t, "developer", developer[0].Role)
+	assert.Equal(t, messages[1:], developer[1:])
+	assert.Equal(t, "system", messages[0].Role)
+
+	assert.Equal(t, []Message{
+		{Role: "user", Content: "Be brief\n\nhi"},
+		{Role: "assistant", Content: "hello"},
+		{Role: "user", Content: "bye"},
+	}, PlaceInstructions(messages, InstructionsUserMessage))
+	assert.Equal(t, "hi", messages[1].Content)
+
+	// Instructions without a user message after them become one
+	assert.Equal(t, []Message{
+		{Role: "user", Content: "Be brief\n\nSummarize"},
+		{Role: "assistant", Content: "hello"},
+	}, PlaceInstructions([]Message{
+		{Role: "system", Content: "Be brief"},
+		{Role: "system", Content: "Summarize"},
+		{Role: "assistant", Content: "hello"},
+	}, InstructionsUserMessage))
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedSchemaType is returned when a Go type cannot be expressed as a strict JSON schema
+var ErrUnsupportedSchemaType = errors.New("type is not supported by strict JSON schemas")
+
+// ResponseSchema is a JSON schema the model's response must follow,
+// sent as the json_schema response format
+type ResponseSchema struct {
+	// Name identifies the schema (letters, digits, underscores and dashes)
+	Name string
+
+	// Description explains the response to the model (optional)
+	Description string
+
+	// Schema is the JSON schema
+	Schema map[string]any
+
+	// Strict makes the provider enforce the schema exactly; the schema must then satisfy the
+	// strict mode rules, as the schemas generated by StrictJSONSchema do
+	Strict bool
+}
+
+// MarshalJSON encodes the schema itself, so it can be sent as the json_schema of a request
+func (s *ResponseSchema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Schema)
+}
+
+// StrictJSONSchema generates a JSON schema for values of t that satisfies the rules of OpenAI's
+// strict structured outputs: t must be a struct (or a pointer to one), every field is required,
+// additional properties are not allowed, and pointer fields are nullable.
+// Field names follow the json tags, and a description tag becomes the field's description.
+// Maps, interfaces, channels and functions are not supported.
+func StrictJSONSchema(t reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return nil, fmt.Errorf("%w: the root must be a struct, not %s", ErrUnsupportedSchemaType, t)
+	}
+
+	return strictTypeSchema(t, map[reflect.Type]bool{})
+}
+
+// strictTypeSchema generates the schema of a type; visiting guards against recursive types
+func strictTypeSchema(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema, err := strictTypeSchema(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes byte slices as base64 strings
+			return map[string]any{"type": "string"}, nil
+		}
+		items, err := strictTypeSchema(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Struct:
+		if visiting[t] {
+			return nil, fmt.Errorf("%w: recursive type %s", ErrUnsupportedSchemaType, t)
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		properties := map[string]any{}
+		required := []string{}
+		if err := addStrictFields(t, properties, &required, visiting); err != nil {
+			return nil, err
+		}
+
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSchemaType, t)
+	}
+}
+
+// addStrictFields adds the schemas of the struct's fields; embedded structs are flattened like encoding/json does
+func addStrictFields(t reflect.Type, properties map[string]any, required *[]string, visiting map[reflect.Type]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := addStrictFields(field.Type, properties, required, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema, err := strictTypeSchema(field.Type, visiting)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if description := field.Tag.Get("description"); description != "" {
+			schema["description"] = description
+		}
+
+		properties[name] = schema
+		*required = append(*required, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaBase struct {
+	ID int `json:"id"`
+}
+
+type schemaReport struct {
+	schemaBase
+	Title    string    `json:"title" description:"The report title"`
+	Score    *float64  `json:"score"`
+	Tags     []string  `json:"tags"`
+	Created  time.Time `json:"created"`
+	Internal string    `json:"-"`
+	hidden   string
+}
+
+type schemaNode struct {
+	Children []schemaNode `json:"children"`
+}
+
+func TestStrictJSONSchema(t *testing.T) {
+	schema, err := StrictJSONSchema(reflect.TypeOf(&schemaReport{}))
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer"},
+			"title": {"type": "string", "description": "The report title"},
+			"score": {"anyOf": [{"type": "number"}, {"type": "null"}]},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"created": {"type": "string", "format": "date-time"}
+		},
+		"required": ["id", "title", "score", "tags", "created"],
+		"additionalProperties": false
+	}`, string(encoded))
+}
+
+func TestStrictJSONSchemaUnsupported(t *testing.T) {
+	_, err := StrictJSONSchema(reflect.TypeOf(""))
+	assert.ErrorIs(t, err, ErrUnsupportedSchemaType)
+
+	_, err = StrictJSONSchema(reflect.TypeOf(struct {
+		Values map[string]int `json:"values"`
+	}{}))
+	assert.ErrorIs(t, err, ErrUnsupportedSchemaType)
+
+	_, err = StrictJSONSchema(reflect.TypeOf(schemaNode{}))
+	assert.ErrorIs(t, err, ErrUnsupportedSchemaType)
+}
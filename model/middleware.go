@@ -0,0 +1,79 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+)
+
+// ProviderMiddleware wraps a provider to add behavior such as caching, rate limiting,
+// request rewriting or logging around its calls
+type ProviderMiddleware func(next Provider) Provider
+
+// WithMiddleware wraps the provider with the middlewares. The first middleware is the outermost,
+// so it sees each request first and each response last.
+func WithMiddleware(provider Provider, middlewares ...ProviderMiddleware) Provider {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		provider = &wrappedProvider{
+			Provider: middlewares[i](provider),
+			next:     provider,
+		}
+	}
+	return provider
+}
+
+// ProviderFuncs implements Provider with functions, for writing middlewares without declaring a type.
+// A nil function delegates to Next.
+type ProviderFuncs struct {
+	// Next is the provider calls are delegated to
+	Next Provider
+
+	// Completion handles CreateChatCompletion (optional)
+	Completion func(ctx context.Context, messages []Message, settings Settings) (*Response, error)
+
+	// Stream handles CreateChatCompletionStream (optional)
+	Stream func(ctx context.Context, messages []Message, settings Settings) (Stream, error)
+}
+
+// CreateChatCompletion calls Completion, or Next if it is nil
+func (p ProviderFuncs) CreateChatCompletion(ctx context.Context, messages []Message, settings Settings) (*Response, error) {
+	if p.Completion != nil {
+		return p.Completion(ctx, messages, settings)
+	}
+	return p.Next.CreateChatCompletion(ctx, messages, settings)
+}
+
+// CreateChatCompletionStream calls Stream, or Next if it is nil
+func (p ProviderFuncs) CreateChatCompletionStream(ctx context.Context, messages []Message, settings Settings) (Stream, error) {
+	if p.Stream != nil {
+		return p.Stream(ctx, messages, settings)
+	}
+	return p.Next.CreateChatCompletionStream(ctx, messages, settings)
+}
+
+// CompletionMiddleware creates a middleware that intercepts CreateChatCompletion calls.
+// fn receives the next provider to call; streaming calls pass through unchanged.
+func CompletionMiddleware(fn func(ctx context.Context, messages []Message, settings Settings, next Provider) (*Response, error)) ProviderMiddleware {
+	return func(next Provider) Provider {
+		return ProviderFuncs{
+			Next: next,
+			Completion: func(ctx context.Context, messages []Message, settings Settings) (*Response, error) {
+				return fn(ctx, messages, settings, next)
+			},
+		}
+	}
+}
+
+// wrappedProvider is a provider returned by a middleware. It keeps the wrapped provider so
+// optional interfaces such as ServerStateProvider can still be detected.
+type wrappedProvider struct {
+	Provider
+	next Provider
+}
+
+// Unwrap returns the provider the middleware wraps
+func (p *wrappedProvider) Unwrap() Provider {
+	return p.next
+}
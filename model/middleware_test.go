@@ -0,0 +1,61 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var calls []string
+	base := ProviderFuncs{
+		Completion: func(ctx context.Context, messages []Message, settings Settings) (*Response, error) {
+			calls = append(calls, "provider:"+messages[0].Content)
+			return &Response{Message: Message{Role: "assistant", Content: "hi"}}, nil
+		},
+		Stream: func(ctx context.Context, messages []Message, settings Settings) (Stream, error) {
+			return nil, errors.New("streaming")
+		},
+	}
+
+	record := func(name string) ProviderMiddleware {
+		return CompletionMiddleware(func(ctx context.Context, messages []Message, settings Settings, next Provider) (*Response, error) {
+			calls = append(calls, name)
+			return next.CreateChatCompletion(ctx, messages, settings)
+		})
+	}
+	rewrite := CompletionMiddleware(func(ctx context.Context, messages []Message, settings Settings, next Provider) (*Response, error) {
+		rewritten := append([]Message{}, messages...)
+		rewritten[0].Content = "rewritten"
+		return next.CreateChatCompletion(ctx, rewritten, settings)
+	})
+
+	provider := WithMiddleware(base, record("outer"), record("inner"), rewrite)
+	response, err := provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "original"}}, DefaultSettings())
+	require.NoError(t, err)
+	assert.Equal(t, "hi", response.Message.Content)
+	assert.Equal(t, []string{"outer", "inner", "provider:rewritten"}, calls)
+
+	// Streaming calls pass through completion middlewares
+	_, err = provider.CreateChatCompletionStream(context.Background(), nil, DefaultSettings())
+	assert.EqualError(t, err, "streaming")
+
+	unwrapped := &statefulProvider{}
+	assert.Same(t, unwrapped, WithMiddleware(unwrapped))
+}
+
+func TestMiddlewareKeepsServerState(t *testing.T) {
+	logging := CompletionMiddleware(func(ctx context.Context, messages []Message, settings Settings, next Provider) (*Response, error) {
+		return next.CreateChatCompletion(ctx, messages, settings)
+	})
+
+	assert.True(t, SupportsServerState(WithMiddleware(&statefulProvider{}, logging), "gpt-4o"))
+	assert.False(t, SupportsServerState(WithMiddleware(ProviderFuncs{}, logging), "gpt-4o"))
+}
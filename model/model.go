@@ -6,10 +6,44 @@ package model
 
 import (
 	"context"
+	"strings"
 )
 
+// ToolChoice controls whether and which tool the model calls: ToolChoiceAuto, ToolChoiceNone,
+// ToolChoiceRequired, or the name of a function tool to call (see ToolChoiceFunction)
+type ToolChoice string
+
+const (
+	// ToolChoiceAuto lets the model decide whether to call tools
+	ToolChoiceAuto ToolChoice = "auto"
+
+	// ToolChoiceNone prevents the model from calling tools
+	ToolChoiceNone ToolChoice = "none"
+
+	// ToolChoiceRequired requires the model to call at least one tool
+	ToolChoiceRequired ToolChoice = "required"
+)
+
+// ToolChoiceFunction requires the model to call the named function tool
+func ToolChoiceFunction(name string) ToolChoice {
+	return ToolChoice(name)
+}
+
+// FunctionName returns the name of the tool the choice requires, or "" for auto, none and required
+func (c ToolChoice) FunctionName() string {
+	switch c {
+	case "", ToolChoiceAuto, ToolChoiceNone, ToolChoiceRequired:
+		return ""
+	default:
+		return string(c)
+	}
+}
+
 // Settings represents model settings
 type Settings struct {
+	// Model is the name of the model (optional, defaults to the provider's default model)
+	Model string
+
 	// Temperature sets the generation temperature (0.0-2.0)
 	Temperature float64
 
@@ -25,56 +59,296 @@ type Settings struct {
 	// PresencePenalty sets the presence penalty (-2.0-2.0)
 	PresencePenalty float64
 
+	// ExplicitSampling marks Temperature, TopP, FrequencyPenalty and PresencePenalty as set even
+	// when they are 0, e.g. for deterministic sampling: Resolve applies them and providers send
+	// a 0 instead of leaving the field to the API default
+	ExplicitSampling bool
+
 	// StopSequences sets sequences that stop generation
 	StopSequences []string
 
+	// ResponseFormat sets the format of the response: "json_object", "json_schema" (with ResponseSchema)
+	// or "text" (optional)
 	ResponseFormat string
 
-	// Seed sets the generation seed
-	Seed int
+	// ResponseSchema is the JSON schema of the response when ResponseFormat is "json_schema"
+	ResponseSchema *ResponseSchema
+
+	// Seed makes sampling deterministic on a best-effort basis: repeated requests with the same
+	// seed and parameters should return the same result (optional). It is a pointer so that a
+	// seed of 0 can be told apart from unset; use Int to set it.
+	Seed *int
+
+	// LogitBias changes the likelihood of tokens, by token ID, from -100 (ban) to 100 (exclusive
+	// selection) (optional)
+	LogitBias map[string]int
+
+	// Logprobs asks for the log probabilities of the output tokens, returned in Response.Logprobs
+	Logprobs bool
+
+	// TopLogprobs is the number of most likely tokens, 0 to 20, returned with each output token
+	// (requires Logprobs)
+	TopLogprobs int
+
+	// N is the number of choices to generate (optional, defaults to 1). The runner continues
+	// with the first one; the others are in Response.Choices.
+	N int
+
+	// ParallelToolCalls allows (true) or prevents (false) the model from calling several tools
+	// in one response (optional, defaults to the provider's default). It is only sent with tools.
+	ParallelToolCalls *bool
+
+	// Tools are the tools offered to the model
+	Tools []ToolDefinition
+
+	// ToolChoice controls whether and which tool the model calls (optional, defaults to auto)
+	ToolChoice ToolChoice
+
+	// ReasoningEffort constrains the effort reasoning models (o-series, gpt-5) spend on reasoning:
+	// "minimal", "low", "medium" or "high" (optional)
+	ReasoningEffort string
+
+	// Verbosity constrains the length of the response: "low", "medium" or "high" (optional)
+	Verbosity string
+
+	// InstructionPlacement is how the system messages, such as the agent's instructions, are
+	// sent (optional, defaults to InstructionsSystem). See PlaceInstructions.
+	InstructionPlacement InstructionPlacement
+
+	// User identifies the end user on whose behalf the request is made, so the provider can
+	// attribute it for abuse monitoring (optional). Use a stable pseudonymous ID, not an email.
+	User string
 
-	// Tools sets tool definitions
-	Tools []map[string]any
+	// Metadata are key-value pairs stored with the request by providers that store requests (optional)
+	Metadata map[string]string
 
-	// Custom holds custom settings
+	// Store asks the provider to store the request and its response for later retrieval,
+	// evaluations or distillation (optional, defaults to the provider's default)
+	Store *bool
+
+	// PromptCacheKey routes requests sharing a long static prefix to the same prompt cache (optional)
+	PromptCacheKey string
+
+	// CacheStablePrefix marks the system prompt as a prompt cache breakpoint, which models that
+	// only cache marked prefixes, such as Anthropic's behind OpenAI-compatible gateways, require
+	CacheStablePrefix bool
+
+	// Prompt references a prompt stored on the server, sent instead of inline instructions (optional)
+	Prompt *Prompt
+
+	// PreviousResponseID continues from a response stored by a ServerStateProvider (optional)
+	PreviousResponseID string
+
+	// ConversationID appends to a conversation stored by a ServerStateProvider (optional)
+	ConversationID string
+
+	// Custom holds provider-specific settings. The "model", "tools" and "tool_choice" keys are
+	// the old way of setting Model, Tools and ToolChoice; they are still read when those fields
+	// are unset, but new code should use the fields.
 	Custom map[string]any
 }
 
 // DefaultSettings returns default model settings
 func DefaultSettings() Settings {
 	return Settings{
-		Temperature:      0.7,
-		MaxTokens:        1024,
-		TopP:             1.0,
-		FrequencyPenalty: 0.0,
-		PresencePenalty:  0.0,
-		StopSequences:    []string{},
-		ResponseFormat:   "",
-		Seed:             0,
-		Tools:            []map[string]any{},
-		Custom:           make(map[string]any),
+		Temperature:    0.7,
+		MaxTokens:      1024,
+		TopP:           1.0,
+		StopSequences:  []string{},
+		ResponseFormat: "",
+		Tools:          []ToolDefinition{},
+		Custom:         make(map[string]any),
 	}
 }
 
+// Int returns a pointer to v, for Seed
+func Int(v int) *int {
+	return &v
+}
+
+// Resolve returns the settings with every non-zero field of override applied; Seed, and the
+// sampling settings when override.ExplicitSampling is set, are applied even when they are 0.
+// Custom settings and metadata are merged, with the keys of override taking precedence.
+func (s Settings) Resolve(override Settings) Settings {
+	resolved := s
+
+	if override.Model != "" {
+		resolved.Model = override.Model
+	}
+	if override.ExplicitSampling {
+		resolved.Temperature = override.Temperature
+		resolved.TopP = override.TopP
+		resolved.FrequencyPenalty = override.FrequencyPenalty
+		resolved.PresencePenalty = override.PresencePenalty
+		resolved.ExplicitSampling = true
+	}
+	if override.Temperature != 0 {
+		resolved.Temperature = override.Temperature
+	}
+	if override.MaxTokens != 0 {
+		resolved.MaxTokens = override.MaxTokens
+	}
+	if override.TopP != 0 {
+		resolved.TopP = override.TopP
+	}
+	if override.FrequencyPenalty != 0 {
+		resolved.FrequencyPenalty = override.FrequencyPenalty
+	}
+	if override.PresencePenalty != 0 {
+		resolved.PresencePenalty = override.PresencePenalty
+	}
+	if len(override.StopSequences) > 0 {
+		resolved.StopSequences = override.StopSequences
+	}
+	if override.ResponseFormat != "" {
+		resolved.ResponseFormat = override.ResponseFormat
+	}
+	if override.ResponseSchema != nil {
+		resolved.ResponseSchema = override.ResponseSchema
+	}
+	if override.Seed != nil {
+		resolved.Seed = override.Seed
+	}
+	if len(override.LogitBias) > 0 {
+		resolved.LogitBias = override.LogitBias
+	}
+	if override.Logprobs {
+		resolved.Logprobs = true
+	}
+	if override.TopLogprobs != 0 {
+		resolved.TopLogprobs = override.TopLogprobs
+	}
+	if override.N != 0 {
+		resolved.N = override.N
+	}
+	if override.ParallelToolCalls != nil {
+		resolved.ParallelToolCalls = override.ParallelToolCalls
+	}
+	if len(override.Tools) > 0 {
+		resolved.Tools = override.Tools
+	}
+	if override.ToolChoice != "" {
+		resolved.ToolChoice = override.ToolChoice
+	}
+	if override.ReasoningEffort != "" {
+		resolved.ReasoningEffort = override.ReasoningEffort
+	}
+	if override.Verbosity != "" {
+		resolved.Verbosity = override.Verbosity
+	}
+	if override.InstructionPlacement != "" {
+		resolved.InstructionPlacement = override.InstructionPlacement
+	}
+	if override.User != "" {
+		resolved.User = override.User
+	}
+	if len(override.Metadata) > 0 {
+		resolved.Metadata = make(map[string]string, len(s.Metadata)+len(override.Metadata))
+		for k, v := range s.Metadata {
+			resolved.Metadata[k] = v
+		}
+		for k, v := range override.Metadata {
+			resolved.Metadata[k] = v
+		}
+	}
+	if override.Store != nil {
+		resolved.Store = override.Store
+	}
+	if override.PromptCacheKey != "" {
+		resolved.PromptCacheKey = override.PromptCacheKey
+	}
+	if override.CacheStablePrefix {
+		resolved.CacheStablePrefix = true
+	}
+	if override.Prompt != nil {
+		resolved.Prompt = override.Prompt
+	}
+	if override.PreviousResponseID != "" {
+		resolved.PreviousResponseID = override.PreviousResponseID
+	}
+	if override.ConversationID != "" {
+		resolved.ConversationID = override.ConversationID
+	}
+
+	resolved.Custom = make(map[string]any, len(s.Custom)+len(override.Custom))
+	for k, v := range s.Custom {
+		resolved.Custom[k] = v
+	}
+	for k, v := range override.Custom {
+		resolved.Custom[k] = v
+	}
+
+	return resolved
+}
+
+// EffectiveModel returns Model, or the deprecated Custom["model"] if Model is unset
+func (s Settings) EffectiveModel() string {
+	if s.Model != "" {
+		return s.Model
+	}
+	name, _ := s.Custom["model"].(string)
+	return name
+}
+
+// EffectiveTools returns Tools, or the deprecated Custom["tools"] in the Chat Completions format
+// if Tools is empty
+func (s Settings) EffectiveTools() []ToolDefinition {
+	if len(s.Tools) > 0 {
+		return s.Tools
+	}
+	if definitions, ok := s.Custom["tools"].([]map[string]any); ok {
+		tools, _ := ToolDefinitionsFromMaps(definitions)
+		return tools
+	}
+	return nil
+}
+
+// EffectiveToolChoice returns ToolChoice, or the deprecated Custom["tool_choice"] if ToolChoice
+// is unset. Its "force_<name>" values require the named tool.
+func (s Settings) EffectiveToolChoice() ToolChoice {
+	if s.ToolChoice != "" {
+		return s.ToolChoice
+	}
+	choice, _ := s.Custom["tool_choice"].(string)
+	if name, ok := strings.CutPrefix(choice, "force_"); ok {
+		return ToolChoiceFunction(name)
+	}
+	return ToolChoice(choice)
+}
+
 // Message represents a chat message
 type Message struct {
 	// Role is the role of the message (system, user, assistant, tool)
-	Role       string
-	Content    string
-	ToolCalls  []ToolCall
-	ToolCallID string
-	Name       string
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+	Name       string     `json:"name,omitempty"`
+
+	// ContentParts are images, files, audio or additional text sent after Content (optional)
+	ContentParts []ContentPart `json:"content_parts,omitempty"`
+
+	// Reasoning is the reasoning or reasoning summary returned with an assistant message, if the
+	// provider exposes it (e.g. reasoning_content). It is not sent back to the model.
+	Reasoning string `json:"reasoning,omitempty"`
+
+	// Refusal is the model's explanation when it refuses to answer with a structured output
+	Refusal string `json:"refusal,omitempty"`
 }
 
 type ToolCall struct {
-	ID       string
-	Type     string
-	Function FunctionCall
+	ID       string       `json:"id"`
+	Type     string       `json:"type"`
+	Function FunctionCall `json:"function"`
+
+	// Index is the position of the call in the response. Only stream deltas set it, to tell which
+	// call an argument fragment belongs to; StreamAccumulator assembles them into complete calls.
+	Index *int `json:"index,omitempty"`
 }
 
 type FunctionCall struct {
-	Name      string
-	Arguments string
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
 }
 
 // Provider is the interface for model providers
@@ -85,8 +359,30 @@ type Provider interface {
 
 // Response represents a model response
 type Response struct {
+	// ID is the provider's identifier for the response, if it returns one
+	ID string
+
 	Message Message
 	Usage   Usage
+
+	// Choices holds every generated message when Settings.N asks for several, Message being
+	// the first one (optional)
+	Choices []Message
+
+	// Logprobs are the log probabilities of the tokens of Message when Settings.Logprobs asks for them
+	Logprobs []TokenLogprob
+}
+
+// TokenLogprob is the log probability of a generated token
+type TokenLogprob struct {
+	// Token is the token
+	Token string `json:"token"`
+
+	// Logprob is the log probability of the token
+	Logprob float64 `json:"logprob"`
+
+	// TopLogprobs are the most likely tokens at this position (see Settings.TopLogprobs)
+	TopLogprobs []TokenLogprob `json:"top_logprobs,omitempty"`
 }
 
 // Usage represents token usage
@@ -99,6 +395,12 @@ type Usage struct {
 
 	// TotalTokens is the total number of tokens
 	TotalTokens int
+
+	// CachedPromptTokens is the number of prompt tokens served from the provider's prompt cache
+	CachedPromptTokens int
+
+	// ReasoningTokens is the number of completion tokens spent on reasoning (reasoning models only)
+	ReasoningTokens int
 }
 
 // Stream is the interface for streaming responses
@@ -114,4 +416,7 @@ type StreamChunk struct {
 	Delta Message
 
 	FinishReason string
+
+	// Usage is the token usage of the whole request, sent with the last chunk by providers that report it
+	Usage *Usage
 }
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownModelPrefix is returned when a model name has a prefix with no registered provider
+var ErrUnknownModelPrefix = errors.New("no provider registered for model prefix")
+
+// MultiProvider routes requests to providers by the prefix of the model name.
+// A model name such as "groq/llama-3.1-8b-instant" is sent to the provider registered
+// for "groq" with the model "llama-3.1-8b-instant". Names without a registered prefix,
+// such as "gpt-4o", are sent unchanged to the fallback provider.
+//
+// Set it as RunConfig.ModelProvider to select providers with RunConfig.Model or Agent.Model.
+type MultiProvider struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+	fallback  Provider
+}
+
+// NewMultiProvider creates a MultiProvider. fallback handles model names without
+// a registered prefix; it can be nil to reject them.
+func NewMultiProvider(fallback Provider) *MultiProvider {
+	return &MultiProvider{
+		providers: make(map[string]Provider),
+		fallback:  fallback,
+	}
+}
+
+// Register routes model names starting with "prefix/" to the provider
+func (m *MultiProvider) Register(prefix string, provider Provider) *MultiProvider {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.providers[prefix] = provider
+	return m
+}
+
+// Resolve returns the provider for the model name and the model name to send to it
+func (m *MultiProvider) Resolve(modelName string) (Provider, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if prefix, name, ok := strings.Cut(modelName, "/"); ok {
+		if provider, ok := m.providers[prefix]; ok {
+			return provider, name, nil
+		}
+	}
+
+	if m.fallback == nil {
+		return nil, "", fmt.Errorf("%w: %q", ErrUnknownModelPrefix, modelName)
+	}
+
+	// Names like "meta-llama/Llama-3.3-70B" are valid model names for some providers
+	return m.fallback, modelName, nil
+}
+
+// CreateChatCompletion sends the request to the provider for settings.Model
+func (m *MultiProvider) CreateChatCompletion(ctx context.Context, messages []Message, settings Settings) (*Response, error) {
+	provider, settings, err := m.route(settings)
+	if err != nil {
+		return nil, err
+	}
+	return provider.CreateChatCompletion(ctx, messages, settings)
+}
+
+// CreateChatCompletionStream sends the request to the provider for settings.Model
+func (m *MultiProvider) CreateChatCompletionStream(ctx context.Context, messages []Message, settings Settings) (Stream, error) {
+	provider, settings, err := m.route(settings)
+	if err != nil {
+		return nil, err
+	}
+	return provider.CreateChatCompletionStream(ctx, messages, settings)
+}
+
+// route resolves the provider and returns settings with the prefix removed from the model name
+func (m *MultiProvider) route(settings Settings) (Provider, Settings, error) {
+	modelName := settings.EffectiveModel()
+
+	provider, name, err := m.Resolve(modelName)
+	if err != nil {
+		return nil, settings, err
+	}
+
+	// settings is a copy, so the caller's settings keep the prefixed name
+	settings.Model = name
+	return provider, settings, nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAICompatibleProvider(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "openai-key")
+	server, lastRequest, lastBody := newTestServer(t, defaultChatResponse())
+
+	provider, err := NewOpenAICompatibleProvider(server.URL, "", OpenAICompatibleOptions{DefaultModel: "llama3"})
+	require.NoError(t, err)
+
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, DefaultSettings())
+	require.NoError(t, err)
+	assert.Equal(t, "llama3", (*lastBody)["model"])
+	assert.NotContains(t, lastRequest.Header.Get("Authorization"), "openai-key")
+
+	_, err = NewOpenAICompatibleProvider("", "key", OpenAICompatibleOptions{})
+	assert.Error(t, err)
+}
+
+func TestMultiProvider(t *testing.T) {
+	openaiServer, _, openaiBody := newTestServer(t, defaultChatResponse())
+	groqServer, _, groqBody := newTestServer(t, defaultChatResponse())
+
+	openaiProvider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: openaiServer.URL})
+	require.NoError(t, err)
+	groqProvider, err := NewOpenAICompatibleProvider(groqServer.URL, "groq-key", OpenAICompatibleOptions{})
+	require.NoError(t, err)
+
+	multi := NewMultiProvider(openaiProvider).Register("groq", groqProvider)
+
+	settings := DefaultSettings()
+	settings.Model = "groq/llama-3.1-8b-instant"
+	_, err = multi.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	assert.Equal(t, "llama-3.1-8b-instant", (*groqBody)["model"])
+	assert.Equal(t, "groq/llama-3.1-8b-instant", settings.Model)
+
+	settings.Model = "meta-llama/Llama-3.3-70B"
+	_, err = multi.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	assert.Equal(t, "meta-llama/Llama-3.3-70B", (*openaiBody)["model"])
+
+	_, _, err = NewMultiProvider(nil).Resolve("gpt-4o")
+	assert.ErrorIs(t, err, ErrUnknownModelPrefix)
+}
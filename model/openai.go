@@ -5,14 +5,18 @@
 package model
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
-	"strings"
 
 	"github.com/sashabaranov/go-openai"
+
+	"github.com/ryichk/ai-agents-sdk-go/version"
 )
 
 // OpenAIConfig represents OpenAI provider configuration
@@ -25,6 +29,19 @@ type OpenAIConfig struct {
 
 	// Organization is the OpenAI Organization (optional)
 	Organization string
+
+	// HTTPClient is the HTTP client used for API calls (optional, defaults to http.DefaultClient's transport)
+	HTTPClient *http.Client
+
+	// UserAgent overrides the User-Agent header sent with API calls (optional, defaults to version.UserAgent())
+	UserAgent string
+
+	// DefaultModel is the model used when the settings don't name one (optional, defaults to "gpt-4o")
+	DefaultModel string
+
+	// RateLimiter makes API calls wait for rate limit capacity (optional, defaults to the
+	// limiter set with SetDefaultRateLimiter, if any)
+	RateLimiter *RateLimiter
 }
 
 type OpenAIProvider struct {
@@ -33,6 +50,20 @@ type OpenAIProvider struct {
 }
 
 func NewOpenAIProvider(config OpenAIConfig) (*OpenAIProvider, error) {
+	client, err := NewOpenAIClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAIProvider{
+		config: config,
+		client: client,
+	}, nil
+}
+
+// NewOpenAIClient creates an OpenAI API client from the configuration.
+// It is shared by the chat provider and other OpenAI-backed components such as the voice models.
+func NewOpenAIClient(config OpenAIConfig) (*openai.Client, error) {
 	if config.APIKey == "" {
 		config.APIKey = os.Getenv("OPENAI_API_KEY")
 		if config.APIKey == "" {
@@ -40,6 +71,11 @@ func NewOpenAIProvider(config OpenAIConfig) (*OpenAIProvider, error) {
 		}
 	}
 
+	return newOpenAIClient(config), nil
+}
+
+// newOpenAIClient creates an API client without resolving the API key from the environment
+func newOpenAIClient(config OpenAIConfig) *openai.Client {
 	clientConfig := openai.DefaultConfig(config.APIKey)
 	if config.BaseURL != "" {
 		clientConfig.BaseURL = config.BaseURL
@@ -47,73 +83,187 @@ func NewOpenAIProvider(config OpenAIConfig) (*OpenAIProvider, error) {
 	if config.Organization != "" {
 		clientConfig.OrgID = config.Organization
 	}
+	clientConfig.HTTPClient = newUserAgentHTTPClient(config.HTTPClient, config.UserAgent)
 
-	return &OpenAIProvider{
-		config: config,
-		client: openai.NewClientWithConfig(clientConfig),
-	}, nil
+	return openai.NewClientWithConfig(clientConfig)
 }
 
-// NewDefaultOpenAIProvider creates an OpenAI provider using API key from environment variables
-func NewDefaultOpenAIProvider() (*OpenAIProvider, error) {
-	return NewOpenAIProvider(OpenAIConfig{})
+// requestTransport sets the User-Agent header on every outgoing request,
+// applies the request body edit carried by the request context and captures the response body if requested
+type requestTransport struct {
+	base      http.RoundTripper
+	userAgent string
 }
 
-func (p *OpenAIProvider) CreateChatCompletion(ctx context.Context, messages []Message, settings Settings) (*Response, error) {
-	openaiMessages := convertToOpenAIMessages(messages)
+func (t *requestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
 
-	request := openai.ChatCompletionRequest{
-		Model:            getModelName(settings),
-		Messages:         openaiMessages,
-		Temperature:      float32(settings.Temperature),
-		MaxTokens:        settings.MaxTokens,
-		TopP:             float32(settings.TopP),
-		FrequencyPenalty: float32(settings.FrequencyPenalty),
-		PresencePenalty:  float32(settings.PresencePenalty),
-		Stop:             settings.StopSequences,
+	if err := applyRequestBodyEdit(req); err != nil {
+		return nil, err
 	}
 
-	if tools, ok := settings.Custom["tools"].([]map[string]any); ok && len(tools) > 0 {
-		openaiTools := make([]openai.Tool, 0, len(tools))
-		for _, toolDef := range tools {
-			openaiTool, err := mapToOpenAITool(toolDef)
-			if err != nil {
-				continue
-			}
-			openaiTools = append(openaiTools, openaiTool)
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := captureResponseBody(req.Context(), resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// newUserAgentHTTPClient returns a copy of client whose transport adds the SDK User-Agent header
+func newUserAgentHTTPClient(client *http.Client, userAgent string) *http.Client {
+	if userAgent == "" {
+		userAgent = version.UserAgent()
+	}
+
+	var wrapped http.Client
+	if client != nil {
+		wrapped = *client
+	}
+
+	base := wrapped.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	wrapped.Transport = &requestTransport{base: base, userAgent: userAgent}
+
+	return &wrapped
+}
+
+// requestBodyEditKey is the context key of the request body edit
+type requestBodyEditKey struct{}
+
+// withRequestBodyEdit returns a context whose API requests have their JSON body modified by edit.
+// It is used for request fields the API client does not support.
+func withRequestBodyEdit(ctx context.Context, edit func(body map[string]any)) context.Context {
+	if edit == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, requestBodyEditKey{}, edit)
+}
+
+// responseCapture holds the raw body of a successful API response
+type responseCapture struct {
+	body []byte
+}
+
+// responseCaptureKey is the context key of the response capture
+type responseCaptureKey struct{}
+
+// withResponseCapture returns a context whose API responses are captured, for response fields
+// the API client does not decode
+func withResponseCapture(ctx context.Context) (context.Context, *responseCapture) {
+	capture := &responseCapture{}
+	return context.WithValue(ctx, responseCaptureKey{}, capture), capture
+}
+
+// captureResponseBody stores a copy of the body of a successful response in the context's capture
+func captureResponseBody(ctx context.Context, resp *http.Response) error {
+	capture, ok := ctx.Value(responseCaptureKey{}).(*responseCapture)
+	if !ok || resp.StatusCode >= http.StatusBadRequest {
+		return nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	capture.body = data
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	return nil
+}
+
+// requestBodyEdit combines the request body edits needed for the request's messages and settings
+func requestBodyEdit(request openai.ChatCompletionRequest, messages []Message, settings Settings) func(body map[string]any) {
+	var edits []func(body map[string]any)
+	for _, edit := range []func(body map[string]any){
+		contentPartsEdit(messages),
+		zeroSamplingEdit(request.Model, settings),
+		storeEdit(settings),
+		promptCacheEdit(settings),
+		promptEdit(settings),
+		reasoningEdit(settings),
+	} {
+		if edit != nil {
+			edits = append(edits, edit)
 		}
-		request.Tools = openaiTools
-	}
-
-	if toolChoice, ok := settings.Custom["tool_choice"].(string); ok {
-		switch toolChoice {
-		case "auto":
-			request.ToolChoice = "auto"
-		case "none":
-			request.ToolChoice = "none"
-		default:
-			if strings.HasPrefix(toolChoice, "force_") {
-				toolName := strings.TrimPrefix(toolChoice, "force_")
-				request.ToolChoice = map[string]any{
-					"type": "function",
-					"function": map[string]any{
-						"name": toolName,
-					},
-				}
-			}
+	}
+	if len(edits) == 0 {
+		return nil
+	}
+
+	return func(body map[string]any) {
+		for _, edit := range edits {
+			edit(body)
 		}
 	}
+}
 
-	if settings.ResponseFormat != "" {
-		if settings.ResponseFormat == "json_object" {
-			request.ResponseFormat = &openai.ChatCompletionResponseFormat{
-				Type: openai.ChatCompletionResponseFormatTypeJSONObject,
-			}
+// applyRequestBodyEdit rewrites the JSON body of req with the edit carried by its context
+func applyRequestBodyEdit(req *http.Request) error {
+	edit, ok := req.Context().Value(requestBodyEditKey{}).(func(body map[string]any))
+	if !ok || req.Body == nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	// Numbers are decoded as json.Number so they are written back verbatim, such as seeds and
+	// logit_bias token IDs beyond the precision of float64. The keys of the edited body are sorted.
+	var body map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err == nil {
+		edit(body)
+		if edited, err := json.Marshal(body); err == nil {
+			data = edited
 		}
 	}
 
-	result, err := p.client.CreateChatCompletion(ctx, request)
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	return nil
+}
+
+// NewDefaultOpenAIProvider creates an OpenAI provider using API key from environment variables
+func NewDefaultOpenAIProvider() (*OpenAIProvider, error) {
+	return NewOpenAIProvider(OpenAIConfig{})
+}
+
+func (p *OpenAIProvider) CreateChatCompletion(ctx context.Context, messages []Message, settings Settings) (*Response, error) {
+	if limiter := p.rateLimiter(); limiter != nil {
+		return limitCompletion(ctx, limiter, messages, settings, p.createChatCompletion)
+	}
+	return p.createChatCompletion(ctx, messages, settings)
+}
+
+// createChatCompletion calls the Chat Completions API
+func (p *OpenAIProvider) createChatCompletion(ctx context.Context, messages []Message, settings Settings) (*Response, error) {
+	messages = PlaceInstructions(messages, settings.InstructionPlacement)
+	request := p.newChatCompletionRequest(messages, settings)
+
+	ctx, capture := withResponseCapture(ctx)
+	result, err := p.client.CreateChatCompletion(withRequestBodyEdit(ctx, requestBodyEdit(request, messages, settings)), request)
 	if err != nil {
+		if IsContextWindowExceeded(err) {
+			return nil, fmt.Errorf("OpenAI API call failed: %w: %w", ErrContextWindowExceeded, err)
+		}
 		return nil, fmt.Errorf("OpenAI API call failed: %w", err)
 	}
 
@@ -129,16 +279,31 @@ func (p *OpenAIProvider) CreateChatCompletion(ctx context.Context, messages []Me
 	}
 
 	response := &Response{
+		ID: result.ID,
 		Message: Message{
 			Role:      choice.Message.Role,
 			Content:   choice.Message.Content,
 			ToolCalls: toolCalls,
+			Reasoning: reasoningFromResponse(capture.body),
+			Refusal:   choice.Message.Refusal,
 		},
-		Usage: Usage{
-			PromptTokens:     result.Usage.PromptTokens,
-			CompletionTokens: result.Usage.CompletionTokens,
-			TotalTokens:      result.Usage.TotalTokens,
-		},
+		Usage:    convertAPIUsage(result.Usage),
+		Logprobs: convertAPILogprobs(choice.LogProbs),
+	}
+	if len(result.Choices) > 1 {
+		response.Choices = []Message{response.Message}
+		for _, c := range result.Choices[1:] {
+			toolCalls, err := convertAPIToolCalls(c.Message.ToolCalls)
+			if err != nil {
+				return nil, fmt.Errorf("error converting tool calls: %w", err)
+			}
+			response.Choices = append(response.Choices, Message{
+				Role:      c.Message.Role,
+				Content:   c.Message.Content,
+				ToolCalls: toolCalls,
+				Refusal:   c.Message.Refusal,
+			})
+		}
 	}
 
 	return response, nil
@@ -146,67 +311,161 @@ func (p *OpenAIProvider) CreateChatCompletion(ctx context.Context, messages []Me
 
 // CreateChatCompletionStream creates a streaming chat completion
 func (p *OpenAIProvider) CreateChatCompletionStream(ctx context.Context, messages []Message, settings Settings) (Stream, error) {
-	openaiMessages := convertToOpenAIMessages(messages)
+	if limiter := p.rateLimiter(); limiter != nil {
+		return limitStream(ctx, limiter, messages, settings, p.createChatCompletionStream)
+	}
+	return p.createChatCompletionStream(ctx, messages, settings)
+}
+
+// createChatCompletionStream opens a streaming chat completion, asking for the usage in its last chunk
+func (p *OpenAIProvider) createChatCompletionStream(ctx context.Context, messages []Message, settings Settings) (Stream, error) {
+	messages = PlaceInstructions(messages, settings.InstructionPlacement)
+	request := p.newChatCompletionRequest(messages, settings)
+	request.Stream = true
+	request.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+
+	stream, err := p.client.CreateChatCompletionStream(withRequestBodyEdit(ctx, requestBodyEdit(request, messages, settings)), request)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI API stream call failed: %w", err)
+	}
+
+	return &OpenAIStream{
+		stream: stream,
+	}, nil
+}
+
+// rateLimiter returns the provider's rate limiter, or the default one
+func (p *OpenAIProvider) rateLimiter() *RateLimiter {
+	if p.config.RateLimiter != nil {
+		return p.config.RateLimiter
+	}
+	return DefaultRateLimiter()
+}
+
+// zeroSamplingEdit returns the request body edit that sends the sampling settings set to 0 with
+// ExplicitSampling, which the client omits, or nil if there are none
+func zeroSamplingEdit(modelName string, settings Settings) func(body map[string]any) {
+	if !settings.ExplicitSampling || usesReasoningParameters(modelName, settings) {
+		return nil
+	}
+
+	var fields []string
+	for _, param := range []struct {
+		field string
+		value float64
+	}{
+		{"temperature", settings.Temperature},
+		{"top_p", settings.TopP},
+		{"frequency_penalty", settings.FrequencyPenalty},
+		{"presence_penalty", settings.PresencePenalty},
+	} {
+		if param.value == 0 {
+			fields = append(fields, param.field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return func(body map[string]any) {
+		for _, field := range fields {
+			body[field] = 0
+		}
+	}
+}
+
+// storeEdit returns the request body edit that sends Store, or nil if it is unset. The client
+// omits a false store, so it is written to the body to tell false apart from the provider default.
+func storeEdit(settings Settings) func(body map[string]any) {
+	if settings.Store == nil {
+		return nil
+	}
+
+	store := *settings.Store
+	return func(body map[string]any) {
+		body["store"] = store
+	}
+}
 
+// newChatCompletionRequest converts messages and settings to a Chat Completions request
+func (p *OpenAIProvider) newChatCompletionRequest(messages []Message, settings Settings) openai.ChatCompletionRequest {
 	request := openai.ChatCompletionRequest{
-		Model:            getModelName(settings),
-		Messages:         openaiMessages,
+		Model:            getModelName(settings, p.config.DefaultModel),
+		Messages:         convertToOpenAIMessages(messages),
 		Temperature:      float32(settings.Temperature),
 		MaxTokens:        settings.MaxTokens,
 		TopP:             float32(settings.TopP),
 		FrequencyPenalty: float32(settings.FrequencyPenalty),
 		PresencePenalty:  float32(settings.PresencePenalty),
 		Stop:             settings.StopSequences,
-		Stream:           true,
+		User:             settings.User,
+		Metadata:         settings.Metadata,
+		LogitBias:        settings.LogitBias,
+		LogProbs:         settings.Logprobs,
+		TopLogProbs:      settings.TopLogprobs,
+		N:                settings.N,
+	}
+	if settings.Seed != nil {
+		seed := *settings.Seed
+		request.Seed = &seed
 	}
 
-	if tools, ok := settings.Custom["tools"].([]map[string]any); ok && len(tools) > 0 {
-		openaiTools := make([]openai.Tool, 0, len(tools))
-		for _, toolDef := range tools {
-			openaiTool, err := mapToOpenAITool(toolDef)
-			if err != nil {
-				continue
-			}
-			openaiTools = append(openaiTools, openaiTool)
+	tools := settings.EffectiveTools()
+	for _, definition := range tools {
+		// Chat Completions only supports function tools
+		if !definition.IsFunction() {
+			continue
 		}
-		request.Tools = openaiTools
-	}
-
-	if toolChoice, ok := settings.Custom["tool_choice"].(string); ok {
-		switch toolChoice {
-		case "auto":
-			request.ToolChoice = "auto"
-		case "none":
-			request.ToolChoice = "none"
-		default:
-			if strings.HasPrefix(toolChoice, "force_") {
-				toolName := strings.TrimPrefix(toolChoice, "force_")
-				request.ToolChoice = map[string]any{
-					"type": "function",
-					"function": map[string]any{
-						"name": toolName,
-					},
-				}
-			}
+		request.Tools = append(request.Tools, openai.Tool{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        definition.Name,
+				Description: definition.Description,
+				Strict:      definition.Strict,
+				Parameters:  definition.functionParameters(),
+			},
+		})
+	}
+
+	if settings.ParallelToolCalls != nil && len(request.Tools) > 0 {
+		request.ParallelToolCalls = *settings.ParallelToolCalls
+	}
+
+	switch choice := settings.EffectiveToolChoice(); choice {
+	case "":
+	case ToolChoiceAuto, ToolChoiceNone, ToolChoiceRequired:
+		request.ToolChoice = string(choice)
+	default:
+		request.ToolChoice = map[string]any{
+			"type": "function",
+			"function": map[string]any{
+				"name": choice.FunctionName(),
+			},
 		}
 	}
 
-	if settings.ResponseFormat != "" {
-		if settings.ResponseFormat == "json_object" {
+	switch settings.ResponseFormat {
+	case "json_object":
+		request.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		}
+	case "json_schema":
+		if schema := settings.ResponseSchema; schema != nil {
 			request.ResponseFormat = &openai.ChatCompletionResponseFormat{
-				Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+				Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+				JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+					Name:        schema.Name,
+					Description: schema.Description,
+					Schema:      schema,
+					Strict:      schema.Strict,
+				},
 			}
 		}
 	}
 
-	stream, err := p.client.CreateChatCompletionStream(ctx, request)
-	if err != nil {
-		return nil, fmt.Errorf("OpenAI API stream call failed: %w", err)
-	}
+	applyReasoningSettings(&request, settings)
 
-	return &OpenAIStream{
-		stream: stream,
-	}, nil
+	return request
 }
 
 // OpenAIStream handles OpenAI streaming responses
@@ -225,6 +484,11 @@ func (s *OpenAIStream) Recv() (*StreamChunk, error) {
 	}
 
 	if len(resp.Choices) == 0 {
+		// The usage comes in a last chunk without choices
+		if resp.Usage != nil {
+			usage := convertAPIUsage(*resp.Usage)
+			return &StreamChunk{Usage: &usage}, nil
+		}
 		return nil, errors.New("no choices in stream response")
 	}
 
@@ -249,6 +513,7 @@ func (s *OpenAIStream) Recv() (*StreamChunk, error) {
 					Name:      tc.Function.Name,
 					Arguments: tc.Function.Arguments,
 				},
+				Index: tc.Index,
 			}
 		}
 		chunk.Delta.ToolCalls = toolCalls
@@ -261,47 +526,19 @@ func (s *OpenAIStream) Close() error {
 	return s.stream.Close()
 }
 
-func mapToOpenAITool(toolMap map[string]any) (openai.Tool, error) {
-	tool := openai.Tool{}
-
-	toolType, ok := toolMap["type"].(string)
-	if !ok {
-		return tool, errors.New("invalid tool type")
+// convertAPILogprobs converts the log probabilities of a choice
+func convertAPILogprobs(logprobs *openai.LogProbs) []TokenLogprob {
+	if logprobs == nil {
+		return nil
 	}
-
-	tool.Type = openai.ToolTypeFunction
-
-	if toolType == "function" {
-		functionMap, ok := toolMap["function"].(map[string]any)
-		if !ok {
-			return tool, errors.New("invalid function definition")
-		}
-
-		function := openai.FunctionDefinition{}
-
-		if name, ok := functionMap["name"].(string); ok {
-			function.Name = name
-		} else {
-			return tool, errors.New("function name is required")
-		}
-
-		if description, ok := functionMap["description"].(string); ok {
-			function.Description = description
-		}
-
-		if parameters, ok := functionMap["parameters"].(map[string]any); ok {
-			function.Parameters = parameters
-		} else {
-			function.Parameters = map[string]any{
-				"type":       "object",
-				"properties": map[string]any{},
-			}
+	result := make([]TokenLogprob, len(logprobs.Content))
+	for i, logprob := range logprobs.Content {
+		result[i] = TokenLogprob{Token: logprob.Token, Logprob: logprob.LogProb}
+		for _, top := range logprob.TopLogProbs {
+			result[i].TopLogprobs = append(result[i].TopLogprobs, TokenLogprob{Token: top.Token, Logprob: top.LogProb})
 		}
-
-		tool.Function = &function
 	}
-
-	return tool, nil
+	return result
 }
 
 // convertToOpenAIMessages converts messages to OpenAI format
@@ -313,6 +550,10 @@ func convertToOpenAIMessages(messages []Message) []openai.ChatCompletionMessage
 			Content:   msg.Content,
 			ToolCalls: convertToOpenAIToolCalls(msg.ToolCalls),
 		}
+		if len(msg.ContentParts) > 0 {
+			result[i].Content = ""
+			result[i].MultiContent = convertToOpenAIParts(messageContentParts(msg))
+		}
 		if msg.Name != "" {
 			result[i].Name = msg.Name
 		}
@@ -323,6 +564,62 @@ func convertToOpenAIMessages(messages []Message) []openai.ChatCompletionMessage
 	return result
 }
 
+// messageContentParts returns the content of a multimodal message as parts, starting with Content as text
+func messageContentParts(msg Message) []ContentPart {
+	parts := make([]ContentPart, 0, len(msg.ContentParts)+1)
+	if msg.Content != "" {
+		parts = append(parts, NewTextPart(msg.Content))
+	}
+	return append(parts, msg.ContentParts...)
+}
+
+// convertToOpenAIParts converts content parts to OpenAI format.
+// The client only supports text and image parts; other parts are sent by contentPartsEdit.
+func convertToOpenAIParts(parts []ContentPart) []openai.ChatMessagePart {
+	result := make([]openai.ChatMessagePart, len(parts))
+	for i, part := range parts {
+		result[i] = openai.ChatMessagePart{
+			Type: openai.ChatMessagePartType(part.Type),
+			Text: part.Text,
+		}
+		if part.ImageURL != nil {
+			result[i].ImageURL = &openai.ChatMessageImageURL{
+				URL:    part.ImageURL.URL,
+				Detail: openai.ImageURLDetail(part.ImageURL.Detail),
+			}
+		}
+	}
+	return result
+}
+
+// contentPartsEdit returns a request body edit that sends the content parts the client cannot encode,
+// such as files and audio, or nil if there are none
+func contentPartsEdit(messages []Message) func(body map[string]any) {
+	contents := make(map[int][]ContentPart)
+	for i, msg := range messages {
+		for _, part := range msg.ContentParts {
+			if part.Type != ContentPartText && part.Type != ContentPartImageURL {
+				contents[i] = messageContentParts(msg)
+				break
+			}
+		}
+	}
+	if len(contents) == 0 {
+		return nil
+	}
+
+	return func(body map[string]any) {
+		bodyMessages, _ := body["messages"].([]any)
+		for i, parts := range contents {
+			if i < len(bodyMessages) {
+				if message, ok := bodyMessages[i].(map[string]any); ok {
+					message["content"] = parts
+				}
+			}
+		}
+	}
+}
+
 // convertToOpenAIToolCalls converts tool calls to OpenAI format
 func convertToOpenAIToolCalls(toolCalls []ToolCall) []openai.ToolCall {
 	result := make([]openai.ToolCall, len(toolCalls))
@@ -339,16 +636,34 @@ func convertToOpenAIToolCalls(toolCalls []ToolCall) []openai.ToolCall {
 	return result
 }
 
-func getModelName(settings Settings) string {
-	defaultModel := "gpt-4o"
+func getModelName(settings Settings, defaultModel string) string {
+	if defaultModel == "" {
+		defaultModel = "gpt-4o"
+	}
 
-	if modelName, ok := settings.Custom["model"].(string); ok && modelName != "" {
+	if modelName := settings.EffectiveModel(); modelName != "" {
 		return modelName
 	}
 
 	return defaultModel
 }
 
+// convertAPIUsage converts OpenAI usage to model usage
+func convertAPIUsage(usage openai.Usage) Usage {
+	result := Usage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+	}
+	if usage.PromptTokensDetails != nil {
+		result.CachedPromptTokens = usage.PromptTokensDetails.CachedTokens
+	}
+	if usage.CompletionTokensDetails != nil {
+		result.ReasoningTokens = usage.CompletionTokensDetails.ReasoningTokens
+	}
+	return result
+}
+
 func convertAPIToolCalls(apiToolCalls []openai.ToolCall) ([]ToolCall, error) {
 	result := make([]ToolCall, 0, len(apiToolCalls))
 
@@ -0,0 +1,266 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/version"
+)
+
+// newTestServer starts a fake Chat Completions endpoint that records the last request
+func newTestServer(t *testing.T, response map[string]any) (*httptest.Server, *http.Request, *map[string]any) {
+	t.Helper()
+
+	var lastRequest http.Request
+	var lastBody map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = *r
+		lastBody = map[string]any{}
+		_ = json.NewDecoder(r.Body).Decode(&lastBody)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &lastRequest, &lastBody
+}
+
+func defaultChatResponse() map[string]any {
+	return map[string]any{
+		"id":     "chatcmpl-1",
+		"object": "chat.completion",
+		"model":  "gpt-4o",
+		"choices": []any{
+			map[string]any{
+				"index":         0,
+				"finish_reason": "stop",
+				"message": map[string]any{
+					"role":    "assistant",
+					"content": "hello",
+				},
+			},
+		},
+		"usage": map[string]any{
+			"prompt_tokens":     10,
+			"completion_tokens": 5,
+			"total_tokens":      15,
+		},
+	}
+}
+
+func TestOpenAIProviderUserAgent(t *testing.T) {
+	server, lastRequest, _ := newTestServer(t, defaultChatResponse())
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	response, err := provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, DefaultSettings())
+	require.NoError(t, err)
+	assert.Equal(t, "hello", response.Message.Content)
+	assert.Equal(t, version.UserAgent(), lastRequest.Header.Get("User-Agent"))
+
+	provider, err = NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL, UserAgent: "my-app/1.0"})
+	require.NoError(t, err)
+
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, DefaultSettings())
+	require.NoError(t, err)
+	assert.Equal(t, "my-app/1.0", lastRequest.Header.Get("User-Agent"))
+}
+
+func TestOpenAIProviderJSONSchemaResponseFormat(t *testing.T) {
+	response := defaultChatResponse()
+	message := response["choices"].([]any)[0].(map[string]any)["message"].(map[string]any)
+	message["content"] = nil
+	message["refusal"] = "I can't help with that."
+	server, _, lastBody := newTestServer(t, response)
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	settings := DefaultSettings()
+	settings.ResponseFormat = "json_schema"
+	settings.ResponseSchema = &ResponseSchema{
+		Name:   "final_output",
+		Schema: map[string]any{"type": "object", "properties": map[string]any{}, "additionalProperties": false},
+		Strict: true,
+	}
+
+	result, err := provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	assert.Equal(t, "I can't help with that.", result.Message.Refusal)
+
+	format := (*lastBody)["response_format"].(map[string]any)
+	assert.Equal(t, "json_schema", format["type"])
+	jsonSchema := format["json_schema"].(map[string]any)
+	assert.Equal(t, "final_output", jsonSchema["name"])
+	assert.Equal(t, true, jsonSchema["strict"])
+	assert.Equal(t, "object", jsonSchema["schema"].(map[string]any)["type"])
+}
+
+func TestOpenAIProviderRequiredStrictTool(t *testing.T) {
+	server, _, lastBody := newTestServer(t, defaultChatResponse())
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	settings := DefaultSettings()
+	definition := NewFunctionTool("final_output", "", map[string]any{"type": "object", "properties": map[string]any{}, "additionalProperties": false})
+	definition.Strict = true
+	settings.Tools = []ToolDefinition{definition, {Type: "web_search_preview"}}
+	settings.ToolChoice = ToolChoiceRequired
+
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+
+	assert.Equal(t, "required", (*lastBody)["tool_choice"])
+	// Hosted tools are not supported by Chat Completions
+	tools := (*lastBody)["tools"].([]any)
+	require.Len(t, tools, 1)
+	function := tools[0].(map[string]any)["function"].(map[string]any)
+	assert.Equal(t, true, function["strict"])
+}
+
+func TestOpenAIProviderUserMetadataStore(t *testing.T) {
+	server, _, lastBody := newTestServer(t, defaultChatResponse())
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	store := true
+	settings := DefaultSettings().Resolve(Settings{User: "user-123", Metadata: map[string]string{"tenant": "acme"}})
+	settings = settings.Resolve(Settings{Metadata: map[string]string{"feature": "support"}, Store: &store})
+
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+
+	body := *lastBody
+	assert.Equal(t, "user-123", body["user"])
+	assert.Equal(t, map[string]any{"tenant": "acme", "feature": "support"}, body["metadata"])
+	assert.Equal(t, true, body["store"])
+
+	// An explicit false is sent, unlike the client's zero value
+	store = false
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings.Resolve(Settings{Store: &store}))
+	require.NoError(t, err)
+	require.Contains(t, *lastBody, "store")
+	assert.Equal(t, false, (*lastBody)["store"])
+
+	// Nothing is sent by default
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, DefaultSettings())
+	require.NoError(t, err)
+	assert.NotContains(t, *lastBody, "user")
+	assert.NotContains(t, *lastBody, "metadata")
+	assert.NotContains(t, *lastBody, "store")
+}
+
+func TestOpenAIProviderInstructionPlacement(t *testing.T) {
+	server, _, lastBody := newTestServer(t, defaultChatResponse())
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	messages := []Message{{Role: "system", Content: "Be brief"}, {Role: "user", Content: "hi"}}
+	settings := DefaultSettings().Resolve(Settings{InstructionPlacement: InstructionsDeveloper})
+	_, err = provider.CreateChatCompletion(context.Background(), messages, settings)
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"role": "developer", "content": "Be brief"},
+		map[string]any{"role": "user", "content": "hi"},
+	}, (*lastBody)["messages"])
+	assert.Equal(t, "system", messages[0].Role)
+}
+
+func TestOpenAIProviderSamplingSettings(t *testing.T) {
+	response := defaultChatResponse()
+	choices := response["choices"].([]any)
+	choices[0].(map[string]any)["logprobs"] = map[string]any{"content": []any{
+		map[string]any{"token": "hello", "logprob": -0.1, "top_logprobs": []any{
+			map[string]any{"token": "hello", "logprob": -0.1},
+			map[string]any{"token": "hi", "logprob": -2.5},
+		}},
+	}}
+	response["choices"] = append(choices, map[string]any{
+		"index":         1,
+		"finish_reason": "stop",
+		"message":       map[string]any{"role": "assistant", "content": "hi there"},
+	})
+	server, _, lastBody := newTestServer(t, response)
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	parallel := false
+	settings := DefaultSettings().Resolve(Settings{
+		Seed:              Int(42),
+		LogitBias:         map[string]int{"50256": -100},
+		Logprobs:          true,
+		TopLogprobs:       2,
+		N:                 2,
+		ParallelToolCalls: &parallel,
+		Tools:             []ToolDefinition{NewFunctionTool("lookup", "Looks up", nil)},
+	})
+	result, err := provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+
+	body := *lastBody
+	assert.Equal(t, float64(42), body["seed"])
+	assert.Equal(t, map[string]any{"50256": float64(-100)}, body["logit_bias"])
+	assert.Equal(t, true, body["logprobs"])
+	assert.Equal(t, float64(2), body["top_logprobs"])
+	assert.Equal(t, float64(2), body["n"])
+	assert.Equal(t, false, body["parallel_tool_calls"])
+
+	assert.Equal(t, []TokenLogprob{{Token: "hello", Logprob: -0.1, TopLogprobs: []TokenLogprob{
+		{Token: "hello", Logprob: -0.1},
+		{Token: "hi", Logprob: -2.5},
+	}}}, result.Logprobs)
+	require.Len(t, result.Choices, 2)
+	assert.Equal(t, "hello", result.Choices[0].Content)
+	assert.Equal(t, "hi there", result.Choices[1].Content)
+
+	// A seed of 0 is a seed too
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings.Resolve(Settings{Seed: Int(0)}))
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), (*lastBody)["seed"])
+
+	// parallel_tool_calls is only sent with tools, and nothing by default
+	settings.Tools = nil
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	assert.NotContains(t, *lastBody, "parallel_tool_calls")
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, DefaultSettings())
+	require.NoError(t, err)
+	for _, key := range []string{"seed", "logit_bias", "logprobs", "top_logprobs", "n"} {
+		assert.NotContains(t, *lastBody, key)
+	}
+}
+
+func TestRequestBodyEditKeepsNumbers(t *testing.T) {
+	ctx := withRequestBodyEdit(context.Background(), func(body map[string]any) {
+		body["prompt_cache_key"] = "support-agent"
+	})
+	body := `{"model":"gpt-4o","seed":9007199254740993,"logit_bias":{"50256":-100},"temperature":0.7}`
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/chat/completions", strings.NewReader(body))
+	require.NoError(t, err)
+
+	require.NoError(t, applyRequestBodyEdit(req))
+	edited, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"model":"gpt-4o","seed":9007199254740993,"logit_bias":{"50256":-100},"temperature":0.7,"prompt_cache_key":"support-agent"}`, string(edited))
+	assert.Contains(t, string(edited), `"seed":9007199254740993`, "integers are not rounded to float64")
+	assert.EqualValues(t, len(edited), req.ContentLength)
+}
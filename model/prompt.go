@@ -0,0 +1,120 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// ErrPromptNotFound is returned when a PromptRegistry has no prompt with the requested ID and version
+var ErrPromptNotFound = errors.New("prompt not found")
+
+// Prompt references a prompt stored on the server (an OpenAI dashboard prompt) by ID, with the
+// values of its variables. It is an alternative to inline instructions.
+//
+// The runner sends the reference in Settings.Prompt, which the OpenAI provider sends
+// as the prompt request field. The prompt field belongs to OpenAI's Responses API, so servers that
+// only implement Chat Completions reject it; with them, resolve prompts locally by setting a
+// PromptResolver such as a PromptRegistry on the run.
+type Prompt struct {
+	// ID is the ID of the stored prompt
+	ID string `json:"id"`
+
+	// Version is the version of the prompt (optional, defaults to the current version)
+	Version string `json:"version,omitempty"`
+
+	// Variables are substituted for the {{name}} placeholders of the prompt (optional)
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// PromptResolver turns a prompt reference into instructions before the request is sent
+type PromptResolver interface {
+	ResolvePrompt(ctx context.Context, prompt Prompt) (string, error)
+}
+
+// PromptResolverFunc is a function that implements PromptResolver
+type PromptResolverFunc func(ctx context.Context, prompt Prompt) (string, error)
+
+// ResolvePrompt calls the function
+func (f PromptResolverFunc) ResolvePrompt(ctx context.Context, prompt Prompt) (string, error) {
+	return f(ctx, prompt)
+}
+
+// promptVariable matches the {{name}} placeholders of stored prompts
+var promptVariable = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.\-]+)\s*\}\}`)
+
+// PromptRegistry is a PromptResolver that keeps prompts in memory, for providers without stored
+// prompts and for tests. A PromptRegistry is safe for concurrent use.
+type PromptRegistry struct {
+	mu      sync.RWMutex
+	prompts map[string]map[string]string
+	current map[string]string
+}
+
+// NewPromptRegistry creates an empty prompt registry
+func NewPromptRegistry() *PromptRegistry {
+	return &PromptRegistry{
+		prompts: map[string]map[string]string{},
+		current: map[string]string{},
+	}
+}
+
+// Register adds a version of a prompt. The last registered version is the current one.
+func (r *PromptRegistry) Register(id string, version string, text string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.prompts[id] == nil {
+		r.prompts[id] = map[string]string{}
+	}
+	r.prompts[id][version] = text
+	r.current[id] = version
+}
+
+// ResolvePrompt returns the text of the prompt version with its variables substituted.
+// Placeholders without a value are kept as they are.
+func (r *PromptRegistry) ResolvePrompt(ctx context.Context, prompt Prompt) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	version := prompt.Version
+	if version == "" {
+		version = r.current[prompt.ID]
+	}
+	text, ok := r.prompts[prompt.ID][version]
+	if !ok {
+		return "", fmt.Errorf("%w: %s (version %q)", ErrPromptNotFound, prompt.ID, prompt.Version)
+	}
+
+	return promptVariable.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := promptVariable.FindStringSubmatch(placeholder)[1]
+		if value, ok := prompt.Variables[name]; ok {
+			return value
+		}
+		return placeholder
+	}), nil
+}
+
+// promptEdit returns the request body edit that sends Settings.Prompt, or nil if it is not set
+func promptEdit(settings Settings) func(body map[string]any) {
+	prompt := settings.Prompt
+	if prompt == nil {
+		return nil
+	}
+
+	return func(body map[string]any) {
+		reference := map[string]any{"id": prompt.ID}
+		if prompt.Version != "" {
+			reference["version"] = prompt.Version
+		}
+		if len(prompt.Variables) > 0 {
+			reference["variables"] = prompt.Variables
+		}
+		body["prompt"] = reference
+	}
+}
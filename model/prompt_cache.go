@@ -0,0 +1,68 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+// Prompt caching
+//
+// OpenAI caches the longest previously seen prefix of a prompt automatically (for prompts of
+// 1024 tokens or more), so requests hit the cache when they start with the same tool definitions
+// and system prompt. The runner keeps them stable between requests: the agent's tools are sent
+// in the same order and the system prompt comes first in the messages. Cached prompt tokens are
+// reported in Usage.CachedPromptTokens.
+//
+// Two settings improve hit rates further:
+//   - PromptCacheKey is sent as prompt_cache_key so requests sharing a long static
+//     prefix are routed to the same cache.
+//   - CacheStablePrefix marks the system prompt with an ephemeral cache_control
+//     breakpoint, which Anthropic models behind OpenAI-compatible gateways such as LiteLLM
+//     require to cache the tools and system prompt.
+
+// promptCacheEdit returns the request body edit for the prompt cache settings, or nil if none are set
+func promptCacheEdit(settings Settings) func(body map[string]any) {
+	cacheKey := settings.PromptCacheKey
+	stablePrefix := settings.CacheStablePrefix
+	if cacheKey == "" && !stablePrefix {
+		return nil
+	}
+
+	return func(body map[string]any) {
+		if cacheKey != "" {
+			body["prompt_cache_key"] = cacheKey
+		}
+		if stablePrefix {
+			markStablePrefix(body)
+		}
+	}
+}
+
+// markStablePrefix adds a cache breakpoint to the last system message of the leading system messages
+func markStablePrefix(body map[string]any) {
+	messages, _ := body["messages"].([]any)
+
+	last := -1
+	for i, m := range messages {
+		message, _ := m.(map[string]any)
+		if message["role"] != "system" {
+			break
+		}
+		last = i
+	}
+	if last < 0 {
+		return
+	}
+
+	message := messages[last].(map[string]any)
+	text, ok := message["content"].(string)
+	if !ok {
+		return
+	}
+	message["content"] = []any{
+		map[string]any{
+			"type":          "text",
+			"text":          text,
+			"cache_control": map[string]any{"type": "ephemeral"},
+		},
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptCaching(t *testing.T) {
+	response := defaultChatResponse()
+	response["usage"] = map[string]any{
+		"prompt_tokens":         2000,
+		"completion_tokens":     5,
+		"total_tokens":          2005,
+		"prompt_tokens_details": map[string]any{"cached_tokens": 1920},
+	}
+	server, _, lastBody := newTestServer(t, response)
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	settings := DefaultSettings()
+	settings.Tools = []ToolDefinition{NewFunctionTool("get_weather", "", nil)}
+	settings.PromptCacheKey = "support-agent"
+	settings.CacheStablePrefix = true
+
+	messages := []Message{
+		{Role: "system", Content: "Long static instructions"},
+		{Role: "user", Content: "hi"},
+	}
+	result, err := provider.CreateChatCompletion(context.Background(), messages, settings)
+	require.NoError(t, err)
+	assert.Equal(t, 1920, result.Usage.CachedPromptTokens)
+
+	body := *lastBody
+	assert.Equal(t, "support-agent", body["prompt_cache_key"])
+	assert.Len(t, body["tools"], 1)
+
+	sent := body["messages"].([]any)
+	assert.Equal(t, []any{map[string]any{
+		"type":          "text",
+		"text":          "Long static instructions",
+		"cache_control": map[string]any{"type": "ephemeral"},
+	}}, sent[0].(map[string]any)["content"])
+	assert.Equal(t, "hi", sent[1].(map[string]any)["content"])
+
+	// Without cache settings the request is sent unchanged
+	_, err = provider.CreateChatCompletion(context.Background(), messages, DefaultSettings())
+	require.NoError(t, err)
+	assert.NotContains(t, *lastBody, "prompt_cache_key")
+	assert.Equal(t, "Long static instructions", (*lastBody)["messages"].([]any)[0].(map[string]any)["content"])
+}
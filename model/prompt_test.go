@@ -0,0 +1,63 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry := NewPromptRegistry()
+	registry.Register("pmpt_support", "1", "Help {{customer}} with {{ topic }}.")
+	registry.Register("pmpt_support", "2", "Help {{customer}} politely with {{topic}}.")
+
+	text, err := registry.ResolvePrompt(ctx, Prompt{
+		ID:        "pmpt_support",
+		Variables: map[string]string{"customer": "Alice", "topic": "billing"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Help Alice politely with billing.", text)
+
+	text, err = registry.ResolvePrompt(ctx, Prompt{
+		ID:        "pmpt_support",
+		Version:   "1",
+		Variables: map[string]string{"customer": "Alice"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Help Alice with {{ topic }}.", text)
+
+	_, err = registry.ResolvePrompt(ctx, Prompt{ID: "pmpt_support", Version: "3"})
+	assert.ErrorIs(t, err, ErrPromptNotFound)
+	_, err = registry.ResolvePrompt(ctx, Prompt{ID: "pmpt_other"})
+	assert.ErrorIs(t, err, ErrPromptNotFound)
+}
+
+func TestOpenAIProviderSendsPrompt(t *testing.T) {
+	server, _, lastBody := newTestServer(t, defaultChatResponse())
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	settings := DefaultSettings()
+	settings.Prompt = &Prompt{ID: "pmpt_123", Version: "2", Variables: map[string]string{"city": "Tokyo"}}
+
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"id":        "pmpt_123",
+		"version":   "2",
+		"variables": map[string]any{"city": "Tokyo"},
+	}, (*lastBody)["prompt"])
+
+	// Without a prompt the field is not sent
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, DefaultSettings())
+	require.NoError(t, err)
+	assert.NotContains(t, *lastBody, "prompt")
+}
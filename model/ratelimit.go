@@ -0,0 +1,271 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimit configures a RateLimiter. Zero values mean no limit.
+type RateLimit struct {
+	// RequestsPerMinute is the maximum number of requests per minute
+	RequestsPerMinute int
+
+	// TokensPerMinute is the maximum number of tokens (prompt and completion) per minute
+	TokensPerMinute int
+
+	// MaxConcurrent is the maximum number of requests in flight at the same time
+	MaxConcurrent int
+}
+
+// RateLimiter keeps model calls within requests-per-minute and tokens-per-minute budgets.
+// Share one limiter between all providers that use the same API key, for example across
+// concurrent runs, so that they wait for capacity instead of failing with 429 errors.
+//
+// Tokens are reserved before each call from an estimate of the prompt plus Settings.MaxTokens,
+// and the reservation is corrected with the usage reported in the response. Streams hold their
+// reservation until they end or are closed.
+// A RateLimiter is safe for concurrent use.
+type RateLimiter struct {
+	limit RateLimit
+
+	mu       sync.Mutex
+	requests float64
+	tokens   float64
+	updated  time.Time
+
+	slots chan struct{}
+	now   func() time.Time
+}
+
+var (
+	defaultRateLimiter   *RateLimiter
+	defaultRateLimiterMu sync.RWMutex
+)
+
+// NewRateLimiter creates a rate limiter that starts with its full per-minute budget available
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		limit:    limit,
+		requests: float64(limit.RequestsPerMinute),
+		tokens:   float64(limit.TokensPerMinute),
+		now:      time.Now,
+	}
+	l.updated = l.now()
+	if limit.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, limit.MaxConcurrent)
+	}
+	return l
+}
+
+// SetDefaultRateLimiter sets the rate limiter used by OpenAI providers without their own
+// OpenAIConfig.RateLimiter. Pass nil to remove it.
+func SetDefaultRateLimiter(l *RateLimiter) {
+	defaultRateLimiterMu.Lock()
+	defer defaultRateLimiterMu.Unlock()
+	defaultRateLimiter = l
+}
+
+// DefaultRateLimiter returns the rate limiter set with SetDefaultRateLimiter, or nil
+func DefaultRateLimiter() *RateLimiter {
+	defaultRateLimiterMu.RLock()
+	defer defaultRateLimiterMu.RUnlock()
+	return defaultRateLimiter
+}
+
+// Acquire waits until a request of the estimated number of tokens fits in the budget, or ctx is done.
+// The returned release function must be called when the request completes, with the tokens it
+// actually used (0 if unknown, which keeps the estimate).
+func (l *RateLimiter) Acquire(ctx context.Context, estimatedTokens int) (func(usedTokens int), error) {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	reserved, err := l.reserve(ctx, estimatedTokens)
+	if err != nil {
+		l.releaseSlot()
+		return nil, err
+	}
+
+	var once sync.Once
+	return func(usedTokens int) {
+		once.Do(func() {
+			if usedTokens > 0 && l.limit.TokensPerMinute > 0 {
+				l.mu.Lock()
+				l.tokens += reserved - float64(usedTokens)
+				l.mu.Unlock()
+			}
+			l.releaseSlot()
+		})
+	}, nil
+}
+
+// Middleware returns a middleware that makes every call of the wrapped provider wait for the limiter
+func (l *RateLimiter) Middleware() ProviderMiddleware {
+	return func(next Provider) Provider {
+		return ProviderFuncs{
+			Completion: func(ctx context.Context, messages []Message, settings Settings) (*Response, error) {
+				return limitCompletion(ctx, l, messages, settings, next.CreateChatCompletion)
+			},
+			Stream: func(ctx context.Context, messages []Message, settings Settings) (Stream, error) {
+				return limitStream(ctx, l, messages, settings, next.CreateChatCompletionStream)
+			},
+		}
+	}
+}
+
+// reserve waits until the request and its tokens fit in the budget and takes them from it.
+// It returns the number of tokens reserved.
+func (l *RateLimiter) reserve(ctx context.Context, estimatedTokens int) (float64, error) {
+	tokens := float64(estimatedTokens)
+	if budget := float64(l.limit.TokensPerMinute); budget > 0 && tokens > budget {
+		// A request larger than the whole budget would wait forever
+		tokens = budget
+	}
+
+	for {
+		l.mu.Lock()
+		l.refill()
+		wait := l.waitTime(tokens)
+		if wait == 0 {
+			if l.limit.RequestsPerMinute > 0 {
+				l.requests--
+			}
+			if l.limit.TokensPerMinute > 0 {
+				l.tokens -= tokens
+			}
+			l.mu.Unlock()
+			return tokens, nil
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// refill adds the budget accumulated since the last update; l.mu must be held
+func (l *RateLimiter) refill() {
+	now := l.now()
+	elapsed := now.Sub(l.updated).Minutes()
+	l.updated = now
+
+	if rpm := float64(l.limit.RequestsPerMinute); rpm > 0 {
+		l.requests = min(rpm, l.requests+elapsed*rpm)
+	}
+	if tpm := float64(l.limit.TokensPerMinute); tpm > 0 {
+		l.tokens = min(tpm, l.tokens+elapsed*tpm)
+	}
+}
+
+// waitTime returns how long to wait until a request of tokens fits in the budget; l.mu must be held
+func (l *RateLimiter) waitTime(tokens float64) time.Duration {
+	var wait time.Duration
+	if rpm := float64(l.limit.RequestsPerMinute); rpm > 0 && l.requests < 1 {
+		wait = max(wait, time.Duration((1-l.requests)/rpm*float64(time.Minute)))
+	}
+	if tpm := float64(l.limit.TokensPerMinute); tpm > 0 && l.tokens < tokens {
+		wait = max(wait, time.Duration((tokens-l.tokens)/tpm*float64(time.Minute)))
+	}
+	if wait > 0 && wait < time.Millisecond {
+		wait = time.Millisecond
+	}
+	return wait
+}
+
+// releaseSlot frees a concurrency slot
+func (l *RateLimiter) releaseSlot() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// limitCompletion calls complete once the limiter has capacity and corrects the reservation with the usage
+func limitCompletion(ctx context.Context, l *RateLimiter, messages []Message, settings Settings,
+	complete func(ctx context.Context, messages []Message, settings Settings) (*Response, error)) (*Response, error) {
+	release, err := l.Acquire(ctx, estimateRequestTokens(messages, settings))
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := complete(ctx, messages, settings)
+	used := 0
+	if response != nil {
+		used = response.Usage.TotalTokens
+	}
+	release(used)
+
+	return response, err
+}
+
+// limitStream opens a stream once the limiter has capacity. The reservation, with its concurrency
+// slot, is held until the stream ends or is closed, and is corrected with the usage the stream reports.
+func limitStream(ctx context.Context, l *RateLimiter, messages []Message, settings Settings,
+	open func(ctx context.Context, messages []Message, settings Settings) (Stream, error)) (Stream, error) {
+	release, err := l.Acquire(ctx, estimateRequestTokens(messages, settings))
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := open(ctx, messages, settings)
+	if err != nil {
+		release(0)
+		return nil, err
+	}
+	return &limitedStream{Stream: stream, release: release}, nil
+}
+
+// limitedStream releases a rate limiter reservation when the stream ends or is closed
+type limitedStream struct {
+	Stream
+	release func(usedTokens int)
+	used    atomic.Int64
+}
+
+func (s *limitedStream) Recv() (*StreamChunk, error) {
+	chunk, err := s.Stream.Recv()
+	if chunk != nil && chunk.Usage != nil {
+		s.used.Store(int64(chunk.Usage.TotalTokens))
+	}
+	if err != nil {
+		// The stream ended, at EOF or on a failure
+		s.release(int(s.used.Load()))
+	}
+	return chunk, err
+}
+
+func (s *limitedStream) Close() error {
+	err := s.Stream.Close()
+	s.release(int(s.used.Load()))
+	return err
+}
+
+// estimateRequestTokens estimates the tokens of a request: the prompt at four characters per token
+// plus the completion budget
+func estimateRequestTokens(messages []Message, settings Settings) int {
+	chars := 0
+	for _, msg := range messages {
+		chars += len(msg.Content)
+		for _, call := range msg.ToolCalls {
+			chars += len(call.Function.Name) + len(call.Function.Arguments)
+		}
+		for _, part := range msg.ContentParts {
+			chars += len(part.Text)
+		}
+	}
+	return chars/4 + len(messages)*4 + settings.MaxTokens
+}
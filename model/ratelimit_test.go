@@ -0,0 +1,220 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRateLimiter creates a rate limiter with a clock that only moves when advanced
+func newTestRateLimiter(limit RateLimit) (*RateLimiter, func(time.Duration)) {
+	now := time.Now()
+	l := NewRateLimiter(limit)
+	l.now = func() time.Time { return now }
+	l.updated = now
+
+	advance := func(d time.Duration) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		now = now.Add(d)
+	}
+	return l, advance
+}
+
+func acquireWithin(l *RateLimiter, tokens int, timeout time.Duration) (func(int), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return l.Acquire(ctx, tokens)
+}
+
+func TestRateLimiterRequestsPerMinute(t *testing.T) {
+	l, advance := newTestRateLimiter(RateLimit{RequestsPerMinute: 2})
+
+	for i := 0; i < 2; i++ {
+		release, err := acquireWithin(l, 0, 10*time.Millisecond)
+		require.NoError(t, err)
+		release(0)
+	}
+
+	_, err := acquireWithin(l, 0, 10*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Half a minute refills one request
+	advance(30 * time.Second)
+	_, err = acquireWithin(l, 0, 10*time.Millisecond)
+	assert.NoError(t, err)
+}
+
+func TestRateLimiterTokensPerMinute(t *testing.T) {
+	l, _ := newTestRateLimiter(RateLimit{TokensPerMinute: 1000})
+
+	release, err := acquireWithin(l, 600, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	_, err = acquireWithin(l, 600, 10*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The request used fewer tokens than estimated, so the rest is returned to the budget
+	release(100)
+	_, err = acquireWithin(l, 800, 10*time.Millisecond)
+	assert.NoError(t, err)
+}
+
+func TestRateLimiterMaxConcurrent(t *testing.T) {
+	l, _ := newTestRateLimiter(RateLimit{MaxConcurrent: 1})
+
+	release, err := acquireWithin(l, 0, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	_, err = acquireWithin(l, 0, 10*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release(0)
+	release(0) // Releasing twice has no effect
+	release, err = acquireWithin(l, 0, 10*time.Millisecond)
+	require.NoError(t, err)
+	release(0)
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	l, _ := newTestRateLimiter(RateLimit{TokensPerMinute: 1000})
+	base := ProviderFuncs{
+		Completion: func(ctx context.Context, messages []Message, settings Settings) (*Response, error) {
+			return &Response{Usage: Usage{TotalTokens: 50}}, nil
+		},
+	}
+	provider := WithMiddleware(base, l.Middleware())
+
+	settings := DefaultSettings()
+	settings.MaxTokens = 900
+	_, err := provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+
+	// Only the 50 tokens reported in the usage are taken from the budget
+	l.mu.Lock()
+	assert.InDelta(t, 950, l.tokens, 0.01)
+	l.mu.Unlock()
+}
+
+func TestOpenAIProviderRateLimiter(t *testing.T) {
+	server, _, _ := newTestServer(t, defaultChatResponse())
+	l, _ := newTestRateLimiter(RateLimit{RequestsPerMinute: 1})
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL, RateLimiter: l})
+	require.NoError(t, err)
+
+	messages := []Message{{Role: "user", Content: "hi"}}
+	_, err = provider.CreateChatCompletion(context.Background(), messages, DefaultSettings())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = provider.CreateChatCompletion(ctx, messages, DefaultSettings())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// chunkStream streams the given chunks, then io.EOF
+type chunkStream struct {
+	chunks []*StreamChunk
+	closed bool
+}
+
+func (s *chunkStream) Recv() (*StreamChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *chunkStream) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestRateLimiterMiddlewareStream(t *testing.T) {
+	l, _ := newTestRateLimiter(RateLimit{TokensPerMinute: 1000, MaxConcurrent: 1})
+	base := ProviderFuncs{
+		Stream: func(ctx context.Context, messages []Message, settings Settings) (Stream, error) {
+			return &chunkStream{chunks: []*StreamChunk{
+				{Delta: Message{Role: "assistant", Content: "hi"}},
+				{Usage: &Usage{TotalTokens: 50}},
+			}}, nil
+		},
+	}
+	provider := WithMiddleware(base, l.Middleware())
+
+	settings := DefaultSettings()
+	settings.MaxTokens = 900
+	stream, err := provider.CreateChatCompletionStream(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+
+	// The concurrency slot is held while the stream is open
+	_, err = acquireWithin(l, 0, 10*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// At the end of the stream, the slot is released and only the reported usage is taken from the budget
+	response, err := CollectStream(stream)
+	require.NoError(t, err)
+	assert.Equal(t, 50, response.Usage.TotalTokens)
+	l.mu.Lock()
+	assert.InDelta(t, 950, l.tokens, 0.01)
+	l.mu.Unlock()
+	release, err := acquireWithin(l, 0, 10*time.Millisecond)
+	require.NoError(t, err)
+	release(0)
+
+	// A stream closed before its end releases the slot too, keeping the estimate
+	stream, err = provider.CreateChatCompletionStream(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+	_, err = acquireWithin(l, 0, 10*time.Millisecond)
+	assert.NoError(t, err)
+}
+
+func TestOpenAIProviderRateLimiterStream(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"id\":\"chatcmpl-1\",\"object\":\"chat.completion.chunk\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"hi\"},\"finish_reason\":\"stop\"}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"chatcmpl-1\",\"object\":\"chat.completion.chunk\",\"choices\":[],\"usage\":{\"prompt_tokens\":8,\"completion_tokens\":2,\"total_tokens\":10}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	l, _ := newTestRateLimiter(RateLimit{TokensPerMinute: 1000, MaxConcurrent: 1})
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL, RateLimiter: l})
+	require.NoError(t, err)
+
+	stream, err := provider.CreateChatCompletionStream(context.Background(), []Message{{Role: "user", Content: "hi"}}, DefaultSettings())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"include_usage": true}, body["stream_options"])
+
+	_, err = acquireWithin(l, 0, 10*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	response, err := CollectStream(stream)
+	require.NoError(t, err)
+	assert.Equal(t, "hi", response.Message.Content)
+	assert.Equal(t, 10, response.Usage.TotalTokens)
+	l.mu.Lock()
+	assert.InDelta(t, 990, l.tokens, 0.01)
+	l.mu.Unlock()
+	_, err = acquireWithin(l, 0, 10*time.Millisecond)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// reasoningModelPrefixes are the prefixes of OpenAI reasoning model names
+var reasoningModelPrefixes = []string{"o1", "o3", "o4", "gpt-5"}
+
+// IsReasoningModel reports whether the model name is an OpenAI reasoning model
+func IsReasoningModel(name string) bool {
+	for _, prefix := range reasoningModelPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyReasoningSettings adapts a request to reasoning models, which reject max_tokens and
+// sampling parameters other than their defaults
+func applyReasoningSettings(request *openai.ChatCompletionRequest, settings Settings) {
+	request.ReasoningEffort = settings.ReasoningEffort
+
+	if !usesReasoningParameters(request.Model, settings) {
+		return
+	}
+
+	request.MaxCompletionTokens = request.MaxTokens
+	request.MaxTokens = 0
+	request.Temperature = 0
+	request.TopP = 0
+	request.FrequencyPenalty = 0
+	request.PresencePenalty = 0
+}
+
+// usesReasoningParameters reports whether a request to the model is sent with the parameters of
+// reasoning models, which take max_completion_tokens and no sampling settings
+func usesReasoningParameters(modelName string, settings Settings) bool {
+	return IsReasoningModel(modelName) || settings.ReasoningEffort != ""
+}
+
+// reasoningEdit returns the request body edit for settings the API client does not support, or nil
+func reasoningEdit(settings Settings) func(body map[string]any) {
+	if settings.Verbosity == "" {
+		return nil
+	}
+
+	return func(body map[string]any) {
+		body["verbosity"] = settings.Verbosity
+	}
+}
+
+// reasoningFromResponse extracts the reasoning of the first choice from a raw Chat Completions response.
+// OpenAI-compatible servers return it as reasoning_content (DeepSeek, vLLM, LiteLLM) or reasoning (OpenRouter).
+func reasoningFromResponse(body []byte) string {
+	var response struct {
+		Choices []struct {
+			Message struct {
+				ReasoningContent string `json:"reasoning_content"`
+				Reasoning        string `json:"reasoning"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || len(response.Choices) == 0 {
+		return ""
+	}
+
+	message := response.Choices[0].Message
+	if message.ReasoningContent != "" {
+		return message.ReasoningContent
+	}
+	return message.Reasoning
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReasoningSettings(t *testing.T) {
+	response := defaultChatResponse()
+	response["choices"].([]any)[0].(map[string]any)["message"].(map[string]any)["reasoning_content"] = "The user greeted me."
+	server, _, lastBody := newTestServer(t, response)
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	settings := DefaultSettings().Resolve(Settings{ReasoningEffort: "high", Verbosity: "low"})
+	settings.Model = "o3-mini"
+
+	result, err := provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	assert.Equal(t, "The user greeted me.", result.Message.Reasoning)
+
+	body := *lastBody
+	assert.Equal(t, "high", body["reasoning_effort"])
+	assert.Equal(t, "low", body["verbosity"])
+	assert.EqualValues(t, 1024, body["max_completion_tokens"])
+	assert.NotContains(t, body, "max_tokens")
+	assert.NotContains(t, body, "temperature")
+
+	// Other models keep their sampling parameters
+	settings = DefaultSettings()
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1024, (*lastBody)["max_tokens"])
+	assert.Contains(t, *lastBody, "temperature")
+	assert.NotContains(t, *lastBody, "reasoning_effort")
+}
+
+func TestSettingsResolve(t *testing.T) {
+	base := DefaultSettings()
+	base.Custom["tool_choice"] = "auto"
+
+	resolved := base.Resolve(Settings{
+		MaxTokens:       4096,
+		ReasoningEffort: "low",
+		Custom:          map[string]any{"model": "o4-mini"},
+	})
+
+	assert.Equal(t, 0.7, resolved.Temperature)
+	assert.Equal(t, 4096, resolved.MaxTokens)
+	assert.Equal(t, "low", resolved.ReasoningEffort)
+	assert.Equal(t, map[string]any{"tool_choice": "auto", "model": "o4-mini"}, resolved.Custom)
+	assert.Equal(t, map[string]any{"tool_choice": "auto"}, base.Custom)
+}
+
+func TestZeroSamplingSettings(t *testing.T) {
+	// A 0 is left to the default unless the sampling settings are explicit
+	assert.Equal(t, 0.7, DefaultSettings().Resolve(Settings{Temperature: 0}).Temperature)
+	settings := DefaultSettings().Resolve(Settings{TopP: 1.0, ExplicitSampling: true})
+	assert.Zero(t, settings.Temperature)
+	assert.Equal(t, 1.0, settings.TopP)
+
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Numbers are kept as written, to tell a literal 0 from a missing field
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		body = nil
+		_ = decoder.Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(defaultChatResponse())
+	}))
+	t.Cleanup(server.Close)
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	// An explicit 0 is sent as a literal 0
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	require.Contains(t, body, "temperature")
+	assert.Equal(t, json.Number("0"), body["temperature"])
+	require.Contains(t, body, "presence_penalty")
+	assert.Equal(t, json.Number("0"), body["presence_penalty"])
+	assert.Equal(t, json.Number("1"), body["top_p"])
+	require.Contains(t, body, "frequency_penalty")
+
+	// Without ExplicitSampling, a 0 is not sent
+	settings.ExplicitSampling = false
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	assert.NotContains(t, body, "temperature")
+	assert.NotContains(t, body, "presence_penalty")
+	settings.ExplicitSampling = true
+
+	// Reasoning models take no sampling settings, not even an explicit 0
+	settings.Model = "o3-mini"
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+	require.NotNil(t, body)
+	assert.NotContains(t, body, "temperature")
+	assert.NotContains(t, body, "presence_penalty")
+	assert.NotContains(t, body, "top_p")
+}
+
+func TestDeprecatedCustomSettings(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Custom["model"] = "gpt-4o-mini"
+	settings.Custom["tool_choice"] = "force_get_weather"
+	settings.Custom["tools"] = []map[string]any{{"type": "function", "function": map[string]any{"name": "get_weather"}}}
+
+	assert.Equal(t, "gpt-4o-mini", settings.EffectiveModel())
+	assert.Equal(t, ToolChoiceFunction("get_weather"), settings.EffectiveToolChoice())
+	assert.Equal(t, "get_weather", settings.EffectiveToolChoice().FunctionName())
+	assert.Equal(t, []ToolDefinition{NewFunctionTool("get_weather", "", nil)}, settings.EffectiveTools())
+
+	// The typed fields take precedence
+	settings = settings.Resolve(Settings{Model: "gpt-4o", ToolChoice: ToolChoiceNone})
+	assert.Equal(t, "gpt-4o", settings.EffectiveModel())
+	assert.Equal(t, ToolChoiceNone, settings.EffectiveToolChoice())
+	assert.Empty(t, ToolChoiceNone.FunctionName())
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"errors"
+	"io"
+)
+
+// StreamAccumulator assembles the chunks of a stream into the complete message. Content,
+// reasoning and refusal deltas are concatenated, and tool call deltas are merged by index, or
+// by ID for providers that do not send indexes, so the arguments of each call are complete.
+//
+//	acc := model.NewStreamAccumulator()
+//	for {
+//		chunk, err := stream.Recv()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//		acc.Add(chunk)
+//	}
+//	message := acc.Message()
+type StreamAccumulator struct {
+	message      Message
+	finishReason string
+
+	// indexes maps the stream index of each tool call to its position in message.ToolCalls
+	indexes map[int]int
+}
+
+// NewStreamAccumulator creates an empty StreamAccumulator
+func NewStreamAccumulator() *StreamAccumulator {
+	return &StreamAccumulator{indexes: make(map[int]int)}
+}
+
+// Add merges a chunk into the message
+func (a *StreamAccumulator) Add(chunk *StreamChunk) {
+	if chunk == nil {
+		return
+	}
+	delta := chunk.Delta
+	if delta.Role != "" {
+		a.message.Role = delta.Role
+	}
+	a.message.Content += delta.Content
+	a.message.Reasoning += delta.Reasoning
+	a.message.Refusal += delta.Refusal
+	a.message.ContentParts = append(a.message.ContentParts, delta.ContentParts...)
+	for _, tc := range delta.ToolCalls {
+		a.addToolCall(tc)
+	}
+	if chunk.FinishReason != "" {
+		a.finishReason = chunk.FinishReason
+	}
+}
+
+// addToolCall merges a tool call delta into the call it continues, or starts a new call
+func (a *StreamAccumulator) addToolCall(delta ToolCall) {
+	position := -1
+	switch {
+	case delta.Index != nil:
+		if p, ok := a.indexes[*delta.Index]; ok {
+			position = p
+		}
+	case delta.ID != "":
+		for i, tc := range a.message.ToolCalls {
+			if tc.ID == delta.ID {
+				position = i
+			}
+		}
+	default:
+		// A fragment without index or ID continues the last call
+		position = len(a.message.ToolCalls) - 1
+	}
+
+	if position < 0 {
+		a.message.ToolCalls = append(a.message.ToolCalls, ToolCall{Type: "function"})
+		position = len(a.message.ToolCalls) - 1
+		if delta.Index != nil {
+			a.indexes[*delta.Index] = position
+		}
+	}
+
+	call := &a.message.ToolCalls[position]
+	if delta.ID != "" {
+		call.ID = delta.ID
+	}
+	if delta.Type != "" {
+		call.Type = delta.Type
+	}
+	call.Function.Name += delta.Function.Name
+	call.Function.Arguments += delta.Function.Arguments
+}
+
+// Message returns the message assembled so far
+func (a *StreamAccumulator) Message() Message {
+	message := a.message
+	message.ToolCalls = append([]ToolCall(nil), a.message.ToolCalls...)
+	message.ContentParts = append([]ContentPart(nil), a.message.ContentParts...)
+	if message.Role == "" {
+		message.Role = "assistant"
+	}
+	return message
+}
+
+// FinishReason returns the finish reason of the stream, or "" if it has not finished
+func (a *StreamAccumulator) FinishReason() string {
+	return a.finishReason
+}
+
+// CollectStream reads a stream to the end, closes it and returns the assembled response, with the
+// usage if the stream reports it.
+// On error, the response holds the message received before the error.
+func CollectStream(stream Stream) (*Response, error) {
+	defer stream.Close()
+
+	acc := NewStreamAccumulator()
+	var usage Usage
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return &Response{Message: acc.Message(), Usage: usage}, nil
+		}
+		if err != nil {
+			return &Response{Message: acc.Message(), Usage: usage}, err
+		}
+		if chunk != nil && chunk.Usage != nil {
+			usage = *chunk.Usage
+		}
+		acc.Add(chunk)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamAccumulator(t *testing.T) {
+	index := func(i int) *int { return &i }
+
+	acc := NewStreamAccumulator()
+	for _, chunk := range []*StreamChunk{
+		{Delta: Message{Role: "assistant", Content: "Checking "}},
+		{Delta: Message{Content: "both."}},
+		{Delta: Message{ToolCalls: []ToolCall{{ID: "call_1", Index: index(0), Function: FunctionCall{Name: "get_weather"}}}}},
+		{Delta: Message{ToolCalls: []ToolCall{{ID: "call_2", Index: index(1), Function: FunctionCall{Name: "get_time"}}}}},
+		{Delta: Message{ToolCalls: []ToolCall{{Index: index(0), Function: FunctionCall{Arguments: `{"city":`}}}}},
+		{Delta: Message{ToolCalls: []ToolCall{{Index: index(1), Function: FunctionCall{Arguments: `{}`}}}}},
+		{Delta: Message{ToolCalls: []ToolCall{{Index: index(0), Function: FunctionCall{Arguments: `"Tokyo"}`}}}}},
+	} {
+		acc.Add(chunk)
+	}
+	assert.Empty(t, acc.FinishReason())
+	acc.Add(&StreamChunk{FinishReason: "tool_calls"})
+
+	message := acc.Message()
+	assert.Equal(t, "assistant", message.Role)
+	assert.Equal(t, "Checking both.", message.Content)
+	assert.Equal(t, []ToolCall{
+		{ID: "call_1", Type: "function", Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Tokyo"}`}},
+		{ID: "call_2", Type: "function", Function: FunctionCall{Name: "get_time", Arguments: `{}`}},
+	}, message.ToolCalls)
+	assert.Equal(t, "tool_calls", acc.FinishReason())
+
+	// Without indexes, deltas are merged by ID, and fragments continue the last call
+	acc = NewStreamAccumulator()
+	acc.Add(&StreamChunk{Delta: Message{ToolCalls: []ToolCall{{ID: "call_1", Function: FunctionCall{Name: "search", Arguments: `{"q":`}}}}})
+	acc.Add(&StreamChunk{Delta: Message{ToolCalls: []ToolCall{{Function: FunctionCall{Arguments: `"go"}`}}}}})
+	acc.Add(&StreamChunk{Delta: Message{ToolCalls: []ToolCall{{ID: "call_2", Function: FunctionCall{Name: "noop", Arguments: `{}`}}}}})
+	calls := acc.Message().ToolCalls
+	require.Len(t, calls, 2)
+	assert.Equal(t, `{"q":"go"}`, calls[0].Function.Arguments)
+	assert.Equal(t, "call_2", calls[1].ID)
+}
+
+func TestCollectOpenAIStream(t *testing.T) {
+	chunks := []string{
+		`{"role":"assistant","tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}`,
+		`{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":"}}]}`,
+		`{"tool_calls":[{"index":0,"function":{"arguments":"\"Paris\"}"}}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, delta := range chunks {
+			fmt.Fprintf(w, "data: {\"id\":\"chatcmpl-1\",\"object\":\"chat.completion.chunk\",\"choices\":[{\"index\":0,\"delta\":%s}]}\n\n", delta)
+		}
+		fmt.Fprint(w, "data: {\"id\":\"chatcmpl-1\",\"object\":\"chat.completion.chunk\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"tool_calls\"}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+	stream, err := provider.CreateChatCompletionStream(context.Background(), []Message{{Role: "user", Content: "weather?"}}, DefaultSettings())
+	require.NoError(t, err)
+
+	response, err := CollectStream(stream)
+	require.NoError(t, err)
+	require.Len(t, response.Message.ToolCalls, 1)
+	call := response.Message.ToolCalls[0]
+	assert.Equal(t, "call_1", call.ID)
+	assert.Equal(t, "get_weather", call.Function.Name)
+	assert.Equal(t, `{"city":"Paris"}`, call.Function.Arguments)
+	assert.Nil(t, call.Index)
+}
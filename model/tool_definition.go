@@ -0,0 +1,127 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ToolType is the type of a tool offered to the model
+type ToolType string
+
+// ToolTypeFunction is a function tool, run by the caller
+const ToolTypeFunction ToolType = "function"
+
+// ToolDefinition describes a tool offered to the model, independently of the wire format of a
+// provider. Function tools have a name, a description and a JSON schema for their arguments.
+// Other types are hosted tools run by the provider, such as "web_search_preview"; their settings
+// are in Options, and providers that do not support them skip them.
+type ToolDefinition struct {
+	// Type is the type of the tool (defaults to ToolTypeFunction)
+	Type ToolType `json:"type,omitempty"`
+
+	// Name is the name the model calls the tool by
+	Name string `json:"name,omitempty"`
+
+	// Description tells the model what the tool does
+	Description string `json:"description,omitempty"`
+
+	// Parameters is the JSON schema of the arguments of a function tool
+	Parameters map[string]any `json:"parameters,omitempty"`
+
+	// Strict requires the arguments to match Parameters exactly (structured outputs)
+	Strict bool `json:"strict,omitempty"`
+
+	// Options are the settings of a hosted tool, sent as is next to its type
+	Options map[string]any `json:"options,omitempty"`
+}
+
+// NewFunctionTool returns the definition of a function tool
+func NewFunctionTool(name string, description string, parameters map[string]any) ToolDefinition {
+	return ToolDefinition{Type: ToolTypeFunction, Name: name, Description: description, Parameters: parameters}
+}
+
+// IsFunction reports whether the tool is a function tool
+func (d ToolDefinition) IsFunction() bool {
+	return d.Type == "" || d.Type == ToolTypeFunction
+}
+
+// ToolDefinitionFromMap converts a tool in the Chat Completions format, such as
+// {"type": "function", "function": {"name": ..., "parameters": ...}}, to a ToolDefinition
+func ToolDefinitionFromMap(definition map[string]any) (ToolDefinition, error) {
+	toolType, ok := definition["type"].(string)
+	if !ok {
+		return ToolDefinition{}, errors.New("invalid tool type")
+	}
+	if toolType != string(ToolTypeFunction) {
+		options := make(map[string]any, len(definition))
+		for k, v := range definition {
+			if k != "type" {
+				options[k] = v
+			}
+		}
+		return ToolDefinition{Type: ToolType(toolType), Options: options}, nil
+	}
+
+	function, ok := definition["function"].(map[string]any)
+	if !ok {
+		return ToolDefinition{}, errors.New("invalid function definition")
+	}
+	d := ToolDefinition{Type: ToolTypeFunction}
+	if d.Name, ok = function["name"].(string); !ok {
+		return ToolDefinition{}, errors.New("function name is required")
+	}
+	d.Description, _ = function["description"].(string)
+	d.Parameters, _ = function["parameters"].(map[string]any)
+	d.Strict, _ = function["strict"].(bool)
+	return d, nil
+}
+
+// ToolDefinitionsFromMaps converts tools in the Chat Completions format to ToolDefinitions
+func ToolDefinitionsFromMaps(definitions []map[string]any) ([]ToolDefinition, error) {
+	result := make([]ToolDefinition, 0, len(definitions))
+	for i, definition := range definitions {
+		d, err := ToolDefinitionFromMap(definition)
+		if err != nil {
+			return nil, fmt.Errorf("tool %d: %w", i, err)
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// Map returns the tool in the Chat Completions format
+func (d ToolDefinition) Map() map[string]any {
+	if !d.IsFunction() {
+		definition := make(map[string]any, len(d.Options)+1)
+		for k, v := range d.Options {
+			definition[k] = v
+		}
+		definition["type"] = string(d.Type)
+		return definition
+	}
+
+	function := map[string]any{
+		"name":        d.Name,
+		"description": d.Description,
+		"parameters":  d.functionParameters(),
+	}
+	if d.Strict {
+		function["strict"] = true
+	}
+	return map[string]any{"type": string(ToolTypeFunction), "function": function}
+}
+
+// functionParameters returns the parameters of a function tool, an empty object schema if unset
+func (d ToolDefinition) functionParameters() map[string]any {
+	if d.Parameters != nil {
+		return d.Parameters
+	}
+	return map[string]any{
+		"type":       "object",
+		"properties": map[string]any{},
+	}
+}
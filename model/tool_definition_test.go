@@ -0,0 +1,53 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolDefinitionMap(t *testing.T) {
+	parameters := map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}}
+	definition := NewFunctionTool("get_weather", "Gets the weather", parameters)
+	definition.Strict = true
+
+	converted, err := ToolDefinitionFromMap(definition.Map())
+	require.NoError(t, err)
+	assert.Equal(t, definition, converted)
+
+	hosted := ToolDefinition{Type: "web_search_preview", Options: map[string]any{"search_context_size": "low"}}
+	assert.Equal(t, map[string]any{"type": "web_search_preview", "search_context_size": "low"}, hosted.Map())
+	converted, err = ToolDefinitionFromMap(hosted.Map())
+	require.NoError(t, err)
+	assert.Equal(t, hosted, converted)
+	assert.False(t, converted.IsFunction())
+
+	_, err = ToolDefinitionsFromMaps([]map[string]any{{"type": "function"}})
+	assert.EqualError(t, err, "tool 0: invalid function definition")
+}
+
+func TestOpenAIProviderLegacyCustomTools(t *testing.T) {
+	server, _, lastBody := newTestServer(t, defaultChatResponse())
+	provider, err := NewOpenAIProvider(OpenAIConfig{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	settings := DefaultSettings()
+	settings.Custom["tools"] = []map[string]any{{
+		"type":     "function",
+		"function": map[string]any{"name": "lookup"},
+	}}
+	_, err = provider.CreateChatCompletion(context.Background(), []Message{{Role: "user", Content: "hi"}}, settings)
+	require.NoError(t, err)
+
+	tools := (*lastBody)["tools"].([]any)
+	require.Len(t, tools, 1)
+	function := tools[0].(map[string]any)["function"].(map[string]any)
+	assert.Equal(t, "lookup", function["name"])
+	assert.Equal(t, map[string]any{"type": "object", "properties": map[string]any{}}, function["parameters"])
+}
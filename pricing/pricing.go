@@ -0,0 +1,128 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package pricing estimates the cost of model calls from their token usage. A Table maps model
+// names to per-token prices; the built-in prices of OpenAI's models can be overridden or extended
+// with Register, or per run with a table of its own.
+package pricing
+
+import (
+	"strings"
+	"sync"
+)
+
+// Price is the price of a model in US dollars per million tokens
+type Price struct {
+	// Input is the price of prompt tokens
+	Input float64 `json:"input"`
+
+	// CachedInput is the price of prompt tokens served from the prompt cache (optional, defaults to Input)
+	CachedInput float64 `json:"cached_input,omitempty"`
+
+	// Output is the price of completion tokens, including reasoning tokens
+	Output float64 `json:"output"`
+}
+
+// Cost returns the cost of a model call in US dollars. The cached prompt tokens are part of the
+// prompt tokens.
+func (p Price) Cost(promptTokens, cachedPromptTokens, completionTokens int) float64 {
+	cachedPrice := p.CachedInput
+	if cachedPrice == 0 {
+		cachedPrice = p.Input
+	}
+	uncached := promptTokens - cachedPromptTokens
+	return (float64(uncached)*p.Input + float64(cachedPromptTokens)*cachedPrice + float64(completionTokens)*p.Output) / 1_000_000
+}
+
+// Table maps model names to prices. Names match dated snapshots of the model too, so "gpt-4o"
+// prices "gpt-4o-2024-08-06".
+type Table map[string]Price
+
+// Lookup returns the price of a model. Provider prefixes such as "openai/" are ignored, and the
+// longest name that is the model or a prefix of it followed by "-" wins.
+func (t Table) Lookup(modelName string) (Price, bool) {
+	if i := strings.LastIndex(modelName, "/"); i >= 0 {
+		modelName = modelName[i+1:]
+	}
+	if price, ok := t[modelName]; ok {
+		return price, true
+	}
+
+	var best string
+	for name := range t {
+		if len(name) > len(best) && strings.HasPrefix(modelName, name+"-") {
+			best = name
+		}
+	}
+	if best == "" {
+		return Price{}, false
+	}
+	return t[best], true
+}
+
+var (
+	// defaultTable holds the built-in prices (standard tier, as published by OpenAI)
+	defaultTable = Table{
+		"gpt-4o":        {Input: 2.50, CachedInput: 1.25, Output: 10.00},
+		"gpt-4o-mini":   {Input: 0.15, CachedInput: 0.075, Output: 0.60},
+		"gpt-4.1":       {Input: 2.00, CachedInput: 0.50, Output: 8.00},
+		"gpt-4.1-mini":  {Input: 0.40, CachedInput: 0.10, Output: 1.60},
+		"gpt-4.1-nano":  {Input: 0.10, CachedInput: 0.025, Output: 0.40},
+		"gpt-4-turbo":   {Input: 10.00, Output: 30.00},
+		"gpt-3.5-turbo": {Input: 0.50, Output: 1.50},
+		"o1":            {Input: 15.00, CachedInput: 7.50, Output: 60.00},
+		"o1-mini":       {Input: 1.10, CachedInput: 0.55, Output: 4.40},
+		"o3":            {Input: 2.00, CachedInput: 0.50, Output: 8.00},
+		"o3-mini":       {Input: 1.10, CachedInput: 0.55, Output: 4.40},
+		"o4-mini":       {Input: 1.10, CachedInput: 0.275, Output: 4.40},
+	}
+	defaultTableMu sync.RWMutex
+)
+
+// Register sets the price of a model in the default table, replacing the built-in price
+func Register(modelName string, price Price) {
+	defaultTableMu.Lock()
+	defer defaultTableMu.Unlock()
+	defaultTable[modelName] = price
+}
+
+// DefaultTable returns a copy of the default table
+func DefaultTable() Table {
+	defaultTableMu.RLock()
+	defer defaultTableMu.RUnlock()
+	table := make(Table, len(defaultTable))
+	for name, price := range defaultTable {
+		table[name] = price
+	}
+	return table
+}
+
+// Lookup returns the price of a model in the given tables, in order, and then in the default table
+func Lookup(modelName string, tables ...Table) (Price, bool) {
+	for _, table := range tables {
+		if price, ok := table.Lookup(modelName); ok {
+			return price, true
+		}
+	}
+	defaultTableMu.RLock()
+	defer defaultTableMu.RUnlock()
+	return defaultTable.Lookup(modelName)
+}
+
+// Cost returns the cost of a model call with the price of Lookup, or 0 when the model has no price
+func Cost(modelName string, promptTokens, cachedPromptTokens, completionTokens int, tables ...Table) float64 {
+	price, ok := Lookup(modelName, tables...)
+	if !ok {
+		return 0
+	}
+	return price.Cost(promptTokens, cachedPromptTokens, completionTokens)
+}
+
+// CostFunc returns a function computing costs with the tables and the default table, for
+// analytics.WithCostFunc
+func CostFunc(tables ...Table) func(modelName string, promptTokens int, completionTokens int) float64 {
+	return func(modelName string, promptTokens int, completionTokens int) float64 {
+		return Cost(modelName, promptTokens, 0, completionTokens, tables...)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	price, ok := Lookup("gpt-4o")
+	assert.True(t, ok)
+	assert.Equal(t, 2.50, price.Input)
+
+	// Dated snapshots and provider prefixes use the price of the longest matching name
+	price, ok = Lookup("openai/gpt-4o-mini-2024-07-18")
+	assert.True(t, ok)
+	assert.Equal(t, 0.15, price.Input)
+
+	_, ok = Lookup("gpt-4omni")
+	assert.False(t, ok)
+
+	// The given tables come before the default table
+	custom := Table{"gpt-4o": {Input: 1, Output: 2}}
+	price, ok = Lookup("gpt-4o-2024-08-06", custom)
+	assert.True(t, ok)
+	assert.Equal(t, Price{Input: 1, Output: 2}, price)
+}
+
+func TestCost(t *testing.T) {
+	price := Price{Input: 2, CachedInput: 1, Output: 8}
+	assert.InDelta(t, 2.0*0.5+1.0*0.5+8.0*0.25, price.Cost(1_000_000, 500_000, 250_000), 1e-9)
+
+	// Cached tokens default to the input price
+	assert.InDelta(t, 2.0, Price{Input: 2}.Cost(1_000_000, 500_000, 0), 1e-9)
+
+	assert.Zero(t, Cost("unknown-model", 1000, 0, 1000))
+
+	Register("my-model", Price{Input: 1, Output: 1})
+	t.Cleanup(func() {
+		defaultTableMu.Lock()
+		delete(defaultTable, "my-model")
+		defaultTableMu.Unlock()
+	})
+	assert.InDelta(t, 2.0, CostFunc()("my-model", 1_000_000, 1_000_000), 1e-9)
+	assert.Contains(t, DefaultTable(), "my-model")
+}
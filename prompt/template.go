@@ -0,0 +1,228 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package prompt builds agent instructions from text/template templates, with helper functions
+// and partials for the parts most system prompts repeat: handoff instructions, tool usage
+// guidance and the current date and time.
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RecommendedPromptPrefix is the system context recommended for agents that take part in handoffs
+const RecommendedPromptPrefix = "# System context\n" +
+	"You are part of a multi-agent system called the Agents SDK, designed to make agent coordination " +
+	"and execution easy. Agents uses two primary abstractions: **Agents** and **Handoffs**. An agent " +
+	"encompasses instructions and tools and can hand off a conversation to another agent when " +
+	"appropriate. Handoffs are achieved by calling a handoff function, generally named " +
+	"`transfer_to_<agent_name>`. Transfers between agents are handled seamlessly in the background; " +
+	"do not mention or draw attention to these transfers in your conversation with the user.\n"
+
+// WithHandoffInstructions prepends RecommendedPromptPrefix to the instructions
+func WithHandoffInstructions(instructions string) string {
+	return RecommendedPromptPrefix + "\n" + instructions
+}
+
+// partials are the templates every Template can include with {{template "name" .}}
+const partials = `
+{{- define "handoff_instructions"}}` + "{{handoffPrefix}}" + `
+{{- if .Agent.Handoffs}}
+You can transfer the conversation to these agents:
+{{- range .Agent.Handoffs}}
+- {{.Name}}: {{.Description}}
+{{- end}}
+{{end}}
+{{- end}}
+
+{{- define "tool_guidance"}}
+{{- if .Agent.Tools}}# Tools
+Use the tools when they help answer the request. Do not guess information a tool can look up.
+{{- range .Agent.Tools}}
+- {{.Name}}: {{.Description}}
+{{- end}}
+{{end}}
+{{- end}}
+
+{{- define "datetime"}}The current date and time is {{.Now.Format "Monday, January 2, 2006 15:04 MST"}}.{{end}}
+`
+
+// ToolInfo describes a tool of the agent to templates
+type ToolInfo struct {
+	Name        string
+	Description string
+}
+
+// HandoffInfo describes an agent the agent can hand off to
+type HandoffInfo struct {
+	Name        string
+	Description string
+}
+
+// AgentInfo describes the agent whose instructions are rendered
+type AgentInfo struct {
+	Name     string
+	Tools    []ToolInfo
+	Handoffs []HandoffInfo
+}
+
+// Context is the value templates are executed with
+type Context struct {
+	// Agent describes the agent
+	Agent AgentInfo
+
+	// Data is the value returned by the agent's DataFunc
+	Data any
+
+	// Now is the time the instructions are rendered at
+	Now time.Time
+}
+
+// DataFunc provides the data of a template each time the instructions are rendered
+type DataFunc func(ctx context.Context) (any, error)
+
+// Template is a parsed instructions template. A Template is safe for concurrent use.
+//
+// Templates are executed with a Context, so they refer to {{.Agent.Name}}, {{.Data.Field}}
+// and {{.Now}}, and can include the partials "handoff_instructions", "tool_guidance" and
+// "datetime". Besides the text/template builtins, the functions upper, lower, title, trim, join,
+// indent, bullets, json, default and date are available.
+type Template struct {
+	tmpl *template.Template
+}
+
+// New parses an instructions template
+func New(name string, text string) (*Template, error) {
+	tmpl, err := template.New(name).Funcs(Funcs()).Option("missingkey=error").Parse(partials)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmpl.Parse(text); err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Must panics if err is not nil; it is meant for templates parsed in package variables
+func Must(t *Template, err error) *Template {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Name returns the name of the template
+func (t *Template) Name() string {
+	return t.tmpl.Name()
+}
+
+// Render executes the template. A zero Now is set to the current time.
+func (t *Template) Render(c Context) (string, error) {
+	if c.Now.IsZero() {
+		c.Now = time.Now()
+	}
+
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, c); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", t.Name(), err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// Funcs returns the helper functions available in templates, to use with other text/template templates
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"handoffPrefix": func() string { return RecommendedPromptPrefix },
+		"upper":         strings.ToUpper,
+		"lower":         strings.ToLower,
+		"title":         title,
+		"trim":          strings.TrimSpace,
+		"join":          join,
+		"indent":        indent,
+		"bullets":       bullets,
+		"json":          toJSON,
+		"default":       defaultValue,
+		"date":          func(layout string, t time.Time) string { return t.Format(layout) },
+	}
+}
+
+// title capitalizes the first letter of every word
+func title(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + w[size:]
+	}
+	return strings.Join(words, " ")
+}
+
+// join joins the string forms of items with sep: {{join ", " .Data.Items}}
+func join(sep string, items any) string {
+	return strings.Join(toStrings(items), sep)
+}
+
+// bullets formats items as a markdown list: {{bullets .Data.Rules}}
+func bullets(items any) string {
+	var b strings.Builder
+	for i, item := range toStrings(items) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("- ")
+		b.WriteString(item)
+	}
+	return b.String()
+}
+
+// indent indents every line of s by n spaces
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// toJSON encodes v as indented JSON
+func toJSON(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// defaultValue returns value, or fallback if value is empty: {{.Data.Name | default "there"}}
+func defaultValue(fallback any, value any) any {
+	if value == nil {
+		return fallback
+	}
+	if s, ok := value.(string); ok && s == "" {
+		return fallback
+	}
+	return value
+}
+
+// toStrings converts a string slice or any slice to strings
+func toStrings(items any) []string {
+	switch v := items.(type) {
+	case nil:
+		return nil
+	case []string:
+		return v
+	case []any:
+		out := make([]string, len(v))
+		for i, item := range v {
+			out[i] = fmt.Sprint(item)
+		}
+		return out
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
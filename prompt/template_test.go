@@ -0,0 +1,82 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package prompt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateRender(t *testing.T) {
+	tmpl, err := New("support", `You are {{.Agent.Name}}, helping {{.Data.Customer | default "a customer"}}.
+Rules:
+{{bullets .Data.Rules}}
+Plans: {{join ", " .Data.Plans}}
+Today is {{date "2006-01-02" .Now}}.`)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	out, err := tmpl.Render(Context{
+		Agent: AgentInfo{Name: "Support"},
+		Data: map[string]any{
+			"Customer": "",
+			"Rules":    []string{"Be polite", "Never share secrets"},
+			"Plans":    []any{"free", "pro"},
+		},
+		Now: now,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `You are Support, helping a customer.
+Rules:
+- Be polite
+- Never share secrets
+Plans: free, pro
+Today is 2025-03-14.`, out)
+}
+
+func TestTemplatePartials(t *testing.T) {
+	tmpl := Must(New("triage", `{{template "handoff_instructions" .}}
+{{template "tool_guidance" .}}
+{{template "datetime" .}}`))
+
+	out, err := tmpl.Render(Context{
+		Agent: AgentInfo{
+			Name:     "Triage",
+			Tools:    []ToolInfo{{Name: "lookup_order", Description: "Looks up an order"}},
+			Handoffs: []HandoffInfo{{Name: "transfer_to_billing", Description: "Billing questions"}},
+		},
+		Now: time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, RecommendedPromptPrefix))
+	assert.Contains(t, out, "- transfer_to_billing: Billing questions")
+	assert.Contains(t, out, "- lookup_order: Looks up an order")
+	assert.Contains(t, out, "The current date and time is Friday, March 14, 2025 09:30 UTC.")
+
+	// Without tools or handoffs the partials only add the system context
+	out, err = tmpl.Render(Context{Agent: AgentInfo{Name: "Plain"}})
+	require.NoError(t, err)
+	assert.NotContains(t, out, "# Tools")
+	assert.NotContains(t, out, "You can transfer")
+}
+
+func TestTemplateErrors(t *testing.T) {
+	_, err := New("broken", "{{.Agent.Name")
+	assert.Error(t, err)
+
+	tmpl := Must(New("missing", "{{.Data.Name}}"))
+	_, err = tmpl.Render(Context{Data: map[string]any{}})
+	assert.ErrorContains(t, err, "failed to render template missing")
+}
+
+func TestWithHandoffInstructions(t *testing.T) {
+	assert.Equal(t, RecommendedPromptPrefix+"\nHelp the user.", WithHandoffInstructions("Help the user."))
+	assert.Equal(t, "Hello World", title("hello world"))
+	assert.Equal(t, "  a\n  b", indent(2, "a\nb"))
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package retrieval
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MemoryStore is a VectorStore that keeps documents in memory and compares the query with every
+// one of them. It suits tests and small corpora. A MemoryStore is safe for concurrent use.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	docs map[string]Document
+	dims int
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{docs: map[string]Document{}}
+}
+
+// Upsert inserts the documents, replacing the ones with the same IDs
+func (s *MemoryStore) Upsert(ctx context.Context, docs []Document) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dims := s.dims
+	for _, doc := range docs {
+		if len(doc.Embedding) == 0 {
+			return fmt.Errorf("%w: %s", ErrMissingEmbedding, doc.ID)
+		}
+		if dims == 0 {
+			dims = len(doc.Embedding)
+		}
+		if len(doc.Embedding) != dims {
+			return fmt.Errorf("%w: document %s has %d dimensions, want %d", ErrDimensionMismatch, doc.ID, len(doc.Embedding), dims)
+		}
+	}
+
+	s.dims = dims
+	for _, doc := range docs {
+		s.docs[doc.ID] = doc
+	}
+	return nil
+}
+
+// Query returns the k documents most similar to the embedding, best first
+func (s *MemoryStore) Query(ctx context.Context, embedding []float32, k int) ([]Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make([]Match, 0, len(s.docs))
+	for _, doc := range s.docs {
+		score, err := CosineSimilarity(embedding, doc.Embedding)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, Match{Document: doc, Score: score})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ID < matches[j].ID
+	})
+	if k > 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches, nil
+}
+
+// Delete removes the documents with the IDs
+func (s *MemoryStore) Delete(ctx context.Context, ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		delete(s.docs, id)
+	}
+	return nil
+}
+
+// Len returns the number of stored documents
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.docs)
+}
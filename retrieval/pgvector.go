@@ -0,0 +1,122 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package retrieval
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidTableName is returned for table names that are not plain SQL identifiers
+var ErrInvalidTableName = errors.New("invalid table name")
+
+var tableNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)?$`)
+
+// PGVectorStore is a VectorStore backed by a PostgreSQL table using the pgvector extension.
+// It works with any database/sql driver for PostgreSQL, registered by the application.
+type PGVectorStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewPGVectorStore creates a store for the table, which CreateTable can create
+func NewPGVectorStore(db *sql.DB, table string) (*PGVectorStore, error) {
+	if !tableNamePattern.MatchString(table) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTableName, table)
+	}
+	return &PGVectorStore{db: db, table: table}, nil
+}
+
+// CreateTable creates the pgvector extension and the table for embeddings of the dimensions,
+// if they do not exist
+func (s *PGVectorStore) CreateTable(ctx context.Context, dimensions int) error {
+	statements := []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id TEXT PRIMARY KEY,
+	content TEXT NOT NULL,
+	metadata JSONB,
+	embedding vector(%d) NOT NULL
+)`, s.table, dimensions),
+	}
+	for _, statement := range statements {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create table %s: %w", s.table, err)
+		}
+	}
+	return nil
+}
+
+// Upsert inserts the documents, replacing the ones with the same IDs
+func (s *PGVectorStore) Upsert(ctx context.Context, docs []Document) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	statement := fmt.Sprintf(`INSERT INTO %s (id, content, metadata, embedding) VALUES ($1, $2, $3, $4::vector)
+ON CONFLICT (id) DO UPDATE SET content = EXCLUDED.content, metadata = EXCLUDED.metadata, embedding = EXCLUDED.embedding`, s.table)
+	for _, doc := range docs {
+		if len(doc.Embedding) == 0 {
+			return fmt.Errorf("%w: %s", ErrMissingEmbedding, doc.ID)
+		}
+		metadata, err := json.Marshal(doc.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode metadata of %s: %w", doc.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx, statement, doc.ID, doc.Content, string(metadata), vectorLiteral(doc.Embedding)); err != nil {
+			return fmt.Errorf("failed to upsert %s: %w", doc.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Query returns the k documents most similar to the embedding, best first
+func (s *PGVectorStore) Query(ctx context.Context, embedding []float32, k int) ([]Match, error) {
+	query := fmt.Sprintf(`SELECT id, content, metadata, 1 - (embedding <=> $1::vector) AS score
+FROM %s ORDER BY embedding <=> $1::vector LIMIT $2`, s.table)
+	rows, err := s.db.QueryContext(ctx, query, vectorLiteral(embedding), k)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", s.table, err)
+	}
+	defer rows.Close()
+
+	var matches []Match
+	for rows.Next() {
+		var match Match
+		var metadata sql.NullString
+		if err := rows.Scan(&match.ID, &match.Content, &metadata, &match.Score); err != nil {
+			return nil, err
+		}
+		if metadata.Valid && metadata.String != "" {
+			if err := json.Unmarshal([]byte(metadata.String), &match.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to decode metadata of %s: %w", match.ID, err)
+			}
+		}
+		matches = append(matches, match)
+	}
+	return matches, rows.Err()
+}
+
+// vectorLiteral formats an embedding as a pgvector literal, such as "[0.1,0.2,0.3]"
+func vectorLiteral(embedding []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, v := range embedding {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
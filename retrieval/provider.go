@@ -0,0 +1,32 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package retrieval
+
+import (
+	"context"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// ProviderEmbedder embeds texts with a model provider implementing model.Embeddings, such as
+// model.OpenAIProvider
+type ProviderEmbedder struct {
+	provider model.Provider
+	settings model.EmbeddingSettings
+}
+
+// NewProviderEmbedder creates an embedder that calls the provider with the settings
+func NewProviderEmbedder(provider model.Provider, settings model.EmbeddingSettings) *ProviderEmbedder {
+	return &ProviderEmbedder{provider: provider, settings: settings}
+}
+
+// Embed returns one embedding per text, in the order of the texts
+func (e *ProviderEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	response, err := model.CreateEmbeddings(ctx, e.provider, texts, e.settings)
+	if err != nil {
+		return nil, err
+	}
+	return response.Embeddings, nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package retrieval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// qdrantNamespace derives the UUIDs of Qdrant points from document IDs, since Qdrant only
+// accepts unsigned integers and UUIDs as point IDs
+var qdrantNamespace = uuid.MustParse("3f4a6a9e-2b8e-4c53-9d0c-6b1f7d2e8a51")
+
+// QdrantStore is a VectorStore backed by a Qdrant collection, using its REST API. The
+// collection must exist and use the cosine distance. Document IDs, contents and metadata are
+// kept in the payload of the points.
+type QdrantStore struct {
+	baseURL    string
+	collection string
+	apiKey     string
+	client     *http.Client
+}
+
+// QdrantOption configures a QdrantStore
+type QdrantOption func(*QdrantStore)
+
+// WithQdrantAPIKey sets the API key sent in the api-key header
+func WithQdrantAPIKey(apiKey string) QdrantOption {
+	return func(s *QdrantStore) {
+		s.apiKey = apiKey
+	}
+}
+
+// WithQdrantHTTPClient sets the client sending the requests
+func WithQdrantHTTPClient(client *http.Client) QdrantOption {
+	return func(s *QdrantStore) {
+		s.client = client
+	}
+}
+
+// NewQdrantStore creates a store for the collection of the Qdrant server at baseURL, such as
+// "http://localhost:6333"
+func NewQdrantStore(baseURL, collection string, opts ...QdrantOption) *QdrantStore {
+	s := &QdrantStore{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		collection: collection,
+		client:     http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+type qdrantPoint struct {
+	ID      string         `json:"id"`
+	Vector  []float32      `json:"vector,omitempty"`
+	Payload map[string]any `json:"payload"`
+	Score   float64        `json:"score,omitempty"`
+}
+
+// Upsert inserts the documents, replacing the ones with the same IDs
+func (s *QdrantStore) Upsert(ctx context.Context, docs []Document) error {
+	points := make([]qdrantPoint, 0, len(docs))
+	for _, doc := range docs {
+		if len(doc.Embedding) == 0 {
+			return fmt.Errorf("%w: %s", ErrMissingEmbedding, doc.ID)
+		}
+		points = append(points, qdrantPoint{
+			ID:     uuid.NewSHA1(qdrantNamespace, []byte(doc.ID)).String(),
+			Vector: doc.Embedding,
+			Payload: map[string]any{
+				"id":       doc.ID,
+				"content":  doc.Content,
+				"metadata": doc.Metadata,
+			},
+		})
+	}
+
+	return s.do(ctx, http.MethodPut, "/points?wait=true", map[string]any{"points": points}, nil)
+}
+
+// Query returns the k documents most similar to the embedding, best first
+func (s *QdrantStore) Query(ctx context.Context, embedding []float32, k int) ([]Match, error) {
+	var response struct {
+		Result []qdrantPoint `json:"result"`
+	}
+	request := map[string]any{
+		"vector":       embedding,
+		"limit":        k,
+		"with_payload": true,
+	}
+	if err := s.do(ctx, http.MethodPost, "/points/search", request, &response); err != nil {
+		return nil, err
+	}
+
+	matches := make([]Match, 0, len(response.Result))
+	for _, point := range response.Result {
+		match := Match{Score: point.Score}
+		match.ID, _ = point.Payload["id"].(string)
+		match.Content, _ = point.Payload["content"].(string)
+		match.Metadata, _ = point.Payload["metadata"].(map[string]any)
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// do sends a request to the collection's endpoint and decodes the response into out
+func (s *QdrantStore) do(ctx context.Context, method, path string, body any, out any) error {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode qdrant request: %w", err)
+	}
+
+	endpoint := s.baseURL + "/collections/" + url.PathEscape(s.collection) + path
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(encoded))
+	if err != nil {
+		return fmt.Errorf("failed to create qdrant request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("api-key", s.apiKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("qdrant request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read qdrant response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("qdrant request failed with status %d: %s", resp.StatusCode, data)
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to decode qdrant response: %w", err)
+		}
+	}
+	return nil
+}
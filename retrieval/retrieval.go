@@ -0,0 +1,161 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package retrieval stores documents as embeddings and finds the ones most similar to a query.
+// A VectorStore holds the documents, an Embedder turns text into vectors, and
+// tool.NewRetrievalTool lets agents search a store.
+package retrieval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Errors returned by stores and embedders
+var (
+	// ErrDimensionMismatch is returned when vectors of different lengths are compared or stored together
+	ErrDimensionMismatch = errors.New("embedding dimension mismatch")
+
+	// ErrMissingEmbedding is returned when a document without an embedding is upserted
+	ErrMissingEmbedding = errors.New("document has no embedding")
+)
+
+// Document is a chunk of text stored with its embedding
+type Document struct {
+	// ID identifies the document; upserting a document with the same ID replaces it
+	ID string `json:"id"`
+
+	// Content is the text returned to the model
+	Content string `json:"content"`
+
+	// Metadata is stored with the document, such as its source or title
+	Metadata map[string]any `json:"metadata,omitempty"`
+
+	// Embedding is the vector of the content
+	Embedding []float32 `json:"-"`
+}
+
+// Match is a document found by a query
+type Match struct {
+	Document
+
+	// Score is the cosine similarity between the query and the document, higher is closer
+	Score float64 `json:"score"`
+}
+
+// VectorStore stores documents and finds the ones nearest to a vector
+type VectorStore interface {
+	// Upsert inserts the documents, replacing the ones with the same IDs
+	Upsert(ctx context.Context, docs []Document) error
+
+	// Query returns the k documents most similar to the embedding, best first
+	Query(ctx context.Context, embedding []float32, k int) ([]Match, error)
+}
+
+// Embedder turns texts into embeddings
+type Embedder interface {
+	// Embed returns one embedding per text, in the order of the texts
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// EmbedderFunc is a function implementing Embedder
+type EmbedderFunc func(ctx context.Context, texts []string) ([][]float32, error)
+
+// Embed calls the function
+func (f EmbedderFunc) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return f(ctx, texts)
+}
+
+// Index embeds the documents that have no embedding and upserts all of them into the store
+func Index(ctx context.Context, store VectorStore, embedder Embedder, docs []Document) error {
+	var texts []string
+	var missing []int
+	for i, doc := range docs {
+		if len(doc.Embedding) == 0 {
+			texts = append(texts, doc.Content)
+			missing = append(missing, i)
+		}
+	}
+
+	if len(texts) > 0 {
+		embeddings, err := embedder.Embed(ctx, texts)
+		if err != nil {
+			return fmt.Errorf("failed to embed documents: %w", err)
+		}
+		if len(embeddings) != len(texts) {
+			return fmt.Errorf("embedder returned %d embeddings for %d texts", len(embeddings), len(texts))
+		}
+		docs = append([]Document(nil), docs...)
+		for j, i := range missing {
+			docs[i].Embedding = embeddings[j]
+		}
+	}
+
+	return store.Upsert(ctx, docs)
+}
+
+// Search embeds the query and returns the k documents of the store most similar to it
+func Search(ctx context.Context, store VectorStore, embedder Embedder, query string, k int) ([]Match, error) {
+	embeddings, err := embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(embeddings) != 1 {
+		return nil, fmt.Errorf("embedder returned %d embeddings for 1 text", len(embeddings))
+	}
+	return store.Query(ctx, embeddings[0], k)
+}
+
+// CosineSimilarity returns the cosine of the angle between two vectors, or 0 if either is zero
+func CosineSimilarity(a, b []float32) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("%w: %d and %d", ErrDimensionMismatch, len(a), len(b))
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0, nil
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB)), nil
+}
+
+// Chunk splits text into chunks of at most size runes, each overlapping the previous one by
+// overlap runes. Chunks end at whitespace when possible so words are not cut.
+func Chunk(text string, size, overlap int) []string {
+	runes := []rune(text)
+	if size <= 0 || len(runes) <= size {
+		if len(runes) == 0 {
+			return nil
+		}
+		return []string{text}
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	var chunks []string
+	for start := 0; start < len(runes); {
+		end := start + size
+		if end >= len(runes) {
+			chunks = append(chunks, string(runes[start:]))
+			break
+		}
+		// Cut at the last whitespace of the second half of the chunk
+		for i := end; i > start+size/2; i-- {
+			if runes[i-1] == ' ' || runes[i-1] == '\n' || runes[i-1] == '\t' {
+				end = i
+				break
+			}
+		}
+		chunks = append(chunks, string(runes[start:end]))
+		start = max(end-overlap, start+1)
+	}
+	return chunks
+}
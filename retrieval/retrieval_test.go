@@ -0,0 +1,167 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package retrieval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// wordEmbedder embeds texts as counts of a fixed vocabulary
+var wordEmbedder = EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+	vocabulary := []string{"refund", "shipping", "password", "order"}
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embeddings[i] = make([]float32, len(vocabulary))
+		for j, word := range vocabulary {
+			embeddings[i][j] = float32(strings.Count(strings.ToLower(text), word))
+		}
+	}
+	return embeddings, nil
+})
+
+func TestMemoryStoreSearch(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	err := Index(ctx, store, wordEmbedder, []Document{
+		{ID: "refunds", Content: "Refund requests are processed within 5 days of the order."},
+		{ID: "shipping", Content: "Shipping takes 2 days. Shipping is free over $50."},
+		{ID: "accounts", Content: "Reset your password from the login page.", Metadata: map[string]any{"section": "accounts"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, store.Len())
+
+	matches, err := Search(ctx, store, wordEmbedder, "How long does shipping take?", 2)
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "shipping", matches[0].ID)
+	assert.InDelta(t, 1.0, matches[0].Score, 1e-9)
+
+	matches, err = Search(ctx, store, wordEmbedder, "I forgot my password", 1)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, map[string]any{"section": "accounts"}, matches[0].Metadata)
+
+	// Upserting replaces documents with the same ID
+	require.NoError(t, Index(ctx, store, wordEmbedder, []Document{{ID: "accounts", Content: "Orders can be cancelled before shipping."}}))
+	assert.Equal(t, 3, store.Len())
+	require.NoError(t, store.Delete(ctx, "accounts"))
+	assert.Equal(t, 2, store.Len())
+
+	err = store.Upsert(ctx, []Document{{ID: "short", Content: "x", Embedding: []float32{1}}})
+	assert.ErrorIs(t, err, ErrDimensionMismatch)
+	err = store.Upsert(ctx, []Document{{ID: "empty", Content: "x"}})
+	assert.ErrorIs(t, err, ErrMissingEmbedding)
+}
+
+func TestChunk(t *testing.T) {
+	assert.Nil(t, Chunk("", 10, 0))
+	assert.Equal(t, []string{"short text"}, Chunk("short text", 20, 5))
+
+	chunks := Chunk("the quick brown fox jumps over the lazy dog", 16, 4)
+	assert.Equal(t, []string{"the quick brown ", "own fox jumps ", "mps over the ", "the lazy dog"}, chunks)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, len(chunk), 16)
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	score, err := CosineSimilarity([]float32{1, 0}, []float32{0, 1})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, score)
+
+	score, err = CosineSimilarity([]float32{1, 1}, []float32{2, 2})
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, score, 1e-9)
+
+	_, err = CosineSimilarity([]float32{1}, []float32{1, 2})
+	assert.ErrorIs(t, err, ErrDimensionMismatch)
+}
+
+func TestQdrantStore(t *testing.T) {
+	var upserted map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/collections/docs/points":
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "secret", r.Header.Get("api-key"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&upserted))
+			w.Write([]byte(`{"result":{"status":"completed"},"status":"ok"}`))
+		case "/collections/docs/points/search":
+			var search map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&search))
+			assert.Equal(t, float64(3), search["limit"])
+			w.Write([]byte(`{"result":[{"id":"a","score":0.9,"payload":{"id":"refunds","content":"Refunds take 5 days.","metadata":{"page":2}}}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	store := NewQdrantStore(server.URL+"/", "docs", WithQdrantAPIKey("secret"))
+	ctx := context.Background()
+
+	require.NoError(t, store.Upsert(ctx, []Document{{ID: "refunds", Content: "Refunds take 5 days.", Embedding: []float32{1, 0}}}))
+	points := upserted["points"].([]any)
+	require.Len(t, points, 1)
+	point := points[0].(map[string]any)
+	assert.Len(t, point["id"], 36)
+	assert.Equal(t, "refunds", point["payload"].(map[string]any)["id"])
+
+	matches, err := store.Query(ctx, []float32{1, 0}, 3)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "refunds", matches[0].ID)
+	assert.Equal(t, "Refunds take 5 days.", matches[0].Content)
+	assert.Equal(t, 0.9, matches[0].Score)
+	assert.Equal(t, map[string]any{"page": float64(2)}, matches[0].Metadata)
+
+	_, err = NewQdrantStore(server.URL, "missing").Query(ctx, []float32{1, 0}, 3)
+	assert.ErrorContains(t, err, "status 404")
+}
+
+func TestPGVectorStore(t *testing.T) {
+	assert.Equal(t, "[0.5,-1,2.25]", vectorLiteral([]float32{0.5, -1, 2.25}))
+
+	_, err := NewPGVectorStore(nil, "public.documents")
+	assert.NoError(t, err)
+	_, err = NewPGVectorStore(nil, "documents; DROP TABLE users")
+	assert.ErrorIs(t, err, ErrInvalidTableName)
+}
+
+// embeddingProvider is a model provider that only creates embeddings
+type embeddingProvider struct {
+	model.ProviderFuncs
+	settings model.EmbeddingSettings
+}
+
+func (p *embeddingProvider) CreateEmbeddings(ctx context.Context, texts []string, settings model.EmbeddingSettings) (*model.EmbeddingsResponse, error) {
+	p.settings = settings
+	embeddings, err := wordEmbedder(ctx, texts)
+	return &model.EmbeddingsResponse{Embeddings: embeddings}, err
+}
+
+func TestProviderEmbedder(t *testing.T) {
+	provider := &embeddingProvider{}
+	embedder := NewProviderEmbedder(provider, model.EmbeddingSettings{Model: "text-embedding-3-large"})
+
+	embeddings, err := embedder.Embed(context.Background(), []string{"refund my order"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0, 0, 1}}, embeddings)
+	assert.Equal(t, "text-embedding-3-large", provider.settings.Model)
+
+	_, err = NewProviderEmbedder(model.ProviderFuncs{}, model.EmbeddingSettings{}).Embed(context.Background(), []string{"a"})
+	assert.ErrorIs(t, err, model.ErrEmbeddingsUnsupported)
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package router triages user input with a small classification model. A Router asks the
+// model to pick one of its route labels, with the answer constrained to the labels by a JSON
+// schema, and maps the label to the agent that handles it. It runs before the agents as a
+// pre-step of the run, or inside a triage agent as handoffs.
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+)
+
+// DefaultModel is the classification model used when the router does not name one
+const DefaultModel = "gpt-4o-mini"
+
+// Errors returned by the router
+var (
+	// ErrNoRoutes is returned when a router has no routes
+	ErrNoRoutes = errors.New("router has no routes")
+
+	// ErrUnknownRoute is returned when the model answers with a label that is not a route
+	ErrUnknownRoute = errors.New("unknown route")
+)
+
+// Route is a destination of the router
+type Route struct {
+	// Label is the answer of the classification model that selects the route
+	Label string
+
+	// Description tells the classification model which inputs belong to the route
+	Description string
+
+	// Agent handles the inputs of the route
+	Agent *agent.Agent
+}
+
+// Router classifies user input into routes with a model
+type Router struct {
+	// Provider calls the classification model
+	Provider model.Provider
+
+	// Model is the classification model (optional, defaults to DefaultModel)
+	Model string
+
+	// Routes are the destinations to choose from
+	Routes []Route
+
+	// Instructions replace the default instructions of the classification model (optional).
+	// The routes are listed after them.
+	Instructions string
+
+	// Fallback is the label used when the model answers with an unknown label (optional).
+	// Without it, ErrUnknownRoute is returned.
+	Fallback string
+
+	// The last classification is kept, since every handoff of the router checks the same input
+	mu        sync.Mutex
+	lastInput string
+	lastLabel string
+}
+
+// New creates a router that classifies with the model of the provider
+func New(provider model.Provider, modelName string, routes ...Route) *Router {
+	return &Router{
+		Provider: provider,
+		Model:    modelName,
+		Routes:   routes,
+	}
+}
+
+// Classify returns the label of the route the input belongs to
+func (r *Router) Classify(ctx context.Context, input string) (string, error) {
+	if len(r.Routes) == 0 {
+		return "", ErrNoRoutes
+	}
+
+	r.mu.Lock()
+	if r.lastLabel != "" && r.lastInput == input {
+		label := r.lastLabel
+		r.mu.Unlock()
+		return label, nil
+	}
+	r.mu.Unlock()
+
+	response, err := r.Provider.CreateChatCompletion(ctx, []model.Message{
+		{Role: "system", Content: r.systemPrompt()},
+		{Role: "user", Content: input},
+	}, r.settings())
+	if err != nil {
+		return "", fmt.Errorf("failed to classify input: %w", err)
+	}
+
+	var answer struct {
+		Route string `json:"route"`
+	}
+	if err := json.Unmarshal([]byte(response.Message.Content), &answer); err != nil {
+		// Models without structured outputs may answer with the bare label
+		answer.Route = strings.Trim(strings.TrimSpace(response.Message.Content), `"`)
+	}
+
+	label := answer.Route
+	if _, ok := r.route(label); !ok {
+		if _, ok := r.route(r.Fallback); !ok || r.Fallback == "" {
+			return "", fmt.Errorf("%w: %q", ErrUnknownRoute, label)
+		}
+		label = r.Fallback
+	}
+
+	r.mu.Lock()
+	r.lastInput, r.lastLabel = input, label
+	r.mu.Unlock()
+	return label, nil
+}
+
+// Select returns the route the input belongs to
+func (r *Router) Select(ctx context.Context, input string) (Route, error) {
+	label, err := r.Classify(ctx, input)
+	if err != nil {
+		return Route{}, err
+	}
+	route, _ := r.route(label)
+	return route, nil
+}
+
+// Run classifies the input and runs the agent of its route, as a pre-step that saves the
+// turn a triage agent would spend on choosing a handoff
+func (r *Router) Run(ctx context.Context, input string, config runner.RunConfig) (*runner.Result, error) {
+	route, err := r.Select(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.RunWithConfig(ctx, route.Agent, input, config)
+}
+
+// Handoffs returns a handoff per route for a triage agent. A handoff is accepted only when the
+// router classifies the handoff's input (the arguments of the tool call) into its route, so
+// the classification model double-checks the triage agent's choice. Give the handoffs an
+// InputJSONSchema with a field describing the request, since an empty input cannot be classified.
+func (r *Router) Handoffs(options handoff.Options) []handoff.Handoff {
+	handoffs := make([]handoff.Handoff, 0, len(r.Routes))
+	for _, route := range r.Routes {
+		opts := options
+		if opts.ToolName == "" {
+			opts.ToolName = handoff.DefaultToolName(route.Agent.Name)
+		}
+		label := route.Label
+		handoffs = append(handoffs, handoff.NewFunctionHandoffWithOptions(route.Agent, route.Description,
+			func(ctx context.Context, input string) (bool, error) {
+				selected, err := r.Classify(ctx, input)
+				if err != nil {
+					return false, err
+				}
+				return selected == label, nil
+			}, opts))
+	}
+	return handoffs
+}
+
+func (r *Router) route(label string) (Route, bool) {
+	for _, route := range r.Routes {
+		if route.Label == label {
+			return route, true
+		}
+	}
+	return Route{}, false
+}
+
+// systemPrompt lists the routes for the classification model
+func (r *Router) systemPrompt() string {
+	var b strings.Builder
+	if r.Instructions != "" {
+		b.WriteString(r.Instructions)
+	} else {
+		b.WriteString("Classify the user's message into exactly one of the routes below. ")
+		b.WriteString(`Answer with a JSON object such as {"route": "<label>"}.`)
+	}
+	b.WriteString("\n\nRoutes:\n")
+	for _, route := range r.Routes {
+		fmt.Fprintf(&b, "- %s", route.Label)
+		if route.Description != "" {
+			fmt.Fprintf(&b, ": %s", route.Description)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// settings constrains the answer to the route labels
+func (r *Router) settings() model.Settings {
+	labels := make([]any, 0, len(r.Routes))
+	for _, route := range r.Routes {
+		labels = append(labels, route.Label)
+	}
+
+	modelName := r.Model
+	if modelName == "" {
+		modelName = DefaultModel
+	}
+
+	settings := model.DefaultSettings()
+	settings.Temperature = 0
+	settings.ExplicitSampling = true
+	settings.MaxTokens = 50
+	settings.ResponseFormat = "json_schema"
+	settings.ResponseSchema = &model.ResponseSchema{
+		Name: "route",
+		Schema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"route": map[string]any{"type": "string", "enum": labels},
+			},
+			"required":             []string{"route"},
+			"additionalProperties": false,
+		},
+		Strict: true,
+	}
+	settings.Model = modelName
+	return settings
+}
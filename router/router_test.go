@@ -0,0 +1,116 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/agentstest"
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+)
+
+// classifier answers with the route, recording the settings and the number of calls
+type classifier struct {
+	answer   string
+	calls    int
+	settings model.Settings
+	messages []model.Message
+}
+
+func (c *classifier) provider() model.Provider {
+	return model.ProviderFuncs{
+		Completion: func(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+			c.calls++
+			c.settings = settings
+			c.messages = messages
+			return &model.Response{Message: model.Message{Role: "assistant", Content: c.answer}}, nil
+		},
+	}
+}
+
+func newTestRouter(c *classifier) (*Router, *agent.Agent, *agent.Agent) {
+	billing := agent.New("Billing", "You handle billing")
+	support := agent.New("Support", "You handle technical issues")
+	return New(c.provider(), "", Route{Label: "billing", Description: "Payments and refunds", Agent: billing},
+		Route{Label: "support", Description: "Technical problems", Agent: support}), billing, support
+}
+
+func TestRouterClassify(t *testing.T) {
+	c := &classifier{answer: `{"route":"billing"}`}
+	r, billing, _ := newTestRouter(c)
+
+	route, err := r.Select(context.Background(), "I was charged twice")
+	require.NoError(t, err)
+	assert.Equal(t, "billing", route.Label)
+	assert.Equal(t, billing, route.Agent)
+
+	// The answer is constrained to the labels
+	assert.Equal(t, DefaultModel, c.settings.Model)
+	assert.Equal(t, "json_schema", c.settings.ResponseFormat)
+	routeSchema := c.settings.ResponseSchema.Schema["properties"].(map[string]any)["route"].(map[string]any)
+	assert.Equal(t, []any{"billing", "support"}, routeSchema["enum"])
+	assert.Contains(t, c.messages[0].Content, "- billing: Payments and refunds\n- support: Technical problems")
+	assert.Equal(t, "I was charged twice", c.messages[1].Content)
+
+	// A bare label is accepted, and the same input is classified once
+	c.answer = "support"
+	label, err := r.Classify(context.Background(), "My app crashes")
+	require.NoError(t, err)
+	assert.Equal(t, "support", label)
+	_, err = r.Classify(context.Background(), "My app crashes")
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.calls)
+
+	c.answer = `{"route":"sales"}`
+	_, err = r.Classify(context.Background(), "I want to buy more seats")
+	assert.ErrorIs(t, err, ErrUnknownRoute)
+
+	r.Fallback = "support"
+	label, err = r.Classify(context.Background(), "I want to buy more seats")
+	require.NoError(t, err)
+	assert.Equal(t, "support", label)
+
+	_, err = New(c.provider(), "").Classify(context.Background(), "hi")
+	assert.ErrorIs(t, err, ErrNoRoutes)
+}
+
+func TestRouterRun(t *testing.T) {
+	c := &classifier{answer: `{"route":"support"}`}
+	r, _, support := newTestRouter(c)
+
+	fakeModel := agentstest.NewFakeModel()
+	fakeModel.AddTurn(agentstest.GetTextMessage("Try restarting the app"))
+
+	result, err := r.Run(context.Background(), "My app crashes", runner.RunConfig{ModelProvider: fakeModel, MaxTurns: 3})
+	require.NoError(t, err)
+	assert.Equal(t, support, result.LastAgent)
+	assert.Equal(t, "Try restarting the app", result.FinalOutput)
+}
+
+func TestRouterHandoffs(t *testing.T) {
+	c := &classifier{answer: `{"route":"billing"}`}
+	r, billing, _ := newTestRouter(c)
+
+	handoffs := r.Handoffs(handoff.Options{})
+	require.Len(t, handoffs, 2)
+	assert.Equal(t, "transfer_to_billing", handoffs[0].ToolName())
+	assert.Equal(t, billing, handoffs[0].TargetAgent())
+
+	accepted, err := handoffs[0].ShouldHandoff(context.Background(), `{"reason":"refund request"}`)
+	require.NoError(t, err)
+	assert.True(t, accepted)
+
+	accepted, err = handoffs[1].ShouldHandoff(context.Background(), `{"reason":"refund request"}`)
+	require.NoError(t, err)
+	assert.False(t, accepted)
+	assert.Equal(t, 1, c.calls)
+}
@@ -6,9 +6,12 @@ package runner
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
 )
 
 // Adapter is an adapter that implements the Runner interface
@@ -57,3 +60,112 @@ func (g *GetResult) GetFinalOutput() string {
 	}
 	return g.Result.FinalOutput
 }
+
+// AgentToolRunner implements interfaces.Runner by running agents with a fixed RunConfig.
+// It lets agents used as tools run with the same provider and settings as the rest of the application.
+type AgentToolRunner struct {
+	config RunConfig
+}
+
+// NewAgentToolRunner creates a runner for agents used as tools.
+// If config has no ModelProvider, DefaultProvider is used.
+func NewAgentToolRunner(config RunConfig) *AgentToolRunner {
+	return &AgentToolRunner{config: config}
+}
+
+// Run executes the agent with the runner's configuration
+func (r *AgentToolRunner) Run(ctx context.Context, agentIF any, input string) (any, error) {
+	return r.RunWithOptions(ctx, agentIF, input, tool.AgentRunOptions{})
+}
+
+// RunWithOptions executes the agent with the runner's configuration, overridden by options.
+// The model and model settings overrides apply to a copy of the agent, not to the agents it hands off to.
+func (r *AgentToolRunner) RunWithOptions(ctx context.Context, agentIF any, input string, options tool.AgentRunOptions) (any, error) {
+	a, ok := agentIF.(*agent.Agent)
+	if !ok {
+		return nil, fmt.Errorf("agent must be of type *agent.Agent")
+	}
+
+	config := r.config
+	if config.ModelProvider == nil {
+		config.ModelProvider = DefaultProvider
+	}
+	if options.MaxTurns > 0 {
+		config.MaxTurns = options.MaxTurns
+	}
+	if options.Model != "" || options.ModelSettings != nil {
+		a = a.Clone()
+		if options.Model != "" {
+			a.Model = options.Model
+		}
+		if options.ModelSettings != nil {
+			a.ModelSettings = a.ModelSettings.Resolve(*options.ModelSettings)
+		}
+	}
+
+	result, err := RunWithConfig(ctx, a, input, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetResult{Result: result}, nil
+}
+
+// AsToolWithConfig converts the agent to a tool that runs it with config.
+// It is a shorthand for a.AsTool(NewAgentToolRunner(config), options...).
+func AsToolWithConfig(a *agent.Agent, config RunConfig, options ...tool.AgentToolOption) (tool.Tool, error) {
+	return a.AsTool(NewAgentToolRunner(config), options...)
+}
+
+// OutputExtractor adapts a function of the run result to tool.AgentToolOption.OutputExtractor,
+// so an agent tool can return something other than the final output (e.g. one field of a structured output).
+// It must be used with a runner from this package.
+func OutputExtractor(extract func(result *Result) (string, error)) func(result any) (string, error) {
+	return func(result any) (string, error) {
+		getResult, ok := result.(*GetResult)
+		if !ok || getResult.Result == nil {
+			return "", fmt.Errorf("unexpected agent tool result type %T", result)
+		}
+		return extract(getResult.Result)
+	}
+}
+
+// ExtractJSONField returns an extractor for OutputExtractor picking one field of a JSON final
+// output, such as a structured output. Nested fields are separated by dots ("summary.text").
+// String values are returned as is, other values as JSON.
+func ExtractJSONField(path string) func(result *Result) (string, error) {
+	return func(result *Result) (string, error) {
+		var value any
+		if err := json.Unmarshal([]byte(result.FinalOutput), &value); err != nil {
+			return "", fmt.Errorf("final output is not JSON: %w", err)
+		}
+		for _, key := range strings.Split(path, ".") {
+			object, ok := value.(map[string]any)
+			if !ok {
+				return "", fmt.Errorf("final output has no field %q", path)
+			}
+			if value, ok = object[key]; !ok {
+				return "", fmt.Errorf("final output has no field %q", path)
+			}
+		}
+		if text, ok := value.(string); ok {
+			return text, nil
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
+
+// ExtractLastMessage is an extractor for OutputExtractor returning the text of the last assistant
+// message of the run, rather than its final output (e.g. the structured output)
+func ExtractLastMessage(result *Result) (string, error) {
+	for i := len(result.History) - 1; i >= 0; i-- {
+		if message := result.History[i]; message.Role == "assistant" && message.Content != "" {
+			return message.Content, nil
+		}
+	}
+	return "", fmt.Errorf("the run has no assistant message")
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+func TestAsToolWithConfig(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("Hola")})
+
+	spanishAgent := agent.New("spanish_agent", "Translate to Spanish")
+	config := RunConfig{ModelProvider: fakeModel, MaxTurns: 3}
+
+	spanishTool, err := AsToolWithConfig(spanishAgent, config, tool.AgentToolOption{Name: "translate_to_spanish"})
+	require.NoError(t, err)
+	assert.Equal(t, "translate_to_spanish", spanishTool.Name())
+
+	output, err := spanishTool.Invoke(context.Background(), `{"input":"Hello"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Hola", output)
+
+	// A custom extractor sees the whole run result
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("Hola")})
+	extractingTool, err := AsToolWithConfig(spanishAgent, config, tool.AgentToolOption{
+		OutputExtractor: OutputExtractor(func(result *Result) (string, error) {
+			return strings.ToUpper(result.FinalOutput) + " from " + result.LastAgent.Name, nil
+		}),
+	})
+	require.NoError(t, err)
+
+	output, err = extractingTool.Invoke(context.Background(), `{"input":"Hello"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "HOLA from spanish_agent", output)
+}
+
+func TestAgentToolRunOverrides(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("lookup", "{}")},
+		{GetFunctionToolCall("lookup", "{}")},
+		{GetFunctionToolCall("lookup", "{}")},
+	})
+
+	researcher := agent.New("researcher", "Research the question")
+	researcher.SetModel("gpt-4o")
+	researcher.SetModelSettings(model.Settings{Temperature: 0.7, MaxTokens: 500})
+	researcher.AddTool(NewFunctionTool("lookup", "found"))
+
+	researchTool, err := AsToolWithConfig(researcher, RunConfig{ModelProvider: fakeModel, MaxTurns: 10}, tool.AgentToolOption{
+		MaxTurns:      2,
+		Model:         "gpt-4o-mini",
+		ModelSettings: &model.Settings{Temperature: 0.1},
+	})
+	require.NoError(t, err)
+
+	// The inner run stops after two turns, made with the cheaper model
+	_, err = researchTool.Invoke(context.Background(), `{"input":"Why is the sky blue?"}`)
+	var maxTurnsErr *MaxTurnsExceededError
+	require.ErrorAs(t, err, &maxTurnsErr)
+
+	calls := fakeModel.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "gpt-4o-mini", calls[0].Settings.Model)
+	assert.Equal(t, 0.1, calls[0].Settings.Temperature)
+	assert.Equal(t, 500, calls[0].Settings.MaxTokens)
+
+	// The agent itself is unchanged
+	assert.Equal(t, "gpt-4o", researcher.Model)
+	assert.Equal(t, 0.7, researcher.ModelSettings.Temperature)
+}
+
+func TestOutputExtractors(t *testing.T) {
+	fakeModel := NewFakeModel()
+	config := RunConfig{ModelProvider: fakeModel, MaxTurns: 3}
+	reportAgent := agent.New("report_agent", "Write a report")
+
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage(`{"summary":{"text":"All good","score":9}}`)})
+	summaryTool, err := AsToolWithConfig(reportAgent, config, tool.AgentToolOption{
+		OutputExtractor: OutputExtractor(ExtractJSONField("summary.text")),
+	})
+	require.NoError(t, err)
+	output, err := summaryTool.Invoke(context.Background(), `{"input":"Report"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "All good", output)
+
+	result := &Result{FinalOutput: `{"summary":{"text":"All good","score":9}}`}
+	output, err = ExtractJSONField("summary")(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"All good","score":9}`, output)
+	_, err = ExtractJSONField("summary.missing")(result)
+	assert.Error(t, err)
+	_, err = ExtractJSONField("summary")(&Result{FinalOutput: "plain text"})
+	assert.Error(t, err)
+
+	result = &Result{History: []Message{
+		{Role: "user", Content: "Report"},
+		{Role: "assistant", Content: "Drafting the report"},
+		{Role: "assistant", ToolCalls: []model.ToolCall{{ID: "call_1"}}},
+		{Role: "tool", Content: "saved", ToolCallID: "call_1"},
+	}}
+	output, err = ExtractLastMessage(result)
+	require.NoError(t, err)
+	assert.Equal(t, "Drafting the report", output)
+	_, err = ExtractLastMessage(&Result{})
+	assert.Error(t, err)
+}
+
+func TestAgentToolRunnerDefaultProvider(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+
+	original := DefaultProvider
+	DefaultProvider = fakeModel
+	defer func() { DefaultProvider = original }()
+
+	result, err := NewAgentToolRunner(DefaultRunConfig()).Run(context.Background(), agent.New("test", "test instructions"), "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.(*GetResult).GetFinalOutput())
+
+	_, err = NewAgentToolRunner(DefaultRunConfig()).Run(context.Background(), "not an agent", "hi")
+	assert.Error(t, err)
+}
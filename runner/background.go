@@ -0,0 +1,340 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+)
+
+// DefaultSchedulerWorkers is the number of workers of the scheduler used by Submit
+const DefaultSchedulerWorkers = 8
+
+// DefaultRunRetention is how long a scheduler keeps finished runs, for Get, unless told otherwise
+const DefaultRunRetention = time.Hour
+
+// ErrRunNotFinished is returned by RunHandle.Result while the run is queued or running
+var ErrRunNotFinished = errors.New("run not finished")
+
+// RunStatus is the status of a background run
+type RunStatus string
+
+const (
+	RunStatusQueued    RunStatus = "queued"
+	RunStatusRunning   RunStatus = "running"
+	RunStatusCompleted RunStatus = "completed"
+	RunStatusFailed    RunStatus = "failed"
+	RunStatusCancelled RunStatus = "cancelled"
+)
+
+// Finished reports whether the run has ended
+func (s RunStatus) Finished() bool {
+	return s == RunStatusCompleted || s == RunStatusFailed || s == RunStatusCancelled
+}
+
+// RunEventType is the type of a RunEvent
+type RunEventType string
+
+const (
+	// RunEventStatus reports a change of the run's status
+	RunEventStatus RunEventType = "status"
+
+	// RunEventTurnStart reports the start of a turn of the agent loop
+	RunEventTurnStart RunEventType = "turn_start"
+
+	// RunEventTurnEnd reports the end of a turn of the agent loop
+	RunEventTurnEnd RunEventType = "turn_end"
+)
+
+// RunEvent is an event of a background run
+type RunEvent struct {
+	// Type is the type of the event
+	Type RunEventType
+
+	// Status is the status of the run after the event
+	Status RunStatus
+
+	// Turn describes the turn of turn events
+	Turn *TurnEvent
+
+	// Time is the time of the event
+	Time time.Time
+}
+
+// RunHandle follows a run started by Submit
+type RunHandle struct {
+	id     string
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	status RunStatus
+	events []RunEvent
+	result *Result
+	err    error
+
+	finishedAt time.Time
+}
+
+// ID returns the ID of the run
+func (h *RunHandle) ID() string {
+	return h.id
+}
+
+// Status returns the current status of the run
+func (h *RunHandle) Status() RunStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.status
+}
+
+// Events returns a channel receiving the events of the run, starting with the ones that already
+// happened. The channel is closed after the run finishes, or when ctx is done; cancel ctx to stop
+// reading earlier.
+func (h *RunHandle) Events(ctx context.Context) <-chan RunEvent {
+	events := make(chan RunEvent, 16)
+
+	// Wake the goroutine below when ctx is done while it waits for an event
+	stop := context.AfterFunc(ctx, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.cond.Broadcast()
+	})
+
+	go func() {
+		defer close(events)
+		defer stop()
+		for i := 0; ; i++ {
+			h.mu.Lock()
+			for i >= len(h.events) && !h.status.Finished() && ctx.Err() == nil {
+				h.cond.Wait()
+			}
+			if i >= len(h.events) || ctx.Err() != nil {
+				h.mu.Unlock()
+				return
+			}
+			event := h.events[i]
+			h.mu.Unlock()
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// Done returns a channel that is closed when the run finishes
+func (h *RunHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Result returns the result or the error of the finished run, or ErrRunNotFinished
+func (h *RunHandle) Result() (*Result, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.status.Finished() {
+		return nil, ErrRunNotFinished
+	}
+	return h.result, h.err
+}
+
+// Wait waits for the run to finish and returns its result
+func (h *RunHandle) Wait(ctx context.Context) (*Result, error) {
+	select {
+	case <-h.done:
+		return h.Result()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Cancel cancels the run. A queued run does not start; a running run ends with a RunCancelledError.
+func (h *RunHandle) Cancel() {
+	h.cancel()
+}
+
+// record appends an event, updating the status of the run
+func (h *RunHandle) record(event RunEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if event.Type == RunEventStatus {
+		h.status = event.Status
+	}
+	event.Status = h.status
+	event.Time = time.Now()
+	h.events = append(h.events, event)
+	h.cond.Broadcast()
+}
+
+// endStatus returns the status of a run that ended with err. Runs stopped by their context,
+// including RunConfig.Timeout, are cancelled.
+func endStatus(err error) RunStatus {
+	switch {
+	case err == nil:
+		return RunStatusCompleted
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return RunStatusCancelled
+	default:
+		return RunStatusFailed
+	}
+}
+
+// finish records the outcome of the run
+func (h *RunHandle) finish(result *Result, err error) {
+	status := endStatus(err)
+
+	h.mu.Lock()
+	h.result, h.err = result, err
+	h.finishedAt = time.Now()
+	h.mu.Unlock()
+	h.record(RunEvent{Type: RunEventStatus, Status: status})
+	close(h.done)
+}
+
+// Scheduler runs agents in the background on a pool of workers. It keeps the handles of its
+// runs for Get, and forgets finished runs after its retention.
+type Scheduler struct {
+	workers   chan struct{}
+	retention time.Duration
+	now       func() time.Time
+
+	mu   sync.Mutex
+	runs map[string]*RunHandle
+}
+
+// NewScheduler creates a scheduler running at most workers runs at once. Further runs are queued.
+// Finished runs are kept for DefaultRunRetention.
+func NewScheduler(workers int) *Scheduler {
+	return NewSchedulerWithRetention(workers, DefaultRunRetention)
+}
+
+// NewSchedulerWithRetention creates a scheduler that keeps finished runs for retention;
+// retention 0 keeps them until Remove
+func NewSchedulerWithRetention(workers int, retention time.Duration) *Scheduler {
+	if workers <= 0 {
+		workers = DefaultSchedulerWorkers
+	}
+	return &Scheduler{
+		workers:   make(chan struct{}, workers),
+		retention: retention,
+		now:       time.Now,
+		runs:      make(map[string]*RunHandle),
+	}
+}
+
+// Submit queues a run of the agent and returns its handle right away. The run keeps the values
+// of ctx, such as its trace, but not its cancellation, so it outlives the request that submitted
+// it; cancel it with RunHandle.Cancel. Its turns are reported as events, in addition to the
+// config's OnTurnStart and OnTurnEnd callbacks.
+func (s *Scheduler) Submit(ctx context.Context, a *agent.Agent, input string, config RunConfig) *RunHandle {
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	h := &RunHandle{
+		id:     uuid.New().String(),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	h.cond = sync.NewCond(&h.mu)
+	h.record(RunEvent{Type: RunEventStatus, Status: RunStatusQueued})
+
+	onTurnStart, onTurnEnd := config.OnTurnStart, config.OnTurnEnd
+	config.OnTurnStart = func(ctx context.Context, event TurnEvent) error {
+		h.record(RunEvent{Type: RunEventTurnStart, Turn: &event})
+		if onTurnStart != nil {
+			return onTurnStart(ctx, event)
+		}
+		return nil
+	}
+	config.OnTurnEnd = func(ctx context.Context, event TurnEvent) error {
+		h.record(RunEvent{Type: RunEventTurnEnd, Turn: &event})
+		if onTurnEnd != nil {
+			return onTurnEnd(ctx, event)
+		}
+		return nil
+	}
+
+	s.mu.Lock()
+	s.expire()
+	s.runs[h.id] = h
+	s.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		select {
+		case s.workers <- struct{}{}:
+			defer func() { <-s.workers }()
+		case <-runCtx.Done():
+			h.finish(nil, runCtx.Err())
+			return
+		}
+
+		h.record(RunEvent{Type: RunEventStatus, Status: RunStatusRunning})
+		result, err := RunWithConfig(runCtx, a, input, config)
+		h.finish(result, err)
+	}()
+
+	return h
+}
+
+// Get returns the handle of a run submitted to the scheduler, unless it finished longer than the
+// retention ago
+func (s *Scheduler) Get(id string) (*RunHandle, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	h, ok := s.runs[id]
+	return h, ok
+}
+
+// Remove forgets a run, so its handle and result can be garbage collected. It does not cancel the run.
+func (s *Scheduler) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.runs, id)
+}
+
+// expire forgets the runs that finished longer than the retention ago; s.mu must be held
+func (s *Scheduler) expire() {
+	if s.retention <= 0 {
+		return
+	}
+	cutoff := s.now().Add(-s.retention)
+	for id, h := range s.runs {
+		h.mu.Lock()
+		expired := h.status.Finished() && h.finishedAt.Before(cutoff)
+		h.mu.Unlock()
+		if expired {
+			delete(s.runs, id)
+		}
+	}
+}
+
+var (
+	defaultScheduler     *Scheduler
+	defaultSchedulerOnce sync.Once
+)
+
+// DefaultScheduler returns the scheduler used by Submit, with DefaultSchedulerWorkers workers and
+// DefaultRunRetention
+func DefaultScheduler() *Scheduler {
+	defaultSchedulerOnce.Do(func() {
+		defaultScheduler = NewScheduler(DefaultSchedulerWorkers)
+	})
+	return defaultScheduler
+}
+
+// Submit queues a run of the agent on the default scheduler, see Scheduler.Submit
+func Submit(ctx context.Context, a *agent.Agent, input string, config RunConfig) *RunHandle {
+	return DefaultScheduler().Submit(ctx, a, input, config)
+}
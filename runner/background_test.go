@@ -0,0 +1,178 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestSubmit(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("foo", "{}")},
+		{GetTextMessage("done")},
+	})
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("foo", "result"))
+
+	// The run is not tied to the submitting context
+	ctx, cancel := context.WithCancel(context.Background())
+	h := Submit(ctx, testAgent, "hello", RunConfig{ModelProvider: fakeModel, MaxTurns: 5})
+	cancel()
+	assert.NotEmpty(t, h.ID())
+
+	result, err := h.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, RunStatusCompleted, h.Status())
+
+	var types []RunEventType
+	var statuses []RunStatus
+	for event := range h.Events(context.Background()) {
+		types = append(types, event.Type)
+		statuses = append(statuses, event.Status)
+	}
+	assert.Equal(t, []RunEventType{
+		RunEventStatus, RunEventStatus,
+		RunEventTurnStart, RunEventTurnEnd, RunEventTurnStart, RunEventTurnEnd,
+		RunEventStatus,
+	}, types)
+	assert.Equal(t, RunStatusQueued, statuses[0])
+	assert.Equal(t, RunStatusRunning, statuses[2])
+	assert.Equal(t, RunStatusCompleted, statuses[6])
+
+	found, ok := DefaultScheduler().Get(h.ID())
+	assert.True(t, ok)
+	assert.Same(t, h, found)
+	DefaultScheduler().Remove(h.ID())
+	_, ok = DefaultScheduler().Get(h.ID())
+	assert.False(t, ok)
+}
+
+func TestSchedulerQueueAndCancel(t *testing.T) {
+	started := make(chan struct{}, 1)
+	blocking := model.ProviderFuncs{
+		Completion: func(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+			started <- struct{}{}
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	testAgent := agent.New("test", "Test agent")
+	config := RunConfig{ModelProvider: blocking, MaxTurns: 5}
+
+	scheduler := NewScheduler(1)
+	running := scheduler.Submit(context.Background(), testAgent, "first", config)
+	<-started
+	queued := scheduler.Submit(context.Background(), testAgent, "second", config)
+
+	assert.Equal(t, RunStatusRunning, running.Status())
+	assert.Equal(t, RunStatusQueued, queued.Status())
+	_, err := queued.Result()
+	assert.ErrorIs(t, err, ErrRunNotFinished)
+
+	// A cancelled queued run never starts
+	queued.Cancel()
+	select {
+	case <-queued.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the queued run was not cancelled")
+	}
+	assert.Equal(t, RunStatusCancelled, queued.Status())
+
+	running.Cancel()
+	_, err = running.Wait(context.Background())
+	assert.ErrorIs(t, err, ErrRunCancelled)
+	assert.Equal(t, RunStatusCancelled, running.Status())
+}
+
+func TestSchedulerTimeout(t *testing.T) {
+	blocking := model.ProviderFuncs{
+		Completion: func(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	testAgent := agent.New("test", "Test agent")
+	store := NewMemoryRunStore()
+	config := RunConfig{ModelProvider: blocking, MaxTurns: 5, Timeout: 10 * time.Millisecond, RunStore: store, RunID: "run-1"}
+
+	// A run stopped by its timeout is cancelled, on the handle as in its checkpoint
+	h := NewScheduler(1).Submit(context.Background(), testAgent, "hello", config)
+	_, err := h.Wait(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, RunStatusCancelled, h.Status())
+
+	checkpoint, err := store.LoadCheckpoint(context.Background(), "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, RunStatusCancelled, checkpoint.Status)
+}
+
+func TestSchedulerRetention(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{{GetTextMessage("done")}, {GetTextMessage("done")}})
+	testAgent := agent.New("test", "Test agent")
+	config := RunConfig{ModelProvider: fakeModel, MaxTurns: 5}
+
+	now := time.Now()
+	scheduler := NewSchedulerWithRetention(1, time.Minute)
+	scheduler.now = func() time.Time { return now }
+
+	finished := scheduler.Submit(context.Background(), testAgent, "first", config)
+	_, err := finished.Wait(context.Background())
+	require.NoError(t, err)
+	_, ok := scheduler.Get(finished.ID())
+	assert.True(t, ok, "a finished run is kept for the retention")
+
+	// Finished runs are forgotten after the retention
+	now = now.Add(2 * time.Minute)
+	_, ok = scheduler.Get(finished.ID())
+	assert.False(t, ok)
+
+	// The default scheduler forgets its runs too
+	assert.Equal(t, DefaultRunRetention, DefaultScheduler().retention)
+}
+
+func TestRunHandleEventsStop(t *testing.T) {
+	started := make(chan struct{}, 1)
+	blocking := model.ProviderFuncs{
+		Completion: func(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+			started <- struct{}{}
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	scheduler := NewScheduler(1)
+	h := scheduler.Submit(context.Background(), agent.New("test", "Test agent"), "hello", RunConfig{ModelProvider: blocking, MaxTurns: 5})
+	defer h.Cancel()
+	<-started
+
+	// A reader that stops reading releases the events goroutine by cancelling its context
+	ctx, cancel := context.WithCancel(context.Background())
+	events := h.Events(ctx)
+	<-events
+	cancel()
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				assert.Equal(t, RunStatusRunning, h.Status(), "the run goes on")
+				return
+			}
+		case <-timeout:
+			t.Fatal("the events channel was not closed")
+		}
+	}
+}
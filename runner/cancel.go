@@ -0,0 +1,108 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+// ErrRunCancelled is matched by errors.Is for every RunCancelledError
+var ErrRunCancelled = errors.New("run cancelled")
+
+// RunCancelledError is returned when the run's context is cancelled or RunConfig.Timeout expires.
+// It carries the progress made before the run stopped. errors.Is also matches its cause,
+// e.g. context.Canceled or context.DeadlineExceeded.
+type RunCancelledError struct {
+	// Cause is the context error
+	Cause error
+
+	// History is the message history up to the cancellation
+	History []Message
+
+	// LastAgent is the agent that was running
+	LastAgent *agent.Agent
+
+	// Usage is the token usage up to the cancellation
+	Usage Usage
+
+	// Turns is the number of completed turns
+	Turns int
+
+	// TraceID is the ID of the run's trace (empty when tracing is disabled)
+	TraceID string
+
+	// SpanID is the ID of the run's agent_run span (empty when tracing is disabled)
+	SpanID string
+}
+
+func (e *RunCancelledError) Error() string {
+	return fmt.Sprintf("run cancelled after %d turns: %v", e.Turns, e.Cause)
+}
+
+// Unwrap returns the context error
+func (e *RunCancelledError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is ErrRunCancelled
+func (e *RunCancelledError) Is(target error) bool {
+	return target == ErrRunCancelled
+}
+
+// newRunCancelledError creates a RunCancelledError from the execution state
+func newRunCancelledError(state *executionState, cause error) *RunCancelledError {
+	traceID, spanID := spanIDs(state.span)
+	return &RunCancelledError{
+		TraceID:   traceID,
+		SpanID:    spanID,
+		Cause:     cause,
+		History:   convertModelMessages(state.resultMessages),
+		LastAgent: state.currentAgent.Origin(),
+		Usage:     state.usage,
+		Turns:     state.stepCounter,
+	}
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// invokeToolContext runs the tool and returns as soon as ctx is done, so a tool that ignores
+// its context cannot keep a cancelled run alive. The abandoned call finishes in the background.
+// Panics of the tool are returned as ToolPanicErrors.
+func invokeToolContext(ctx context.Context, t tool.Tool, args string) (*tool.ToolOutput, error) {
+	type toolResult struct {
+		output *tool.ToolOutput
+		err    error
+	}
+
+	done := make(chan toolResult, 1)
+	go func() {
+		output, err := invokeToolRecover(ctx, t, args)
+		done <- toolResult{output: output, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.output, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
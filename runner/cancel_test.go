@@ -0,0 +1,88 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+func TestRunTimeoutAbandonsBlockingTool(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	// The tool ignores its context, so only the runner can stop waiting for it
+	slowTool, err := tool.NewFunctionToolWithName(func() string {
+		<-release
+		return "done"
+	}, "slow", "Never finishes in time")
+	require.NoError(t, err)
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("slow", "{}")},
+		{GetTextMessage("unreachable")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(slowTool)
+
+	start := time.Now()
+	_, err = RunWithConfig(context.Background(), testAgent, "go", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      3,
+		Timeout:       50 * time.Millisecond,
+	})
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrRunCancelled)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var cancelErr *RunCancelledError
+	require.True(t, errors.As(err, &cancelErr))
+	assert.Equal(t, testAgent, cancelErr.LastAgent)
+	require.Len(t, cancelErr.History, 1)
+	assert.Equal(t, "go", cancelErr.History[0].Content)
+}
+
+func TestRunCancelledDuringStepDelay(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("lookup", `{"a": "b"}`)},
+		{GetTextMessage("unreachable")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("lookup", "found"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := RunWithConfig(ctx, testAgent, "go", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      3,
+		StepDelay:     time.Hour,
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrRunCancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var cancelErr *RunCancelledError
+	require.True(t, errors.As(err, &cancelErr))
+	assert.Equal(t, 1, cancelErr.Turns)
+	require.Len(t, cancelErr.History, 3)
+	assert.Equal(t, "tool", cancelErr.History[2].Role)
+	assert.Equal(t, "found", cancelErr.History[2].Content)
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+)
+
+// ErrRunNotFound is returned by RunStore.LoadCheckpoint when the store has no checkpoint for the run
+var ErrRunNotFound = errors.New("run not found")
+
+// RunCheckpoint is a snapshot of a run, saved to RunConfig.RunStore after each turn and when the
+// run ends. A run interrupted by a crash can be continued from its last checkpoint with ResumeRun.
+type RunCheckpoint struct {
+	// RunID identifies the run
+	RunID string `json:"run_id"`
+
+	// Status is RunStatusRunning until the run ends
+	Status RunStatus `json:"status"`
+
+	// Agent is the name of the agent running after the last turn
+	Agent string `json:"agent"`
+
+	// Input is the user input of the run
+	Input string `json:"input"`
+
+	// Step is the number of completed turns
+	Step int `json:"step"`
+
+	// HandoffStack holds the names of the agents that handed off, for return handoffs
+	HandoffStack []string `json:"handoff_stack,omitempty"`
+
+	// Metadata is the RunConfig.Metadata of the run
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Result is the progress of the run: the history, usage and handoffs so far while it runs,
+	// and the final result once it completed
+	Result *Result `json:"result"`
+
+	// Error is the error of a failed or cancelled run
+	Error string `json:"error,omitempty"`
+
+	// UpdatedAt is the time the checkpoint was saved
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RunStore persists run checkpoints, for crash recovery and auditing of long runs.
+// SaveCheckpoint replaces the previous checkpoint of the run.
+type RunStore interface {
+	// SaveCheckpoint stores the checkpoint of a run
+	SaveCheckpoint(ctx context.Context, checkpoint *RunCheckpoint) error
+
+	// LoadCheckpoint returns the last checkpoint of a run, or an error wrapping ErrRunNotFound
+	LoadCheckpoint(ctx context.Context, runID string) (*RunCheckpoint, error)
+}
+
+// MemoryRunStore is an in-memory RunStore, for tests and single-process deployments
+type MemoryRunStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]*RunCheckpoint
+}
+
+// NewMemoryRunStore creates an in-memory run store
+func NewMemoryRunStore() *MemoryRunStore {
+	return &MemoryRunStore{checkpoints: make(map[string]*RunCheckpoint)}
+}
+
+// SaveCheckpoint stores the checkpoint of a run
+func (s *MemoryRunStore) SaveCheckpoint(ctx context.Context, checkpoint *RunCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[checkpoint.RunID] = checkpoint
+	return nil
+}
+
+// LoadCheckpoint returns the last checkpoint of a run
+func (s *MemoryRunStore) LoadCheckpoint(ctx context.Context, runID string) (*RunCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoint, ok := s.checkpoints[runID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+	}
+	return checkpoint, nil
+}
+
+// newCheckpoint snapshots the execution state. Runs continued by Resume or ResumeRun add the
+// progress made before them, so the checkpoint always covers the whole run.
+func newCheckpoint(state *executionState, status RunStatus, step int, result *Result) *RunCheckpoint {
+	if result == nil {
+		result = progressResult(state)
+	} else {
+		copied := *result
+		result = &copied
+	}
+
+	input := state.originalInput
+	if base := state.config.checkpointBase; base != nil {
+		mergePartial(result, base)
+		step += base.Turns
+		input = base.input
+	}
+
+	checkpoint := &RunCheckpoint{
+		RunID:     state.config.RunID,
+		Status:    status,
+		Agent:     state.currentAgent.Name,
+		Input:     input,
+		Step:      step,
+		Metadata:  state.config.Metadata,
+		Result:    result,
+		UpdatedAt: time.Now(),
+	}
+	for _, a := range state.handoffStack {
+		checkpoint.HandoffStack = append(checkpoint.HandoffStack, a.Name)
+	}
+	return checkpoint
+}
+
+// saveCheckpoint saves a checkpoint of the run to RunConfig.RunStore, if there is one
+func saveCheckpoint(state *executionState, status RunStatus, step int, result *Result) error {
+	if state.config.RunStore == nil {
+		return nil
+	}
+	checkpoint := newCheckpoint(state, status, step, result)
+	if err := state.config.RunStore.SaveCheckpoint(state.ctx, checkpoint); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// saveFailedCheckpoint records the error that ended the run. It is saved even when the run was
+// cancelled, and a store error is ignored so the run's own error is returned.
+func saveFailedCheckpoint(state *executionState, runErr error) {
+	if state.config.RunStore == nil {
+		return
+	}
+	checkpoint := newCheckpoint(state, endStatus(runErr), state.stepCounter, nil)
+	checkpoint.Error = runErr.Error()
+	_ = state.config.RunStore.SaveCheckpoint(context.WithoutCancel(state.ctx), checkpoint)
+}
+
+// ResumeRun continues a run from its last checkpoint in store, such as a run interrupted by a
+// crash. root is the agent the run started with; the agent that was running is found by name
+// among root and the agents it hands off to, directly or not. config is the configuration of
+// the run, with the same RunStore; its MaxTurns counts the turns made before the checkpoint.
+//
+// The result of a completed run is returned as is. Like Resume, ResumeRun sends the history in
+// full and does not apply input guardrails again. The turn in progress at the time of the crash
+// is made again, so its tools may run twice.
+func ResumeRun(ctx context.Context, store RunStore, runID string, root *agent.Agent, config RunConfig) (*Result, error) {
+	checkpoint, err := store.LoadCheckpoint(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint.Result == nil {
+		return nil, fmt.Errorf("%w: the checkpoint of run %s has no result", ErrNothingToResume, runID)
+	}
+
+	agents := agentsByName(root)
+	current, ok := agents[checkpoint.Agent]
+	if !ok {
+		return nil, fmt.Errorf("%w: agent %q of run %s is not reachable from %s", ErrNothingToResume, checkpoint.Agent, runID, root.Name)
+	}
+
+	result := *checkpoint.Result
+	result.LastAgent = current
+	if checkpoint.Status == RunStatusCompleted {
+		return &result, nil
+	}
+
+	var stack []*agent.Agent
+	for _, name := range checkpoint.HandoffStack {
+		if a, ok := agents[name]; ok {
+			stack = append(stack, a)
+		}
+	}
+
+	if config.MaxTurns <= 0 {
+		config.MaxTurns = DefaultMaxTurns
+	}
+	config.RunStore = store
+	config.RunID = runID
+	partial := &MaxTurnsExceededError{
+		Partial:      &result,
+		Turns:        checkpoint.Step,
+		config:       config,
+		handoffStack: stack,
+		input:        checkpoint.Input,
+	}
+	if checkpoint.Step >= config.MaxTurns {
+		return nil, partial
+	}
+	return Resume(ctx, partial, config.MaxTurns-checkpoint.Step)
+}
+
+// agentsByName returns root and the agents reachable from it through handoffs, by name
+func agentsByName(root *agent.Agent) map[string]*agent.Agent {
+	agents := make(map[string]*agent.Agent)
+	var walk func(a *agent.Agent)
+	walk = func(a *agent.Agent) {
+		if _, ok := agents[a.Name]; ok {
+			return
+		}
+		agents[a.Name] = a
+		for _, h := range a.Handoffs {
+			if target, ok := h.TargetAgent().(*agent.Agent); ok && target != nil {
+				walk(target)
+			}
+		}
+	}
+	walk(root)
+	return agents
+}
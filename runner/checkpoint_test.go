@@ -0,0 +1,168 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+func TestRunCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRunStore()
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("foo", "{}")},
+		{GetTextMessage("done")},
+	})
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("foo", "result"))
+
+	var saved []RunCheckpoint
+	recorder := runStoreFunc(func(ctx context.Context, checkpoint *RunCheckpoint) error {
+		saved = append(saved, *checkpoint)
+		return store.SaveCheckpoint(ctx, checkpoint)
+	})
+
+	result, err := RunWithConfig(ctx, testAgent, "hello", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		RunStore:      recorder,
+		Metadata:      map[string]string{"tenant": "acme"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.RunID)
+
+	// One checkpoint after the tool turn, and one when the run completed
+	require.Len(t, saved, 2)
+	assert.Equal(t, RunStatusRunning, saved[0].Status)
+	assert.Equal(t, 1, saved[0].Step)
+	assert.Len(t, saved[0].Result.History, 3)
+	assert.Equal(t, RunStatusCompleted, saved[1].Status)
+	assert.Equal(t, 2, saved[1].Step)
+	assert.Equal(t, "done", saved[1].Result.FinalOutput)
+	assert.Equal(t, "hello", saved[1].Input)
+	assert.Equal(t, "acme", saved[1].Metadata["tenant"])
+
+	// A completed run is not run again
+	resumed, err := ResumeRun(ctx, store, result.RunID, testAgent, RunConfig{ModelProvider: fakeModel})
+	require.NoError(t, err)
+	assert.Equal(t, "done", resumed.FinalOutput)
+	assert.Same(t, testAgent, resumed.LastAgent)
+	assert.Len(t, fakeModel.Calls(), 2)
+
+	_, err = ResumeRun(ctx, store, "unknown", testAgent, RunConfig{})
+	assert.ErrorIs(t, err, ErrRunNotFound)
+}
+
+func TestResumeRunAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRunStore()
+
+	billing := agent.New("billing", "Billing agent")
+	triage := agent.New("triage", "Triage agent")
+	triage.AddTool(NewFunctionTool("foo", "result"))
+	toBilling := handoff.NewHandoff(billing, "Handoff to billing")
+	triage.AddHandoff(toBilling)
+
+	// The model fails after the handoff, as a crash would
+	fakeModel := NewFakeModel()
+	fakeModel.AddTurn(GetFunctionToolCall("foo", "{}"))
+	fakeModel.AddTurn(GetFunctionToolCall(toBilling.ToolName(), "{}"))
+	fakeModel.AddError(errors.New("connection reset"))
+
+	config := RunConfig{ModelProvider: fakeModel, MaxTurns: 10, RunStore: store, RunID: "run-1"}
+	_, err := RunWithConfig(ctx, triage, "refund my order", config)
+	require.Error(t, err)
+
+	checkpoint, err := store.LoadCheckpoint(ctx, "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, RunStatusFailed, checkpoint.Status)
+	assert.Equal(t, "billing", checkpoint.Agent)
+	assert.Equal(t, 2, checkpoint.Step)
+	assert.Contains(t, checkpoint.Error, "connection reset")
+
+	// The run goes on from the billing agent, with the whole history
+	retryModel := NewFakeModel()
+	retryModel.AddTurn(GetTextMessage("refunded"))
+	config.ModelProvider = retryModel
+	result, err := ResumeRun(ctx, store, "run-1", triage, config)
+	require.NoError(t, err)
+	assert.Equal(t, "refunded", result.FinalOutput)
+	assert.Equal(t, "billing", result.LastAgent.Name)
+	assert.Equal(t, "run-1", result.RunID)
+	assert.Equal(t, 3, result.Usage.Requests)
+
+	calls := retryModel.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "refund my order", calls[0].Messages[1].Content)
+
+	// The final checkpoint covers the whole run
+	checkpoint, err = store.LoadCheckpoint(ctx, "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, RunStatusCompleted, checkpoint.Status)
+	assert.Equal(t, 3, checkpoint.Step)
+	assert.Equal(t, "refund my order", checkpoint.Input)
+	assert.Equal(t, 3, checkpoint.Result.Usage.Requests)
+	assert.Len(t, checkpoint.Result.UsageReport.Steps, 3)
+}
+
+func TestCheckpointNestedRuns(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRunStore()
+
+	innerModel := NewFakeModel()
+	innerModel.AddTurn(GetTextMessage("hola"))
+	translator := agent.New("translator", "Translate to Spanish")
+	translatorTool, err := AsToolWithConfig(translator, RunConfig{ModelProvider: innerModel}, tool.AgentToolOption{Name: "translate"})
+	require.NoError(t, err)
+
+	// The model fails after the nested run, as a crash would
+	outerModel := NewFakeModel()
+	outerModel.AddTurn(GetFunctionToolCall("translate", `{"input": "hello"}`))
+	outerModel.AddError(errors.New("connection reset"))
+	orchestrator := agent.New("orchestrator", "Use the translator")
+	orchestrator.AddTool(translatorTool)
+
+	config := RunConfig{ModelProvider: outerModel, MaxTurns: 5, RunStore: store, RunID: "run-1", KeepNestedResults: true}
+	_, err = RunWithConfig(ctx, orchestrator, "translate hello", config)
+	require.Error(t, err)
+
+	checkpoint, err := store.LoadCheckpoint(ctx, "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, RunStatusFailed, checkpoint.Status)
+	require.Len(t, checkpoint.Result.NestedRuns, 1)
+	assert.Equal(t, "hola", checkpoint.Result.NestedRuns[0].Result.FinalOutput)
+
+	// The resumed result keeps the nested run made before the failure
+	retryModel := NewFakeModel()
+	retryModel.AddTurn(GetTextMessage("hola!"))
+	config.ModelProvider = retryModel
+	result, err := ResumeRun(ctx, store, "run-1", orchestrator, config)
+	require.NoError(t, err)
+	require.Len(t, result.NestedRuns, 1)
+	assert.Equal(t, "translate", result.NestedRuns[0].ToolName)
+}
+
+// runStoreFunc is a RunStore that only saves checkpoints, with a function
+type runStoreFunc func(ctx context.Context, checkpoint *RunCheckpoint) error
+
+func (f runStoreFunc) SaveCheckpoint(ctx context.Context, checkpoint *RunCheckpoint) error {
+	return f(ctx, checkpoint)
+}
+
+func (f runStoreFunc) LoadCheckpoint(ctx context.Context, runID string) (*RunCheckpoint, error) {
+	return nil, ErrRunNotFound
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// maxContextRecoveries is the number of times a model call is retried with a shorter history
+const maxContextRecoveries = 3
+
+// ContextRecovery records a model call that exceeded the context window and was retried after
+// RunConfig.ContextWindowRecovery shortened the history
+type ContextRecovery struct {
+	// Step is the turn of the model call
+	Step int `json:"step"`
+
+	// AgentName is the name of the agent that made the call
+	AgentName string `json:"agent_name"`
+
+	// Error is the provider error that reported the exceeded context window
+	Error string `json:"error"`
+
+	// MessagesBefore and MessagesAfter are the lengths of the history sent before and after recovery
+	MessagesBefore int `json:"messages_before"`
+	MessagesAfter  int `json:"messages_after"`
+
+	// Dropped are the messages that are no longer sent to the model. They remain in Result.History.
+	Dropped []Message `json:"dropped"`
+}
+
+// HalvingTrimmer keeps the system messages and the most recent half of the other messages.
+// As RunConfig.ContextWindowRecovery, each retry halves the history again.
+type HalvingTrimmer struct{}
+
+// Trim drops the oldest half of the non-system messages
+func (HalvingTrimmer) Trim(ctx context.Context, messages []model.Message) ([]model.Message, error) {
+	conversation := conversationMessages(messages)
+	return LastMessagesTrimmer{N: len(conversation) / 2}.Trim(ctx, messages)
+}
+
+// shouldRecoverContext reports whether a failed model call can be retried with a shorter history
+func shouldRecoverContext(state *executionState, serverState bool, err error) bool {
+	return state.config.ContextWindowRecovery != nil && !serverState && model.IsContextWindowExceeded(err)
+}
+
+// recoverContextWindow shortens the working history with the run's ContextWindowRecovery
+// trimmer after a model call exceeded the context window. It reports false if the trimmer did
+// not drop any message, since retrying would fail again.
+func recoverContextWindow(ctx context.Context, state *executionState, cause error) (bool, error) {
+	before := state.messages
+	trimmed, err := state.config.ContextWindowRecovery.Trim(ctx, before)
+	if err != nil {
+		return false, fmt.Errorf("failed to trim history after the context window was exceeded: %w", err)
+	}
+	if len(trimmed) >= len(before) {
+		return false, nil
+	}
+	state.messages = trimmed
+
+	dropped := droppedMessages(before, trimmed)
+	state.contextRecoveries = append(state.contextRecoveries, ContextRecovery{
+		Step:           state.stepCounter + 1,
+		AgentName:      state.currentAgent.Name,
+		Error:          cause.Error(),
+		MessagesBefore: len(before),
+		MessagesAfter:  len(trimmed),
+		Dropped:        convertModelMessages(dropped),
+	})
+
+	if span := tracing.GetActiveSpan(ctx); span != nil {
+		span.AddEvent("context_window_recovery", map[string]any{
+			"messages_before": len(before),
+			"messages_after":  len(trimmed),
+			"dropped":         len(dropped),
+		})
+	}
+	return true, nil
+}
+
+// droppedMessages returns the messages of before that are not in after
+func droppedMessages(before []model.Message, after []model.Message) []model.Message {
+	kept := make(map[string]int, len(after))
+	for _, msg := range after {
+		kept[messageKey(msg)]++
+	}
+
+	var dropped []model.Message
+	for _, msg := range before {
+		key := messageKey(msg)
+		if kept[key] > 0 {
+			kept[key]--
+			continue
+		}
+		dropped = append(dropped, msg)
+	}
+	return dropped
+}
+
+// messageKey identifies a message by its content
+func messageKey(msg model.Message) string {
+	data, _ := json.Marshal(msg)
+	return string(data)
+}
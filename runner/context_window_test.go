@@ -0,0 +1,107 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// smallWindowModel answers only prompts of at most maxMessages messages
+func smallWindowModel(maxMessages int, calls *[]int) model.Provider {
+	return model.ProviderFuncs{
+		Completion: func(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+			*calls = append(*calls, len(messages))
+			if len(messages) > maxMessages {
+				return nil, errors.New("This model's maximum context length is 128000 tokens. Please reduce the length of the messages.")
+			}
+			return &model.Response{Message: model.Message{Role: "assistant", Content: "done"}}, nil
+		},
+	}
+}
+
+func longHistory(n int) []Message {
+	history := make([]Message, 0, n)
+	for i := 0; i < n; i++ {
+		role := "user"
+		if i%2 == 1 {
+			role = "assistant"
+		}
+		history = append(history, Message{Role: role, Content: fmt.Sprintf("message %d", i)})
+	}
+	return history
+}
+
+func TestContextWindowRecovery(t *testing.T) {
+	var calls []int
+	testAgent := agent.New("assistant", "You are helpful.")
+	hooks := &llmHooksRecorder{}
+
+	result, err := RunWithConfig(context.Background(), testAgent, "latest question", RunConfig{
+		ModelProvider:         smallWindowModel(4, &calls),
+		History:               longHistory(10),
+		ContextWindowRecovery: HalvingTrimmer{},
+		LLMHooks:              hooks,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	// system + 11 messages, then system + 5, then system + 2
+	assert.Equal(t, []int{12, 6, 3}, calls)
+	require.Len(t, result.ContextRecoveries, 2)
+
+	// OnLLMStart sees the messages of every attempt, including the retries
+	require.Len(t, hooks.startMessages, 3)
+	for i, messages := range hooks.startMessages {
+		assert.Len(t, messages, calls[i])
+	}
+
+	// Every OnLLMStart is paired with an OnLLMEnd, failed attempts included
+	require.Len(t, hooks.responses, len(hooks.startMessages))
+	assert.Error(t, hooks.errs[0])
+	assert.Error(t, hooks.errs[1])
+	assert.NoError(t, hooks.errs[2])
+
+	first := result.ContextRecoveries[0]
+	assert.Equal(t, 1, first.Step)
+	assert.Equal(t, "assistant", first.AgentName)
+	assert.Contains(t, first.Error, "maximum context length")
+	assert.Equal(t, 12, first.MessagesBefore)
+	assert.Equal(t, 6, first.MessagesAfter)
+	require.Len(t, first.Dropped, 6)
+	assert.Equal(t, "message 0", first.Dropped[0].Content)
+
+	// The full conversation is kept in the history
+	assert.Len(t, result.History, 12)
+}
+
+func TestContextWindowExceededError(t *testing.T) {
+	var calls []int
+	testAgent := agent.New("assistant", "You are helpful.")
+
+	// Without recovery, the error is recognizable
+	_, err := RunWithConfig(context.Background(), testAgent, "question", RunConfig{
+		ModelProvider: smallWindowModel(1, &calls),
+	})
+	assert.ErrorIs(t, err, model.ErrContextWindowExceeded)
+	assert.Len(t, calls, 1)
+
+	// Recovery stops when the trimmer cannot drop anything more
+	calls = nil
+	_, err = RunWithConfig(context.Background(), testAgent, "question", RunConfig{
+		ModelProvider:         smallWindowModel(1, &calls),
+		ContextWindowRecovery: HalvingTrimmer{},
+	})
+	assert.ErrorIs(t, err, model.ErrContextWindowExceeded)
+	assert.Len(t, calls, 1)
+}
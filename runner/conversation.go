@@ -0,0 +1,66 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// usesServerState reports whether the run keeps its history on the provider.
+// It fails if the run continues a stored response or conversation with a provider without
+// server state, since the history stored there cannot be resent.
+func usesServerState(state *executionState, provider model.Provider, modelName string) (bool, error) {
+	if state.previousResponseID == "" && state.config.ConversationID == "" {
+		return false, nil
+	}
+	if !model.SupportsServerState(provider, modelName) {
+		return false, fmt.Errorf("%w: agent %s, model %q", model.ErrServerStateUnsupported, state.currentAgent.Name, modelName)
+	}
+	return true, nil
+}
+
+// applyServerState sets the conversation state settings and returns the messages to send:
+// the system messages and the messages the provider has not stored yet
+func applyServerState(state *executionState, settings *model.Settings) []model.Message {
+	if state.config.ConversationID != "" {
+		settings.ConversationID = state.config.ConversationID
+	} else {
+		settings.PreviousResponseID = state.previousResponseID
+	}
+
+	messages := make([]model.Message, 0, len(state.messages))
+	stored := 0
+	for _, msg := range state.messages {
+		if msg.Role == "system" {
+			messages = append(messages, msg)
+			continue
+		}
+		if stored < state.serverHistory {
+			stored++
+			continue
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages
+}
+
+// recordServerState marks the current history and the response as stored by the provider
+func recordServerState(state *executionState, responseID string) {
+	stored := 0
+	for _, msg := range state.messages {
+		if msg.Role != "system" {
+			stored++
+		}
+	}
+
+	// The response message is appended to the history after this call
+	state.serverHistory = stored + 1
+	if responseID != "" {
+		state.previousResponseID = responseID
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// statefulModel is a FakeModel that stores conversation state and numbers its responses
+type statefulModel struct {
+	*FakeModel
+	requests [][]model.Message
+	settings []model.Settings
+}
+
+func (m *statefulModel) SupportsServerState() bool {
+	return true
+}
+
+func (m *statefulModel) CreateChatCompletion(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+	m.requests = append(m.requests, messages)
+	m.settings = append(m.settings, settings)
+
+	response, err := m.FakeModel.CreateChatCompletion(ctx, messages, settings)
+	if err != nil {
+		return nil, err
+	}
+	response.ID = fmt.Sprintf("resp_%d", len(m.requests))
+	return response, nil
+}
+
+func TestPreviousResponseID(t *testing.T) {
+	provider := &statefulModel{FakeModel: NewFakeModel()}
+	provider.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("lookup", `{"a": "b"}`)},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("lookup", "found"))
+
+	result, err := RunWithConfig(context.Background(), testAgent, "next question", RunConfig{
+		ModelProvider:      provider,
+		MaxTurns:           3,
+		PreviousResponseID: "resp_earlier",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "resp_2", result.LastResponseID)
+	require.Len(t, provider.requests, 2)
+
+	// The first turn sends only the new input on top of the stored response
+	assert.Equal(t, "resp_earlier", provider.settings[0].PreviousResponseID)
+	require.Len(t, provider.requests[0], 2)
+	assert.Equal(t, "system", provider.requests[0][0].Role)
+	assert.Equal(t, "next question", provider.requests[0][1].Content)
+
+	// The second turn chains from the first response and sends only the tool result
+	assert.Equal(t, "resp_1", provider.settings[1].PreviousResponseID)
+	require.Len(t, provider.requests[1], 2)
+	assert.Equal(t, "system", provider.requests[1][0].Role)
+	assert.Equal(t, "tool", provider.requests[1][1].Role)
+
+	// The history of the run is still complete
+	assert.Len(t, result.History, 4)
+}
+
+func TestConversationID(t *testing.T) {
+	provider := &statefulModel{FakeModel: NewFakeModel()}
+	provider.SetNextOutput([]model.Message{GetTextMessage("hi")})
+
+	_, err := RunWithConfig(context.Background(), agent.New("test", "Test agent"), "hello", RunConfig{
+		ModelProvider:  provider,
+		MaxTurns:       3,
+		ConversationID: "conv_123",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "conv_123", provider.settings[0].ConversationID)
+	assert.Empty(t, provider.settings[0].PreviousResponseID)
+}
+
+func TestPreviousResponseIDStatelessProvider(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+
+	for _, config := range []RunConfig{
+		{ModelProvider: fakeModel, MaxTurns: 3, PreviousResponseID: "resp_earlier"},
+		{ModelProvider: fakeModel, MaxTurns: 3, ConversationID: "conv_123"},
+	} {
+		_, err := RunWithConfig(context.Background(), agent.New("test", "Test agent"), "question", config)
+
+		// The stored history cannot be resent, so the run fails instead of losing it
+		assert.ErrorIs(t, err, model.ErrServerStateUnsupported)
+	}
+	assert.Empty(t, fakeModel.Calls())
+}
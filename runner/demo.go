@@ -0,0 +1,127 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+// DemoLoopOptions configures RunDemoLoop
+type DemoLoopOptions struct {
+	// Config is the configuration of every run (optional, defaults to DefaultRunConfig)
+	Config *RunConfig
+
+	// Input is read line by line (defaults to os.Stdin)
+	Input io.Reader
+
+	// Output receives the prompt, the agent's output and tool events (defaults to os.Stdout)
+	Output io.Writer
+
+	// Prompt is written before each line is read (defaults to "> ")
+	Prompt string
+}
+
+// RunDemoLoop runs an interactive session with the agent for development: each line read from
+// the input is run as a new turn of the conversation, and the agent's output, tool calls, tool
+// progress and handoffs are written to the output. The conversation history is kept across
+// turns, and the agent that answered last handles the next line.
+//
+// The loop ends on end of input, "exit" or "quit", or when ctx is done. Run errors are written
+// to the output and the session continues.
+func RunDemoLoop(ctx context.Context, a *agent.Agent, opts DemoLoopOptions) error {
+	config := DefaultRunConfig()
+	if opts.Config != nil {
+		config = *opts.Config
+	}
+	input := opts.Input
+	if input == nil {
+		input = os.Stdin
+	}
+	out := opts.Output
+	if out == nil {
+		out = os.Stdout
+	}
+	prompt := opts.Prompt
+	if prompt == "" {
+		prompt = "> "
+	}
+
+	config.LLMHooks = &demoHooks{next: config.LLMHooks, out: out}
+	progressHandler := config.ToolProgressHandler
+	config.ToolProgressHandler = func(ctx context.Context, event tool.ProgressEvent) {
+		fmt.Fprintf(out, "[%s] %s\n", event.ToolName, event.Message)
+		if progressHandler != nil {
+			progressHandler(ctx, event)
+		}
+	}
+
+	current := a
+	scanner := bufio.NewScanner(input)
+	for {
+		fmt.Fprint(out, prompt)
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "exit" || line == "quit" {
+			return nil
+		}
+
+		result, err := RunWithConfig(ctx, current, line, config)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Fprintf(out, "Error: %v\n", err)
+			continue
+		}
+
+		if result.LastAgent != nil && result.LastAgent != current {
+			fmt.Fprintf(out, "[handoff to %s]\n", result.LastAgent.Name)
+			current = result.LastAgent
+		}
+		fmt.Fprintln(out, result.FinalOutput)
+		config.History = result.History
+	}
+}
+
+// demoHooks writes the tool calls of every model response to the demo output
+type demoHooks struct {
+	next agent.LLMHooks
+	out  io.Writer
+}
+
+func (h *demoHooks) OnLLMStart(ctx context.Context, a *agent.Agent, messages []model.Message, settings model.Settings) error {
+	if h.next != nil {
+		return h.next.OnLLMStart(ctx, a, messages, settings)
+	}
+	return nil
+}
+
+func (h *demoHooks) OnLLMEnd(ctx context.Context, a *agent.Agent, response *model.Response, err error) error {
+	if response != nil {
+		for _, call := range response.Message.ToolCalls {
+			fmt.Fprintf(h.out, "[%s calls %s(%s)]\n", a.Name, call.Function.Name, call.Function.Arguments)
+		}
+	}
+	if h.next != nil {
+		return h.next.OnLLMEnd(ctx, a, response, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestRunDemoLoop(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("weather", `{"a": "Tokyo"}`)},
+		{GetTextMessage("It is sunny in Tokyo")},
+		{GetTextMessage("You asked about Tokyo")},
+	})
+	hooks := &llmHooksRecorder{}
+
+	testAgent := agent.New("assistant", "Test agent")
+	testAgent.AddTool(NewFunctionTool("weather", "sunny"))
+
+	var out bytes.Buffer
+	err := RunDemoLoop(context.Background(), testAgent, DemoLoopOptions{
+		Config: &RunConfig{ModelProvider: fakeModel, MaxTurns: 3, LLMHooks: hooks},
+		Input:  strings.NewReader("Weather in Tokyo?\n\nWhich city did I ask about?\nexit\nnot read\n"),
+		Output: &out,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "> [assistant calls weather({\"a\": \"Tokyo\"})]\n"+
+		"It is sunny in Tokyo\n"+
+		"> > You asked about Tokyo\n"+
+		"> ", out.String())
+
+	// The second line continues the conversation of the first
+	require.Len(t, hooks.startMessages, 3)
+	second := hooks.startMessages[2]
+	require.Len(t, second, 6)
+	assert.Equal(t, "Weather in Tokyo?", second[1].Content)
+	assert.Equal(t, "Which city did I ask about?", second[5].Content)
+}
+
+func TestRunDemoLoopReportsErrors(t *testing.T) {
+	var out bytes.Buffer
+	err := RunDemoLoop(context.Background(), agent.New("assistant", "Test agent"), DemoLoopOptions{
+		Config: &RunConfig{ModelProvider: &echoProvider{err: errors.New("rate limited")}, MaxTurns: 3},
+		Input:  strings.NewReader("hello\n"),
+		Output: &out,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Error: ")
+	assert.Contains(t, out.String(), "rate limited")
+	assert.True(t, strings.HasSuffix(out.String(), "> \n"))
+}
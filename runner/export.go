@@ -0,0 +1,174 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// ErrUnsupportedExportFormat is returned by Result.Export for unknown formats
+var ErrUnsupportedExportFormat = errors.New("unsupported export format")
+
+// ExportFormat is the format of an exported run transcript
+type ExportFormat string
+
+const (
+	// ExportJSON is the indented JSON wire format of Result, described by JSONSchema
+	ExportJSON ExportFormat = "json"
+
+	// ExportMarkdown is a human-readable transcript
+	ExportMarkdown ExportFormat = "markdown"
+)
+
+// Export returns a transcript of the run in the given format: the messages, the tool calls with
+// their arguments and outputs, the handoffs, the guardrail results, the usage and the timings.
+// JSON transcripts can be decoded back into a Result.
+func (r *Result) Export(format ExportFormat) ([]byte, error) {
+	switch format {
+	case ExportJSON:
+		return json.MarshalIndent(r, "", "  ")
+	case ExportMarkdown:
+		return []byte(r.markdown()), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedExportFormat, format)
+	}
+}
+
+// markdown renders the run as a markdown transcript
+func (r *Result) markdown() string {
+	var b strings.Builder
+
+	b.WriteString("# Run transcript\n\n")
+	if r.LastAgent != nil {
+		fmt.Fprintf(&b, "- **Last agent:** %s\n", r.LastAgent.Name)
+	}
+	if !r.StartedAt.IsZero() {
+		fmt.Fprintf(&b, "- **Started at:** %s\n", r.StartedAt.Format(time.RFC3339))
+	}
+	if r.Duration > 0 {
+		fmt.Fprintf(&b, "- **Duration:** %s\n", r.Duration.Round(time.Millisecond))
+	}
+	fmt.Fprintf(&b, "- **Usage:** %d tokens (%d prompt, %d completion) in %d requests\n",
+		r.Usage.TotalTokens, r.Usage.PromptTokens, r.Usage.CompletionTokens, r.Usage.Requests)
+
+	b.WriteString("\n## Conversation\n")
+	outputs := make(map[string]string)
+	for _, msg := range r.History {
+		if msg.Role == "tool" {
+			outputs[msg.ToolCallID] = msg.Content
+		}
+	}
+	answered := make(map[string]bool)
+	for _, msg := range r.History {
+		if msg.Role == "tool" {
+			if !answered[msg.ToolCallID] {
+				fmt.Fprintf(&b, "\n### Tool output (`%s`)\n\n%s", msg.ToolCallID, fence(msg.Content, ""))
+			}
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n### %s\n", roleTitle(msg.Role))
+		if msg.Reasoning != "" {
+			fmt.Fprintf(&b, "\n> %s\n", strings.ReplaceAll(msg.Reasoning, "\n", "\n> "))
+		}
+		if msg.Content != "" {
+			fmt.Fprintf(&b, "\n%s\n", msg.Content)
+		}
+		for _, part := range msg.ContentParts {
+			fmt.Fprintf(&b, "\n%s\n", describePart(part))
+		}
+		for _, tc := range msg.ToolCalls {
+			fmt.Fprintf(&b, "\n**Tool call** `%s` (`%s`)\n\n%s", tc.Function.Name, tc.ID, fence(tc.Function.Arguments, "json"))
+			if output, ok := outputs[tc.ID]; ok {
+				fmt.Fprintf(&b, "\n**Output**\n\n%s", fence(output, ""))
+				answered[tc.ID] = true
+			}
+		}
+	}
+
+	if len(r.Handoffs) > 0 {
+		b.WriteString("\n## Handoffs\n\n")
+		for _, h := range r.Handoffs {
+			fmt.Fprintf(&b, "- Step %d: %s → %s", h.Step, h.FromAgent, h.ToAgent)
+			if h.Input != "" && h.Input != "{}" {
+				fmt.Fprintf(&b, " with `%s`", h.Input)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(r.InputGuardrailResults) > 0 || len(r.OutputGuardrailResults) > 0 {
+		b.WriteString("\n## Guardrails\n\n| Kind | Guardrail | Agent | Allowed | Message |\n|---|---|---|---|---|\n")
+		for _, g := range r.InputGuardrailResults {
+			writeGuardrailRow(&b, "input", g)
+		}
+		for _, g := range r.OutputGuardrailResults {
+			writeGuardrailRow(&b, "output", g)
+		}
+	}
+
+	if len(r.UsageReport.Steps) > 0 {
+		b.WriteString("\n## Steps\n\n| Step | Agent | Model | Tokens | Duration |\n|---|---|---|---|---|\n")
+		for _, step := range r.UsageReport.Steps {
+			fmt.Fprintf(&b, "| %d | %s | %s | %d | %dms |\n", step.Step, step.AgentName, step.Model, step.Usage.TotalTokens, step.DurationMS)
+		}
+	}
+
+	b.WriteString("\n## Final output\n\n")
+	b.WriteString(fence(r.FinalOutput, ""))
+	return b.String()
+}
+
+// roleTitle returns the heading of a message role
+func roleTitle(role string) string {
+	if role == "" {
+		return "Message"
+	}
+	return strings.ToUpper(role[:1]) + role[1:]
+}
+
+// describePart describes a content part without its inline data
+func describePart(part model.ContentPart) string {
+	switch {
+	case part.Type == model.ContentPartText:
+		return part.Text
+	case part.ImageURL != nil:
+		if strings.HasPrefix(part.ImageURL.URL, "data:") {
+			return "_[inline image]_"
+		}
+		return fmt.Sprintf("_[image: %s]_", part.ImageURL.URL)
+	case part.File != nil:
+		name := part.File.FileName
+		if name == "" {
+			name = part.File.FileID
+		}
+		return fmt.Sprintf("_[file: %s]_", name)
+	case part.InputAudio != nil:
+		return fmt.Sprintf("_[audio: %s]_", part.InputAudio.Format)
+	default:
+		return fmt.Sprintf("_[%s]_", part.Type)
+	}
+}
+
+// writeGuardrailRow writes a guardrail result as a markdown table row
+func writeGuardrailRow(b *strings.Builder, kind string, g GuardrailResult) {
+	message := strings.ReplaceAll(g.Message, "|", `\|`)
+	fmt.Fprintf(b, "| %s | %s | %s | %t | %s |\n", kind, g.Guardrail, g.AgentName, g.Allowed, message)
+}
+
+// fence wraps content in a code block whose fence is longer than any backtick run in it
+func fence(content string, lang string) string {
+	marker := "```"
+	for strings.Contains(content, marker) {
+		marker += "`"
+	}
+	return marker + lang + "\n" + content + "\n" + marker + "\n"
+}
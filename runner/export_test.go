@@ -0,0 +1,86 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func exportedRun(t *testing.T) *Result {
+	t.Helper()
+
+	billing := agent.New("billing", "billing instructions")
+	triage := agent.New("triage", "triage instructions")
+	triage.AddTool(NewFunctionTool("lookup", "order ```42```"))
+	triage.AddHandoff(handoff.NewHandoffWithOptions(billing, "Billing", handoff.Options{ToolName: "transfer_to_billing"}))
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("lookup", `{"id": 42}`)},
+		{GetFunctionToolCall("transfer_to_billing", `{}`)},
+		{GetTextMessage("Your refund is on its way")},
+	})
+
+	result, err := RunWithConfig(context.Background(), triage, "where is my refund?", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestResultRecordsHandoffsAndTimings(t *testing.T) {
+	result := exportedRun(t)
+
+	assert.Equal(t, []HandoffRecord{{Step: 2, FromAgent: "triage", ToAgent: "billing", Input: "{}"}}, result.Handoffs)
+	assert.False(t, result.StartedAt.IsZero())
+	assert.Positive(t, result.Duration)
+	require.Len(t, result.UsageReport.Steps, 3)
+}
+
+func TestExportJSON(t *testing.T) {
+	result := exportedRun(t)
+
+	data, err := result.Export(ExportJSON)
+	require.NoError(t, err)
+
+	var decoded Result
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, result.History, decoded.History)
+	assert.Equal(t, result.Handoffs, decoded.Handoffs)
+	assert.True(t, result.StartedAt.Equal(decoded.StartedAt))
+	assert.Equal(t, result.Duration.Milliseconds(), decoded.Duration.Milliseconds())
+}
+
+func TestExportMarkdown(t *testing.T) {
+	result := exportedRun(t)
+
+	data, err := result.Export(ExportMarkdown)
+	require.NoError(t, err)
+	transcript := string(data)
+
+	assert.Contains(t, transcript, "# Run transcript")
+	assert.Contains(t, transcript, "- **Last agent:** billing")
+	assert.Contains(t, transcript, "### User\n\nwhere is my refund?\n")
+	assert.Contains(t, transcript, "**Tool call** `lookup` (`call_lookup`)\n\n```json\n{\"id\": 42}\n```\n")
+	// Outputs containing fences get a longer fence
+	assert.Contains(t, transcript, "**Output**\n\n````\norder ```42```\n````\n")
+	assert.Contains(t, transcript, "- Step 2: triage → billing\n")
+	assert.Contains(t, transcript, "| 3 | billing |")
+	assert.Contains(t, transcript, "## Final output\n\n```\nYour refund is on its way\n```\n")
+	assert.NotContains(t, transcript, "### Tool output")
+
+	_, err = result.Export("pdf")
+	assert.ErrorIs(t, err, ErrUnsupportedExportFormat)
+}
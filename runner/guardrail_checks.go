@@ -0,0 +1,138 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// ErrGuardrailTimeout is matched by errors.Is when a guardrail check exceeds its time limit
+var ErrGuardrailTimeout = errors.New("guardrail check timed out")
+
+// guardrailTimeout returns the time limit of the guardrail's checks, or 0 for no limit
+func guardrailTimeout(config RunConfig, name string) time.Duration {
+	if timeout, ok := config.GuardrailTimeouts[name]; ok {
+		return timeout
+	}
+	return config.GuardrailTimeout
+}
+
+// checkGuardrail runs a guardrail check in a "guardrail" span of its own, named after the
+// guardrail. kind is "input" or "output", and outcome reports whether the result allows the run
+// to go on, with its message.
+func checkGuardrail[T any](ctx context.Context, config RunConfig, kind string, name string, check func(ctx context.Context) (T, error), outcome func(result T) (bool, string)) (T, error) {
+	span, ctx := tracing.StartSpan(ctx, name, map[string]any{
+		"span_type":      "guardrail",
+		"guardrail_name": name,
+		"guardrail_type": kind,
+	})
+	defer span.End()
+
+	start := time.Now()
+	result, err := checkGuardrailTimeout(ctx, config, name, check)
+	span.SetAttribute("duration_ms", time.Since(start).Milliseconds())
+	if err != nil {
+		span.SetAttribute("error", err.Error())
+		return result, err
+	}
+
+	allowed, message := outcome(result)
+	span.SetAttribute("allowed", allowed)
+	span.SetAttribute("triggered", !allowed)
+	if message != "" {
+		span.SetAttribute("guardrail_message", message)
+	}
+	return result, nil
+}
+
+// checkGuardrailTimeout runs a guardrail check within its time limit, if it has one. The check
+// then returns as soon as the limit expires or ctx is done, even if it ignores its context; the
+// abandoned check finishes in the background. Exceeding the limit returns an error wrapping
+// ErrGuardrailTimeout.
+func checkGuardrailTimeout[T any](ctx context.Context, config RunConfig, name string, check func(ctx context.Context) (T, error)) (T, error) {
+	timeout := guardrailTimeout(config, name)
+	if timeout <= 0 {
+		return check(ctx)
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		result T
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := check(checkCtx)
+		done <- outcome{result: result, err: err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.result, o.err
+	case <-checkCtx.Done():
+		var zero T
+		if ctx.Err() == nil && errors.Is(checkCtx.Err(), context.DeadlineExceeded) {
+			return zero, fmt.Errorf("%w: guardrail '%s' did not finish within %s", ErrGuardrailTimeout, name, timeout)
+		}
+		return zero, checkCtx.Err()
+	}
+}
+
+// parallelCheck is the outcome of a guardrail check run by runParallelChecks
+type parallelCheck[T any] struct {
+	result T
+	err    error
+
+	// done is false for the checks cancelled because another guardrail tripped
+	done bool
+}
+
+// runParallelChecks runs n guardrail checks concurrently. The first check to trip wins: it
+// returns right away, the other checks are cancelled and their outcomes dropped. It returns the
+// outcomes in guardrail order and the index of the check that tripped, or -1.
+func runParallelChecks[T any](ctx context.Context, n int, check func(ctx context.Context, i int) (T, error), tripped func(result T) bool) ([]parallelCheck[T], int) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	checks := make([]parallelCheck[T], n)
+	trip, remaining := -1, n
+	finished := make(chan struct{})
+	if n == 0 {
+		close(finished)
+	}
+
+	var mu sync.Mutex
+	for i := range n {
+		go func() {
+			result, err := check(ctx, i)
+
+			mu.Lock()
+			defer mu.Unlock()
+			remaining--
+			if trip >= 0 {
+				return
+			}
+			checks[i] = parallelCheck[T]{result: result, err: err, done: true}
+			if err == nil && tripped(result) {
+				trip = i
+				close(finished)
+			} else if remaining == 0 {
+				close(finished)
+			}
+		}()
+	}
+	<-finished
+
+	mu.Lock()
+	defer mu.Unlock()
+	return checks, trip
+}
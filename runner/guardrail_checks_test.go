@@ -0,0 +1,187 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/guardrail"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// slowInputGuardrail allows the input after a delay, like a guardrail calling a model
+func slowInputGuardrail(name string, delay time.Duration) guardrail.InputGuardrail {
+	return guardrail.NewInputGuardrail(name, "Slow guardrail", func(ctx context.Context, input string) (guardrail.InputGuardrailResult, error) {
+		select {
+		case <-time.After(delay):
+			return guardrail.InputGuardrailResult{Allowed: true}, nil
+		case <-ctx.Done():
+			return guardrail.InputGuardrailResult{}, ctx.Err()
+		}
+	})
+}
+
+func TestParallelInputGuardrails(t *testing.T) {
+	ctx := context.Background()
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddInputGuardrail(slowInputGuardrail("first", 100*time.Millisecond))
+	testAgent.AddInputGuardrail(slowInputGuardrail("second", 100*time.Millisecond))
+	testAgent.AddInputGuardrail(slowInputGuardrail("third", 100*time.Millisecond))
+
+	start := time.Now()
+	result, err := RunWithConfig(ctx, testAgent, "hello", RunConfig{
+		ModelProvider:      fakeModel,
+		MaxTurns:           1,
+		ParallelGuardrails: true,
+	})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 250*time.Millisecond)
+	require.Len(t, result.InputGuardrailResults, 3)
+	assert.Equal(t, "third", result.InputGuardrailResults[2].Guardrail)
+
+	// The first tripwire wins without waiting for the slow guardrails
+	blocking := agent.New("test", "Test agent")
+	blocking.AddInputGuardrail(slowInputGuardrail("slow", time.Hour))
+	blocking.AddInputGuardrail(guardrail.NewInputGuardrail("deny", "Denies everything", func(ctx context.Context, input string) (guardrail.InputGuardrailResult, error) {
+		return guardrail.InputGuardrailResult{Message: "denied"}, nil
+	}))
+	start = time.Now()
+	_, err = RunWithConfig(ctx, blocking, "hello", RunConfig{
+		ModelProvider:      fakeModel,
+		MaxTurns:           1,
+		ParallelGuardrails: true,
+	})
+	assert.ErrorIs(t, err, ErrGuardrailTripwire)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Without a tripwire, the errors of all guardrails are returned
+	failing := agent.New("test", "Test agent")
+	for _, name := range []string{"moderation", "pii"} {
+		failing.AddInputGuardrail(guardrail.NewInputGuardrail(name, "Fails", func(ctx context.Context, input string) (guardrail.InputGuardrailResult, error) {
+			return guardrail.InputGuardrailResult{}, errors.New(name + " unavailable")
+		}))
+	}
+	_, err = RunWithConfig(ctx, failing, "hello", RunConfig{
+		ModelProvider:      fakeModel,
+		MaxTurns:           1,
+		ParallelGuardrails: true,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "moderation unavailable")
+	assert.Contains(t, err.Error(), "pii unavailable")
+}
+
+func TestParallelOutputGuardrails(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("call me at 555-0100, bob@example.com")})
+
+	redact := func(name string, secret string) guardrail.OutputGuardrail {
+		return guardrail.NewOutputGuardrail(name, "Redacts", func(ctx context.Context, output string) (guardrail.OutputGuardrailResult, error) {
+			return guardrail.OutputGuardrailResult{Allowed: true, ModifiedOutput: strings.ReplaceAll(output, secret, "[redacted]")}, nil
+		})
+	}
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddOutputGuardrail(redact("phone", "555-0100"))
+	testAgent.AddOutputGuardrail(redact("email", "bob@example.com"))
+
+	// Both guardrails check the original output; the last modification wins
+	result, err := RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		ModelProvider:      fakeModel,
+		MaxTurns:           1,
+		ParallelGuardrails: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "call me at 555-0100, [redacted]", result.FinalOutput)
+	assert.Len(t, result.OutputGuardrailResults, 2)
+}
+
+func TestGuardrailTimeout(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+
+	// The guardrail ignores its context
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddInputGuardrail(guardrail.NewInputGuardrail("stuck", "Never answers in time", func(ctx context.Context, input string) (guardrail.InputGuardrailResult, error) {
+		time.Sleep(time.Second)
+		return guardrail.InputGuardrailResult{Allowed: true}, nil
+	}))
+
+	start := time.Now()
+	_, err := RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		ModelProvider:     fakeModel,
+		MaxTurns:          1,
+		GuardrailTimeouts: map[string]time.Duration{"stuck": 20 * time.Millisecond},
+	})
+	assert.ErrorIs(t, err, ErrGuardrailTimeout)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestGuardrailSpans(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("the password is hunter2")})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddInputGuardrail(guardrail.NewInputGuardrail("no_secrets", "Blocks secrets", func(ctx context.Context, input string) (guardrail.InputGuardrailResult, error) {
+		return guardrail.InputGuardrailResult{Allowed: true}, nil
+	}))
+	testAgent.AddOutputGuardrail(guardrail.NewOutputGuardrail("no_passwords", "Blocks passwords", func(ctx context.Context, output string) (guardrail.OutputGuardrailResult, error) {
+		return guardrail.OutputGuardrailResult{Message: "password leaked"}, nil
+	}))
+
+	_, err := RunWithConfig(context.Background(), testAgent, "hello", RunConfig{ModelProvider: fakeModel, MaxTurns: 1})
+	assert.ErrorIs(t, err, ErrGuardrailTripwire)
+
+	// Each guardrail has a span of its own, under the span of its set
+	spans := recorder.byName("no_secrets")
+	require.Len(t, spans, 1)
+	attributes := spans[0].Context().Attributes
+	assert.Equal(t, "guardrail", attributes["span_type"])
+	assert.Equal(t, "input", attributes["guardrail_type"])
+	assert.Equal(t, true, attributes["allowed"])
+	assert.Contains(t, attributes, "duration_ms")
+	assert.Equal(t, recorder.byName("input_guardrails")[0].Context().SpanID, spans[0].Context().ParentSpanID)
+
+	spans = recorder.byName("no_passwords")
+	require.Len(t, spans, 1)
+	attributes = spans[0].Context().Attributes
+	assert.Equal(t, "output", attributes["guardrail_type"])
+	assert.Equal(t, true, attributes["triggered"])
+	assert.Equal(t, "password leaked", attributes["guardrail_message"])
+}
+
+func TestGuardrailSpanRedaction(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddInputGuardrail(guardrail.NewInputGuardrail("no_secrets", "Blocks secrets", func(ctx context.Context, input string) (guardrail.InputGuardrailResult, error) {
+		return guardrail.InputGuardrailResult{Message: "input quotes " + input}, nil
+	}))
+
+	_, err := RunWithConfig(context.Background(), testAgent, "my password is hunter2", RunConfig{
+		ModelProvider:  fakeModel,
+		MaxTurns:       1,
+		TraceRedaction: tracing.ExcludeSensitiveData(),
+	})
+	assert.ErrorIs(t, err, ErrGuardrailTripwire)
+
+	spans := recorder.byName("no_secrets")
+	require.Len(t, spans, 1)
+	assert.Equal(t, tracing.RedactedValue, spans[0].Context().Attributes["guardrail_message"])
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+// GuardrailResult records the outcome of an input or output guardrail during a run
+type GuardrailResult struct {
+	// Guardrail is the name of the guardrail
+	Guardrail string `json:"guardrail"`
+
+	// AgentName is the name of the agent the guardrail belongs to
+	AgentName string `json:"agent_name"`
+
+	// Allowed reports whether the guardrail let the input or output through
+	Allowed bool `json:"allowed"`
+
+	// Message is the guardrail's message, if any
+	Message string `json:"message,omitempty"`
+
+	// Modified reports whether an output guardrail replaced the output
+	Modified bool `json:"modified,omitempty"`
+
+	// Metadata is the extra information returned by the guardrail
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
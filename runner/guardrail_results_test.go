@@ -0,0 +1,224 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/guardrail"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+func TestGuardrailResults(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddTurn(GetTextMessage("my email is a@example.com"))
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddInputGuardrail(guardrail.NewInputGuardrail("no_secrets", "Blocks secrets",
+		func(ctx context.Context, input string) (guardrail.InputGuardrailResult, error) {
+			return guardrail.InputGuardrailResult{Allowed: true, Metadata: map[string]any{"score": 0.1}}, nil
+		}))
+	testAgent.AddOutputGuardrail(guardrail.NewOutputGuardrail("length", "Checks the length",
+		func(ctx context.Context, output string) (guardrail.OutputGuardrailResult, error) {
+			return guardrail.OutputGuardrailResult{Allowed: true}, nil
+		}))
+	testAgent.AddOutputGuardrail(guardrail.NewOutputGuardrail("redact", "Redacts emails",
+		func(ctx context.Context, output string) (guardrail.OutputGuardrailResult, error) {
+			return guardrail.OutputGuardrailResult{Allowed: true, Message: "redacted 1 email", ModifiedOutput: "my email is [redacted]"}, nil
+		}))
+
+	result, err := RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []GuardrailResult{
+		{Guardrail: "no_secrets", AgentName: "test", Allowed: true, Metadata: map[string]any{"score": 0.1}},
+	}, result.InputGuardrailResults)
+	assert.Equal(t, []GuardrailResult{
+		{Guardrail: "length", AgentName: "test", Allowed: true},
+		{Guardrail: "redact", AgentName: "test", Allowed: true, Message: "redacted 1 email", Modified: true},
+	}, result.OutputGuardrailResults)
+
+	// The results are part of the wire format
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	var decoded Result
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, result.InputGuardrailResults, decoded.InputGuardrailResults)
+	assert.Equal(t, result.OutputGuardrailResults, decoded.OutputGuardrailResults)
+}
+
+func TestOutputGuardrailReask(t *testing.T) {
+	schema := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"city": map[string]any{"type": "string"}},
+		"required":   []string{"city"},
+	}
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage(`{"town":"Paris"}`)},
+		{GetTextMessage(`{"city":"Paris"}`)},
+	})
+	schemaGuardrail := guardrail.NewJSONSchemaOutputGuardrail(schema)
+	schemaGuardrail.Reask = true
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddOutputGuardrail(schemaGuardrail)
+
+	result, err := RunWithConfig(context.Background(), testAgent, "Where is the Louvre?", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"city":"Paris"}`, result.FinalOutput)
+	assert.Len(t, result.OutputGuardrailResults, 2)
+	assert.False(t, result.OutputGuardrailResults[0].Allowed)
+	assert.True(t, result.OutputGuardrailResults[1].Allowed)
+
+	// The model saw its rejected answer and the correction
+	messages := fakeModel.Calls()[1].Messages
+	correction := messages[len(messages)-1]
+	assert.Equal(t, "user", correction.Role)
+	assert.Contains(t, correction.Content, "missing required field: city")
+
+	// A guardrail is re-asked only once
+	fakeModel = NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage(`{"town":"Paris"}`)},
+		{GetTextMessage(`{"town":"Paris"}`)},
+	})
+	_, err = RunWithConfig(context.Background(), testAgent, "Where is the Louvre?", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+	})
+	assert.ErrorIs(t, err, ErrGuardrailTripwire)
+	assert.Len(t, fakeModel.Calls(), 2)
+}
+
+func TestOutputGuardrailReaskRedaction(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	schema := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"city": map[string]any{"type": "string"}},
+		"required":   []string{"city"},
+	}
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage(`{"town":"Paris"}`)},
+		{GetTextMessage(`{"city":"Paris"}`)},
+	})
+	schemaGuardrail := guardrail.NewJSONSchemaOutputGuardrail(schema)
+	schemaGuardrail.Reask = true
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddOutputGuardrail(schemaGuardrail)
+
+	_, err := RunWithConfig(context.Background(), testAgent, "Where is the Louvre?", RunConfig{
+		ModelProvider:  fakeModel,
+		MaxTurns:       5,
+		TraceRedaction: tracing.ExcludeSensitiveData(),
+	})
+	require.NoError(t, err)
+
+	// Span events are only readable from outside the tracing package once exported
+	var request struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					Events []struct {
+						Name       string `json:"name"`
+						Attributes []struct {
+							Key   string         `json:"key"`
+							Value map[string]any `json:"value"`
+						} `json:"attributes"`
+					} `json:"events"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+	}))
+	defer server.Close()
+
+	recorder.mu.Lock()
+	spans := recorder.spans
+	recorder.mu.Unlock()
+	require.NoError(t, tracing.NewOTLPExporter(tracing.OTLPExporterOptions{Endpoint: server.URL}).ExportSpans(context.Background(), spans))
+
+	attributes := map[string]any{}
+	for _, resourceSpans := range request.ResourceSpans {
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			for _, span := range scopeSpans.Spans {
+				for _, event := range span.Events {
+					if event.Name != "output_guardrail_reask" {
+						continue
+					}
+					for _, attribute := range event.Attributes {
+						attributes[attribute.Key] = attribute.Value["stringValue"]
+					}
+				}
+			}
+		}
+	}
+	require.Contains(t, attributes, "guardrail_message")
+	assert.Equal(t, tracing.RedactedValue, attributes["guardrail_message"])
+	assert.NotContains(t, attributes, "message")
+}
+
+func TestOutputGuardrailRetry(t *testing.T) {
+	banned := guardrail.NewOutputGuardrail("banned_phrases", "Blocks banned phrases",
+		func(ctx context.Context, output string) (guardrail.OutputGuardrailResult, error) {
+			if strings.Contains(output, "guarantee") {
+				return guardrail.OutputGuardrailResult{Message: "do not promise a guarantee"}, nil
+			}
+			return guardrail.OutputGuardrailResult{Allowed: true}, nil
+		})
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddOutputGuardrail(guardrail.WithOutputGuardrailRetry(banned, 2))
+
+	// Two corrections are enough
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("We guarantee delivery")},
+		{GetTextMessage("We guarantee it, really")},
+		{GetTextMessage("Delivery is planned for tomorrow")},
+	})
+	result, err := RunWithConfig(context.Background(), testAgent, "When will it arrive?", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Delivery is planned for tomorrow", result.FinalOutput)
+	assert.Len(t, result.OutputGuardrailResults, 3)
+	messages := fakeModel.Calls()[2].Messages
+	assert.Contains(t, messages[len(messages)-1].Content, "do not promise a guarantee")
+
+	// A third failure surfaces the tripwire error
+	fakeModel = NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("We guarantee delivery")},
+		{GetTextMessage("We guarantee delivery")},
+		{GetTextMessage("We guarantee delivery")},
+	})
+	_, err = RunWithConfig(context.Background(), testAgent, "When will it arrive?", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      10,
+	})
+	assert.ErrorIs(t, err, ErrGuardrailTripwire)
+	assert.Len(t, fakeModel.Calls(), 3)
+}
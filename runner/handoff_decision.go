@@ -0,0 +1,91 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// HandoffDecision records an evaluation of the condition of a handoff (its ShouldHandoff) when the
+// model called it, kept in Result.HandoffDecisions with RunConfig.RecordHandoffDecisions
+type HandoffDecision struct {
+	// Step is the turn in which the model called the handoff
+	Step int `json:"step"`
+
+	// AgentName is the name of the agent that called the handoff
+	AgentName string `json:"agent_name"`
+
+	// Handoff is the tool name of the handoff
+	Handoff string `json:"handoff"`
+
+	// ToolCallID is the ID of the handoff call
+	ToolCallID string `json:"tool_call_id"`
+
+	// Decision is true when the handoff accepted the call
+	Decision bool `json:"decision"`
+
+	// Reason explains the decision, for handoffs implementing handoff.Explainer
+	Reason string `json:"reason,omitempty"`
+
+	// LatencyMS is the time the evaluation took, in milliseconds
+	LatencyMS int64 `json:"latency_ms"`
+
+	// Error is the error of a failed evaluation
+	Error string `json:"error,omitempty"`
+}
+
+// evaluateHandoff decides whether the handoff accepts the call, in a span recording the decision,
+// its latency and, in the span's data, its reason
+func evaluateHandoff(ctx context.Context, state *executionState, a string, h handoff.Handoff, call model.ToolCall) (bool, error) {
+	span, spanCtx := tracing.StartSpan(ctx, "handoff_condition", map[string]any{
+		"span_type":    "custom",
+		"agent_name":   a,
+		"handoff_name": h.ToolName(),
+		"tool_call_id": call.ID,
+	})
+	defer span.End()
+
+	start := time.Now()
+	shouldHandoff, reason, err := handoff.Evaluate(spanCtx, h, call.Function.Arguments)
+	decision := HandoffDecision{
+		Step:       state.stepCounter + 1,
+		AgentName:  a,
+		Handoff:    h.ToolName(),
+		ToolCallID: call.ID,
+		Decision:   shouldHandoff,
+		Reason:     reason,
+		LatencyMS:  time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		decision.Decision = false
+		decision.Error = err.Error()
+	}
+
+	attributes := map[string]any{
+		"decision":   decision.Decision,
+		"latency_ms": decision.LatencyMS,
+	}
+	if err != nil {
+		attributes["error"] = decision.Error
+	}
+	span.SetAttributes(attributes)
+
+	// Reasons often quote the user's message, so they are redacted like the other sensitive data
+	span.SetAttribute("data", map[string]any{
+		"handoff":        decision.Handoff,
+		"decision":       decision.Decision,
+		"handoff_reason": tracing.RedactionPolicyFromContext(spanCtx).Redact("handoff_reason", decision.Reason),
+	})
+
+	if state.config.RecordHandoffDecisions {
+		state.handoffDecisions = append(state.handoffDecisions, decision)
+	}
+	return shouldHandoff, err
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/retrieval"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+func handoffCall(id string, toolName string, arguments string) model.Message {
+	return model.Message{
+		Role: "assistant",
+		ToolCalls: []model.ToolCall{{
+			ID:       id,
+			Type:     "function",
+			Function: model.FunctionCall{Name: toolName, Arguments: arguments},
+		}},
+	}
+}
+
+func TestHandoffDecisions(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	billingAgent := agent.New("billing", "Handle billing questions")
+	triageAgent := agent.New("triage", "Route the user")
+	triageAgent.AddHandoff(handoff.NewKeywordHandoffWithOptions(billingAgent, "Billing", []string{"refund"}, handoff.Options{ToolName: "transfer_to_billing"}))
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{handoffCall("call_1", "transfer_to_billing", `{"reason":"greeting"}`)},
+		{handoffCall("call_2", "transfer_to_billing", `{"reason":"refund"}`)},
+		{GetTextMessage("Your refund is on its way.")},
+	})
+
+	result, err := RunWithConfig(context.Background(), triageAgent, "I want a refund", RunConfig{
+		ModelProvider:          fakeModel,
+		MaxTurns:               5,
+		RecordHandoffDecisions: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "billing", result.LastAgent.Name)
+
+	require.Len(t, result.HandoffDecisions, 2)
+	rejected, accepted := result.HandoffDecisions[0], result.HandoffDecisions[1]
+	assert.Equal(t, 1, rejected.Step)
+	assert.Equal(t, "triage", rejected.AgentName)
+	assert.Equal(t, "transfer_to_billing", rejected.Handoff)
+	assert.Equal(t, "call_1", rejected.ToolCallID)
+	assert.False(t, rejected.Decision)
+	assert.Equal(t, "input contains none of the keywords", rejected.Reason)
+	assert.Equal(t, 2, accepted.Step)
+	assert.True(t, accepted.Decision)
+	assert.Equal(t, `input contains keyword "refund"`, accepted.Reason)
+
+	// Each evaluation is traced
+	spans := recorder.byName("handoff_condition")
+	require.Len(t, spans, 2)
+	attributes := spans[0].Context().Attributes
+	assert.Equal(t, "transfer_to_billing", attributes["handoff_name"])
+	assert.Equal(t, false, attributes["decision"])
+	assert.Equal(t, map[string]any{
+		"handoff":        "transfer_to_billing",
+		"decision":       false,
+		"handoff_reason": "input contains none of the keywords",
+	}, attributes["data"])
+	assert.NotContains(t, attributes, "reason")
+	assert.Contains(t, attributes, "latency_ms")
+	assert.Equal(t, true, spans[1].Context().Attributes["decision"])
+
+	// The decisions are part of the wire format
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	var decoded Result
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, result.HandoffDecisions, decoded.HandoffDecisions)
+}
+
+func TestHandoffDecisionRedaction(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	billingAgent := agent.New("billing", "Handle billing questions")
+	triageAgent := agent.New("triage", "Route the user")
+	triageAgent.AddHandoff(handoff.NewKeywordHandoffWithOptions(billingAgent, "Billing", []string{"refund"}, handoff.Options{ToolName: "transfer_to_billing"}))
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{handoffCall("call_1", "transfer_to_billing", `{"reason":"refund"}`)},
+		{GetTextMessage("Your refund is on its way.")},
+	})
+
+	_, err := RunWithConfig(context.Background(), triageAgent, "I want a refund", RunConfig{
+		ModelProvider:  fakeModel,
+		MaxTurns:       5,
+		TraceRedaction: tracing.ExcludeSensitiveData(),
+	})
+	require.NoError(t, err)
+
+	spans := recorder.byName("handoff_condition")
+	require.Len(t, spans, 1)
+	data, ok := spans[0].Context().Attributes["data"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, tracing.RedactedValue, data["handoff_reason"])
+	assert.Equal(t, true, data["decision"])
+}
+
+func TestHandoffDecisionsNotRecordedByDefault(t *testing.T) {
+	billingAgent := agent.New("billing", "Handle billing questions")
+	triageAgent := agent.New("triage", "Route the user")
+	triageAgent.AddHandoff(handoff.NewFunctionHandoffWithOptions(billingAgent, "Billing", func(ctx context.Context, input string) (bool, error) {
+		return true, nil
+	}, handoff.Options{ToolName: "transfer_to_billing"}))
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{handoffCall("call_1", "transfer_to_billing", "{}")},
+		{GetTextMessage("Hello from billing.")},
+	})
+
+	result, err := RunWithConfig(context.Background(), triageAgent, "hi", RunConfig{ModelProvider: fakeModel, MaxTurns: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "billing", result.LastAgent.Name)
+	assert.Empty(t, result.HandoffDecisions)
+}
+
+func TestSemanticHandoffInRun(t *testing.T) {
+	embedder := retrieval.EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+		embeddings := make([][]float32, len(texts))
+		for i, text := range texts {
+			embeddings[i] = []float32{0, 1}
+			if strings.Contains(strings.ToLower(text), "refund") {
+				embeddings[i] = []float32{1, 0}
+			}
+		}
+		return embeddings, nil
+	})
+
+	billingAgent := agent.New("billing", "Handle billing questions")
+	triageAgent := agent.New("triage", "Route the user")
+	triageAgent.AddHandoff(handoff.NewSemanticHandoffWithOptions(billingAgent, "Billing", []string{"I want a refund"},
+		embedder, handoff.DefaultSemanticThreshold, handoff.Options{ToolName: "transfer_to_billing"}))
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{handoffCall("call_1", "transfer_to_billing", `{"message":"Can I get a refund?"}`)},
+		{GetTextMessage("Your refund is on its way.")},
+	})
+
+	result, err := RunWithConfig(context.Background(), triageAgent, "Can I get a refund?", RunConfig{
+		ModelProvider:          fakeModel,
+		MaxTurns:               5,
+		RecordHandoffDecisions: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "billing", result.LastAgent.Name)
+	require.Len(t, result.HandoffDecisions, 1)
+	assert.True(t, result.HandoffDecisions[0].Decision)
+
+	// The model is asked for the request it hands off
+	var parameters map[string]any
+	for _, definition := range fakeModel.Calls()[0].Settings.Tools {
+		if definition.Name == "transfer_to_billing" {
+			parameters = definition.Parameters
+		}
+	}
+	assert.Equal(t, []string{"message"}, parameters["required"])
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// handoffInputData splits the working history of a handoff step into the run's input, the
+// messages generated before the step and the messages of the step, which end with the handoff call
+func handoffInputData(state *executionState, stepResult *stepResult, handoffInput string) (*handoff.InputData, error) {
+	var history []model.Message
+	for _, message := range state.messages {
+		if message.Role != "system" {
+			history = append(history, message)
+		}
+	}
+
+	// The working history may have been trimmed, so the split is counted from its end
+	newCount := min(len(stepResult.messages), len(history))
+	preCount := min(len(state.resultMessages)-state.inputItems-len(stepResult.messages), len(history)-newCount)
+	preStart := len(history) - newCount - max(preCount, 0)
+	newStart := len(history) - newCount
+
+	inputHistory, err := messagesToItems(history[:preStart])
+	if err != nil {
+		return nil, err
+	}
+	preHandoffItems, err := messagesToItems(history[preStart:newStart])
+	if err != nil {
+		return nil, err
+	}
+	newItems, err := messagesToItems(history[newStart:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &handoff.InputData{
+		InputHistory:    inputHistory,
+		PreHandoffItems: preHandoffItems,
+		NewItems:        newItems,
+		Metadata: map[string]any{
+			"handoff_input":                  handoffInput,
+			"source_agent":                   state.currentAgent.Name,
+			"target_agent":                   stepResult.nextAgent.Name,
+			handoff.MetadataHandoffToolNames: handoffToolNames(state),
+		},
+	}, nil
+}
+
+// handoffToolNames adds the tool names of the current agent's handoffs to the ones of the agents
+// that handed off before, and returns them all, so handoff calls of the whole conversation are known
+func handoffToolNames(state *executionState) []string {
+	for _, h := range state.currentAgent.Handoffs {
+		if !slices.Contains(state.handoffToolNames, h.ToolName()) {
+			state.handoffToolNames = append(state.handoffToolNames, h.ToolName())
+		}
+	}
+	return slices.Clone(state.handoffToolNames)
+}
+
+// applyHandoffInputFilter filters the history the next agent receives with the handoff's
+// FilterInput and then RunConfig.HandoffInputFilter. Result.History is not filtered.
+func applyHandoffInputFilter(ctx context.Context, state *executionState, stepResult *stepResult, handoffInput string) error {
+	inputData, err := handoffInputData(state, stepResult, handoffInput)
+	if err != nil {
+		return err
+	}
+
+	filtered := inputData
+	if stepResult.handoff != nil {
+		if filtered, err = stepResult.handoff.FilterInput(ctx, filtered); err != nil {
+			return fmt.Errorf("handoff input filter failed: %w", err)
+		}
+	}
+	if state.config.HandoffInputFilter != nil {
+		if filtered, err = state.config.HandoffInputFilter(ctx, filtered); err != nil {
+			return fmt.Errorf("handoff input filter failed: %w", err)
+		}
+	}
+	if filtered == nil {
+		return fmt.Errorf("handoff input filter failed: no input data returned")
+	}
+
+	span := tracing.GetActiveSpan(ctx)
+	if span != nil && len(filtered.Metadata) > 0 {
+		metadataJSON, _ := json.Marshal(filtered.Metadata)
+		span.SetAttribute("filtered_metadata", string(metadataJSON))
+	}
+
+	before := [][]map[string]any{inputData.InputHistory, inputData.PreHandoffItems, inputData.NewItems}
+	after := [][]map[string]any{filtered.InputHistory, filtered.PreHandoffItems, filtered.NewItems}
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+
+	var messages []model.Message
+	for _, items := range after {
+		decoded, err := itemsToMessages(items)
+		if err != nil {
+			return fmt.Errorf("handoff input filter returned invalid items: %w", err)
+		}
+		messages = append(messages, decoded...)
+	}
+	if span != nil {
+		span.SetAttribute("filtered_messages", len(messages))
+	}
+
+	// The system message is replaced with the next agent's instructions afterwards
+	state.messages = messages
+	return nil
+}
+
+// messagesToItems converts messages to the JSON objects of handoff.InputData
+func messagesToItems(messages []model.Message) ([]map[string]any, error) {
+	items := make([]map[string]any, 0, len(messages))
+	for _, message := range messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		var item map[string]any
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// itemsToMessages converts the JSON objects of handoff.InputData back to messages
+func itemsToMessages(items []map[string]any) ([]model.Message, error) {
+	messages := make([]model.Message, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var message model.Message
+		if err := json.Unmarshal(data, &message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
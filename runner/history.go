@@ -0,0 +1,265 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tokens"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// ErrSummarizerProviderRequired is returned when a SummarizingTrimmer has no model provider
+var ErrSummarizerProviderRequired = errors.New("summarizing trimmer requires a model provider")
+
+// DefaultSummaryInstructions are the instructions used by SummarizingTrimmer when none are set
+const DefaultSummaryInstructions = "Summarize the following conversation between a user, an assistant and its tools. " +
+	"Keep every fact, decision, tool result and open question needed to continue the conversation. Be concise."
+
+// HistoryTrimmer decides which messages are sent to the model on each turn.
+// Trim receives the working history, system messages included, before every model call and
+// returns the history to use from then on. Result.History always keeps the full conversation.
+//
+// Implementations must not separate a tool message from the assistant message that called the tool.
+type HistoryTrimmer interface {
+	Trim(ctx context.Context, messages []model.Message) ([]model.Message, error)
+}
+
+// HistoryTrimmerFunc adapts a function to a HistoryTrimmer
+type HistoryTrimmerFunc func(ctx context.Context, messages []model.Message) ([]model.Message, error)
+
+// Trim calls f(ctx, messages)
+func (f HistoryTrimmerFunc) Trim(ctx context.Context, messages []model.Message) ([]model.Message, error) {
+	return f(ctx, messages)
+}
+
+// LastMessagesTrimmer keeps the system messages and the last N other messages. System and
+// developer messages stay where they are, as do all the trimmers of this package.
+type LastMessagesTrimmer struct {
+	// N is the number of non-system messages to keep. More are kept if the N-th last message
+	// is a tool result, so that its tool call is kept too.
+	N int
+}
+
+// Trim keeps the system messages and the last N other messages
+func (t LastMessagesTrimmer) Trim(ctx context.Context, messages []model.Message) ([]model.Message, error) {
+	conversation := conversationMessages(messages)
+	if t.N <= 0 || len(conversation) <= t.N {
+		return messages, nil
+	}
+
+	start := safeCutIndex(conversation, len(conversation)-t.N)
+	return dropConversation(messages, start, nil), nil
+}
+
+// TokenWindowTrimmer drops the oldest non-system messages until the history fits in MaxTokens
+type TokenWindowTrimmer struct {
+	// MaxTokens is the token budget of the history
+	MaxTokens int
+
+	// CountTokens counts the tokens of a message (optional, defaults to tokens.CountMessage)
+	CountTokens func(msg model.Message) int
+
+	// Model selects the encoding of the default counter (optional, defaults to o200k_base)
+	Model string
+}
+
+// Trim drops the oldest non-system messages until the history fits in MaxTokens.
+// The most recent message, and the tool call it answers, are always kept.
+func (t TokenWindowTrimmer) Trim(ctx context.Context, messages []model.Message) ([]model.Message, error) {
+	if t.MaxTokens <= 0 {
+		return messages, nil
+	}
+	count := t.CountTokens
+	if count == nil {
+		count = tokens.MessageCounter(t.Model)
+	}
+
+	conversation := conversationMessages(messages)
+	total := 0
+	for _, msg := range messages {
+		total += count(msg)
+	}
+
+	start := 0
+	for total > t.MaxTokens && start < len(conversation)-1 {
+		total -= count(conversation[start])
+		start++
+		// Drop the tool results of a removed tool call as well
+		for start < len(conversation)-1 && conversation[start].Role == "tool" {
+			total -= count(conversation[start])
+			start++
+		}
+	}
+
+	// The last messages can be tool results; keep their tool call even if it exceeds the budget
+	start = safeCutIndex(conversation, start)
+	if start == 0 {
+		return messages, nil
+	}
+	return dropConversation(messages, start, nil), nil
+}
+
+// SummarizingTrimmer replaces older messages with a summary written by a (typically cheap) model
+// once the history grows past MaxMessages non-system messages. The summary is kept as a user
+// message and is itself summarized again when the history grows further.
+type SummarizingTrimmer struct {
+	// Provider is the model provider used for summaries
+	Provider model.Provider
+
+	// Model is the model used for summaries (e.g. "gpt-4o-mini")
+	Model string
+
+	// MaxMessages is the number of non-system messages that triggers a summary (default 20)
+	MaxMessages int
+
+	// KeepLast is the number of recent messages kept verbatim (default 6)
+	KeepLast int
+
+	// Instructions are the summarizer's instructions (default DefaultSummaryInstructions)
+	Instructions string
+}
+
+// Trim summarizes the older messages if the history is longer than MaxMessages
+func (t SummarizingTrimmer) Trim(ctx context.Context, messages []model.Message) ([]model.Message, error) {
+	if t.Provider == nil {
+		return nil, ErrSummarizerProviderRequired
+	}
+	maxMessages := t.MaxMessages
+	if maxMessages <= 0 {
+		maxMessages = 20
+	}
+	keepLast := t.KeepLast
+	if keepLast <= 0 {
+		keepLast = 6
+	}
+
+	conversation := conversationMessages(messages)
+	if len(conversation) <= maxMessages || len(conversation) <= keepLast {
+		return messages, nil
+	}
+
+	start := safeCutIndex(conversation, len(conversation)-keepLast)
+	if start == 0 {
+		return messages, nil
+	}
+
+	summary, err := t.summarize(ctx, conversation[:start])
+	if err != nil {
+		return nil, err
+	}
+
+	return dropConversation(messages, start, &model.Message{
+		Role:    "user",
+		Content: "Summary of the earlier conversation:\n" + summary,
+	}), nil
+}
+
+// summarize asks the model for a summary of the messages
+func (t SummarizingTrimmer) summarize(ctx context.Context, messages []model.Message) (string, error) {
+	instructions := t.Instructions
+	if instructions == "" {
+		instructions = DefaultSummaryInstructions
+	}
+
+	settings := model.DefaultSettings()
+	settings.Model = t.Model
+
+	response, err := t.Provider.CreateChatCompletion(ctx, []model.Message{
+		{Role: "system", Content: instructions},
+		{Role: "user", Content: transcript(messages)},
+	}, settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize history: %w", err)
+	}
+
+	return response.Message.Content, nil
+}
+
+// transcript renders messages as plain text for the summarizer
+func transcript(messages []model.Message) string {
+	var b strings.Builder
+	for _, msg := range messages {
+		switch {
+		case msg.Role == "tool":
+			fmt.Fprintf(&b, "tool result: %s\n", msg.Content)
+		case len(msg.ToolCalls) > 0:
+			if msg.Content != "" {
+				fmt.Fprintf(&b, "%s: %s\n", msg.Role, msg.Content)
+			}
+			for _, call := range msg.ToolCalls {
+				fmt.Fprintf(&b, "%s called %s(%s)\n", msg.Role, call.Function.Name, call.Function.Arguments)
+			}
+		default:
+			fmt.Fprintf(&b, "%s: %s\n", msg.Role, msg.Content)
+		}
+	}
+	return b.String()
+}
+
+// isSystemMessage reports whether the message instructs the model rather than being part of the conversation
+func isSystemMessage(msg model.Message) bool {
+	return msg.Role == "system" || msg.Role == "developer"
+}
+
+// conversationMessages returns the messages of the history other than system and developer messages
+func conversationMessages(messages []model.Message) []model.Message {
+	var conversation []model.Message
+	for _, msg := range messages {
+		if !isSystemMessage(msg) {
+			conversation = append(conversation, msg)
+		}
+	}
+	return conversation
+}
+
+// dropConversation removes the first n non-system messages of the history. System and developer
+// messages keep their positions; the replacement, if any, takes the place of the first removed message.
+func dropConversation(messages []model.Message, n int, replacement *model.Message) []model.Message {
+	kept := make([]model.Message, 0, len(messages)-n+1)
+	dropped := 0
+	for _, msg := range messages {
+		if isSystemMessage(msg) || dropped >= n {
+			kept = append(kept, msg)
+			continue
+		}
+		if dropped == 0 && replacement != nil {
+			kept = append(kept, *replacement)
+		}
+		dropped++
+	}
+	return kept
+}
+
+// safeCutIndex moves a cut back to the tool call of the tool results it would split
+func safeCutIndex(conversation []model.Message, start int) int {
+	if start < 0 {
+		return 0
+	}
+	for start > 0 && start < len(conversation) && conversation[start].Role == "tool" {
+		start--
+	}
+	return start
+}
+
+// trimHistory applies the run's HistoryTrimmer to the working history
+func trimHistory(ctx context.Context, state *executionState) error {
+	before := len(state.messages)
+	trimmed, err := state.config.HistoryTrimmer.Trim(ctx, state.messages)
+	if err != nil {
+		return fmt.Errorf("failed to trim history: %w", err)
+	}
+	state.messages = trimmed
+
+	if span := tracing.GetActiveSpan(ctx); span != nil && len(trimmed) != before {
+		span.SetAttribute("history_trimmed_from", before)
+		span.SetAttribute("history_trimmed_to", len(trimmed))
+	}
+	return nil
+}
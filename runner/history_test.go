@@ -0,0 +1,161 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func historyWithToolCall() []model.Message {
+	return []model.Message{
+		{Role: "system", Content: "instructions"},
+		{Role: "user", Content: "first question"},
+		{Role: "assistant", Content: "first answer"},
+		{Role: "user", Content: "second question"},
+		GetFunctionToolCall("lookup", `{"a": "b"}`),
+		{Role: "tool", Content: "found", ToolCallID: "1"},
+	}
+}
+
+func TestLastMessagesTrimmer(t *testing.T) {
+	messages := historyWithToolCall()
+
+	trimmed, err := LastMessagesTrimmer{N: 3}.Trim(context.Background(), messages)
+	require.NoError(t, err)
+	require.Len(t, trimmed, 4)
+	assert.Equal(t, "system", trimmed[0].Role)
+	assert.Equal(t, "second question", trimmed[1].Content)
+
+	// The tool call of a kept tool result is kept too
+	trimmed, err = LastMessagesTrimmer{N: 1}.Trim(context.Background(), messages)
+	require.NoError(t, err)
+	require.Len(t, trimmed, 3)
+	assert.Len(t, trimmed[1].ToolCalls, 1)
+
+	trimmed, err = LastMessagesTrimmer{N: 10}.Trim(context.Background(), messages)
+	require.NoError(t, err)
+	assert.Equal(t, messages, trimmed)
+}
+
+func TestTokenWindowTrimmer(t *testing.T) {
+	messages := historyWithToolCall()
+	oneTokenEach := func(model.Message) int { return 1 }
+
+	trimmed, err := TokenWindowTrimmer{MaxTokens: 4, CountTokens: oneTokenEach}.Trim(context.Background(), messages)
+	require.NoError(t, err)
+	require.Len(t, trimmed, 4)
+	assert.Equal(t, "second question", trimmed[1].Content)
+
+	// The tool result and its call are kept even when they exceed the budget
+	trimmed, err = TokenWindowTrimmer{MaxTokens: 1, CountTokens: oneTokenEach}.Trim(context.Background(), messages)
+	require.NoError(t, err)
+	require.Len(t, trimmed, 3)
+	assert.Equal(t, "tool", trimmed[2].Role)
+
+	trimmed, err = TokenWindowTrimmer{MaxTokens: 1000}.Trim(context.Background(), messages)
+	require.NoError(t, err)
+	assert.Equal(t, messages, trimmed)
+}
+
+func TestSummarizingTrimmer(t *testing.T) {
+	summarizer := NewFakeModel()
+	summarizer.SetNextOutput([]model.Message{GetTextMessage("the user asked a question")})
+
+	_, err := SummarizingTrimmer{}.Trim(context.Background(), historyWithToolCall())
+	assert.ErrorIs(t, err, ErrSummarizerProviderRequired)
+
+	trimmer := SummarizingTrimmer{Provider: summarizer, MaxMessages: 4, KeepLast: 3}
+	trimmed, err := trimmer.Trim(context.Background(), historyWithToolCall())
+	require.NoError(t, err)
+	require.Len(t, trimmed, 5)
+	assert.Equal(t, "system", trimmed[0].Role)
+	assert.Equal(t, "Summary of the earlier conversation:\nthe user asked a question", trimmed[1].Content)
+	assert.Equal(t, "second question", trimmed[2].Content)
+
+	// Short histories are not summarized
+	trimmed, err = SummarizingTrimmer{Provider: summarizer}.Trim(context.Background(), historyWithToolCall())
+	require.NoError(t, err)
+	assert.Len(t, trimmed, 6)
+}
+
+func TestTrimmersKeepSystemMessagesInPlace(t *testing.T) {
+	messages := []model.Message{
+		{Role: "system", Content: "instructions"},
+		{Role: "user", Content: "first question"},
+		{Role: "assistant", Content: "first answer"},
+		{Role: "developer", Content: "answer in French from now on"},
+		{Role: "user", Content: "second question"},
+		{Role: "assistant", Content: "second answer"},
+	}
+	contents := func(messages []model.Message) []string {
+		var list []string
+		for _, msg := range messages {
+			list = append(list, msg.Content)
+		}
+		return list
+	}
+
+	trimmed, err := LastMessagesTrimmer{N: 3}.Trim(context.Background(), messages)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"instructions", "first answer", "answer in French from now on", "second question", "second answer"}, contents(trimmed))
+
+	oneTokenEach := func(model.Message) int { return 1 }
+	trimmed, err = TokenWindowTrimmer{MaxTokens: 4, CountTokens: oneTokenEach}.Trim(context.Background(), messages)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"instructions", "answer in French from now on", "second question", "second answer"}, contents(trimmed))
+
+	summarizer := NewFakeModel()
+	summarizer.SetNextOutput([]model.Message{GetTextMessage("the user asked a question")})
+	trimmed, err = SummarizingTrimmer{Provider: summarizer, MaxMessages: 3, KeepLast: 2}.Trim(context.Background(), messages)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"instructions",
+		"Summary of the earlier conversation:\nthe user asked a question",
+		"answer in French from now on",
+		"second question",
+		"second answer",
+	}, contents(trimmed))
+
+	// The input is left untouched
+	assert.Equal(t, "first question", messages[1].Content)
+}
+
+func TestRunWithHistoryTrimmer(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("lookup", `{"a": "b"}`)},
+		{GetFunctionToolCall("lookup", `{"a": "c"}`)},
+		{GetTextMessage("done")},
+	})
+	hooks := &llmHooksRecorder{}
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("lookup", "found"))
+
+	result, err := RunWithConfig(context.Background(), testAgent, "question", RunConfig{
+		ModelProvider:  fakeModel,
+		MaxTurns:       5,
+		LLMHooks:       hooks,
+		HistoryTrimmer: LastMessagesTrimmer{N: 2},
+	})
+	require.NoError(t, err)
+
+	// The model sees the system prompt and the last tool call and result
+	require.Len(t, hooks.startMessages, 3)
+	last := hooks.startMessages[2]
+	require.Len(t, last, 3)
+	assert.Equal(t, "system", last[0].Role)
+	assert.Equal(t, `{"a": "c"}`, last[1].ToolCalls[0].Function.Arguments)
+
+	// The result keeps the full history
+	assert.Len(t, result.History, 6)
+}
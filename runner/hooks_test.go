@@ -183,3 +183,134 @@ func TestRunWithHandoffHooks(t *testing.T) {
 	assert.Equal(t, 1, hooks2.EndCount, "agent2 OnEnd should be called once")
 	assert.Equal(t, 1, hooks2.HandoffCount, "agent2 OnHandoff should be called once")
 }
+
+type llmHooksRecorder struct {
+	agent.BaseAgentHooks
+	startMessages [][]model.Message
+	settings      []model.Settings
+	responses     []*model.Response
+	errs          []error
+}
+
+func (h *llmHooksRecorder) OnLLMStart(ctx context.Context, a *agent.Agent, messages []model.Message, settings model.Settings) error {
+	h.startMessages = append(h.startMessages, messages)
+	h.settings = append(h.settings, settings)
+	return nil
+}
+
+func (h *llmHooksRecorder) OnLLMEnd(ctx context.Context, a *agent.Agent, response *model.Response, err error) error {
+	h.responses = append(h.responses, response)
+	h.errs = append(h.errs, err)
+	return nil
+}
+
+// TestRunWithLLMHooks tests that LLM hooks are called around every provider call
+func TestRunWithLLMHooks(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("test-tool", `{"a":"b"}`)},
+		{GetTextMessage("Final response after tool call")},
+	})
+
+	testAgent := agent.New("test-agent", "Test instructions")
+	testAgent.AddTool(NewFunctionTool("test-tool", "Tool execution result"))
+
+	agentHooks := &llmHooksRecorder{}
+	testAgent.SetHooks(agentHooks)
+	runHooks := &llmHooksRecorder{}
+
+	config := RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		LLMHooks:      runHooks,
+	}
+	_, err := RunWithConfig(context.Background(), testAgent, "Use the test tool", config)
+	assert.NoError(t, err, "RunWithConfig should not return an error")
+
+	for _, hooks := range []*llmHooksRecorder{agentHooks, runHooks} {
+		assert.Len(t, hooks.startMessages, 2, "OnLLMStart should be called for each provider call")
+		assert.Len(t, hooks.responses, 2, "OnLLMEnd should be called for each provider call")
+		assert.Equal(t, "user", hooks.startMessages[0][len(hooks.startMessages[0])-1].Role)
+		assert.Equal(t, "Final response after tool call", hooks.responses[1].Message.Content)
+	}
+}
+
+// TestLLMHookError tests that an error returned from OnLLMStart aborts the run
+func TestLLMHookError(t *testing.T) {
+	fakeModel := NewFakeModel()
+	testAgent := agent.New("test-agent", "Test instructions")
+
+	config := RunConfig{
+		ModelProvider: fakeModel,
+		LLMHooks:      &failingLLMHooks{},
+	}
+	_, err := RunWithConfig(context.Background(), testAgent, "Hello", config)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "OnLLMStart")
+}
+
+type failingLLMHooks struct {
+	agent.BaseAgentHooks
+}
+
+func (h *failingLLMHooks) OnLLMStart(ctx context.Context, a *agent.Agent, messages []model.Message, settings model.Settings) error {
+	return assert.AnError
+}
+
+type toolCallRecorder struct {
+	agent.BaseAgentHooks
+	started []model.ToolCall
+	ended   []model.ToolCall
+	outputs []string
+}
+
+func (h *toolCallRecorder) OnToolCallStart(ctx context.Context, a *agent.Agent, t tool.Tool, call model.ToolCall) error {
+	h.started = append(h.started, call)
+	return nil
+}
+
+func (h *toolCallRecorder) OnToolCallEnd(ctx context.Context, a *agent.Agent, t tool.Tool, call model.ToolCall, output string) error {
+	h.ended = append(h.ended, call)
+	h.outputs = append(h.outputs, output)
+	return nil
+}
+
+// TestRunWithToolCallIDHooks tests that tool call hooks and spans receive the model's tool call
+func TestRunWithToolCallIDHooks(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("test-tool", `{"a":"b"}`)},
+		{GetTextMessage("Final response after tool call")},
+	})
+
+	testAgent := agent.New("test-agent", "Test instructions")
+	testAgent.AddTool(NewFunctionTool("test-tool", "Tool execution result"))
+
+	agentHooks := &toolCallRecorder{}
+	testAgent.SetHooks(agentHooks)
+	runHooks := &toolCallRecorder{}
+
+	config := RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		ToolCallHooks: runHooks,
+	}
+	_, err := RunWithConfig(context.Background(), testAgent, "Use the test tool", config)
+	assert.NoError(t, err, "RunWithConfig should not return an error")
+
+	for _, hooks := range []*toolCallRecorder{agentHooks, runHooks} {
+		if assert.Len(t, hooks.started, 1) && assert.Len(t, hooks.ended, 1) {
+			assert.Equal(t, "call_test-tool", hooks.started[0].ID)
+			assert.Equal(t, `{"a":"b"}`, hooks.started[0].Function.Arguments)
+			assert.Equal(t, "call_test-tool", hooks.ended[0].ID)
+			assert.Equal(t, "Tool execution result", hooks.outputs[0])
+		}
+	}
+
+	spans := recorder.byName("tool_call")
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "call_test-tool", spans[0].Context().Attributes["tool_call_id"])
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+)
+
+// ResultStore stores the results of completed runs by idempotency key
+type ResultStore interface {
+	// Get returns the result stored for key, or false if there is none
+	Get(ctx context.Context, key string) (*Result, bool, error)
+
+	// Set stores the result for key
+	Set(ctx context.Context, key string, result *Result) error
+}
+
+// NewIdempotencyKey derives an idempotency key from a session ID and the run input
+func NewIdempotencyKey(sessionID string, input string) string {
+	sum := sha256.Sum256([]byte(sessionID + "\x00" + input))
+	return hex.EncodeToString(sum[:])
+}
+
+// innerRunConfig returns config for one of the runs of a workflow, with an idempotency key
+// derived from the workflow's key and run, so the runs do not share one stored result
+func innerRunConfig(config RunConfig, run string) RunConfig {
+	if config.IdempotencyKey != "" {
+		config.IdempotencyKey = NewIdempotencyKey(config.IdempotencyKey, run)
+	}
+	return config
+}
+
+// MemoryResultStore is an in-memory ResultStore with optional expiry
+type MemoryResultStore struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]memoryResultEntry
+}
+
+type memoryResultEntry struct {
+	result    *Result
+	expiresAt time.Time
+}
+
+// NewMemoryResultStore creates an in-memory result store.
+// Entries expire after ttl; a ttl of zero keeps them until the process exits.
+func NewMemoryResultStore(ttl time.Duration) *MemoryResultStore {
+	return &MemoryResultStore{
+		ttl:     ttl,
+		entries: make(map[string]memoryResultEntry),
+	}
+}
+
+// Get returns the result stored for key
+func (s *MemoryResultStore) Get(ctx context.Context, key string) (*Result, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+
+	return entry.result, true, nil
+}
+
+// Set stores the result for key
+func (s *MemoryResultStore) Set(ctx context.Context, key string, result *Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := memoryResultEntry{result: result}
+	if s.ttl > 0 {
+		entry.expiresAt = time.Now().Add(s.ttl)
+	}
+	s.entries[key] = entry
+
+	return nil
+}
+
+// inflightRun is a run in progress for an idempotency key
+type inflightRun struct {
+	done   chan struct{}
+	result *Result
+	err    error
+
+	// canceled reports that the run stopped because every caller waiting for it left
+	canceled bool
+
+	// callers counts the callers waiting for the run; cancel stops it when none are left
+	callers int
+	cancel  context.CancelFunc
+}
+
+var (
+	inflightMu   sync.Mutex
+	inflightRuns = make(map[string]*inflightRun)
+)
+
+// runIdempotent returns the stored result for config.IdempotencyKey if there is one,
+// otherwise executes the run once and stores its result.
+// Concurrent runs with the same key in this process share one execution. It runs on a
+// context detached from the caller that started it, so it keeps going as long as any
+// caller is still waiting; a caller that joins a run canceled by the others leaving starts it again.
+func runIdempotent(ctx context.Context, a *agent.Agent, input string, config RunConfig) (*Result, error) {
+	key := config.IdempotencyKey
+	store := config.ResultStore
+
+	for {
+		if result, ok, err := store.Get(ctx, key); err != nil {
+			return nil, fmt.Errorf("failed to read result store: %w", err)
+		} else if ok {
+			return cachedResult(result), nil
+		}
+
+		inflightMu.Lock()
+		inflight, joined := inflightRuns[key]
+		if joined {
+			inflight.callers++
+		} else {
+			runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+			inflight = &inflightRun{done: make(chan struct{}), callers: 1, cancel: cancel}
+			inflightRuns[key] = inflight
+			go executeInflightRun(runCtx, inflight, a, input, config)
+		}
+		inflightMu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			inflightMu.Lock()
+			inflight.callers--
+			if inflight.callers == 0 {
+				inflight.cancel()
+			}
+			inflightMu.Unlock()
+			return nil, ctx.Err()
+		case <-inflight.done:
+		}
+
+		if inflight.canceled {
+			// The callers that shared the run left before it finished; run it for this one
+			continue
+		}
+		if inflight.err != nil {
+			return nil, inflight.err
+		}
+		if !joined {
+			return inflight.result, nil
+		}
+		return cachedResult(inflight.result), nil
+	}
+}
+
+// executeInflightRun executes a run registered in inflightRuns and stores its result
+func executeInflightRun(ctx context.Context, inflight *inflightRun, a *agent.Agent, input string, config RunConfig) {
+	key := config.IdempotencyKey
+	store := config.ResultStore
+
+	defer func() {
+		inflight.canceled = inflight.err != nil && ctx.Err() != nil
+		inflight.cancel()
+
+		inflightMu.Lock()
+		delete(inflightRuns, key)
+		inflightMu.Unlock()
+		close(inflight.done)
+	}()
+
+	// Another run may have stored its result after the caller checked the store
+	// and before the key was registered here
+	if result, ok, err := store.Get(ctx, key); err != nil {
+		inflight.err = fmt.Errorf("failed to read result store: %w", err)
+		return
+	} else if ok {
+		inflight.result = cachedResult(result)
+		return
+	}
+
+	inflight.result, inflight.err = executeRun(ctx, a, input, config)
+	if inflight.err != nil {
+		// Failed runs are not stored so that a redelivery can retry them
+		return
+	}
+
+	if err := store.Set(ctx, key, inflight.result); err != nil {
+		// Callers fail, rather than getting a result that was not stored
+		inflight.err = fmt.Errorf("failed to write result store: %w", err)
+	}
+}
+
+// cachedResult returns a shallow copy of a stored result marked as served from the store
+func cachedResult(result *Result) *Result {
+	copied := *result
+	copied.Cached = true
+	return &copied
+}
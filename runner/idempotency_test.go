@@ -0,0 +1,275 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestRunIdempotent(t *testing.T) {
+	ctx := context.Background()
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("first")},
+		{GetTextMessage("second")},
+	})
+
+	testAgent := agent.New("test", "test instructions")
+
+	config := RunConfig{
+		Model:          "gpt-4o",
+		ModelProvider:  fakeModel,
+		MaxTurns:       10,
+		IdempotencyKey: NewIdempotencyKey("session", "hello"),
+		ResultStore:    NewMemoryResultStore(0),
+	}
+
+	result, err := RunWithConfig(ctx, testAgent, "hello", config)
+	require.NoError(t, err)
+	assert.Equal(t, "first", result.FinalOutput)
+	assert.False(t, result.Cached)
+
+	// A redelivery with the same key returns the stored result without calling the model
+	result, err = RunWithConfig(ctx, testAgent, "hello", config)
+	require.NoError(t, err)
+	assert.Equal(t, "first", result.FinalOutput)
+	assert.True(t, result.Cached)
+	assert.Len(t, fakeModel.Calls(), 1)
+
+	// A different key runs the agent again
+	config.IdempotencyKey = NewIdempotencyKey("session", "hello again")
+	result, err = RunWithConfig(ctx, testAgent, "hello again", config)
+	require.NoError(t, err)
+	assert.Equal(t, "second", result.FinalOutput)
+	assert.False(t, result.Cached)
+}
+
+// failingResultStore has no results and fails to store them; it reports each Get
+type failingResultStore struct {
+	gets chan struct{}
+}
+
+func (s *failingResultStore) Get(ctx context.Context, key string) (*Result, bool, error) {
+	s.gets <- struct{}{}
+	return nil, false, nil
+}
+
+func (s *failingResultStore) Set(ctx context.Context, key string, result *Result) error {
+	return assert.AnError
+}
+
+func TestRunIdempotentStoreFailure(t *testing.T) {
+	release := make(chan struct{})
+	blocking := model.ProviderFuncs{
+		Completion: func(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+			<-release
+			return &model.Response{Message: GetTextMessage("done")}, nil
+		},
+	}
+	store := &failingResultStore{gets: make(chan struct{}, 4)}
+	config := RunConfig{
+		ModelProvider:  blocking,
+		MaxTurns:       5,
+		IdempotencyKey: NewIdempotencyKey("session", "hello"),
+		ResultStore:    store,
+	}
+	testAgent := agent.New("test", "test instructions")
+
+	// The second run waits for the first one, whose result cannot be stored
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := RunWithConfig(context.Background(), testAgent, "hello", config)
+			errs <- err
+		}()
+		<-store.gets
+	}
+	close(release)
+
+	for range 2 {
+		err := <-errs
+		assert.ErrorIs(t, err, assert.AnError, "no run reports a result that was not stored")
+	}
+}
+
+func TestRunIdempotentFirstCallerLeaves(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blocking := model.ProviderFuncs{
+		Completion: func(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+			close(started)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-release:
+				return &model.Response{Message: GetTextMessage("done")}, nil
+			}
+		},
+	}
+	config := RunConfig{
+		ModelProvider:  blocking,
+		MaxTurns:       5,
+		IdempotencyKey: NewIdempotencyKey("session", "first caller leaves"),
+		ResultStore:    NewMemoryResultStore(0),
+	}
+	testAgent := agent.New("test", "test instructions")
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := RunWithConfig(firstCtx, testAgent, "hello", config)
+		firstErr <- err
+	}()
+	<-started
+
+	second := make(chan *Result, 1)
+	go func() {
+		result, err := RunWithConfig(context.Background(), testAgent, "hello", config)
+		assert.NoError(t, err)
+		second <- result
+	}()
+	waitForCallers(t, config.IdempotencyKey, 2)
+
+	// The first caller disconnecting does not cancel the run the second one waits for
+	cancelFirst()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+	close(release)
+
+	result := <-second
+	require.NotNil(t, result)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.True(t, result.Cached)
+}
+
+func TestRunIdempotentRetriesCanceledRun(t *testing.T) {
+	started := make(chan struct{}, 1)
+	proceed := make(chan struct{})
+	calls := 0
+	provider := model.ProviderFuncs{
+		Completion: func(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+			calls++
+			if calls > 1 {
+				return &model.Response{Message: GetTextMessage("retried")}, nil
+			}
+			started <- struct{}{}
+			<-ctx.Done()
+			<-proceed
+			return nil, ctx.Err()
+		},
+	}
+	config := RunConfig{
+		ModelProvider:  provider,
+		MaxTurns:       5,
+		IdempotencyKey: NewIdempotencyKey("session", "canceled run"),
+		ResultStore:    NewMemoryResultStore(0),
+	}
+	testAgent := agent.New("test", "test instructions")
+
+	// The only caller leaves, which cancels the run
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := RunWithConfig(firstCtx, testAgent, "hello", config)
+		firstErr <- err
+	}()
+	<-started
+	cancelFirst()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	// A caller that joins the canceled run before it finishes runs it again
+	second := make(chan *Result, 1)
+	go func() {
+		result, err := RunWithConfig(context.Background(), testAgent, "hello", config)
+		assert.NoError(t, err)
+		second <- result
+	}()
+	waitForCallers(t, config.IdempotencyKey, 1)
+	close(proceed)
+
+	result := <-second
+	require.NotNil(t, result)
+	assert.Equal(t, "retried", result.FinalOutput)
+	assert.False(t, result.Cached)
+	assert.Equal(t, 2, calls)
+}
+
+// racingResultStore misses on the first Get, as if another run stored its result right after
+type racingResultStore struct {
+	*MemoryResultStore
+	gets int
+}
+
+func (s *racingResultStore) Get(ctx context.Context, key string) (*Result, bool, error) {
+	s.gets++
+	if s.gets == 1 {
+		return nil, false, nil
+	}
+	return s.MemoryResultStore.Get(ctx, key)
+}
+
+func TestRunIdempotentRechecksStore(t *testing.T) {
+	ctx := context.Background()
+	key := NewIdempotencyKey("session", "recheck")
+
+	store := &racingResultStore{MemoryResultStore: NewMemoryResultStore(0)}
+	require.NoError(t, store.Set(ctx, key, &Result{FinalOutput: "stored"}))
+
+	fakeModel := NewFakeModel()
+	config := RunConfig{
+		ModelProvider:  fakeModel,
+		MaxTurns:       5,
+		IdempotencyKey: key,
+		ResultStore:    store,
+	}
+
+	result, err := RunWithConfig(ctx, agent.New("test", "test instructions"), "hello", config)
+	require.NoError(t, err)
+	assert.Equal(t, "stored", result.FinalOutput)
+	assert.True(t, result.Cached)
+	assert.Empty(t, fakeModel.Calls())
+}
+
+// waitForCallers waits until the run in flight for key has the given number of callers
+func waitForCallers(t *testing.T, key string, callers int) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		inflightMu.Lock()
+		defer inflightMu.Unlock()
+		inflight, ok := inflightRuns[key]
+		return ok && inflight.callers == callers
+	}, time.Second, time.Millisecond)
+}
+
+func TestMemoryResultStoreExpiry(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryResultStore(time.Millisecond)
+
+	require.NoError(t, store.Set(ctx, "key", &Result{FinalOutput: "output"}))
+
+	result, ok, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "output", result.FinalOutput)
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok, err = store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestNewIdempotencyKey(t *testing.T) {
+	assert.Equal(t, NewIdempotencyKey("a", "b"), NewIdempotencyKey("a", "b"))
+	assert.NotEqual(t, NewIdempotencyKey("a", "b"), NewIdempotencyKey("ab", ""))
+}
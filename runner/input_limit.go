@@ -0,0 +1,99 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/ryichk/ai-agents-sdk-go/tokens"
+)
+
+// ErrInputTooLong is matched by errors.Is for every InputTooLongError
+var ErrInputTooLong = errors.New("input too long")
+
+// InputLimitPolicy determines what happens when the input exceeds the configured limits
+type InputLimitPolicy string
+
+const (
+	// InputLimitReject fails the run with an InputTooLongError (default)
+	InputLimitReject InputLimitPolicy = "reject"
+
+	// InputLimitTruncate truncates the input and appends a notice for the model, keeping both within the limit
+	InputLimitTruncate InputLimitPolicy = "truncate"
+)
+
+// InputTooLongError is returned when the input exceeds RunConfig.MaxInputChars or MaxInputTokens
+type InputTooLongError struct {
+	// Unit is the unit of the exceeded limit ("chars" or "tokens")
+	Unit string
+
+	// Limit is the configured limit
+	Limit int
+
+	// Actual is the size of the input in Unit
+	Actual int
+}
+
+func (e *InputTooLongError) Error() string {
+	return fmt.Sprintf("input too long: %d %s exceeds limit of %d", e.Actual, e.Unit, e.Limit)
+}
+
+// Is reports whether target is ErrInputTooLong
+func (e *InputTooLongError) Is(target error) bool {
+	return target == ErrInputTooLong
+}
+
+// applyInputLimits enforces MaxInputChars and MaxInputTokens on the input according to the
+// configured policy, returning the input to use for the run. Tokens are counted with the
+// encoding of modelName. A truncated input keeps room for the truncation notice, so it stays
+// within the limits with the notice; if the notice alone does not fit, the input is rejected.
+func applyInputLimits(input string, config RunConfig, modelName string) (string, error) {
+	if config.MaxInputChars <= 0 && config.MaxInputTokens <= 0 {
+		return input, nil
+	}
+
+	runes := []rune(input)
+	chars := len(runes)
+	count := tokens.ForModel(modelName).Count
+	var tooLong *InputTooLongError
+	if config.MaxInputChars > 0 && chars > config.MaxInputChars {
+		tooLong = &InputTooLongError{Unit: "chars", Limit: config.MaxInputChars, Actual: chars}
+	} else if config.MaxInputTokens > 0 {
+		if n := count(input); n > config.MaxInputTokens {
+			tooLong = &InputTooLongError{Unit: "tokens", Limit: config.MaxInputTokens, Actual: n}
+		}
+	}
+
+	if tooLong == nil {
+		return input, nil
+	}
+	if config.InputLimitPolicy != InputLimitTruncate {
+		return "", tooLong
+	}
+
+	keep := chars
+	if config.MaxInputChars > 0 {
+		// The notice for the full input is at least as long as the notice for any prefix
+		keep = min(keep, config.MaxInputChars-utf8.RuneCountInString(truncationNotice(chars, chars)))
+	}
+	if keep >= 0 && config.MaxInputTokens > 0 {
+		// Keep the longest prefix within the token limit together with its notice
+		keep = sort.Search(keep+1, func(i int) bool {
+			return count(string(runes[:i])+truncationNotice(i, chars)) > config.MaxInputTokens
+		}) - 1
+	}
+	if keep < 0 {
+		return "", tooLong
+	}
+	return string(runes[:keep]) + truncationNotice(keep, chars), nil
+}
+
+// truncationNotice tells the model that only the first keep of chars characters of the input are shown
+func truncationNotice(keep, chars int) string {
+	return fmt.Sprintf("\n\n[Input truncated: showing the first %d of %d characters]", keep, chars)
+}
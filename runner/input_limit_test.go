@@ -0,0 +1,106 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tokens"
+)
+
+func TestMaxInputCharsReject(t *testing.T) {
+	fakeModel := NewFakeModel()
+	testAgent := agent.New("test", "test instructions")
+
+	config := RunConfig{
+		Model:         "gpt-4o",
+		ModelProvider: fakeModel,
+		MaxInputChars: 10,
+	}
+
+	_, err := RunWithConfig(context.Background(), testAgent, strings.Repeat("a", 11), config)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInputTooLong))
+
+	var tooLong *InputTooLongError
+	require.True(t, errors.As(err, &tooLong))
+	assert.Equal(t, "chars", tooLong.Unit)
+	assert.Equal(t, 10, tooLong.Limit)
+	assert.Equal(t, 11, tooLong.Actual)
+	assert.Empty(t, fakeModel.Calls(), "model should not be called")
+}
+
+func TestMaxInputTokensTruncate(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("ok")})
+	testAgent := agent.New("test", "test instructions")
+
+	config := RunConfig{
+		Model:            "gpt-4o",
+		ModelProvider:    fakeModel,
+		MaxInputTokens:   19,
+		InputLimitPolicy: InputLimitTruncate,
+	}
+
+	input := "one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen seventeen eighteen nineteen twenty"
+	result, err := RunWithConfig(context.Background(), testAgent, input, config)
+	require.NoError(t, err)
+
+	// The notice counts toward the limit
+	userMessage := result.History[0]
+	assert.Equal(t, "user", userMessage.Role)
+	assert.Equal(t, "one two \n\n[Input truncated: showing the first 8 of 131 characters]", userMessage.Content)
+	assert.LessOrEqual(t, tokens.ForModel("gpt-4o").Count(userMessage.Content), 19)
+
+	// An input is rejected if the limit leaves no room for the notice
+	config.MaxInputTokens = 2
+	_, err = RunWithConfig(context.Background(), testAgent, input, config)
+	assert.ErrorIs(t, err, ErrInputTooLong)
+}
+
+func TestMaxInputCharsTruncate(t *testing.T) {
+	input := strings.Repeat("a", 100)
+	truncated, err := applyInputLimits(input, RunConfig{MaxInputChars: 70, InputLimitPolicy: InputLimitTruncate}, "gpt-4o")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", 10)+"\n\n[Input truncated: showing the first 10 of 100 characters]", truncated)
+	assert.LessOrEqual(t, len([]rune(truncated)), 70)
+}
+
+func TestMaxInputTokensCJK(t *testing.T) {
+	fakeModel := NewFakeModel()
+	testAgent := agent.New("test", "test instructions")
+
+	// CJK text has about one token per character, so 20 characters exceed 10 tokens
+	input := strings.Repeat("東京都", 7)[:20*len("東")]
+	_, err := RunWithConfig(context.Background(), testAgent, input, RunConfig{
+		Model:          "gpt-4",
+		ModelProvider:  fakeModel,
+		MaxInputTokens: 10,
+	})
+	var tooLong *InputTooLongError
+	require.ErrorAs(t, err, &tooLong)
+	assert.Equal(t, "tokens", tooLong.Unit)
+	assert.Equal(t, 20, tooLong.Actual)
+	assert.Empty(t, fakeModel.Calls(), "model should not be called")
+
+	// The notice takes 17 of the 19 tokens, leaving room for two characters
+	truncated, err := applyInputLimits(input, RunConfig{MaxInputTokens: 19, InputLimitPolicy: InputLimitTruncate}, "gpt-4")
+	require.NoError(t, err)
+	assert.Equal(t, "東京\n\n[Input truncated: showing the first 2 of 20 characters]", truncated)
+}
+
+func TestInputWithinLimits(t *testing.T) {
+	input, err := applyInputLimits("hello", RunConfig{MaxInputChars: 5, MaxInputTokens: 2}, "gpt-4o")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", input)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import "github.com/ryichk/ai-agents-sdk-go/items"
+
+// Items returns a typed view of the result: its history converted to conversation items. The
+// items are derived from History on each call, and changing them does not change the result. With
+// RunConfig.KeepNestedResults, the outputs of tool calls that made nested runs carry their results.
+// Model behavior errors follow the output of the call at fault.
+func (r *Result) Items() []items.Item {
+	list := items.FromMessages(toModelMessages(r.History))
+	if len(r.ModelBehaviorErrors) > 0 {
+		list = insertModelBehaviorErrors(list, r.ModelBehaviorErrors)
+	}
+	if len(r.NestedRuns) == 0 {
+		return list
+	}
+
+	nested := make(map[string]*Result, len(r.NestedRuns))
+	for _, run := range r.NestedRuns {
+		nested[run.ToolCallID] = run.Result
+	}
+	for _, item := range list {
+		if output, ok := item.(*items.FunctionCallOutput); ok {
+			if result, ok := nested[output.CallID]; ok {
+				output.NestedResult = result
+			}
+		}
+	}
+	return list
+}
+
+// insertModelBehaviorErrors adds the errors after the outputs of their calls, or at the end
+func insertModelBehaviorErrors(list []items.Item, behaviorErrs []ModelBehaviorError) []items.Item {
+	byCall := make(map[string][]items.Item, len(behaviorErrs))
+	var unmatched []items.Item
+	for _, e := range behaviorErrs {
+		item := &items.ModelBehaviorError{CallID: e.ToolCallID, Message: e.Message}
+		byCall[e.ToolCallID] = append(byCall[e.ToolCallID], item)
+	}
+
+	result := make([]items.Item, 0, len(list)+len(behaviorErrs))
+	for _, item := range list {
+		result = append(result, item)
+		if output, ok := item.(*items.FunctionCallOutput); ok {
+			result = append(result, byCall[output.CallID]...)
+			delete(byCall, output.CallID)
+		}
+	}
+	for _, e := range behaviorErrs {
+		if pending, ok := byCall[e.ToolCallID]; ok {
+			unmatched = append(unmatched, pending...)
+			delete(byCall, e.ToolCallID)
+		}
+	}
+	return append(result, unmatched...)
+}
+
+// HistoryFromItems converts conversation items, such as the ones returned by Result.Items or
+// decoded with items.Unmarshal, into a history for RunConfig.History
+func HistoryFromItems(list []items.Item) []Message {
+	return convertModelMessages(items.ToMessages(list))
+}
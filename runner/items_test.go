@@ -0,0 +1,44 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/items"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestResultItems(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("lookup", `{}`)},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("lookup", "found"))
+
+	result, err := RunWithConfig(context.Background(), testAgent, "question", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+	})
+	require.NoError(t, err)
+
+	list := result.Items()
+	require.Len(t, list, 4)
+	assert.Equal(t, &items.Message{Role: "user", Content: "question"}, list[0])
+	assert.Equal(t, &items.FunctionCall{CallID: "call_lookup", Name: "lookup", Arguments: `{}`}, list[1])
+	assert.Equal(t, &items.FunctionCallOutput{CallID: "call_lookup", Output: "found"}, list[2])
+	assert.Equal(t, &items.Message{Role: "assistant", Content: "done"}, list[3])
+
+	// Items convert back to the history they came from
+	assert.Equal(t, result.History, HistoryFromItems(list))
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NestedRun is a run made during a tool call of the run, such as the run of an agent used as a tool
+type NestedRun struct {
+	// ToolCallID is the ID of the tool call that made the run
+	ToolCallID string
+
+	// ToolName is the name of the tool that made the run
+	ToolName string
+
+	// Result is the result of the nested run
+	Result *Result
+}
+
+// nestedRunsKey is the context key of the nestedRuns of a tool call
+type nestedRunsKey struct{}
+
+// nestedRuns collects the results of the runs made during a tool call
+type nestedRuns struct {
+	mu      sync.Mutex
+	results []*Result
+}
+
+// contextWithNestedRuns returns a context collecting the runs made with it
+func contextWithNestedRuns(ctx context.Context) (context.Context, *nestedRuns) {
+	runs := &nestedRuns{}
+	return context.WithValue(ctx, nestedRunsKey{}, runs), runs
+}
+
+// recordNestedRun reports the result of a run to the tool call that made it, if any
+func recordNestedRun(ctx context.Context, result *Result) {
+	runs, ok := ctx.Value(nestedRunsKey{}).(*nestedRuns)
+	if !ok {
+		return
+	}
+	runs.mu.Lock()
+	defer runs.mu.Unlock()
+	runs.results = append(runs.results, result)
+}
+
+// take returns the collected results
+func (r *nestedRuns) take() []*Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	results := r.results
+	r.results = nil
+	return results
+}
+
+// addNestedRuns adds the usage of the runs made during a tool call to the run, and keeps their
+// results if RunConfig.KeepNestedResults is set
+func addNestedRuns(state *executionState, toolName string, toolCallID string, results []*Result) {
+	for _, result := range results {
+		accumulateUsage(&state.usage, result.Usage)
+		for _, step := range result.UsageReport.Steps {
+			// The calls of the nested run happen during the current turn
+			state.usageReport.record(state.stepCounter+1, step.AgentName, step.Model, step.Usage, time.Duration(step.DurationMS)*time.Millisecond)
+		}
+		if state.config.KeepNestedResults {
+			state.nestedRuns = append(state.nestedRuns, NestedRun{ToolCallID: toolCallID, ToolName: toolName, Result: result})
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/items"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/pricing"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+func TestAgentToolNestedRun(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	innerModel := NewFakeModel()
+	innerModel.SetUsage(model.Usage{PromptTokens: 7, CompletionTokens: 3, TotalTokens: 10})
+	innerModel.AddTurn(GetTextMessage("hola"))
+	translator := agent.New("translator", "Translate to Spanish")
+	translatorTool, err := AsToolWithConfig(translator, RunConfig{ModelProvider: innerModel, Model: "gpt-4o-mini"}, tool.AgentToolOption{Name: "translate"})
+	require.NoError(t, err)
+
+	outerModel := NewFakeModel()
+	outerModel.SetUsage(model.Usage{PromptTokens: 20, CompletionTokens: 5, TotalTokens: 25})
+	outerModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("translate", `{"input": "hello"}`)},
+		{GetTextMessage("hola!")},
+	})
+	orchestrator := agent.New("orchestrator", "Use the translator")
+	orchestrator.AddTool(translatorTool)
+
+	result, err := RunWithConfig(context.Background(), orchestrator, "translate hello", RunConfig{
+		ModelProvider:     outerModel,
+		Model:             "gpt-4o",
+		MaxTurns:          5,
+		KeepNestedResults: true,
+	})
+	require.NoError(t, err)
+
+	// The usage of the nested run counts towards the outer run
+	assert.Equal(t, 60, result.Usage.TotalTokens)
+	assert.Equal(t, 3, result.Usage.Requests)
+	assert.Equal(t, 10, result.UsageReport.ByAgent["translator"].TotalTokens)
+	assert.Equal(t, 10, result.UsageReport.ByModel["gpt-4o-mini"].TotalTokens)
+	require.Len(t, result.UsageReport.Steps, 3)
+	assert.Equal(t, StepUsage{Step: 1, AgentName: "translator", Model: "gpt-4o-mini", Usage: Usage{PromptTokens: 7, CompletionTokens: 3, TotalTokens: 10, Requests: 1, Cost: pricing.Cost("gpt-4o-mini", 7, 0, 3)}}, result.UsageReport.Steps[1])
+
+	// The nested result is kept with its tool call
+	require.Len(t, result.NestedRuns, 1)
+	nested := result.NestedRuns[0]
+	assert.Equal(t, "translate", nested.ToolName)
+	assert.Equal(t, result.History[1].ToolCalls[0].ID, nested.ToolCallID)
+	assert.Equal(t, "hola", nested.Result.FinalOutput)
+
+	var output *items.FunctionCallOutput
+	for _, item := range result.Items() {
+		if o, ok := item.(*items.FunctionCallOutput); ok {
+			output = o
+		}
+	}
+	require.NotNil(t, output)
+	assert.Same(t, nested.Result, output.NestedResult)
+
+	// The nested run is traced under the tool call
+	toolSpans := recorder.byName("tool_call")
+	require.Len(t, toolSpans, 1)
+	assert.Equal(t, 1, toolSpans[0].Context().Attributes["nested_runs"])
+	runSpans := recorder.byName("agent_run")
+	require.Len(t, runSpans, 2)
+	inner := runSpans[0].Context()
+	assert.Equal(t, "translator", inner.Attributes["agent_name"])
+	assert.Equal(t, toolSpans[0].Context().SpanID, inner.ParentSpanID)
+	assert.Equal(t, toolSpans[0].Context().TraceID, inner.TraceID)
+
+	// Without KeepNestedResults, only the usage is added
+	innerModel.AddTurn(GetTextMessage("hola"))
+	outerModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("translate", `{"input": "hello"}`)},
+		{GetTextMessage("hola!")},
+	})
+	result, err = RunWithConfig(context.Background(), orchestrator, "translate hello", RunConfig{ModelProvider: outerModel, MaxTurns: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 60, result.Usage.TotalTokens)
+	assert.Empty(t, result.NestedRuns)
+}
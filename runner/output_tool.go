@@ -0,0 +1,57 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// FinalOutputToolName is the name of the tool called by agents using agent.OutputModeTool
+const FinalOutputToolName = "final_output"
+
+// finalOutputToolDescription describes the final output tool to the model
+const finalOutputToolDescription = "Return the final answer. Call this tool exactly once, when the task is done, " +
+	"with the answer as arguments."
+
+// applyFinalOutputTool adds the final output tool, whose parameters are the agent's output type,
+// and requires the model to call a tool unless the agent's settings choose otherwise
+func applyFinalOutputTool(a *agent.Agent, settings *model.Settings) {
+	schema, err := model.StrictJSONSchema(a.OutputType)
+	if err != nil {
+		return
+	}
+
+	definition := model.NewFunctionTool(FinalOutputToolName, finalOutputToolDescription, schema)
+	definition.Strict = true
+	settings.Tools = append(settings.Tools, definition)
+	if settings.EffectiveToolChoice() == "" {
+		settings.ToolChoice = model.ToolChoiceRequired
+	}
+}
+
+// finalOutputToolCall returns the call to the final output tool in the message, if the agent uses one
+func finalOutputToolCall(a *agent.Agent, message model.Message) (model.ToolCall, bool) {
+	if a.OutputType == nil || a.OutputMode != agent.OutputModeTool {
+		return model.ToolCall{}, false
+	}
+	for _, tc := range message.ToolCalls {
+		if tc.Function.Name == FinalOutputToolName {
+			return tc, true
+		}
+	}
+	return model.ToolCall{}, false
+}
+
+// finalOutputToolMessages returns the messages recorded for a final output tool call.
+// Other tool calls of the message are dropped, since the run ends without invoking them, and the
+// call is answered so that the history stays valid for a later run.
+func finalOutputToolMessages(message model.Message, call model.ToolCall) []model.Message {
+	message.ToolCalls = []model.ToolCall{call}
+	return []model.Message{
+		message,
+		{Role: "tool", ToolCallID: call.ID, Content: "Final output accepted"},
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestFinalOutputTool(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("lookup", `{}`)},
+		{GetFunctionToolCall("lookup", `{}`), GetFunctionToolCall(FinalOutputToolName, `{"bar": "baz"}`)},
+	})
+	hooks := &llmHooksRecorder{}
+
+	structuredAgent := agent.New("structured", "test instructions")
+	structuredAgent.AddTool(NewFunctionTool("lookup", "found"))
+	structuredAgent.SetOutputType(reflect.TypeOf(TestOutputStruct{}))
+	structuredAgent.SetOutputMode(agent.OutputModeTool)
+
+	result, err := RunWithConfig(context.Background(), structuredAgent, "test input", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      10,
+		LLMHooks:      hooks,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, TestOutputStruct{Bar: "baz"}, result.StructuredOutput)
+	assert.JSONEq(t, `{"bar": "baz"}`, result.FinalOutput)
+
+	// The output type is a tool instead of a response format, and a tool call is required
+	settings := hooks.settings[0]
+	assert.Empty(t, settings.ResponseFormat)
+	assert.Equal(t, model.ToolChoiceRequired, settings.ToolChoice)
+	require.Len(t, settings.Tools, 2)
+	definition := settings.Tools[1]
+	assert.Equal(t, FinalOutputToolName, definition.Name)
+	assert.True(t, definition.Strict)
+	assert.Equal(t, []string{"bar"}, definition.Parameters["required"])
+
+	// The final call is answered, and the calls made alongside it are not run
+	require.Len(t, result.History, 5)
+	final := result.History[3]
+	require.Len(t, final.ToolCalls, 1)
+	assert.Equal(t, FinalOutputToolName, final.ToolCalls[0].Function.Name)
+	assert.Equal(t, "tool", result.History[4].Role)
+	assert.Equal(t, final.ToolCalls[0].ID, result.History[4].ToolCallID)
+}
+
+func TestFinalOutputToolInvalidArguments(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddTurn(GetFunctionToolCall(FinalOutputToolName, `{"bar": `))
+
+	structuredAgent := agent.New("structured", "test instructions")
+	structuredAgent.SetOutputType(reflect.TypeOf(TestOutputStruct{}))
+	structuredAgent.SetOutputMode(agent.OutputModeTool)
+
+	_, err := RunWithConfig(context.Background(), structuredAgent, "test input", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      10,
+	})
+	assert.ErrorIs(t, err, ErrInvalidOutputFormat)
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+)
+
+// AgentRun is one branch of RunParallel
+type AgentRun struct {
+	// Agent is the agent to run
+	Agent *agent.Agent
+
+	// Input is the agent's input
+	Input string
+
+	// Config overrides the shared RunConfig for this branch (optional)
+	Config *RunConfig
+}
+
+// ParallelErrorPolicy determines how RunParallel handles a failing branch
+type ParallelErrorPolicy int
+
+const (
+	// ParallelFailFast cancels the other branches and returns the first error
+	ParallelFailFast ParallelErrorPolicy = iota
+
+	// ParallelContinueOnError lets the other branches finish and records the error of each failed branch.
+	// RunParallel only fails if every branch fails.
+	ParallelContinueOnError
+)
+
+// ParallelOptions configures RunParallel
+type ParallelOptions struct {
+	// ErrorPolicy determines how failing branches are handled (defaults to ParallelFailFast)
+	ErrorPolicy ParallelErrorPolicy
+
+	// MaxConcurrency limits the number of branches running at once (0 means no limit)
+	MaxConcurrency int
+
+	// Synthesizer, if set, runs after the branches with their outputs as input
+	Synthesizer *agent.Agent
+
+	// SynthesizerInput builds the synthesizer's input from the successful branch results
+	// (defaults to the outputs labeled with their agent names)
+	SynthesizerInput func(results []*Result) string
+}
+
+// ParallelOption configures RunParallel
+type ParallelOption func(*ParallelOptions)
+
+// WithErrorPolicy sets how failing branches are handled
+func WithErrorPolicy(policy ParallelErrorPolicy) ParallelOption {
+	return func(o *ParallelOptions) {
+		o.ErrorPolicy = policy
+	}
+}
+
+// WithMaxConcurrency limits the number of branches running at once
+func WithMaxConcurrency(n int) ParallelOption {
+	return func(o *ParallelOptions) {
+		o.MaxConcurrency = n
+	}
+}
+
+// WithSynthesizer runs the agent on the branch outputs after all branches finish.
+// input builds the synthesizer's input and may be nil to use the default format.
+func WithSynthesizer(a *agent.Agent, input func(results []*Result) string) ParallelOption {
+	return func(o *ParallelOptions) {
+		o.Synthesizer = a
+		o.SynthesizerInput = input
+	}
+}
+
+// ParallelResult is the result of RunParallel
+type ParallelResult struct {
+	// Results contains the result of each branch, in the order of the runs (nil for failed branches)
+	Results []*Result
+
+	// Errors contains the error of each branch, in the order of the runs (nil for successful branches)
+	Errors []error
+
+	// Synthesis is the synthesizer's result, if a synthesizer is configured
+	Synthesis *Result
+}
+
+// Succeeded returns the results of the successful branches, in the order of the runs
+func (r *ParallelResult) Succeeded() []*Result {
+	var results []*Result
+	for _, result := range r.Results {
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// RunParallel runs agents concurrently and collects their results.
+// All branches belong to one trace named after config.WorkflowName, unless ctx already carries a trace.
+// Each branch and the synthesizer run with their own idempotency key derived from their config's key.
+func RunParallel(ctx context.Context, runs []AgentRun, config RunConfig, opts ...ParallelOption) (*ParallelResult, error) {
+	options := ParallelOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// A branch must not fail on a nil agent inside its goroutine, so runs are checked first
+	for i, run := range runs {
+		if run.Agent == nil {
+			return nil, fmt.Errorf("parallel run %d: %w", i+1, ErrAgentRequired)
+		}
+	}
+
+	ctx, trace := startWorkflowTrace(ctx, config)
+	if trace != nil {
+		defer trace.End()
+	}
+
+	parallelResult := &ParallelResult{
+		Results: make([]*Result, len(runs)),
+		Errors:  make([]error, len(runs)),
+	}
+
+	branchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var limit chan struct{}
+	if options.MaxConcurrency > 0 {
+		limit = make(chan struct{}, options.MaxConcurrency)
+	}
+
+	var wg sync.WaitGroup
+	var firstErr error
+	var errOnce sync.Once
+
+	for i, run := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if limit != nil {
+				select {
+				case limit <- struct{}{}:
+					defer func() { <-limit }()
+				case <-branchCtx.Done():
+					parallelResult.Errors[i] = branchCtx.Err()
+					return
+				}
+			}
+
+			runConfig := config
+			if run.Config != nil {
+				runConfig = *run.Config
+			}
+
+			result, err := RunWithConfig(branchCtx, run.Agent, run.Input, innerRunConfig(runConfig, fmt.Sprintf("branch %d", i+1)))
+			if err != nil {
+				parallelResult.Errors[i] = fmt.Errorf("parallel run %d (%s): %w", i+1, run.Agent.Name, err)
+				if options.ErrorPolicy == ParallelFailFast {
+					errOnce.Do(func() {
+						firstErr = parallelResult.Errors[i]
+						cancel()
+					})
+				}
+				return
+			}
+			parallelResult.Results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return parallelResult, firstErr
+	}
+
+	succeeded := parallelResult.Succeeded()
+	if len(runs) > 0 && len(succeeded) == 0 {
+		return parallelResult, errors.Join(parallelResult.Errors...)
+	}
+
+	if options.Synthesizer != nil {
+		input := defaultSynthesizerInput(succeeded)
+		if options.SynthesizerInput != nil {
+			input = options.SynthesizerInput(succeeded)
+		}
+
+		synthesis, err := RunWithConfig(ctx, options.Synthesizer, input, innerRunConfig(config, "synthesizer"))
+		if err != nil {
+			return parallelResult, fmt.Errorf("synthesizer (%s): %w", options.Synthesizer.Name, err)
+		}
+		parallelResult.Synthesis = synthesis
+	}
+
+	return parallelResult, nil
+}
+
+// defaultSynthesizerInput labels each output with the name of the agent that produced it
+func defaultSynthesizerInput(results []*Result) string {
+	var b strings.Builder
+	for i, result := range results {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		name := "agent"
+		if result.LastAgent != nil {
+			name = result.LastAgent.Name
+		}
+		fmt.Fprintf(&b, "Output of %s:\n%s", name, result.FinalOutput)
+	}
+	return b.String()
+}
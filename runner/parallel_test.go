@@ -0,0 +1,127 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// echoProvider answers with the last message; it is safe for concurrent use
+type echoProvider struct {
+	err error
+}
+
+func (p *echoProvider) CreateChatCompletion(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &model.Response{
+		Message: model.Message{Role: "assistant", Content: "echo: " + messages[len(messages)-1].Content},
+	}, nil
+}
+
+func (p *echoProvider) CreateChatCompletionStream(ctx context.Context, messages []model.Message, settings model.Settings) (model.Stream, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestRunParallel(t *testing.T) {
+	config := RunConfig{ModelProvider: &echoProvider{}, MaxTurns: 3}
+
+	runs := []AgentRun{
+		{Agent: agent.New("spanish", "Translate to Spanish"), Input: "one"},
+		{Agent: agent.New("french", "Translate to French"), Input: "two"},
+		{Agent: agent.New("italian", "Translate to Italian"), Input: "three"},
+	}
+
+	result, err := RunParallel(context.Background(), runs, config,
+		WithMaxConcurrency(2),
+		WithSynthesizer(agent.New("picker", "Pick the best translation"), nil),
+	)
+	require.NoError(t, err)
+	require.Len(t, result.Results, 3)
+	assert.Equal(t, "echo: one", result.Results[0].FinalOutput)
+	assert.Equal(t, "echo: three", result.Results[2].FinalOutput)
+	assert.Equal(t, "echo: Output of spanish:\necho: one\n\nOutput of french:\necho: two\n\nOutput of italian:\necho: three", result.Synthesis.FinalOutput)
+}
+
+func TestRunParallelErrorPolicy(t *testing.T) {
+	config := RunConfig{ModelProvider: &echoProvider{}, MaxTurns: 3}
+	failing := RunConfig{ModelProvider: &echoProvider{err: errors.New("rate limited")}, MaxTurns: 3}
+
+	runs := []AgentRun{
+		{Agent: agent.New("ok", "instructions"), Input: "one"},
+		{Agent: agent.New("broken", "instructions"), Input: "two", Config: &failing},
+	}
+
+	_, err := RunParallel(context.Background(), runs, config)
+	assert.ErrorContains(t, err, "rate limited")
+
+	result, err := RunParallel(context.Background(), runs, config, WithErrorPolicy(ParallelContinueOnError))
+	require.NoError(t, err)
+	assert.Equal(t, "echo: one", result.Results[0].FinalOutput)
+	assert.Nil(t, result.Results[1])
+	assert.ErrorContains(t, result.Errors[1], "rate limited")
+	assert.Len(t, result.Succeeded(), 1)
+
+	// Every branch failing is an error
+	_, err = RunParallel(context.Background(), runs[1:], config, WithErrorPolicy(ParallelContinueOnError))
+	assert.ErrorContains(t, err, "rate limited")
+}
+
+func TestRunParallelNilAgent(t *testing.T) {
+	config := RunConfig{ModelProvider: &echoProvider{}, MaxTurns: 3}
+	runs := []AgentRun{
+		{Agent: agent.New("ok", "instructions"), Input: "one"},
+		{Input: "two"},
+	}
+
+	result, err := RunParallel(context.Background(), runs, config, WithErrorPolicy(ParallelContinueOnError))
+	assert.ErrorIs(t, err, ErrAgentRequired)
+	assert.EqualError(t, err, "parallel run 2: agent is required")
+	assert.Nil(t, result)
+
+	_, err = RunWithConfig(context.Background(), nil, "hi", config)
+	assert.ErrorIs(t, err, ErrAgentRequired)
+}
+
+func TestRunParallelIdempotencyKey(t *testing.T) {
+	config := RunConfig{
+		ModelProvider:  &echoProvider{},
+		MaxTurns:       3,
+		IdempotencyKey: NewIdempotencyKey("session", "translate"),
+		ResultStore:    NewMemoryResultStore(0),
+	}
+	runs := []AgentRun{
+		{Agent: agent.New("spanish", "Translate to Spanish"), Input: "one"},
+		{Agent: agent.New("french", "Translate to French"), Input: "two"},
+	}
+	synthesizer := WithSynthesizer(agent.New("picker", "Pick the best translation"), nil)
+
+	// Branches and the synthesizer do not share one stored result
+	result, err := RunParallel(context.Background(), runs, config, synthesizer)
+	require.NoError(t, err)
+	assert.Equal(t, "echo: one", result.Results[0].FinalOutput)
+	assert.Equal(t, "echo: two", result.Results[1].FinalOutput)
+	assert.Equal(t, "echo: Output of spanish:\necho: one\n\nOutput of french:\necho: two", result.Synthesis.FinalOutput)
+	assert.False(t, result.Synthesis.Cached)
+
+	// A redelivery gets each run's stored result
+	result, err = RunParallel(context.Background(), runs, config, synthesizer)
+	require.NoError(t, err)
+	assert.True(t, result.Results[0].Cached)
+	assert.Equal(t, "echo: two", result.Results[1].FinalOutput)
+	assert.True(t, result.Synthesis.Cached)
+}
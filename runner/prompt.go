@@ -0,0 +1,39 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+)
+
+// resolveAgentPrompt gets the current agent's stored prompt. With a PromptResolver the prompt is
+// resolved and placed before the instructions; otherwise the reference is kept in the state so it
+// is sent to the provider.
+func resolveAgentPrompt(ctx context.Context, state *executionState, instructions string) (string, error) {
+	state.agentPrompt = nil
+
+	prompt, err := state.currentAgent.GetPrompt(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get prompt: %w", err)
+	}
+	if prompt == nil {
+		return instructions, nil
+	}
+
+	if state.config.PromptResolver == nil {
+		state.agentPrompt = prompt
+		return instructions, nil
+	}
+
+	text, err := state.config.PromptResolver.ResolvePrompt(ctx, *prompt)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve prompt %s: %w", prompt.ID, err)
+	}
+	if instructions == "" {
+		return text, nil
+	}
+	return text + "\n\n" + instructions, nil
+}
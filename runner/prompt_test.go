@@ -0,0 +1,83 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestRunWithPromptResolver(t *testing.T) {
+	registry := model.NewPromptRegistry()
+	registry.Register("pmpt_support", "1", "You help {{customer}}.")
+
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+	hooks := &llmHooksRecorder{}
+
+	supportAgent := agent.New("support", "")
+	supportAgent.SetDynamicPrompt(func(ctx context.Context) (*model.Prompt, error) {
+		return &model.Prompt{ID: "pmpt_support", Variables: map[string]string{"customer": "Alice"}}, nil
+	})
+
+	_, err := RunWithConfig(context.Background(), supportAgent, "hello", RunConfig{
+		ModelProvider:  fakeModel,
+		MaxTurns:       3,
+		LLMHooks:       hooks,
+		PromptResolver: registry,
+	})
+	require.NoError(t, err)
+
+	messages := hooks.startMessages[0]
+	require.Len(t, messages, 2)
+	assert.Equal(t, model.Message{Role: "system", Content: "You help Alice."}, messages[0])
+	assert.Nil(t, hooks.settings[0].Prompt)
+
+	// Instructions follow the resolved prompt
+	supportAgent.Instructions = "Answer in English."
+	_, err = RunWithConfig(context.Background(), supportAgent, "hello", RunConfig{
+		ModelProvider:  fakeModel,
+		MaxTurns:       3,
+		LLMHooks:       hooks,
+		PromptResolver: registry,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "You help Alice.\n\nAnswer in English.", hooks.startMessages[1][0].Content)
+
+	// A prompt that cannot be resolved fails the run
+	supportAgent.SetDynamicPrompt(nil)
+	supportAgent.SetPrompt(&model.Prompt{ID: "pmpt_missing"})
+	_, err = RunWithConfig(context.Background(), supportAgent, "hello", RunConfig{
+		ModelProvider:  fakeModel,
+		MaxTurns:       3,
+		PromptResolver: registry,
+	})
+	assert.ErrorIs(t, err, model.ErrPromptNotFound)
+}
+
+func TestRunSendsPromptToProvider(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+	hooks := &llmHooksRecorder{}
+
+	prompt := &model.Prompt{ID: "pmpt_123", Version: "2"}
+	supportAgent := agent.New("support", "Be brief.")
+	supportAgent.SetPrompt(prompt)
+
+	_, err := RunWithConfig(context.Background(), supportAgent, "hello", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      3,
+		LLMHooks:      hooks,
+	})
+	require.NoError(t, err)
+	assert.Same(t, prompt, hooks.settings[0].Prompt)
+	assert.Equal(t, "Be brief.", hooks.startMessages[0][0].Content)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+)
+
+// ErrNothingToResume is returned by Resume when the error carries no partial result
+var ErrNothingToResume = errors.New("nothing to resume")
+
+// MaxTurnsExceededError is returned when the run reaches RunConfig.MaxTurns without a final output.
+// It carries the progress made so far, which Resume can continue. errors.Is matches it with
+// ErrMaxTurnsExceeded.
+type MaxTurnsExceededError struct {
+	// Partial is the result up to the last turn. FinalOutput is empty and History ends with the
+	// last tool results.
+	Partial *Result
+
+	// Turns is the number of completed turns
+	Turns int
+
+	// config is the configuration of the run, reused by Resume
+	config RunConfig
+
+	// handoffStack is the handoff stack of the run, for return handoffs after Resume
+	handoffStack []*agent.Agent
+
+	// input is the user input of the run, recorded in the checkpoints of the resumed run
+	input string
+}
+
+func (e *MaxTurnsExceededError) Error() string {
+	return fmt.Sprintf("%v after %d turns", ErrMaxTurnsExceeded, e.Turns)
+}
+
+// Is reports whether target is ErrMaxTurnsExceeded
+func (e *MaxTurnsExceededError) Is(target error) bool {
+	return target == ErrMaxTurnsExceeded
+}
+
+// newMaxTurnsExceededError creates a MaxTurnsExceededError from the execution state
+func newMaxTurnsExceededError(state *executionState) *MaxTurnsExceededError {
+	input := state.originalInput
+	if state.config.checkpointBase != nil {
+		input = state.config.checkpointBase.input
+	}
+	return &MaxTurnsExceededError{
+		Partial:      progressResult(state),
+		Turns:        state.stepCounter,
+		config:       state.config,
+		handoffStack: state.handoffStack,
+		input:        input,
+	}
+}
+
+// progressResult returns the result of the run so far from the execution state, without the
+// final output and the output guardrail results. It is the base of every Result of a run, so
+// partial results, checkpoints and final results carry the same progress.
+func progressResult(state *executionState) *Result {
+	traceID, spanID := spanIDs(state.span)
+	return &Result{
+		RunID:          state.config.RunID,
+		TraceID:        traceID,
+		SpanID:         spanID,
+		LastAgent:      state.currentAgent.Origin(),
+		History:        convertModelMessages(state.resultMessages),
+		Usage:          state.usage,
+		UsageReport:    state.usageReport,
+		LastResponseID: state.lastResponseID,
+
+		InputGuardrailResults: state.inputGuardrails,
+		Handoffs:              state.handoffs,
+		HandoffDecisions:      state.handoffDecisions,
+		ModelBehaviorErrors:   state.modelBehaviorErrors,
+		StartedAt:             state.startTime,
+		Duration:              time.Since(state.startTime),
+		ContextRecoveries:     state.contextRecoveries,
+		NestedRuns:            state.nestedRuns,
+	}
+}
+
+// Resume continues a run stopped by MaxTurns for up to extraTurns more turns, with the same
+// configuration and from the agent that was running. The returned Result covers the whole run:
+// its History, Usage, UsageReport, InputGuardrailResults, Handoffs, HandoffDecisions, ModelBehaviorErrors, ContextRecoveries, NestedRuns and Duration include
+// the turns made before Resume.
+// If the run reaches the limit again, the returned MaxTurnsExceededError can be resumed too.
+//
+// The partial history is sent in full, so runs using PreviousResponseID or ConversationID
+// continue without server-side conversation state. Input guardrails are not applied again.
+func Resume(ctx context.Context, partial *MaxTurnsExceededError, extraTurns int) (*Result, error) {
+	if partial == nil || partial.Partial == nil || partial.Partial.LastAgent == nil {
+		return nil, ErrNothingToResume
+	}
+
+	config := partial.config
+	config.MaxTurns = extraTurns
+	config.History = partial.Partial.History
+	config.InputParts = nil
+	config.PreviousResponseID = ""
+	config.ConversationID = ""
+	config.IdempotencyKey = ""
+	config.resumed = true
+	config.handoffStack = partial.handoffStack
+	config.checkpointBase = partial
+
+	result, err := executeRun(ctx, partial.Partial.LastAgent, "", config)
+	var maxErr *MaxTurnsExceededError
+	if errors.As(err, &maxErr) {
+		mergePartial(maxErr.Partial, partial)
+		maxErr.Turns += partial.Turns
+		return nil, maxErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mergePartial(result, partial)
+	return result, nil
+}
+
+// mergePartial adds the usage, guardrail results, handoffs and duration of the turns before
+// Resume to the result of the resumed run
+func mergePartial(result *Result, partial *MaxTurnsExceededError) {
+	result.InputGuardrailResults = partial.Partial.InputGuardrailResults
+	result.StartedAt = partial.Partial.StartedAt
+	result.Duration += partial.Partial.Duration
+
+	handoffs := append([]HandoffRecord(nil), partial.Partial.Handoffs...)
+	for _, h := range result.Handoffs {
+		h.Step += partial.Turns
+		handoffs = append(handoffs, h)
+	}
+	result.Handoffs = handoffs
+
+	decisions := append([]HandoffDecision(nil), partial.Partial.HandoffDecisions...)
+	for _, d := range result.HandoffDecisions {
+		d.Step += partial.Turns
+		decisions = append(decisions, d)
+	}
+	result.HandoffDecisions = decisions
+
+	behaviorErrs := append([]ModelBehaviorError(nil), partial.Partial.ModelBehaviorErrors...)
+	for _, e := range result.ModelBehaviorErrors {
+		e.Step += partial.Turns
+		behaviorErrs = append(behaviorErrs, e)
+	}
+	result.ModelBehaviorErrors = behaviorErrs
+
+	recoveries := append([]ContextRecovery(nil), partial.Partial.ContextRecoveries...)
+	for _, r := range result.ContextRecoveries {
+		r.Step += partial.Turns
+		recoveries = append(recoveries, r)
+	}
+	result.ContextRecoveries = recoveries
+	result.NestedRuns = append(append([]NestedRun(nil), partial.Partial.NestedRuns...), result.NestedRuns...)
+
+	usage := partial.Partial.Usage
+	accumulateUsage(&usage, result.Usage)
+	result.Usage = usage
+
+	report := newUsageReport()
+	for _, step := range partial.Partial.UsageReport.Steps {
+		report.record(step.Step, step.AgentName, step.Model, step.Usage, time.Duration(step.DurationMS)*time.Millisecond)
+	}
+	for _, step := range result.UsageReport.Steps {
+		report.record(step.Step+partial.Turns, step.AgentName, step.Model, step.Usage, time.Duration(step.DurationMS)*time.Millisecond)
+	}
+	result.UsageReport = report
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestResumeAfterMaxTurns(t *testing.T) {
+	ctx := context.Background()
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("test_tool", "{}")},
+		{GetFunctionToolCall("test_tool", "{}")},
+		{GetFunctionToolCall("test_tool", "{}")},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "test instructions")
+	testAgent.AddTool(NewFunctionTool("test_tool", "result"))
+
+	_, err := RunWithConfig(ctx, testAgent, "test input", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      2,
+	})
+	var maxErr *MaxTurnsExceededError
+	require.ErrorAs(t, err, &maxErr)
+
+	// One more turn is not enough: the error can be resumed again
+	_, err = Resume(ctx, maxErr, 1)
+	require.ErrorAs(t, err, &maxErr)
+	assert.Equal(t, 3, maxErr.Turns)
+
+	result, err := Resume(ctx, maxErr, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	// The last call continues from the whole history, with no new user message
+	calls := fakeModel.Calls()
+	require.Len(t, calls, 4)
+	last := calls[3].Messages
+	assert.Equal(t, "system", last[0].Role)
+	assert.Equal(t, "test input", last[1].Content)
+	assert.Equal(t, "tool", last[len(last)-1].Role)
+
+	// The result covers the whole run
+	assert.Len(t, result.History, 8)
+	assert.Equal(t, 4, result.Usage.Requests)
+	require.Len(t, result.UsageReport.Steps, 4)
+	assert.Equal(t, 4, result.UsageReport.Steps[3].Step)
+
+	_, err = Resume(ctx, nil, 1)
+	assert.ErrorIs(t, err, ErrNothingToResume)
+}
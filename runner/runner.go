@@ -12,11 +12,16 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/guardrail"
 	"github.com/ryichk/ai-agents-sdk-go/handoff"
 	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/pricing"
 	"github.com/ryichk/ai-agents-sdk-go/tool"
 	"github.com/ryichk/ai-agents-sdk-go/tracing"
+	"github.com/ryichk/ai-agents-sdk-go/version"
 )
 
 // Default value for maximum turns in the agent loop
@@ -29,6 +34,9 @@ var (
 	ErrAgentMissingInstructions = errors.New("agent has no instructions")
 	ErrInvalidHandoffInput      = errors.New("invalid handoff input")
 	ErrInvalidOutputFormat      = errors.New("invalid output format")
+	ErrOutputRefused            = errors.New("model refused to produce the output")
+	ErrInvalidToolArguments     = tool.ErrInvalidArguments
+	ErrAgentRequired            = errors.New("agent is required")
 )
 
 var DefaultProvider model.Provider
@@ -36,32 +44,61 @@ var DefaultProvider model.Provider
 // Message represents a chat message
 type Message struct {
 	// Role is the role of the message (system, user, assistant, tool)
-	Role string
+	Role string `json:"role"`
 
 	// Content is the content of the message
-	Content string
+	Content string `json:"content"`
+
+	ToolCalls []model.ToolCall `json:"tool_calls,omitempty"`
 
-	ToolCalls []model.ToolCall
+	ToolCallID string `json:"tool_call_id,omitempty"`
 
-	ToolCallID string
+	Name string `json:"name,omitempty"`
 
-	Name string
+	// ContentParts are images, files, audio or additional text sent after Content
+	ContentParts []model.ContentPart `json:"content_parts,omitempty"`
+
+	// Reasoning is the reasoning returned with an assistant message, if the provider exposes it
+	Reasoning string `json:"reasoning,omitempty"`
 }
 
 // Usage represents token usage
 type Usage struct {
 	// PromptTokens is the number of tokens in the prompt
-	PromptTokens int
+	PromptTokens int `json:"prompt_tokens"`
 
 	// CompletionTokens is the number of tokens in the completion
-	CompletionTokens int
+	CompletionTokens int `json:"completion_tokens"`
 
 	// TotalTokens is the total number of tokens
-	TotalTokens int
+	TotalTokens int `json:"total_tokens"`
+
+	// CachedPromptTokens is the number of prompt tokens served from the provider's prompt cache
+	CachedPromptTokens int `json:"cached_prompt_tokens"`
+
+	// ReasoningTokens is the number of completion tokens spent on reasoning
+	ReasoningTokens int `json:"reasoning_tokens"`
+
+	// Requests is the number of model calls
+	Requests int `json:"requests"`
+
+	// Cost is the estimated cost in US dollars, priced with RunConfig.Pricing and the default
+	// pricing table (models without a price cost nothing)
+	Cost float64 `json:"cost,omitempty"`
 }
 
 // Result represents the result of an agent execution
 type Result struct {
+	// TraceID is the ID of the run's trace, for linking to the tracing backend (empty when
+	// tracing is disabled). The OpenAI exporter sends it with a "trace_" prefix.
+	TraceID string
+
+	// SpanID is the ID of the run's agent_run span (empty when tracing is disabled)
+	SpanID string
+
+	// RunID identifies the run in RunConfig.RunStore (empty without a store or RunID)
+	RunID string
+
 	// FinalOutput is the final output (string or JSON string)
 	FinalOutput string
 
@@ -76,6 +113,65 @@ type Result struct {
 
 	// Usage is the token usage
 	Usage Usage
+
+	// UsageReport is the token usage broken down per step, agent and model
+	UsageReport UsageReport
+
+	// Cached indicates the result was returned from RunConfig.ResultStore instead of a new run
+	Cached bool
+
+	// LastResponseID is the provider's ID of the last model response. Pass it as
+	// RunConfig.PreviousResponseID to continue the conversation in the next run.
+	LastResponseID string
+
+	// InputGuardrailResults are the outcomes of the input guardrails, in the order they ran
+	InputGuardrailResults []GuardrailResult
+
+	// OutputGuardrailResults are the outcomes of the output guardrails, in the order they ran
+	OutputGuardrailResults []GuardrailResult
+
+	// Handoffs are the handoffs performed during the run, in order
+	Handoffs []HandoffRecord
+
+	// ModelBehaviorErrors are the unexpected actions of the model, such as calls of unknown tools
+	ModelBehaviorErrors []ModelBehaviorError
+
+	// HandoffDecisions are the evaluations of the conditions of the handoffs the model called,
+	// accepted or not, when RunConfig.RecordHandoffDecisions is set
+	HandoffDecisions []HandoffDecision
+
+	// StartedAt is the time the run started
+	StartedAt time.Time
+
+	// Duration is the duration of the run
+	Duration time.Duration
+
+	// ContextRecoveries are the model calls that exceeded the context window and were retried
+	// with a shorter history (see RunConfig.ContextWindowRecovery)
+	ContextRecoveries []ContextRecovery
+
+	// NestedRuns are the runs made by the run's tools, such as agents used as tools, when
+	// RunConfig.KeepNestedResults is set. They are not part of exported transcripts.
+	NestedRuns []NestedRun
+
+	// StopReason is the reason given by RunConfig.StopCondition when it stopped the run (empty
+	// when the run ended with a final output). FinalOutput is then the last text of the model.
+	StopReason string
+}
+
+// HandoffRecord records a handoff performed during a run
+type HandoffRecord struct {
+	// Step is the turn in which the model called the handoff
+	Step int `json:"step"`
+
+	// FromAgent is the name of the agent that handed off
+	FromAgent string `json:"from_agent"`
+
+	// ToAgent is the name of the agent that took over
+	ToAgent string `json:"to_agent"`
+
+	// Input is the JSON input of the handoff call
+	Input string `json:"input,omitempty"`
 }
 
 // RunConfig represents agent execution configuration
@@ -85,6 +181,10 @@ type RunConfig struct {
 
 	ModelProvider model.Provider
 
+	// ProviderMiddleware wraps ModelProvider for the run, the first middleware being the outermost
+	// (see model.WithMiddleware)
+	ProviderMiddleware []model.ProviderMiddleware
+
 	MaxTurns int
 
 	// StepDelay is the delay between agent steps
@@ -94,8 +194,230 @@ type RunConfig struct {
 	// It receives the current context, target agent, source agent, and handoff input JSON
 	HandoffCallback func(ctx context.Context, targetAgent *agent.Agent, sourceAgent *agent.Agent, inputJSON string) error
 
+	// StopCondition is evaluated after every turn without a final output, and stops the run
+	// when it returns true (optional). See RunState.
+	StopCondition StopCondition
+
+	// OnTurnStart is called at the start of every turn of the agent loop, before the model call
+	OnTurnStart TurnCallback
+
+	// OnTurnEnd is called at the end of every turn of the agent loop, with the messages the turn
+	// added and the usage so far
+	OnTurnEnd TurnCallback
+
 	// HandoffInputFilter is a function that filters the input being passed to the target agent during handoff
 	HandoffInputFilter handoff.InputFilter
+
+	// LLMHooks receives callbacks around every model provider call made during the run,
+	// in addition to the current agent's hooks if they implement agent.LLMHooks
+	LLMHooks agent.LLMHooks
+
+	// ToolCallHooks receives the model's tool call around every tool execution during the run,
+	// in addition to the current agent's hooks if they implement agent.ToolCallHooks
+	ToolCallHooks agent.ToolCallHooks
+
+	// ToolProgressHandler receives progress events reported by tools via tool.ReportProgress
+	ToolProgressHandler tool.ProgressHandler
+
+	// RunContext is an application value, such as the current user or a database handle, that
+	// tools of the run read with tool.FromContext (optional). It is not sent to the model.
+	RunContext any
+
+	// IdempotencyKey identifies the request that triggered the run (e.g. a chat platform message ID).
+	// When set together with ResultStore, a previously stored result for the key is returned
+	// instead of running the agent again. See NewIdempotencyKey.
+	IdempotencyKey string
+
+	// ResultStore stores results of successful runs by IdempotencyKey
+	ResultStore ResultStore
+
+	// RunStore saves a checkpoint of the run after each turn and when it ends, so it can be
+	// audited, or continued with ResumeRun after a crash (optional)
+	RunStore RunStore
+
+	// RunID identifies the run in RunStore (defaults to a new UUID, reported in Result.RunID).
+	// Runs sharing a configuration, such as the steps of a workflow, need an ID each; leave it
+	// empty for them.
+	RunID string
+
+	// MaxInputChars is the maximum number of characters of the user input (0 means no limit)
+	MaxInputChars int
+
+	// MaxInputTokens is the maximum number of tokens of the user input (0 means no limit), counted
+	// with the tokens package encoding of the agent's model
+	MaxInputTokens int
+
+	// InputLimitPolicy determines how inputs exceeding MaxInputChars or MaxInputTokens are handled
+	// (defaults to InputLimitReject)
+	InputLimitPolicy InputLimitPolicy
+
+	// WorkflowName is the name of the trace created for the run when ctx does not already carry one
+	// (defaults to tracing.DefaultWorkflowName)
+	WorkflowName string
+
+	// GroupID links the trace created for the run to other traces (e.g. a conversation ID)
+	GroupID string
+
+	// TraceMetadata is metadata attached to the trace created for the run
+	TraceMetadata map[string]any
+
+	// TraceRedaction controls whether message contents, system prompts and tool inputs/outputs
+	// are recorded into the run's spans (defaults to the global tracing redaction policy)
+	TraceRedaction *tracing.RedactionPolicy
+
+	// TracingDisabled creates no span for the run, its tools and nested runs, whatever the
+	// tracer, so no message content is recorded or buffered for export. Result.TraceID is empty.
+	// See tracing.SetTracingDisabled to disable tracing for the whole process.
+	TracingDisabled bool
+
+	// Pricing prices the models of the run, before the default pricing table (optional).
+	// See pricing.Register to change the default table instead.
+	Pricing pricing.Table
+
+	// UnknownToolPolicy is how calls of tools the agent does not have are answered: with an error
+	// listing the available tools (UnknownToolCorrect, the default), by failing the run
+	// (UnknownToolError) or with UnknownToolHandler (UnknownToolFallback). Each call is recorded
+	// in Result.ModelBehaviorErrors.
+	UnknownToolPolicy UnknownToolPolicy
+
+	// UnknownToolHandler answers calls of unknown tools with UnknownToolFallback
+	UnknownToolHandler UnknownToolHandler
+
+	// ToolArgumentValidation validates the arguments of tool calls against the tools'
+	// ParamsJSONSchema before invoking them: invalid arguments are sent back to the model to be
+	// corrected, up to MaxToolArgumentRetries times (ToolArgumentsFeedback), or fail the run
+	// (ToolArgumentsFail). Unchecked by default.
+	ToolArgumentValidation ToolArgumentValidation
+
+	// MaxToolArgumentRetries is the number of times per tool that invalid arguments (a
+	// tool.ArgumentError, or a schema violation with ToolArgumentsFeedback) are reported back to
+	// the model to be corrected before the run fails with ErrInvalidToolArguments
+	MaxToolArgumentRetries int
+
+	// ToolTimeout limits the duration of each tool call (0 means no limit). A call exceeding it
+	// is answered with an error message for the model, and the run goes on.
+	ToolTimeout time.Duration
+
+	// ToolTimeouts overrides ToolTimeout for the tools with the given names (0 means no limit)
+	ToolTimeouts map[string]time.Duration
+
+	// ParallelGuardrails runs the checks of the agent's input guardrails, and of its output
+	// guardrails, concurrently instead of one after the other. The first guardrail to trip wins
+	// and the others are cancelled; when none trips, the errors of all failed checks are
+	// returned together. Output guardrails then all check the original output, and their
+	// modifications are applied in order.
+	ParallelGuardrails bool
+
+	// GuardrailTimeout limits the duration of each input and output guardrail check (0 means no
+	// limit). A check exceeding it fails the run with an error wrapping ErrGuardrailTimeout.
+	GuardrailTimeout time.Duration
+
+	// GuardrailTimeouts overrides GuardrailTimeout for the guardrails with the given names
+	// (0 means no limit)
+	GuardrailTimeouts map[string]time.Duration
+
+	// MaxToolOutputChars truncates tool results longer than this number of characters before
+	// they are sent to the model (0 means no limit)
+	MaxToolOutputChars int
+
+	// MaxToolImages keeps only the last MaxToolImages images returned by tools in the history sent
+	// to the model (0 keeps them all). Older images are replaced with a short notice, so loops such
+	// as computer use, which return a screenshot every turn, do not fill the context window.
+	// Result.History keeps every image.
+	MaxToolImages int
+
+	// KeepNestedResults keeps the results of the runs made by tools, such as agents used as tools,
+	// in Result.NestedRuns and on the tool outputs of Result.Items. The usage of nested runs is
+	// added to the run's usage either way.
+	KeepNestedResults bool
+
+	// RecordHandoffDecisions keeps every evaluation of a handoff's condition (its ShouldHandoff)
+	// in Result.HandoffDecisions, with the decision, its reason and its latency, to debug why a
+	// KeywordHandoff or FunctionHandoff did or did not fire. The evaluations are traced either way.
+	RecordHandoffDecisions bool
+
+	// PromptCacheKey is sent with every model call so requests sharing the same long instructions
+	// and tools are routed to the same prompt cache (e.g. the agent name or a tenant ID)
+	PromptCacheKey string
+
+	// User identifies the end user of the run in every model call, for the provider's abuse
+	// monitoring (optional, overrides the agents' model settings)
+	User string
+
+	// Metadata is sent with every model call, merged over the agents' model settings, so stored
+	// requests can be found again (optional)
+	Metadata map[string]string
+
+	// Store asks the provider to store every model call of the run (optional, overrides the
+	// agents' model settings)
+	Store *bool
+
+	// InstructionPlacement is how the agents' instructions are sent in every model call: as system
+	// or developer messages, or prepended to the first user message (optional, overrides the
+	// agents' model settings)
+	InstructionPlacement model.InstructionPlacement
+
+	// CacheStablePrefix marks the system prompt and tool definitions as a cacheable prefix
+	// for providers that need explicit cache breakpoints
+	CacheStablePrefix bool
+
+	// PromptResolver resolves the agents' stored prompts (agent.Agent.Prompt) into their
+	// instructions. Without it, prompt references are sent to the provider to resolve (optional).
+	PromptResolver model.PromptResolver
+
+	// InputParts are images, files or audio sent with the user input (see model.NewImagePart and friends)
+	InputParts []model.ContentPart
+
+	// Timeout limits the duration of the whole run (0 means no limit). When it expires, or ctx is
+	// cancelled, the run stops and returns a RunCancelledError with the progress made so far.
+	Timeout time.Duration
+
+	// PreviousResponseID continues from a response stored by the provider, so the history before
+	// it is not resent. Each turn then chains from the response of the previous turn.
+	// It requires a provider implementing model.ServerStateProvider; with other providers the
+	// run fails with model.ErrServerStateUnsupported.
+	PreviousResponseID string
+
+	// ConversationID appends the run to a conversation stored by the provider, so the history
+	// before it is not resent. Like PreviousResponseID, it requires a model.ServerStateProvider.
+	ConversationID string
+
+	// History is an earlier conversation the run continues, such as the History of a previous
+	// Result. The input is appended after it; system messages in it are ignored.
+	History []Message
+
+	// HistoryTrimmer limits the history sent to the model on each turn (optional, defaults to the
+	// full history). It is not applied when the provider keeps the history on the server.
+	HistoryTrimmer HistoryTrimmer
+
+	// ContextWindowRecovery shortens the history when a model call fails because the prompt
+	// exceeds the context window; the call is then retried, up to 3 times per turn, as long as
+	// the trimmer drops messages (optional). Use HalvingTrimmer, or a SummarizingTrimmer to keep
+	// a summary of the dropped messages. Without it, such runs fail with an error wrapping
+	// model.ErrContextWindowExceeded. Each recovery is recorded in Result.ContextRecoveries.
+	ContextWindowRecovery HistoryTrimmer
+
+	// ContextProviders add messages to each turn before the model call, after the history
+	// (optional). The added messages are not kept in the history.
+	ContextProviders []ContextProvider
+
+	// resumed marks a run continued by Resume, whose input was already checked
+	resumed bool
+
+	// handoffStack is the handoff stack a run continued by Resume starts with
+	handoffStack []*agent.Agent
+
+	// checkpointBase is the progress of a run continued by Resume, added to its checkpoints
+	checkpointBase *MaxTurnsExceededError
+
+	// SkipTraceFlush leaves the spans of the run to the processors' export schedule. By default,
+	// a run that starts its own trace flushes the processors when it ends, which adds the export
+	// time to latency-sensitive callers.
+	SkipTraceFlush bool
+
+	// agentMiddleware is the run's ProviderMiddleware, kept to wrap the providers set on agents
+	// once ModelProvider has been wrapped
+	agentMiddleware []model.ProviderMiddleware
 }
 
 // DefaultRunConfig returns the default execution configuration
@@ -113,33 +435,89 @@ func Run(ctx context.Context, agent *agent.Agent, input string) (*Result, error)
 
 // RunWithConfig executes the agent with configuration
 func RunWithConfig(ctx context.Context, a *agent.Agent, input string, config RunConfig) (*Result, error) {
+	if a == nil {
+		return nil, ErrAgentRequired
+	}
+	if config.IdempotencyKey != "" && config.ResultStore != nil {
+		return runIdempotent(ctx, a, input, config)
+	}
+
+	return executeRun(ctx, a, input, config)
+}
+
+// executeRun performs a single agent run
+func executeRun(ctx context.Context, a *agent.Agent, input string, config RunConfig) (_ *Result, err error) {
+	// Work on a snapshot, so other goroutines can modify the agent during the run
+	a = a.Snapshot()
+
 	// Validate inputs and setup initial state
 	if err := validateInputsAndSetup(a, &config); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
-	ctx, span := setupTracing(ctx, a, input, config)
+	if config.RunStore != nil && config.RunID == "" {
+		config.RunID = uuid.New().String()
+	}
+
+	// Enforce input size limits before anything is sent to the model
+	modelName := config.Model
+	if a.Model != "" {
+		modelName = a.Model
+	}
+	input, err = applyInputLimits(input, config, modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.TraceRedaction != nil {
+		ctx = tracing.ContextWithRedactionPolicy(ctx, config.TraceRedaction)
+	}
+
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	ctx, span, trace := setupTracing(ctx, a, input, config)
 	defer func() {
 		if span != nil {
 			span.End()
 		}
+		if trace != nil {
+			trace.End()
+		}
+		// Export the spans of a run that owns its trace, or of an interrupted run, right away so
+		// they are not lost if the program exits
+		if ctx.Err() != nil || (trace != nil && !config.SkipTraceFlush) {
+			tracing.ForceFlush()
+		}
+	}()
+	defer func() {
+		if err != nil {
+			err = wrapRunError(err, span)
+		}
 	}()
 
 	// Create execution state
 	execState := &executionState{
-		agent:            a,
-		currentAgent:     a,
-		originalInput:    input,
-		config:           config,
-		messages:         prepareMessages(a, input),
-		resultMessages:   []model.Message{},
-		usage:            Usage{},
-		startTime:        time.Now(),
-		ctx:              ctx,
-		span:             span,
-		stepCounter:      0,
-		finalOutput:      "",
-		structuredOutput: nil,
+		agent:               a,
+		currentAgent:        a,
+		originalInput:       input,
+		config:              config,
+		messages:            prepareMessages(a, config.History, input, config.InputParts),
+		resultMessages:      toModelMessages(config.History),
+		usage:               Usage{},
+		usageReport:         newUsageReport(),
+		startTime:           time.Now(),
+		ctx:                 ctx,
+		span:                span,
+		stepCounter:         0,
+		finalOutput:         "",
+		structuredOutput:    nil,
+		toolArgumentRetries: make(map[string]int),
+		handoffStack:        append([]*agent.Agent(nil), config.handoffStack...),
+		previousResponseID:  config.PreviousResponseID,
 	}
 
 	// Apply input guardrails
@@ -149,88 +527,153 @@ func RunWithConfig(ctx context.Context, a *agent.Agent, input string, config Run
 	}
 
 	// Call agent start hook
-	if err := a.Hooks.OnStart(ctx, a); err != nil {
+	if err := a.Hooks.OnStart(ctx, a.Origin()); err != nil {
 		recordTracingError(ctx, execState.startTime, "", fmt.Errorf("error in OnStart hook: %w", err))
 		return nil, fmt.Errorf("error in OnStart hook: %w", err)
 	}
 
 	// Add user input to history
-	if input != "" {
+	if input != "" || len(config.InputParts) > 0 {
 		execState.resultMessages = append(execState.resultMessages, model.Message{
-			Role:    "user",
-			Content: input,
+			Role:         "user",
+			Content:      input,
+			ContentParts: config.InputParts,
 		})
 	}
+	execState.inputItems = len(execState.resultMessages)
 
 	// Run agent loop
 	result, err := runAgentExecutionLoop(execState)
+	if err != nil && ctx.Err() != nil {
+		cancelErr := newRunCancelledError(execState, ctx.Err())
+		recordTracingError(ctx, execState.startTime, "", cancelErr)
+		saveFailedCheckpoint(execState, cancelErr)
+		return nil, cancelErr
+	}
 	if err != nil {
 		// Special case for max turns exceeded
 		if errors.Is(err, ErrMaxTurnsExceeded) {
-			recordTracingError(ctx, execState.startTime, "", ErrMaxTurnsExceeded)
-			return nil, ErrMaxTurnsExceeded
+			maxErr := newMaxTurnsExceededError(execState)
+			recordTracingError(ctx, execState.startTime, "", maxErr)
+			saveFailedCheckpoint(execState, maxErr)
+			return nil, maxErr
 		}
 
 		recordTracingError(ctx, execState.startTime, "", err)
+		saveFailedCheckpoint(execState, err)
 		return nil, fmt.Errorf("agent execution error: %w", err)
 	}
 
+	if err := saveCheckpoint(execState, RunStatusCompleted, execState.stepCounter+1, result); err != nil {
+		recordTracingError(ctx, execState.startTime, "", err)
+		return nil, err
+	}
+
 	// Set successful execution attributes in tracing
 	if span != nil {
 		span.SetAttribute("output", result.FinalOutput)
 		span.SetAttribute("duration_ms", time.Since(execState.startTime).Milliseconds())
 		span.SetAttribute("success", true)
 		span.SetAttribute("turns_used", execState.stepCounter)
+		span.SetAttribute("total_cost", result.Usage.Cost)
 
-		if result.LastAgent != a {
+		if result.LastAgent != a.Origin() {
 			span.SetAttribute("final_agent", result.LastAgent.Name)
 		}
 	}
 
+	recordNestedRun(ctx, result)
 	return result, nil
 }
 
+// previousAgent returns the agent a return handoff goes back to, or nil if no agent handed off
+func (s *executionState) previousAgent() *agent.Agent {
+	if len(s.handoffStack) == 0 {
+		return nil
+	}
+	return s.handoffStack[len(s.handoffStack)-1]
+}
+
 // executionState tracks the state during agent execution
 type executionState struct {
-	agent            *agent.Agent
-	currentAgent     *agent.Agent
-	originalInput    string
-	config           RunConfig
-	messages         []model.Message
-	resultMessages   []model.Message
-	usage            Usage
-	startTime        time.Time
-	ctx              context.Context
-	span             tracing.Span
-	stepCounter      int
-	finalOutput      string
-	structuredOutput any
+	agent               *agent.Agent
+	currentAgent        *agent.Agent
+	originalInput       string
+	config              RunConfig
+	messages            []model.Message
+	resultMessages      []model.Message
+	usage               Usage
+	usageReport         UsageReport
+	startTime           time.Time
+	ctx                 context.Context
+	span                tracing.Span
+	stepCounter         int
+	finalOutput         string
+	structuredOutput    any
+	toolArgumentRetries map[string]int
+	previousResponseID  string
+	serverHistory       int
+	lastResponseID      string
+	agentPrompt         *model.Prompt
+	agentProviders      map[*agent.Agent]model.Provider
+	agentTools          map[*agent.Agent]map[string]tool.Tool
+	inputGuardrails     []GuardrailResult
+	outputGuardrails    []GuardrailResult
+	handoffs            []HandoffRecord
+	handoffToolNames    []string
+	handoffDecisions    []HandoffDecision
+	modelBehaviorErrors []ModelBehaviorError
+	contextRecoveries   []ContextRecovery
+	nestedRuns          []NestedRun
+
+	// toolUsed records whether the current agent called a tool since it took over, and
+	// toolUseRetries and forceToolUse track the enforcement of its tool use policy
+	toolUsed       bool
+	toolUseRetries int
+	forceToolUse   bool
+
+	// inputItems is the number of messages of the run's input (its history and user input) at
+	// the start of resultMessages
+	inputItems int
+
+	// stopReason is set when RunConfig.StopCondition stopped the run
+	stopReason string
+
+	// outputReasks counts the re-asks of each output guardrail
+	outputReasks map[string]int
+
+	// handoffStack holds the agents that handed off, for return handoffs to go back to
+	handoffStack []*agent.Agent
 }
 
-// setupTracing initializes tracing for agent execution
-func setupTracing(ctx context.Context, a *agent.Agent, input string, config RunConfig) (context.Context, tracing.Span) {
-	var span tracing.Span
+// setupTracing initializes tracing for agent execution.
+// A trace is created for the run unless ctx already belongs to one; it is returned so the caller can end it.
+func setupTracing(ctx context.Context, a *agent.Agent, input string, config RunConfig) (context.Context, tracing.Span, *tracing.Trace) {
+	ctx, trace := startWorkflowTrace(ctx, config)
+	if tracing.IsTracingDisabled(ctx) {
+		return ctx, &tracing.NoopSpan{}, nil
+	}
 
-	// Start agent execution root span if needed
-	if tracing.GetActiveSpan(ctx) == nil {
-		span, ctx = tracing.StartSpan(ctx, "agent_run", map[string]any{
-			"span_type":  "agent",
-			"agent_name": a.Name,
-			"input":      input,
-			"agent_id":   a.Name,
-			"model":      config.Model,
-			"max_turns":  config.MaxTurns,
-		})
-	} else {
-		span = tracing.GetActiveSpan(ctx)
+	// Start agent execution span
+	attributes := map[string]any{
+		"span_type":  "agent",
+		"agent_name": a.Name,
+		"input":      input,
+		"agent_id":   a.Name,
+		"model":      config.Model,
+		"max_turns":  config.MaxTurns,
+	}
+	for k, v := range version.Attributes() {
+		attributes[k] = v
 	}
+	span, ctx := tracing.StartSpan(ctx, "agent_run", attributes)
 
-	return ctx, span
+	return ctx, span, trace
 }
 
 // applyInputGuardrails executes all input guardrails
 func applyInputGuardrails(ctx context.Context, state *executionState) error {
-	if len(state.agent.InputGuardrails) == 0 {
+	if len(state.agent.InputGuardrails) == 0 || state.config.resumed {
 		return nil
 	}
 
@@ -245,18 +688,65 @@ func applyInputGuardrails(ctx context.Context, state *executionState) error {
 		}
 	}()
 
-	for _, g := range state.agent.InputGuardrails {
-		result, err := g.Check(guardrailsCtx, state.originalInput)
+	guardrails := state.agent.InputGuardrails
+	check := func(ctx context.Context, i int) (guardrail.InputGuardrailResult, error) {
+		g := guardrails[i]
+		return checkGuardrail(ctx, state.config, "input", g.Name(), func(ctx context.Context) (guardrail.InputGuardrailResult, error) {
+			return g.Check(ctx, state.originalInput)
+		}, func(result guardrail.InputGuardrailResult) (bool, string) {
+			return result.Allowed, result.Message
+		})
+	}
+	record := func(g guardrail.InputGuardrail, result guardrail.InputGuardrailResult) {
+		state.inputGuardrails = append(state.inputGuardrails, GuardrailResult{
+			Guardrail: g.Name(),
+			AgentName: state.agent.Name,
+			Allowed:   result.Allowed,
+			Message:   result.Message,
+			Metadata:  result.Metadata,
+		})
+	}
+	trip := func(result guardrail.InputGuardrailResult) error {
+		if span := tracing.GetActiveSpan(guardrailsCtx); span != nil {
+			span.SetAttribute("guardrail_triggered", true)
+			span.SetAttribute("guardrail_message", result.Message)
+		}
+		return fmt.Errorf("%w: %s", ErrGuardrailTripwire, result.Message)
+	}
+
+	if state.config.ParallelGuardrails {
+		if span := tracing.GetActiveSpan(guardrailsCtx); span != nil {
+			span.SetAttribute("parallel", true)
+		}
+		checks, tripped := runParallelChecks(guardrailsCtx, len(guardrails), check, func(result guardrail.InputGuardrailResult) bool {
+			return !result.Allowed
+		})
+
+		var errs []error
+		for i, c := range checks {
+			switch {
+			case !c.done:
+			case c.err != nil:
+				errs = append(errs, fmt.Errorf("input guardrail error: %w", c.err))
+			default:
+				record(guardrails[i], c.result)
+			}
+		}
+		if tripped >= 0 {
+			return trip(checks[tripped].result)
+		}
+		return errors.Join(errs...)
+	}
+
+	for i, g := range guardrails {
+		result, err := check(guardrailsCtx, i)
 		if err != nil {
 			return fmt.Errorf("input guardrail error: %w", err)
 		}
+		record(g, result)
 
 		if !result.Allowed {
-			if span := tracing.GetActiveSpan(guardrailsCtx); span != nil {
-				span.SetAttribute("guardrail_triggered", true)
-				span.SetAttribute("guardrail_message", result.Message)
-			}
-			return fmt.Errorf("%w: %s", ErrGuardrailTripwire, result.Message)
+			return trip(result)
 		}
 	}
 
@@ -267,11 +757,23 @@ func applyInputGuardrails(ctx context.Context, state *executionState) error {
 func runAgentExecutionLoop(state *executionState) (*Result, error) {
 	// Run the main agent loop until we have a final result or exceed max turns
 	for state.stepCounter < state.config.MaxTurns {
+		// Stop between steps once the run is cancelled
+		if err := state.ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		// Apply step delay if needed
 		if state.stepCounter > 0 && state.config.StepDelay > 0 {
-			time.Sleep(state.config.StepDelay)
+			if err := sleepContext(state.ctx, state.config.StepDelay); err != nil {
+				return nil, err
+			}
 		}
 
+		if err := callTurnStart(state); err != nil {
+			return nil, err
+		}
+		turnAgent, turnStart := state.currentAgent, len(state.resultMessages)
+
 		// Execute single step
 		stepResult, err := runSingleTurn(state)
 		if err != nil {
@@ -283,27 +785,34 @@ func runAgentExecutionLoop(state *executionState) (*Result, error) {
 			return nil, err
 		}
 
+		if err := callTurnEnd(state, turnAgent, turnStart); err != nil {
+			return nil, err
+		}
+		checkStopCondition(state, turnStart)
+
 		// Check if we have a final output
-		if state.finalOutput != "" {
+		if state.finalOutput != "" || state.stopReason != "" {
 			break
 		}
 
+		if err := saveCheckpoint(state, RunStatusRunning, state.stepCounter+1, nil); err != nil {
+			return nil, err
+		}
+
 		state.stepCounter++
 	}
 
 	// Check if max turns exceeded
-	if state.finalOutput == "" {
+	if state.finalOutput == "" && state.stopReason == "" {
 		return nil, ErrMaxTurnsExceeded
 	}
 
 	// Create final result
-	result := &Result{
-		FinalOutput:      state.finalOutput,
-		StructuredOutput: state.structuredOutput,
-		LastAgent:        state.currentAgent,
-		History:          convertModelMessages(state.resultMessages),
-		Usage:            state.usage,
-	}
+	result := progressResult(state)
+	result.FinalOutput = state.finalOutput
+	result.StructuredOutput = state.structuredOutput
+	result.OutputGuardrailResults = state.outputGuardrails
+	result.StopReason = state.stopReason
 
 	// Call agent end hook
 	var finalOutputInterface any = state.finalOutput
@@ -311,7 +820,7 @@ func runAgentExecutionLoop(state *executionState) (*Result, error) {
 		finalOutputInterface = state.structuredOutput
 	}
 
-	if err := state.currentAgent.Hooks.OnEnd(state.ctx, state.currentAgent, finalOutputInterface); err != nil {
+	if err := state.currentAgent.Hooks.OnEnd(state.ctx, state.currentAgent.Origin(), finalOutputInterface); err != nil {
 		return nil, fmt.Errorf("error in OnEnd hook: %w", err)
 	}
 
@@ -335,9 +844,17 @@ func runSingleTurn(state *executionState) (*stepResult, error) {
 		return nil, fmt.Errorf("failed to get instructions: %w", err)
 	}
 
+	instructions, err = resolveAgentPrompt(stepCtx, state, instructions)
+	if err != nil {
+		stepSpan.SetAttribute("error", err.Error())
+		return nil, err
+	}
+
 	// Update system message with current instructions if needed
 	if len(state.messages) > 0 && state.messages[0].Role == "system" {
 		state.messages[0].Content = instructions
+	} else if instructions != "" {
+		state.messages = append([]model.Message{{Role: "system", Content: instructions}}, state.messages...)
 	}
 
 	// Process agent step (LLM call + tool execution)
@@ -376,18 +893,54 @@ func createStepContext(state *executionState) (context.Context, tracing.Span) {
 
 // processAgentStep executes a full agent step including LLM call and tool handling
 func processAgentStep(ctx context.Context, state *executionState) (*stepResult, error) {
-	settings := model.DefaultSettings()
+	settings := model.DefaultSettings().Resolve(state.currentAgent.ModelSettings)
 	modelName := state.config.Model
 
 	// Prepare tools definitions
-	settings.Tools = buildToolDefinitions(state.currentAgent)
-	settings.Custom = make(map[string]any)
+	if _, err := agentTools(state, state.currentAgent); err != nil {
+		return nil, err
+	}
+	settings.Tools = buildToolDefinitions(state.currentAgent, state.previousAgent() != nil)
+	applyOutputSchema(state.currentAgent, &settings)
+	if state.forceToolUse && len(settings.Tools) > 0 {
+		settings.ToolChoice = model.ToolChoiceRequired
+	}
 
 	// Use agent's model if specified
 	if state.currentAgent.Model != "" {
 		modelName = state.currentAgent.Model
 	}
-	settings.Custom["model"] = modelName
+	settings.Model = modelName
+	settings = settings.Resolve(model.Settings{
+		User:                 state.config.User,
+		Metadata:             state.config.Metadata,
+		Store:                state.config.Store,
+		InstructionPlacement: state.config.InstructionPlacement,
+		PromptCacheKey:       state.config.PromptCacheKey,
+		CacheStablePrefix:    state.config.CacheStablePrefix,
+		Prompt:               state.agentPrompt,
+	})
+	provider, err := agentProvider(state)
+	if err != nil {
+		return nil, err
+	}
+	serverState, err := usesServerState(state, provider, modelName)
+	if err != nil {
+		return nil, err
+	}
+	if !serverState && state.config.HistoryTrimmer != nil {
+		if err := trimHistory(ctx, state); err != nil {
+			return nil, err
+		}
+	}
+	messages := state.messages
+	if serverState {
+		messages = applyServerState(state, &settings)
+	}
+	messages, err = injectContext(ctx, state, messages)
+	if err != nil {
+		return nil, err
+	}
 
 	// LLM call tracing
 	_, llmCtx := tracing.StartSpan(ctx, "llm_call", map[string]any{
@@ -395,13 +948,70 @@ func processAgentStep(ctx context.Context, state *executionState) (*stepResult,
 		"model":     modelName,
 		"agent":     state.currentAgent.Name,
 	})
+	if settings.ReasoningEffort != "" {
+		if span := tracing.GetActiveSpan(llmCtx); span != nil {
+			span.SetAttribute("reasoning_effort", settings.ReasoningEffort)
+		}
+	}
+
+	// Call LLM start hooks
+	if err := callLLMStartHooks(llmCtx, state, messages, settings); err != nil {
+		if span := tracing.GetActiveSpan(llmCtx); span != nil {
+			span.SetAttribute("error", err.Error())
+			span.End()
+		}
+		return nil, err
+	}
 
 	// Call LLM
-	response, err := state.config.ModelProvider.CreateChatCompletion(
+	callStart := time.Now()
+	response, err := provider.CreateChatCompletion(
 		llmCtx,
-		state.messages,
+		messages,
 		settings,
 	)
+	// Every OnLLMStart is paired with an OnLLMEnd, so a failed attempt is ended
+	// before the retry starts; endHooksDone skips the final OnLLMEnd when a
+	// retry's hooks already failed
+	endHooksDone := false
+	for attempt := 0; attempt < maxContextRecoveries && shouldRecoverContext(state, serverState, err); attempt++ {
+		recovered, recoverErr := recoverContextWindow(llmCtx, state, err)
+		if recoverErr != nil {
+			err = recoverErr
+			break
+		}
+		if !recovered {
+			break
+		}
+		callErr := err
+		if messages, err = injectContext(llmCtx, state, state.messages); err != nil {
+			break
+		}
+		if err = callLLMEndHooks(llmCtx, state, response, callErr); err != nil {
+			endHooksDone = true
+			break
+		}
+		if err = callLLMStartHooks(llmCtx, state, messages, settings); err != nil {
+			endHooksDone = true
+			break
+		}
+		response, err = provider.CreateChatCompletion(llmCtx, messages, settings)
+	}
+	if err != nil && model.IsContextWindowExceeded(err) && !errors.Is(err, model.ErrContextWindowExceeded) {
+		err = fmt.Errorf("%w: %w", model.ErrContextWindowExceeded, err)
+	}
+	callDuration := time.Since(callStart)
+
+	// Call LLM end hooks
+	var hookErr error
+	if !endHooksDone {
+		hookErr = callLLMEndHooks(llmCtx, state, response, err)
+	}
+
+	var cost float64
+	if err == nil {
+		cost = pricing.Cost(modelName, response.Usage.PromptTokens, response.Usage.CachedPromptTokens, response.Usage.CompletionTokens, state.config.Pricing)
+	}
 
 	// End LLM call tracing
 	if span := tracing.GetActiveSpan(llmCtx); span != nil {
@@ -410,6 +1020,14 @@ func processAgentStep(ctx context.Context, state *executionState) (*stepResult,
 		} else {
 			span.SetAttribute("success", true)
 			span.SetAttribute("token_usage", response.Usage.TotalTokens)
+			span.SetAttribute("prompt_tokens", response.Usage.PromptTokens)
+			span.SetAttribute("completion_tokens", response.Usage.CompletionTokens)
+			span.SetAttribute("cached_prompt_tokens", response.Usage.CachedPromptTokens)
+			span.SetAttribute("reasoning_tokens", response.Usage.ReasoningTokens)
+			span.SetAttribute("cost", cost)
+			if response.Message.Reasoning != "" {
+				span.SetAttribute("reasoning", response.Message.Reasoning)
+			}
 		}
 		span.End()
 	}
@@ -417,72 +1035,163 @@ func processAgentStep(ctx context.Context, state *executionState) (*stepResult,
 	if err != nil {
 		return nil, fmt.Errorf("LLM call failed: %w", err)
 	}
+	if hookErr != nil {
+		return nil, hookErr
+	}
+
+	state.lastResponseID = response.ID
+	if serverState {
+		recordServerState(state, response.ID)
+	}
 
 	// Accumulate usage
-	accumulateUsage(&state.usage, convertUsage(response.Usage))
+	stepUsage := convertUsage(response.Usage)
+	stepUsage.Cost = cost
+	accumulateUsage(&state.usage, stepUsage)
+	state.usageReport.record(state.stepCounter+1, state.currentAgent.Name, modelName, stepUsage, callDuration)
+
+	if response.Message.Refusal != "" {
+		return nil, fmt.Errorf("%w: %s", ErrOutputRefused, response.Message.Refusal)
+	}
+
+	// Check the message against the turn guardrails before acting on it
+	if err := applyTurnGuardrails(ctx, state.currentAgent, response.Message); err != nil {
+		return nil, err
+	}
 
 	// Process response
-	if len(response.Message.ToolCalls) > 0 {
-		return processToolCallsAndHandoffs(ctx, state.currentAgent, response.Message)
+	stepMessages := []model.Message{response.Message}
+	finalOutput := response.Message.Content
+	if call, ok := finalOutputToolCall(state.currentAgent, response.Message); ok {
+		finalOutput = call.Function.Arguments
+		stepMessages = finalOutputToolMessages(response.Message, call)
+	} else if len(response.Message.ToolCalls) > 0 {
+		state.toolUsed, state.forceToolUse = true, false
+		return processToolCallsAndHandoffs(ctx, state, response.Message)
+	} else if retry, err := enforceToolUse(ctx, state, stepMessages, stepUsage); retry != nil || err != nil {
+		return retry, err
 	}
 
 	// Process final output
-	finalOutput := response.Message.Content
 	var structuredOutput any
 
 	// Try to parse as structured output if output type is defined
 	if state.currentAgent.OutputType != nil {
-		structValue := reflect.New(state.currentAgent.OutputType).Interface()
-		if err := json.Unmarshal([]byte(finalOutput), structValue); err != nil {
-			return nil, fmt.Errorf("%w: %s", ErrInvalidOutputFormat, err.Error())
+		var err error
+		structuredOutput, err = parseStructuredOutput(state.currentAgent.OutputType, finalOutput)
+		if err != nil {
+			return nil, err
 		}
-		// Get value from pointer
-		structuredOutput = reflect.ValueOf(structValue).Elem().Interface()
 	}
 
 	// Apply output guardrails
 	if len(state.currentAgent.OutputGuardrails) > 0 {
-		checkedOutput, err := applyOutputGuardrails(ctx, state.currentAgent, finalOutput)
+		checkedOutput, checkedStructuredOutput, results, err := applyOutputGuardrails(ctx, state.config, state.currentAgent, finalOutput, structuredOutput)
+		state.outputGuardrails = append(state.outputGuardrails, results...)
+		var reask *outputReask
+		if errors.As(err, &reask) && state.outputReasks[reask.guardrail] < reask.limit {
+			return reaskOutput(ctx, state, stepMessages, stepUsage, reask), nil
+		}
 		if err != nil {
 			return nil, err
 		}
 		finalOutput = checkedOutput
+		structuredOutput = checkedStructuredOutput
 	}
 
 	return &stepResult{
 		finalOutput:      finalOutput,
 		structuredOutput: structuredOutput,
-		usage:            convertUsage(response.Usage),
-		messages:         []model.Message{response.Message},
+		usage:            stepUsage,
+		messages:         stepMessages,
 	}, nil
 }
 
-// buildToolDefinitions builds tool definitions for the agent
-func buildToolDefinitions(a *agent.Agent) []map[string]any {
-	toolDefs := make([]map[string]any, 0, len(a.Tools)+len(a.Handoffs))
+// callLLMStartHooks calls the OnLLMStart hooks of the run config and the current agent
+// with the messages sent to the provider
+func callLLMStartHooks(ctx context.Context, state *executionState, messages []model.Message, settings model.Settings) error {
+	if state.config.LLMHooks != nil {
+		if err := state.config.LLMHooks.OnLLMStart(ctx, state.currentAgent.Origin(), messages, settings); err != nil {
+			return fmt.Errorf("error in OnLLMStart hook: %w", err)
+		}
+	}
+
+	if hooks, ok := state.currentAgent.Hooks.(agent.LLMHooks); ok {
+		if err := hooks.OnLLMStart(ctx, state.currentAgent.Origin(), messages, settings); err != nil {
+			return fmt.Errorf("error in OnLLMStart hook: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// callLLMEndHooks calls the OnLLMEnd hooks of the current agent and the run config
+func callLLMEndHooks(ctx context.Context, state *executionState, response *model.Response, callErr error) error {
+	if hooks, ok := state.currentAgent.Hooks.(agent.LLMHooks); ok {
+		if err := hooks.OnLLMEnd(ctx, state.currentAgent.Origin(), response, callErr); err != nil {
+			return fmt.Errorf("error in OnLLMEnd hook: %w", err)
+		}
+	}
+
+	if state.config.LLMHooks != nil {
+		if err := state.config.LLMHooks.OnLLMEnd(ctx, state.currentAgent.Origin(), response, callErr); err != nil {
+			return fmt.Errorf("error in OnLLMEnd hook: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// callToolCallStartHooks calls the OnToolCallStart hooks of the run config and the current agent
+func callToolCallStartHooks(ctx context.Context, state *executionState, t tool.Tool, call model.ToolCall) error {
+	if state.config.ToolCallHooks != nil {
+		if err := state.config.ToolCallHooks.OnToolCallStart(ctx, state.currentAgent.Origin(), t, call); err != nil {
+			return fmt.Errorf("error in OnToolCallStart hook: %w", err)
+		}
+	}
+
+	if hooks, ok := state.currentAgent.Hooks.(agent.ToolCallHooks); ok {
+		if err := hooks.OnToolCallStart(ctx, state.currentAgent.Origin(), t, call); err != nil {
+			return fmt.Errorf("error in OnToolCallStart hook: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// callToolCallEndHooks calls the OnToolCallEnd hooks of the current agent and the run config
+func callToolCallEndHooks(ctx context.Context, state *executionState, t tool.Tool, call model.ToolCall, output string) error {
+	if hooks, ok := state.currentAgent.Hooks.(agent.ToolCallHooks); ok {
+		if err := hooks.OnToolCallEnd(ctx, state.currentAgent.Origin(), t, call, output); err != nil {
+			return fmt.Errorf("error in OnToolCallEnd hook: %w", err)
+		}
+	}
+
+	if state.config.ToolCallHooks != nil {
+		if err := state.config.ToolCallHooks.OnToolCallEnd(ctx, state.currentAgent.Origin(), t, call, output); err != nil {
+			return fmt.Errorf("error in OnToolCallEnd hook: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// buildToolDefinitions builds tool definitions for the agent. Return handoffs are left out when
+// there is no previous agent to return to.
+func buildToolDefinitions(a *agent.Agent, canReturn bool) []model.ToolDefinition {
+	toolDefs := make([]model.ToolDefinition, 0, len(a.Tools)+len(a.Handoffs))
 
 	// Add regular tools
 	for _, t := range a.Tools {
-		toolDefs = append(toolDefs, map[string]any{
-			"type": "function",
-			"function": map[string]any{
-				"name":        t.Name(),
-				"description": t.Description(),
-				"parameters":  t.ParamsJSONSchema(),
-			},
-		})
+		toolDefs = append(toolDefs, model.NewFunctionTool(t.Name(), t.Description(), t.ParamsJSONSchema()))
 	}
 
 	// Add handoff tools
 	for _, h := range a.Handoffs {
-		toolDefs = append(toolDefs, map[string]any{
-			"type": "function",
-			"function": map[string]any{
-				"name":        h.ToolName(),
-				"description": h.ToolDescription(),
-				"parameters":  h.InputJSONSchema(),
-			},
-		})
+		if !canReturn && handoff.IsReturnHandoff(h) {
+			continue
+		}
+		toolDefs = append(toolDefs, model.NewFunctionTool(h.ToolName(), h.ToolDescription(), h.InputJSONSchema()))
 	}
 
 	return toolDefs
@@ -493,6 +1202,9 @@ func processStepResult(state *executionState, stepResult *stepResult) error {
 	// Update messages with step result
 	state.messages = append(state.messages, stepResult.messages...)
 	state.resultMessages = append(state.resultMessages, stepResult.messages...)
+	if state.config.MaxToolImages > 0 {
+		state.messages = pruneToolImages(state.messages, state.config.MaxToolImages)
+	}
 
 	// Handle handoff if needed
 	if stepResult.nextAgent != nil {
@@ -513,6 +1225,45 @@ func processStepResult(state *executionState, stepResult *stepResult) error {
 	return nil
 }
 
+// agentProvider returns the provider of the current agent: its own provider wrapped with the run's
+// middleware, or the run's provider
+func agentProvider(state *executionState) (model.Provider, error) {
+	a := state.currentAgent
+	if a.ModelProvider == nil {
+		if state.config.ModelProvider == nil {
+			return nil, fmt.Errorf("%w: agent %s has no provider", ErrModelProviderRequired, a.Name)
+		}
+		return state.config.ModelProvider, nil
+	}
+
+	if provider, ok := state.agentProviders[a]; ok {
+		return provider, nil
+	}
+	provider := model.WithMiddleware(a.ModelProvider, state.config.agentMiddleware...)
+	if state.agentProviders == nil {
+		state.agentProviders = map[*agent.Agent]model.Provider{}
+	}
+	state.agentProviders[a] = provider
+	return provider, nil
+}
+
+// agentTools returns the agent's tools by name, indexed once per run.
+// Two tools sharing a name are an error, since the model could not tell them apart.
+func agentTools(state *executionState, a *agent.Agent) (map[string]tool.Tool, error) {
+	if tools, ok := state.agentTools[a]; ok {
+		return tools, nil
+	}
+	tools, err := tool.Index(a.Tools)
+	if err != nil {
+		return nil, fmt.Errorf("agent %s: %w", a.Name, err)
+	}
+	if state.agentTools == nil {
+		state.agentTools = map[*agent.Agent]map[string]tool.Tool{}
+	}
+	state.agentTools[a] = tools
+	return tools, nil
+}
+
 // handleAgentHandoff processes a handoff to another agent
 func handleAgentHandoff(state *executionState, stepResult *stepResult) error {
 	// Get handoff input directly from step result
@@ -537,12 +1288,24 @@ func handleAgentHandoff(state *executionState, stepResult *stepResult) error {
 	}
 
 	// Update messages and state for the new agent
+	source := state.currentAgent
 	if err := updateMessagesForNewAgent(handoffCtx, state, stepResult); err != nil {
 		return err
 	}
+	state.handoffs = append(state.handoffs, HandoffRecord{
+		Step:      state.stepCounter + 1,
+		FromAgent: source.Name,
+		ToAgent:   state.currentAgent.Name,
+		Input:     handoffInput,
+	})
+	if stepResult.handoff != nil && handoff.IsReturnHandoff(stepResult.handoff) {
+		state.handoffStack = state.handoffStack[:len(state.handoffStack)-1]
+	} else {
+		state.handoffStack = append(state.handoffStack, source.Origin())
+	}
 
 	// Call agent start hook for new agent
-	if err := state.currentAgent.Hooks.OnStart(handoffCtx, state.currentAgent); err != nil {
+	if err := state.currentAgent.Hooks.OnStart(handoffCtx, state.currentAgent.Origin()); err != nil {
 		return fmt.Errorf("error in OnStart hook for next agent: %w", err)
 	}
 
@@ -569,11 +1332,13 @@ func setupHandoffTracing(state *executionState, stepResult *stepResult) (context
 // processHandoffCallbacks processes handoff callbacks and hooks
 func processHandoffCallbacks(ctx context.Context, state *executionState, stepResult *stepResult, handoffInput string) error {
 	// Find the handoff object from source agent's handoffs
-	var targetHandoff handoff.Handoff
-	for _, h := range state.currentAgent.Handoffs {
-		if h.TargetAgent() == stepResult.nextAgent {
-			targetHandoff = h
-			break
+	targetHandoff := stepResult.handoff
+	if targetHandoff == nil {
+		for _, h := range state.currentAgent.Handoffs {
+			if h.TargetAgent() == stepResult.nextAgent {
+				targetHandoff = h
+				break
+			}
 		}
 	}
 
@@ -586,7 +1351,7 @@ func processHandoffCallbacks(ctx context.Context, state *executionState, stepRes
 
 	// Execute the config handoff callback if provided
 	if state.config.HandoffCallback != nil {
-		if err := state.config.HandoffCallback(ctx, stepResult.nextAgent, state.currentAgent, handoffInput); err != nil {
+		if err := state.config.HandoffCallback(ctx, stepResult.nextAgent, state.currentAgent.Origin(), handoffInput); err != nil {
 			if span := tracing.GetActiveSpan(ctx); span != nil {
 				span.SetAttribute("error", err.Error())
 			}
@@ -595,7 +1360,7 @@ func processHandoffCallbacks(ctx context.Context, state *executionState, stepRes
 	}
 
 	// Call agent's handoff hook
-	if err := stepResult.nextAgent.Hooks.OnHandoff(ctx, stepResult.nextAgent, state.currentAgent); err != nil {
+	if err := stepResult.nextAgent.Hooks.OnHandoff(ctx, stepResult.nextAgent, state.currentAgent.Origin()); err != nil {
 		if span := tracing.GetActiveSpan(ctx); span != nil {
 			span.SetAttribute("error", err.Error())
 		}
@@ -607,19 +1372,11 @@ func processHandoffCallbacks(ctx context.Context, state *executionState, stepRes
 
 // processTargetHandoff processes the target handoff's OnHandoff method
 func processTargetHandoff(ctx context.Context, targetHandoff handoff.Handoff, state *executionState, stepResult *stepResult, handoffInput string) error {
-	// Prepare input data
-	inputData := &handoff.InputData{
-		InputHistory:    []map[string]any{},
-		PreHandoffItems: []map[string]any{},
-		NewItems:        []map[string]any{},
-		Metadata: map[string]any{
-			"handoff_input": handoffInput,
-			"source_agent":  state.currentAgent.Name,
-			"target_agent":  stepResult.nextAgent.Name,
-		},
-	}
-
 	// Call the handoff's OnHandoff method
+	inputData, err := handoffInputData(state, stepResult, handoffInput)
+	if err != nil {
+		return err
+	}
 	if err := targetHandoff.OnHandoff(ctx, inputData, handoffInput); err != nil {
 		if span := tracing.GetActiveSpan(ctx); span != nil {
 			span.SetAttribute("error", err.Error())
@@ -630,35 +1387,6 @@ func processTargetHandoff(ctx context.Context, targetHandoff handoff.Handoff, st
 	return nil
 }
 
-// applyHandoffInputFilter applies the input filter if provided
-func applyHandoffInputFilter(ctx context.Context, state *executionState, stepResult *stepResult, handoffInput string) error {
-	if state.config.HandoffInputFilter != nil {
-		inputData := &handoff.InputData{
-			InputHistory:    []map[string]any{},
-			PreHandoffItems: []map[string]any{},
-			NewItems:        []map[string]any{},
-			Metadata: map[string]any{
-				"handoff_input": handoffInput,
-				"source_agent":  state.currentAgent.Name,
-				"target_agent":  stepResult.nextAgent.Name,
-			},
-		}
-
-		filtered, err := state.config.HandoffInputFilter(ctx, inputData)
-		if err != nil {
-			return fmt.Errorf("handoff input filter failed: %w", err)
-		}
-
-		// Log filtered metadata
-		if span := tracing.GetActiveSpan(ctx); span != nil && len(filtered.Metadata) > 0 {
-			metadataJSON, _ := json.Marshal(filtered.Metadata)
-			span.SetAttribute("filtered_metadata", string(metadataJSON))
-		}
-	}
-
-	return nil
-}
-
 // updateMessagesForNewAgent updates the messages with the new agent's instructions
 func updateMessagesForNewAgent(ctx context.Context, state *executionState, stepResult *stepResult) error {
 	// Update messages (replace system message with new agent's)
@@ -681,67 +1409,252 @@ func updateMessagesForNewAgent(ctx context.Context, state *executionState, stepR
 	}
 
 	// Update state
-	state.currentAgent = stepResult.nextAgent
+	state.currentAgent = stepResult.nextAgent.Snapshot()
 	state.messages = newMessages
+	state.toolUsed, state.toolUseRetries, state.forceToolUse = false, 0, false
 
 	return nil
 }
 
-// applyOutputGuardrails applies output guardrails to the output
-func applyOutputGuardrails(ctx context.Context, a *agent.Agent, output string) (string, error) {
-	_, guardrailsCtx := tracing.StartSpan(ctx, "output_guardrails", map[string]any{
+// applyOutputSchema asks the provider to enforce the agent's output type with a strict JSON schema,
+// unless the agent's settings choose a response format. Output types that strict schemas cannot
+// express are only parsed from the response.
+func applyOutputSchema(a *agent.Agent, settings *model.Settings) {
+	if a.OutputType == nil {
+		return
+	}
+	if a.OutputMode == agent.OutputModeTool {
+		applyFinalOutputTool(a, settings)
+		return
+	}
+	if settings.ResponseFormat != "" {
+		return
+	}
+
+	schema, err := model.StrictJSONSchema(a.OutputType)
+	if err != nil {
+		return
+	}
+
+	settings.ResponseFormat = "json_schema"
+	settings.ResponseSchema = &model.ResponseSchema{
+		Name:   "final_output",
+		Schema: schema,
+		Strict: true,
+	}
+}
+
+// parseStructuredOutput parses the output as a value of the agent's output type
+func parseStructuredOutput(outputType reflect.Type, output string) (any, error) {
+	structValue := reflect.New(outputType).Interface()
+	if err := json.Unmarshal([]byte(output), structValue); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOutputFormat, err.Error())
+	}
+	// Get value from pointer
+	return reflect.ValueOf(structValue).Elem().Interface(), nil
+}
+
+// applyOutputGuardrails applies output guardrails to the output.
+// For agents with an OutputType, guardrails implementing guardrail.StructuredOutputGuardrail receive
+// the structured output, and the text and structured outputs are kept in sync when either is modified.
+func applyOutputGuardrails(ctx context.Context, config RunConfig, a *agent.Agent, output string, structuredOutput any) (string, any, []GuardrailResult, error) {
+	span, guardrailsCtx := tracing.StartSpan(ctx, "output_guardrails", map[string]any{
 		"span_type":  "guardrails",
 		"agent_name": a.Name,
 		"output":     output,
 	})
+	defer span.End()
 
 	modifiedOutput := output
+	var results []GuardrailResult
+	var err error
+
+	if config.ParallelGuardrails {
+		span.SetAttribute("parallel", true)
+		checks, tripped := runParallelChecks(guardrailsCtx, len(a.OutputGuardrails), func(ctx context.Context, i int) (guardrail.OutputGuardrailResult, error) {
+			return checkOutputGuardrail(ctx, config, a, a.OutputGuardrails[i], output, structuredOutput)
+		}, func(result guardrail.OutputGuardrailResult) bool {
+			return !result.Allowed
+		})
 
-	for _, g := range a.OutputGuardrails {
-		result, err := g.Check(guardrailsCtx, modifiedOutput)
-		if err != nil {
-			if span := tracing.GetActiveSpan(guardrailsCtx); span != nil {
-				span.SetAttribute("error", err.Error())
-				span.End()
+		var errs []error
+		for i, c := range checks {
+			switch {
+			case !c.done:
+			case c.err != nil:
+				errs = append(errs, fmt.Errorf("output guardrail error: %w", c.err))
+			default:
+				results = append(results, newOutputGuardrailResult(a, a.OutputGuardrails[i], c.result))
 			}
-			return "", fmt.Errorf("output guardrail error: %w", err)
+		}
+		if tripped >= 0 {
+			return "", nil, results, outputGuardrailTrip(span, a.OutputGuardrails[tripped], checks[tripped].result)
+		}
+		if len(errs) > 0 {
+			span.SetAttribute("error", errors.Join(errs...).Error())
+			return "", nil, results, errors.Join(errs...)
 		}
 
-		if !result.Allowed {
-			if span := tracing.GetActiveSpan(guardrailsCtx); span != nil {
-				span.SetAttribute("guardrail_triggered", true)
-				span.SetAttribute("guardrail_message", result.Message)
-				span.End()
+		for i, c := range checks {
+			modifiedOutput, structuredOutput, err = applyOutputModification(a, a.OutputGuardrails[i], c.result, modifiedOutput, structuredOutput)
+			if err != nil {
+				return "", nil, results, err
 			}
-			return "", fmt.Errorf("%w: %s", ErrGuardrailTripwire, result.Message)
 		}
+	} else {
+		for _, g := range a.OutputGuardrails {
+			result, err := checkOutputGuardrail(guardrailsCtx, config, a, g, modifiedOutput, structuredOutput)
+			if err != nil {
+				span.SetAttribute("error", err.Error())
+				return "", nil, results, fmt.Errorf("output guardrail error: %w", err)
+			}
+
+			results = append(results, newOutputGuardrailResult(a, g, result))
+
+			if !result.Allowed {
+				return "", nil, results, outputGuardrailTrip(span, g, result)
+			}
 
-		if result.ModifiedOutput != "" {
-			modifiedOutput = result.ModifiedOutput
+			modifiedOutput, structuredOutput, err = applyOutputModification(a, g, result, modifiedOutput, structuredOutput)
+			if err != nil {
+				return "", nil, results, err
+			}
 		}
 	}
 
-	if span := tracing.GetActiveSpan(guardrailsCtx); span != nil {
-		span.SetAttribute("success", true)
-		if modifiedOutput != output {
-			span.SetAttribute("output_modified", true)
+	span.SetAttribute("success", true)
+	if modifiedOutput != output {
+		span.SetAttribute("output_modified", true)
+	}
+
+	return modifiedOutput, structuredOutput, results, nil
+}
+
+// checkOutputGuardrail checks the output with the guardrail, passing the structured output to
+// structured guardrails of agents with an OutputType
+func checkOutputGuardrail(ctx context.Context, config RunConfig, a *agent.Agent, g guardrail.OutputGuardrail, output string, structuredOutput any) (guardrail.OutputGuardrailResult, error) {
+	return checkGuardrail(ctx, config, "output", g.Name(), func(ctx context.Context) (guardrail.OutputGuardrailResult, error) {
+		if structured, ok := g.(guardrail.StructuredOutputGuardrail); ok && a.OutputType != nil {
+			return structured.CheckStructured(ctx, a.Origin(), structuredOutput)
 		}
-		span.End()
+		return g.Check(ctx, output)
+	}, func(result guardrail.OutputGuardrailResult) (bool, string) {
+		return result.Allowed, result.Message
+	})
+}
+
+// newOutputGuardrailResult records the outcome of an output guardrail
+func newOutputGuardrailResult(a *agent.Agent, g guardrail.OutputGuardrail, result guardrail.OutputGuardrailResult) GuardrailResult {
+	return GuardrailResult{
+		Guardrail: g.Name(),
+		AgentName: a.Name,
+		Allowed:   result.Allowed,
+		Message:   result.Message,
+		Modified:  result.Allowed && (result.ModifiedOutput != "" || result.ModifiedStructuredOutput != nil),
+		Metadata:  result.Metadata,
+	}
+}
+
+// outputGuardrailTrip returns the tripwire error of an output guardrail, asking for a re-ask
+// if the guardrail wants one
+func outputGuardrailTrip(span tracing.Span, g guardrail.OutputGuardrail, result guardrail.OutputGuardrailResult) error {
+	span.SetAttribute("guardrail_triggered", true)
+	span.SetAttribute("guardrail_message", result.Message)
+	if result.Reask {
+		limit := 1
+		if limiter, ok := g.(guardrail.ReaskLimiter); ok {
+			limit = limiter.MaxReasks()
+		}
+		return &outputReask{guardrail: g.Name(), message: result.Message, limit: limit}
+	}
+	return fmt.Errorf("%w: %s", ErrGuardrailTripwire, result.Message)
+}
+
+// applyOutputModification applies the output modified by a guardrail, keeping the text and
+// structured outputs in sync
+func applyOutputModification(a *agent.Agent, g guardrail.OutputGuardrail, result guardrail.OutputGuardrailResult, output string, structuredOutput any) (string, any, error) {
+	switch {
+	case result.ModifiedStructuredOutput != nil && a.OutputType != nil:
+		data, err := json.Marshal(result.ModifiedStructuredOutput)
+		if err != nil {
+			return "", nil, fmt.Errorf("output guardrail error: failed to marshal modified output: %w", err)
+		}
+		return string(data), result.ModifiedStructuredOutput, nil
+	case result.ModifiedOutput != "":
+		if a.OutputType != nil {
+			parsed, err := parseStructuredOutput(a.OutputType, result.ModifiedOutput)
+			if err != nil {
+				return "", nil, fmt.Errorf("output guardrail %s returned invalid output: %w", g.Name(), err)
+			}
+			structuredOutput = parsed
+		}
+		return result.ModifiedOutput, structuredOutput, nil
+	}
+	return output, structuredOutput, nil
+}
+
+// outputReask is the tripwire error of an output guardrail that asked for a re-ask
+type outputReask struct {
+	guardrail string
+	message   string
+
+	// limit is the number of re-asks the guardrail allows per run
+	limit int
+}
+
+func (e *outputReask) Error() string {
+	return fmt.Sprintf("%v: %s", ErrGuardrailTripwire, e.message)
+}
+
+func (e *outputReask) Unwrap() error {
+	return ErrGuardrailTripwire
+}
+
+// reaskOutput rejects the final output of the step and sends the guardrail's message back to the
+// model, so the run continues with a corrected answer
+func reaskOutput(ctx context.Context, state *executionState, messages []model.Message, usage Usage, reask *outputReask) *stepResult {
+	if state.outputReasks == nil {
+		state.outputReasks = map[string]int{}
+	}
+	state.outputReasks[reask.guardrail]++
+
+	if span := tracing.GetActiveSpan(ctx); span != nil {
+		span.AddEvent("output_guardrail_reask", map[string]any{
+			"guardrail":         reask.guardrail,
+			"guardrail_message": reask.message,
+			"attempt":           state.outputReasks[reask.guardrail],
+		})
 	}
 
-	return modifiedOutput, nil
+	correction := model.Message{
+		Role:    "user",
+		Content: fmt.Sprintf("Your answer was rejected by the %s guardrail: %s. Please fix it and answer again.", reask.guardrail, reask.message),
+	}
+	return &stepResult{
+		usage:    usage,
+		messages: append(messages, correction),
+	}
 }
 
 // validateInputsAndSetup validates the inputs and sets up default values
 func validateInputsAndSetup(a *agent.Agent, config *RunConfig) error {
-	if a.Instructions == "" {
+	if a.Instructions == "" && !a.HasPrompt() {
 		return ErrAgentMissingInstructions
 	}
 
-	if config.ModelProvider == nil {
+	if config.ModelProvider == nil && a.ModelProvider == nil {
 		return ErrModelProviderRequired
 	}
 
+	if len(config.ProviderMiddleware) > 0 {
+		if config.ModelProvider != nil {
+			config.ModelProvider = model.WithMiddleware(config.ModelProvider, config.ProviderMiddleware...)
+		}
+		// The wrapped provider is passed on with the config; it must not be wrapped again
+		config.agentMiddleware = append(config.agentMiddleware, config.ProviderMiddleware...)
+		config.ProviderMiddleware = nil
+	}
+
 	if config.MaxTurns <= 0 {
 		config.MaxTurns = DefaultMaxTurns
 	}
@@ -778,13 +1691,16 @@ type stepResult struct {
 	finalOutput      string
 	structuredOutput any
 	nextAgent        *agent.Agent
+	handoff          handoff.Handoff
 	messages         []model.Message
 	usage            Usage
 	handoffInput     string
 }
 
 // processToolCallsAndHandoffs processes tool calls and handoffs from LLM response
-func processToolCallsAndHandoffs(ctx context.Context, a *agent.Agent, message model.Message) (*stepResult, error) {
+func processToolCallsAndHandoffs(ctx context.Context, state *executionState, message model.Message) (*stepResult, error) {
+	a := state.currentAgent
+
 	// Check if there are any tool calls
 	if len(message.ToolCalls) == 0 {
 		return nil, fmt.Errorf("no tool calls found in message")
@@ -802,63 +1718,90 @@ func processToolCallsAndHandoffs(ctx context.Context, a *agent.Agent, message mo
 		}
 	}()
 
-	// Check for handoff tool call first
-	for _, tc := range message.ToolCalls {
-		// Check if this is a handoff tool call
-		for _, h := range a.Handoffs {
-			if h.ToolName() == tc.Function.Name {
-				// Handle handoff
-				shouldHandoff, err := h.ShouldHandoff(ctx, tc.Function.Arguments)
-				if err != nil {
-					return nil, fmt.Errorf("failed to check handoff: %w", err)
-				}
-
-				if shouldHandoff {
-					// Handoff input JSON schema validation
-					if schema := h.InputJSONSchema(); len(schema) > 0 {
-						_, err := handoff.ValidateJSON(tc.Function.Arguments, schema)
-						if err != nil {
-							return nil, fmt.Errorf("%w: %s", ErrInvalidHandoffInput, err.Error())
-						}
-					}
-
-					// Get target agent
-					targetAgent := h.TargetAgent().(*agent.Agent)
-
-					// Create step result with handoff information
-					return &stepResult{
-						nextAgent:    targetAgent,
-						messages:     []model.Message{message},
-						handoffInput: tc.Function.Arguments,
-					}, nil
-				}
-			}
-		}
+	// Find the handoff to perform: the first handoff call that is accepted
+	handoffCall, handoffUsed, targetAgent, err := findHandoffCall(ctx, state, a, message)
+	if err != nil {
+		return nil, err
 	}
 
 	// Execute regular tools
 	toolResponses := []model.Message{}
+	var toolMedia []model.ContentPart
 	for _, tc := range message.ToolCalls {
+		// Handoff calls are answered without being executed; only the first one is performed
+		if handoffCall != nil && isHandoffToolCall(a, tc) {
+			content := handoff.TransferMessage(targetAgent.Name)
+			if tc.ID != handoffCall.ID {
+				content = "Multiple handoffs detected, ignoring this one."
+			}
+			toolResponses = append(toolResponses, model.Message{
+				Role:       "tool",
+				ToolCallID: tc.ID,
+				Content:    content,
+			})
+			continue
+		}
+
 		var toolResponse string
+		var toolOutput *tool.ToolOutput
 		var err error
 
 		// Find matching tool
-		var foundTool tool.Tool
-		for _, t := range a.Tools {
-			if t.Name() == tc.Function.Name {
-				foundTool = t
-				break
-			}
+		tools, err := agentTools(state, a)
+		if err != nil {
+			return nil, err
 		}
+		foundTool := tools[tc.Function.Name]
 
 		if foundTool != nil {
 			// Execute tool
-			toolResponse, err = executeToolWithTracing(toolsCtx, a, foundTool, tc.Function.Arguments)
-			if err != nil {
-				return nil, fmt.Errorf("tool execution error: %w", err)
+			toolOutput, err = executeToolWithTracing(toolsCtx, state, foundTool, tc)
+			if isToolFailure(err) {
+				// Panicking and hanging tools fail the call, not the run
+				toolResponse = fmt.Sprintf("Error: %v", errors.Unwrap(err))
+				if span := tracing.GetActiveSpan(toolsCtx); span != nil {
+					span.AddEvent("tool_failure", map[string]any{
+						"tool_name": foundTool.Name(),
+						"error":     err.Error(),
+					})
+				}
+			} else if err != nil {
+				var argErr *tool.ArgumentError
+				if !errors.As(err, &argErr) {
+					return nil, fmt.Errorf("tool execution error: %w", err)
+				}
+				if state.toolArgumentRetries[foundTool.Name()] >= maxToolArgumentRetries(state.config) {
+					return nil, fmt.Errorf("%w for tool %s: %v", ErrInvalidToolArguments, foundTool.Name(), argErr.Err)
+				}
+
+				// Send the validation error back to the model so it can correct the call
+				state.toolArgumentRetries[foundTool.Name()]++
+				toolResponse = fmt.Sprintf("Error: invalid arguments for tool '%s': %v. Please fix the arguments and call the tool again.", foundTool.Name(), argErr.Err)
+				if span := tracing.GetActiveSpan(toolsCtx); span != nil {
+					span.AddEvent("tool_argument_retry", map[string]any{
+						"tool_name": foundTool.Name(),
+						"attempt":   state.toolArgumentRetries[foundTool.Name()],
+						"error":     argErr.Err.Error(),
+					})
+				}
+			} else {
+				toolResponse = toolOutput.Text
+				if len(toolOutput.Parts) > 0 {
+					toolMedia = append(toolMedia, model.NewTextPart(fmt.Sprintf("Output of tool call %s (%s):", tc.ID, foundTool.Name())))
+					toolMedia = append(toolMedia, toolOutput.Parts...)
+					if toolResponse == "" {
+						toolResponse = "The tool returned media, which is attached in the next message."
+					}
+				}
 			}
+		} else if isHandoffToolCall(a, tc) {
+			// The handoff exists, but its condition turned the call down
+			toolResponse = fmt.Sprintf("Error: Handoff '%s' did not accept this request", tc.Function.Name)
 		} else {
-			toolResponse = fmt.Sprintf("Error: Tool '%s' not found", tc.Function.Name)
+			toolResponse, err = handleUnknownTool(toolsCtx, state, a, tc)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		// Add tool response to messages
@@ -869,42 +1812,163 @@ func processToolCallsAndHandoffs(ctx context.Context, a *agent.Agent, message mo
 		})
 	}
 
-	// Return step result with tool responses
-	return &stepResult{
+	// Tool messages can only contain text, so media returned by tools follows them in a user message
+	if len(toolMedia) > 0 {
+		toolResponses = append(toolResponses, model.Message{
+			Role:         "user",
+			ContentParts: toolMedia,
+		})
+	}
+
+	// Return step result with tool responses, handing off after the other tools have run
+	result := &stepResult{
 		messages: append([]model.Message{message}, toolResponses...),
-	}, nil
+	}
+	if handoffCall != nil {
+		result.nextAgent = targetAgent
+		result.handoff = handoffUsed
+		result.handoffInput = handoffCall.Function.Arguments
+	}
+	return result, nil
+}
+
+// findHandoffCall returns the first handoff call of the message whose handoff accepts it, the
+// handoff and its target agent, or nil if the message does not hand off
+func findHandoffCall(ctx context.Context, state *executionState, a *agent.Agent, message model.Message) (*model.ToolCall, handoff.Handoff, *agent.Agent, error) {
+	for i, tc := range message.ToolCalls {
+		for _, h := range a.Handoffs {
+			if h.ToolName() != tc.Function.Name {
+				continue
+			}
+			// Return handoffs go back to the agent that handed off, if any
+			var target *agent.Agent
+			if handoff.IsReturnHandoff(h) {
+				if target = state.previousAgent(); target == nil {
+					continue
+				}
+			} else {
+				target = h.TargetAgent().(*agent.Agent)
+			}
+
+			shouldHandoff, err := evaluateHandoff(ctx, state, a.Name, h, tc)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to check handoff: %w", err)
+			}
+			if !shouldHandoff {
+				continue
+			}
+
+			// Handoff input JSON schema validation
+			if schema := h.InputJSONSchema(); len(schema) > 0 {
+				if _, err := handoff.ValidateJSON(tc.Function.Arguments, schema); err != nil {
+					return nil, nil, nil, fmt.Errorf("%w: %s", ErrInvalidHandoffInput, err.Error())
+				}
+			}
+
+			return &message.ToolCalls[i], h, target, nil
+		}
+	}
+	return nil, nil, nil, nil
 }
 
-// executeToolWithTracing executes a tool with tracing
-func executeToolWithTracing(ctx context.Context, a *agent.Agent, tool tool.Tool, args string) (string, error) {
+// isHandoffToolCall reports whether the tool call calls one of the agent's handoffs
+func isHandoffToolCall(a *agent.Agent, call model.ToolCall) bool {
+	for _, h := range a.Handoffs {
+		if h.ToolName() == call.Function.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// executeToolWithTracing executes a tool with tracing and returns its output with Text set to the tool result
+func executeToolWithTracing(ctx context.Context, state *executionState, t tool.Tool, call model.ToolCall) (*tool.ToolOutput, error) {
+	a := state.currentAgent
+	args := call.Function.Arguments
+
 	_, toolCtx := tracing.StartSpan(ctx, "tool_call", map[string]any{
-		"span_type": "tool",
-		"tool_name": tool.Name(),
-		"tool_args": args,
+		"span_type":    "tool",
+		"tool_name":    t.Name(),
+		"tool_args":    args,
+		"tool_call_id": call.ID,
 	})
 	defer func() {
 		if span := tracing.GetActiveSpan(toolCtx); span != nil {
 			span.End()
 		}
 	}()
+	toolCtx = tool.ContextWithToolContext(toolCtx, &tool.Context{
+		AgentName:  a.Name,
+		ToolName:   t.Name(),
+		ToolCallID: call.ID,
+		Turn:       state.stepCounter + 1,
+		RunID:      state.config.RunID,
+		RunContext: state.config.RunContext,
+	})
+
+	// Arguments that do not match the tool's schema are reported instead of invoking the tool
+	if err := validateToolArguments(toolCtx, state, t, args); err != nil {
+		return nil, err
+	}
+
+	// A tool call rejected by a guardrail is answered with the guardrail's message
+	rejected, message, err := applyToolInputGuardrails(toolCtx, a, t.Name(), args)
+	if err != nil {
+		return nil, err
+	}
+	if rejected {
+		return tool.NewTextOutput(message), nil
+	}
 
 	// Call tool start hook
-	if err := a.Hooks.OnToolStart(ctx, a, tool); err != nil {
-		return "", fmt.Errorf("error in OnToolStart hook: %w", err)
+	if err := a.Hooks.OnToolStart(ctx, a.Origin(), t); err != nil {
+		return nil, fmt.Errorf("error in OnToolStart hook: %w", err)
+	}
+	if err := callToolCallStartHooks(ctx, state, t, call); err != nil {
+		return nil, err
+	}
+
+	// Deliver tool progress events to the configured handler
+	if state.config.ToolProgressHandler != nil {
+		toolCtx = tool.ContextWithProgressHandler(toolCtx, t.Name(), state.config.ToolProgressHandler)
 	}
 
 	// Execute tool
-	result, err := tool.Invoke(toolCtx, args)
+	// Runs made by the tool, such as the run of an agent used as a tool, count towards this run
+	toolCtx, nested := contextWithNestedRuns(toolCtx)
+	output, err := invokeToolSandboxed(toolCtx, state.config, t, args)
+	if results := nested.take(); len(results) > 0 {
+		addNestedRuns(state, t.Name(), call.ID, results)
+		if span := tracing.GetActiveSpan(toolCtx); span != nil {
+			span.SetAttribute("nested_runs", len(results))
+		}
+	}
+	var result string
+	if err == nil {
+		result, err = output.Content()
+	}
 	if err != nil {
 		if span := tracing.GetActiveSpan(toolCtx); span != nil {
 			span.SetAttribute("error", err.Error())
 		}
-		return "", fmt.Errorf("tool execution error: %w", err)
+		return nil, fmt.Errorf("tool execution error: %w", err)
+	}
+	result = truncateToolOutput(result, state.config.MaxToolOutputChars)
+
+	result, rejected, err = applyToolOutputGuardrails(toolCtx, a, t.Name(), args, result)
+	if err != nil {
+		return nil, err
+	}
+	if rejected {
+		output.Parts = nil
 	}
 
 	// Call tool end hook
-	if err := a.Hooks.OnToolEnd(ctx, a, tool, result); err != nil {
-		return "", fmt.Errorf("error in OnToolEnd hook: %w", err)
+	if err := a.Hooks.OnToolEnd(ctx, a.Origin(), t, result); err != nil {
+		return nil, fmt.Errorf("error in OnToolEnd hook: %w", err)
+	}
+	if err := callToolCallEndHooks(ctx, state, t, call, result); err != nil {
+		return nil, err
 	}
 
 	// Record successful execution in tracing
@@ -915,17 +1979,23 @@ func executeToolWithTracing(ctx context.Context, a *agent.Agent, tool tool.Tool,
 			responsePreview = responsePreview[:100] + "..."
 		}
 		span.SetAttribute("response_preview", responsePreview)
+		if len(output.Parts) > 0 {
+			span.SetAttribute("media_parts", len(output.Parts))
+		}
 	}
 
-	return result, nil
+	return &tool.ToolOutput{Text: result, Parts: output.Parts}, nil
 }
 
 // convertUsage converts from model.Usage to runner.Usage
 func convertUsage(usage model.Usage) Usage {
 	return Usage{
-		PromptTokens:     usage.PromptTokens,
-		CompletionTokens: usage.CompletionTokens,
-		TotalTokens:      usage.TotalTokens,
+		PromptTokens:       usage.PromptTokens,
+		CompletionTokens:   usage.CompletionTokens,
+		TotalTokens:        usage.TotalTokens,
+		CachedPromptTokens: usage.CachedPromptTokens,
+		ReasoningTokens:    usage.ReasoningTokens,
+		Requests:           1,
 	}
 }
 
@@ -934,18 +2004,40 @@ func convertModelMessages(messages []model.Message) []Message {
 	result := make([]Message, len(messages))
 	for i, msg := range messages {
 		result[i] = Message{
-			Role:       msg.Role,
-			Content:    msg.Content,
-			ToolCalls:  msg.ToolCalls,
-			ToolCallID: msg.ToolCallID,
-			Name:       msg.Name,
+			Role:         msg.Role,
+			Content:      msg.Content,
+			ToolCalls:    msg.ToolCalls,
+			ToolCallID:   msg.ToolCallID,
+			Name:         msg.Name,
+			ContentParts: msg.ContentParts,
+			Reasoning:    msg.Reasoning,
 		}
 	}
 	return result
 }
 
+// toModelMessages converts Runner messages to model messages, leaving out system messages
+func toModelMessages(messages []Message) []model.Message {
+	result := make([]model.Message, 0, len(messages))
+	for _, msg := range messages {
+		if msg.Role == "system" {
+			continue
+		}
+		result = append(result, model.Message{
+			Role:         msg.Role,
+			Content:      msg.Content,
+			ToolCalls:    msg.ToolCalls,
+			ToolCallID:   msg.ToolCallID,
+			Name:         msg.Name,
+			ContentParts: msg.ContentParts,
+			Reasoning:    msg.Reasoning,
+		})
+	}
+	return result
+}
+
 // prepareMessages prepares message history
-func prepareMessages(agent *agent.Agent, input string) []model.Message {
+func prepareMessages(agent *agent.Agent, history []Message, input string, inputParts []model.ContentPart) []model.Message {
 	var messages []model.Message
 
 	// Add system message
@@ -956,11 +2048,15 @@ func prepareMessages(agent *agent.Agent, input string) []model.Message {
 		})
 	}
 
+	// Add the earlier conversation
+	messages = append(messages, toModelMessages(history)...)
+
 	// Add user message
-	if input != "" {
+	if input != "" || len(inputParts) > 0 {
 		messages = append(messages, model.Message{
-			Role:    "user",
-			Content: input,
+			Role:         "user",
+			Content:      input,
+			ContentParts: inputParts,
 		})
 	}
 
@@ -995,4 +2091,8 @@ func accumulateUsage(totalUsage *Usage, stepUsage Usage) {
 	totalUsage.PromptTokens += stepUsage.PromptTokens
 	totalUsage.CompletionTokens += stepUsage.CompletionTokens
 	totalUsage.TotalTokens += stepUsage.TotalTokens
+	totalUsage.CachedPromptTokens += stepUsage.CachedPromptTokens
+	totalUsage.ReasoningTokens += stepUsage.ReasoningTokens
+	totalUsage.Requests += stepUsage.Requests
+	totalUsage.Cost += stepUsage.Cost
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,7 +13,9 @@ import (
 	"github.com/ryichk/ai-agents-sdk-go/agent"
 	"github.com/ryichk/ai-agents-sdk-go/guardrail"
 	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/interfaces"
 	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
 )
 
 type TestOutputStruct struct {
@@ -255,7 +258,8 @@ func TestHandoffFilters(t *testing.T) {
 	assert.NoError(t, err, "Agent execution should not return an error")
 
 	assert.Equal(t, "last", result.FinalOutput, "Final output does not match")
-	assert.Len(t, result.History, 3, "Filtered history should have 3 items")
+	assert.Len(t, result.History, 4, "Filtered history should have 4 items")
+	assert.Equal(t, Message{Role: "tool", ToolCallID: "handoff_call", Content: `{"assistant":"agent1"}`}, result.History[2])
 }
 
 func TestGuardrailTripwire(t *testing.T) {
@@ -311,6 +315,130 @@ func TestGuardrailTripwire(t *testing.T) {
 	assert.Contains(t, err.Error(), "Output not allowed", "Error message does not match")
 }
 
+func TestStructuredOutputGuardrail(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage(`{"bar": "secret"}`)})
+
+	var checkedAgent string
+	structuredAgent := agent.New("structured", "test instructions")
+	structuredAgent.SetOutputType(reflect.TypeOf(TestOutputStruct{}))
+	structuredAgent.AddOutputGuardrail(guardrail.NewStructuredOutputGuardrail(
+		"redactor",
+		"Redacts bar",
+		func(ctx context.Context, a interfaces.Agent, output any) (guardrail.OutputGuardrailResult, error) {
+			checkedAgent = a.GetName()
+			value := output.(TestOutputStruct)
+			value.Bar = "[redacted " + value.Bar + "]"
+			return guardrail.OutputGuardrailResult{Allowed: true, ModifiedStructuredOutput: value}, nil
+		},
+	))
+
+	config := RunConfig{
+		Model:         "gpt-4o",
+		ModelProvider: fakeModel,
+		MaxTurns:      10,
+	}
+
+	result, err := RunWithConfig(context.Background(), structuredAgent, "test input", config)
+	assert.NoError(t, err)
+	assert.Equal(t, "structured", checkedAgent)
+	assert.Equal(t, TestOutputStruct{Bar: "[redacted secret]"}, result.StructuredOutput)
+	assert.JSONEq(t, `{"bar": "[redacted secret]"}`, result.FinalOutput)
+
+	// Text modifications of a structured output are parsed again
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage(`{"bar": "secret"}`)})
+	textAgent := agent.New("text", "test instructions")
+	textAgent.SetOutputType(reflect.TypeOf(TestOutputStruct{}))
+	textAgent.AddOutputGuardrail(guardrail.NewOutputGuardrail(
+		"rewriter",
+		"Rewrites the output",
+		func(ctx context.Context, output string) (guardrail.OutputGuardrailResult, error) {
+			return guardrail.OutputGuardrailResult{Allowed: true, ModifiedOutput: `{"bar": "public"}`}, nil
+		},
+	))
+
+	result, err = RunWithConfig(context.Background(), textAgent, "test input", config)
+	assert.NoError(t, err)
+	assert.Equal(t, TestOutputStruct{Bar: "public"}, result.StructuredOutput)
+}
+
+func TestProviderMiddleware(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("response")})
+
+	var models []string
+	config := RunConfig{
+		Model:         "gpt-4o",
+		ModelProvider: fakeModel,
+		MaxTurns:      10,
+		ProviderMiddleware: []model.ProviderMiddleware{
+			model.CompletionMiddleware(func(ctx context.Context, messages []model.Message, settings model.Settings, next model.Provider) (*model.Response, error) {
+				settings.Model = "gpt-4o-mini"
+				return next.CreateChatCompletion(ctx, messages, settings)
+			}),
+			model.CompletionMiddleware(func(ctx context.Context, messages []model.Message, settings model.Settings, next model.Provider) (*model.Response, error) {
+				models = append(models, settings.Model)
+				return next.CreateChatCompletion(ctx, messages, settings)
+			}),
+		},
+	}
+
+	result, err := RunWithConfig(context.Background(), agent.New("test", "test instructions"), "test input", config)
+	assert.NoError(t, err)
+	assert.Equal(t, "response", result.FinalOutput)
+	assert.Equal(t, []string{"gpt-4o-mini"}, models)
+}
+
+func TestAgentModelProvider(t *testing.T) {
+	runProvider := NewFakeModel()
+	runProvider.AddTurn(GetFunctionToolCall("transfer_to_local", "{}"))
+	localProvider := NewFakeModel()
+	localProvider.AddTurn(GetTextMessage("answered locally"))
+
+	local := agent.New("local", "local instructions")
+	local.SetModel("llama3")
+	local.SetModelProvider(localProvider)
+	triage := agent.New("triage", "triage instructions")
+	triage.AddHandoff(handoff.NewHandoffWithOptions(local, "Local", handoff.Options{ToolName: "transfer_to_local"}))
+
+	var calls int
+	result, err := RunWithConfig(context.Background(), triage, "hello", RunConfig{
+		Model:         "gpt-4o",
+		ModelProvider: runProvider,
+		MaxTurns:      5,
+		ProviderMiddleware: []model.ProviderMiddleware{
+			model.CompletionMiddleware(func(ctx context.Context, messages []model.Message, settings model.Settings, next model.Provider) (*model.Response, error) {
+				calls++
+				return next.CreateChatCompletion(ctx, messages, settings)
+			}),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "answered locally", result.FinalOutput)
+
+	// Each agent calls its own provider, and the run's middleware wraps both
+	assert.Len(t, runProvider.Calls(), 1)
+	if assert.Len(t, localProvider.Calls(), 1) {
+		assert.Equal(t, "llama3", localProvider.Calls()[0].Settings.Model)
+	}
+	assert.Equal(t, 2, calls)
+
+	// An agent with its own provider does not need the run's provider
+	localProvider.AddTurn(GetTextMessage("no run provider"))
+	result, err = RunWithConfig(context.Background(), local, "hello", RunConfig{MaxTurns: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, "no run provider", result.FinalOutput)
+
+	// A handoff target without a provider fails when the run has none
+	remote := agent.New("remote", "remote instructions")
+	router := agent.New("router", "router instructions")
+	router.SetModelProvider(localProvider)
+	router.AddHandoff(handoff.NewHandoffWithOptions(remote, "Remote", handoff.Options{ToolName: "transfer_to_remote"}))
+	localProvider.AddTurn(GetFunctionToolCall("transfer_to_remote", "{}"))
+	_, err = RunWithConfig(context.Background(), router, "hello", RunConfig{MaxTurns: 5})
+	assert.ErrorIs(t, err, ErrModelProviderRequired)
+}
+
 func TestMaxTurnsExceeded(t *testing.T) {
 	ctx := context.Background()
 
@@ -338,7 +466,17 @@ func TestMaxTurnsExceeded(t *testing.T) {
 
 	_, err := RunWithConfig(ctx, testAgent, "test input", config)
 	assert.Error(t, err, "Max turns exceeded should cause error")
-	assert.Equal(t, ErrMaxTurnsExceeded, err, "Error type does not match")
+	assert.ErrorIs(t, err, ErrMaxTurnsExceeded, "Error type does not match")
+
+	// The error keeps the progress made so far
+	var maxErr *MaxTurnsExceededError
+	if assert.ErrorAs(t, err, &maxErr) {
+		assert.Equal(t, 5, maxErr.Turns)
+		assert.Equal(t, testAgent, maxErr.Partial.LastAgent)
+		assert.Empty(t, maxErr.Partial.FinalOutput)
+		assert.Len(t, maxErr.Partial.History, 11)
+		assert.Equal(t, 5, maxErr.Partial.Usage.Requests)
+	}
 }
 
 func TestHandoffOnInput(t *testing.T) {
@@ -529,3 +667,586 @@ func TestRunWithDelay(t *testing.T) {
 	assert.Equal(t, "delayed response", result.FinalOutput, "Final output does not match")
 	assert.True(t, duration >= time.Millisecond*100, "Should have at least 100ms delay")
 }
+
+type progressTool struct {
+	*FunctionTool
+}
+
+func (t *progressTool) Invoke(ctx context.Context, input string) (string, error) {
+	tool.ReportProgress(ctx, "working", 0.5)
+	return t.FunctionTool.Invoke(ctx, input)
+}
+
+func TestToolProgressHandler(t *testing.T) {
+	ctx := context.Background()
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("progress", "{}")},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "test instructions")
+	testAgent.AddTool(&progressTool{NewFunctionTool("progress", "ok")})
+
+	var events []tool.ProgressEvent
+	config := RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      10,
+		ToolProgressHandler: func(ctx context.Context, event tool.ProgressEvent) {
+			events = append(events, event)
+		},
+	}
+
+	_, err := RunWithConfig(ctx, testAgent, "test input", config)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "progress", events[0].ToolName)
+	assert.Equal(t, "working", events[0].Message)
+}
+
+// contextRecordingTool records the tool context of its calls
+type contextRecordingTool struct {
+	*FunctionTool
+	contexts []*tool.Context
+}
+
+func (t *contextRecordingTool) Invoke(ctx context.Context, input string) (string, error) {
+	if toolContext, ok := tool.FromContext(ctx); ok {
+		t.contexts = append(t.contexts, toolContext)
+	}
+	return t.FunctionTool.Invoke(ctx, input)
+}
+
+func TestToolContext(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("thinking"), GetFunctionToolCall("lookup", "{}")},
+		{GetFunctionToolCall("lookup", "{}")},
+		{GetTextMessage("done")},
+	})
+
+	lookup := &contextRecordingTool{FunctionTool: NewFunctionTool("lookup", "ok")}
+	testAgent := agent.New("support", "test instructions")
+	testAgent.AddTool(lookup)
+
+	type user struct{ ID string }
+	config := RunConfig{ModelProvider: fakeModel, RunID: "run_1", RunContext: &user{ID: "u_42"}}
+	_, err := RunWithConfig(context.Background(), testAgent, "test input", config)
+	assert.NoError(t, err)
+
+	if assert.Len(t, lookup.contexts, 2) {
+		assert.Equal(t, &tool.Context{
+			AgentName:  "support",
+			ToolName:   "lookup",
+			ToolCallID: "call_lookup",
+			Turn:       1,
+			RunID:      "run_1",
+			RunContext: &user{ID: "u_42"},
+		}, lookup.contexts[0])
+		assert.Equal(t, 2, lookup.contexts[1].Turn)
+	}
+
+	_, ok := tool.FromContext(context.Background())
+	assert.False(t, ok)
+}
+
+// argumentCheckingTool rejects arguments without a non-empty "a" field
+type argumentCheckingTool struct {
+	*FunctionTool
+}
+
+func (t *argumentCheckingTool) Invoke(ctx context.Context, input string) (string, error) {
+	var params struct {
+		A string `json:"a"`
+	}
+	if err := json.Unmarshal([]byte(input), &params); err != nil || params.A == "" {
+		return "", tool.NewArgumentError("field 'a' is required")
+	}
+	return t.FunctionTool.Invoke(ctx, input)
+}
+
+func TestToolArgumentRetries(t *testing.T) {
+	ctx := context.Background()
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("check", "{}")},
+		{GetFunctionToolCall("check", `{"a":"b"}`)},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "test instructions")
+	testAgent.AddTool(&argumentCheckingTool{NewFunctionTool("check", "ok")})
+
+	config := RunConfig{
+		ModelProvider:          fakeModel,
+		MaxTurns:               10,
+		MaxToolArgumentRetries: 1,
+	}
+
+	result, err := RunWithConfig(ctx, testAgent, "test input", config)
+	assert.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, "tool", result.History[2].Role)
+	assert.Contains(t, result.History[2].Content, "field 'a' is required")
+	assert.Equal(t, "ok", result.History[4].Content)
+
+	// Without retries the invalid arguments fail the run
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("check", "{}")},
+	})
+	config.MaxToolArgumentRetries = 0
+
+	_, err = RunWithConfig(ctx, testAgent, "test input", config)
+	assert.ErrorIs(t, err, tool.ErrInvalidArguments)
+}
+
+func TestPromptCacheConfig(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+
+	hooks := &llmHooksRecorder{}
+	config := RunConfig{
+		ModelProvider:     fakeModel,
+		MaxTurns:          5,
+		LLMHooks:          hooks,
+		PromptCacheKey:    "support-agent",
+		CacheStablePrefix: true,
+	}
+
+	_, err := RunWithConfig(context.Background(), agent.New("test", "test instructions"), "hi", config)
+	assert.NoError(t, err)
+
+	assert.Len(t, hooks.settings, 1)
+	assert.Equal(t, "support-agent", hooks.settings[0].PromptCacheKey)
+	assert.True(t, hooks.settings[0].CacheStablePrefix)
+}
+
+func TestRequestAttributionConfig(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+
+	testAgent := agent.New("test", "test instructions")
+	testAgent.ModelSettings = model.Settings{User: "agent-user", Metadata: map[string]string{"agent": "test", "env": "dev"}}
+
+	store := true
+	hooks := &llmHooksRecorder{}
+	config := RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		LLMHooks:      hooks,
+		User:          "user-123",
+		Metadata:      map[string]string{"env": "prod"},
+		Store:         &store,
+	}
+
+	_, err := RunWithConfig(context.Background(), testAgent, "hi", config)
+	assert.NoError(t, err)
+
+	assert.Len(t, hooks.settings, 1)
+	assert.Equal(t, "user-123", hooks.settings[0].User)
+	assert.Equal(t, map[string]string{"agent": "test", "env": "prod"}, hooks.settings[0].Metadata)
+	assert.Equal(t, &store, hooks.settings[0].Store)
+}
+
+func TestInputParts(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("a cat")})
+
+	hooks := &llmHooksRecorder{}
+	config := RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		LLMHooks:      hooks,
+		InputParts:    []model.ContentPart{model.NewImagePart("https://example.com/cat.png", "")},
+	}
+
+	result, err := RunWithConfig(context.Background(), agent.New("test", "test instructions"), "", config)
+	assert.NoError(t, err)
+
+	sent := hooks.startMessages[0][len(hooks.startMessages[0])-1]
+	assert.Equal(t, "user", sent.Role)
+	assert.Equal(t, config.InputParts, sent.ContentParts)
+	assert.Equal(t, config.InputParts, result.History[0].ContentParts)
+}
+
+func TestConcurrentRunsOfSharedAgent(t *testing.T) {
+	shared := agent.New("shared", "test instructions")
+	shared.AddTool(NewFunctionTool("lookup", "found"))
+
+	// Run with -race: the runs work on snapshots while the agent is being modified
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			fakeModel := NewFakeModel()
+			fakeModel.AddMultipleTurnOutputs([][]model.Message{
+				{GetFunctionToolCall("lookup", "{}")},
+				{GetTextMessage("done")},
+			})
+			result, err := RunWithConfig(context.Background(), shared, "hi", RunConfig{ModelProvider: fakeModel, MaxTurns: 5})
+			if assert.NoError(t, err) {
+				assert.Equal(t, "done", result.FinalOutput)
+				assert.Same(t, shared, result.LastAgent)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			shared.SetModel("gpt-4o-mini")
+			shared.SetModelSettings(model.Settings{Temperature: 0.2})
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAgentModelSettings(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+
+	testAgent := agent.New("test", "test instructions")
+	testAgent.SetModelSettings(model.Settings{MaxTokens: 4096, ReasoningEffort: "low"})
+
+	hooks := &llmHooksRecorder{}
+	config := RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		LLMHooks:      hooks,
+	}
+
+	_, err := RunWithConfig(context.Background(), testAgent, "hi", config)
+	assert.NoError(t, err)
+
+	settings := hooks.settings[0]
+	assert.Equal(t, 4096, settings.MaxTokens)
+	assert.Equal(t, "low", settings.ReasoningEffort)
+	assert.Equal(t, model.DefaultSettings().Temperature, settings.Temperature)
+}
+
+func TestAgentZeroTemperature(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+
+	testAgent := agent.New("test", "test instructions")
+	testAgent.SetModelSettings(model.Settings{ExplicitSampling: true})
+
+	_, err := RunWithConfig(context.Background(), testAgent, "hi", RunConfig{ModelProvider: fakeModel, MaxTurns: 5})
+	assert.NoError(t, err)
+
+	settings := fakeModel.Calls()[0].Settings
+	assert.True(t, settings.ExplicitSampling)
+	assert.Zero(t, settings.Temperature)
+	assert.Zero(t, settings.TopP)
+}
+
+func TestToolMediaOutput(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("screenshot", `{"param0":"https://example.com"}`)},
+		{GetTextMessage("The page shows a login form")},
+	})
+
+	screenshotTool, err := tool.NewFunctionToolWithName(func(url string) *tool.ToolOutput {
+		return tool.NewImageOutput([]byte("png"), "image/png", "")
+	}, "screenshot", "Takes a screenshot")
+	assert.NoError(t, err)
+
+	testAgent := agent.New("browser", "Use the browser")
+	testAgent.AddTool(screenshotTool)
+
+	result, err := RunWithConfig(context.Background(), testAgent, "What is on the page?", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "The page shows a login form", result.FinalOutput)
+
+	// user, assistant tool call, tool result, media, final answer
+	assert.Len(t, result.History, 5)
+	assert.Equal(t, "tool", result.History[2].Role)
+	assert.Contains(t, result.History[2].Content, "attached in the next message")
+	assert.Equal(t, "user", result.History[3].Role)
+	assert.Len(t, result.History[3].ContentParts, 2)
+	assert.Equal(t, model.ContentPartImageURL, result.History[3].ContentParts[1].Type)
+}
+
+func TestMaxToolImages(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("screenshot", "{}")},
+		{GetFunctionToolCall("screenshot", "{}")},
+		{GetTextMessage("The form was submitted")},
+	})
+
+	screenshotTool, err := tool.NewFunctionToolWithName(func() *tool.ToolOutput {
+		return tool.NewImageURLOutput("https://example.com/screenshot.png", "Current page")
+	}, "screenshot", "Takes a screenshot")
+	assert.NoError(t, err)
+
+	testAgent := agent.New("browser", "Use the browser")
+	testAgent.AddTool(screenshotTool)
+
+	result, err := RunWithConfig(context.Background(), testAgent, "Submit the form", RunConfig{
+		ModelProvider: fakeModel,
+		MaxToolImages: 1,
+	})
+	assert.NoError(t, err)
+
+	// The last call sees the latest screenshot only
+	calls := fakeModel.Calls()
+	assert.Len(t, calls, 3)
+	var images, notices int
+	for _, message := range calls[2].Messages {
+		for _, part := range message.ContentParts {
+			switch {
+			case part.Type == model.ContentPartImageURL:
+				images++
+			case part.Text == prunedImageNotice:
+				notices++
+			}
+		}
+	}
+	assert.Equal(t, 1, images)
+	assert.Equal(t, 1, notices)
+	last := calls[2].Messages[len(calls[2].Messages)-1]
+	assert.Equal(t, "https://example.com/screenshot.png", last.ContentParts[1].ImageURL.URL)
+
+	// The history keeps every screenshot
+	assert.Equal(t, model.ContentPartImageURL, result.History[3].ContentParts[1].Type)
+	assert.Equal(t, model.ContentPartImageURL, result.History[6].ContentParts[1].Type)
+}
+
+func TestStructuredOutputResponseFormat(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage(`{"bar": "baz"}`)})
+	hooks := &llmHooksRecorder{}
+
+	structuredAgent := agent.New("structured", "test instructions")
+	structuredAgent.SetOutputType(reflect.TypeOf(TestOutputStruct{}))
+
+	result, err := RunWithConfig(context.Background(), structuredAgent, "test input", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      10,
+		LLMHooks:      hooks,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, TestOutputStruct{Bar: "baz"}, result.StructuredOutput)
+
+	settings := hooks.settings[0]
+	assert.Equal(t, "json_schema", settings.ResponseFormat)
+	if assert.NotNil(t, settings.ResponseSchema) {
+		assert.Equal(t, "final_output", settings.ResponseSchema.Name)
+		assert.True(t, settings.ResponseSchema.Strict)
+		assert.Equal(t, []string{"bar"}, settings.ResponseSchema.Schema["required"])
+	}
+}
+
+func TestStructuredOutputRefusal(t *testing.T) {
+	provider := model.ProviderFuncs{
+		Completion: func(ctx context.Context, messages []model.Message, settings model.Settings) (*model.Response, error) {
+			return &model.Response{Message: model.Message{Role: "assistant", Refusal: "I can't help with that."}}, nil
+		},
+	}
+
+	structuredAgent := agent.New("structured", "test instructions")
+	structuredAgent.SetOutputType(reflect.TypeOf(TestOutputStruct{}))
+
+	_, err := RunWithConfig(context.Background(), structuredAgent, "test input", RunConfig{
+		ModelProvider: provider,
+		MaxTurns:      10,
+	})
+	assert.ErrorIs(t, err, ErrOutputRefused)
+	assert.Contains(t, err.Error(), "I can't help with that.")
+}
+
+// TestHandoffWithToolCalls tests that tools called with a handoff run before it, and that only the
+// first handoff is performed
+func TestHandoffWithToolCalls(t *testing.T) {
+	billing := agent.New("billing", "billing instructions")
+	shipping := agent.New("shipping", "shipping instructions")
+	lookup := NewFunctionTool("lookup", "found")
+	triage := agent.New("triage", "triage instructions")
+	triage.AddTool(lookup)
+	triage.AddHandoffs(
+		handoff.NewHandoffWithOptions(billing, "Billing", handoff.Options{ToolName: "transfer_to_billing"}),
+		handoff.NewHandoffWithOptions(shipping, "Shipping", handoff.Options{ToolName: "transfer_to_shipping"}),
+	)
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{
+			GetFunctionToolCall("transfer_to_billing", "{}"),
+			GetFunctionToolCall("lookup", `{"a":"order"}`),
+			GetFunctionToolCall("transfer_to_shipping", "{}"),
+		},
+		{GetTextMessage("billing here")},
+	})
+	hooks := &llmHooksRecorder{}
+
+	result, err := RunWithConfig(context.Background(), triage, "refund please", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		LLMHooks:      hooks,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "billing here", result.FinalOutput)
+	assert.Same(t, billing, result.LastAgent)
+	assert.Equal(t, []string{`{"a":"order"}`}, lookup.Inputs())
+
+	// The billing agent sees a result for every tool call
+	messages := hooks.startMessages[1]
+	assert.Len(t, messages, 6)
+	assert.Equal(t, "billing instructions", messages[0].Content)
+	assert.Equal(t, model.Message{Role: "tool", ToolCallID: "call_transfer_to_billing", Content: `{"assistant":"billing"}`}, messages[3])
+	assert.Equal(t, model.Message{Role: "tool", ToolCallID: "call_lookup", Content: "found"}, messages[4])
+	assert.Equal(t, model.Message{Role: "tool", ToolCallID: "call_transfer_to_shipping", Content: "Multiple handoffs detected, ignoring this one."}, messages[5])
+}
+
+func TestToolRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	registry := tool.NewRegistry()
+	registry.MustRegister(NewFunctionTool("get_balance", "100 USD"), tool.WithNamespace("finance"))
+	registry.MustRegister(NewFunctionTool("search", "no results"), tool.WithNamespace("web"))
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("get_balance", "{}")},
+		{GetTextMessage("Your balance is 100 USD")},
+	})
+
+	testAgent := agent.New("finance", "test instructions")
+	testAgent.AddTools(registry.Select("finance/*")...)
+
+	result, err := RunWithConfig(ctx, testAgent, "What is my balance?", RunConfig{ModelProvider: fakeModel, MaxTurns: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "100 USD", result.History[2].Content)
+	calls := fakeModel.Calls()
+	assert.Len(t, calls[0].Settings.Tools, 1)
+
+	// Two tools sharing a name fail the run instead of calling the first one
+	testAgent.AddTool(NewFunctionTool("get_balance", "0 USD"))
+	_, err = RunWithConfig(ctx, testAgent, "What is my balance?", RunConfig{ModelProvider: fakeModel, MaxTurns: 3})
+	assert.ErrorIs(t, err, tool.ErrDuplicateTool)
+}
+
+func TestReturnHandoff(t *testing.T) {
+	billing := agent.New("billing", "billing instructions")
+	billing.AddHandoff(handoff.NewReturnHandoff())
+	triage := agent.New("triage", "triage instructions")
+	triage.AddHandoffs(
+		handoff.NewHandoffWithOptions(billing, "Billing", handoff.Options{ToolName: "transfer_to_billing"}),
+		handoff.NewReturnHandoff(),
+	)
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("transfer_to_billing", "{}")},
+		{GetFunctionToolCall(handoff.ReturnToolName, "{}")},
+		{GetTextMessage("back in triage")},
+	})
+
+	result, err := RunWithConfig(context.Background(), triage, "hello", RunConfig{ModelProvider: fakeModel, MaxTurns: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, "back in triage", result.FinalOutput)
+	assert.Same(t, triage, result.LastAgent)
+	assert.Equal(t, []HandoffRecord{
+		{Step: 1, FromAgent: "triage", ToAgent: "billing", Input: "{}"},
+		{Step: 2, FromAgent: "billing", ToAgent: "triage", Input: "{}"},
+	}, result.Handoffs)
+
+	// The return handoff is only offered to agents reached by a handoff
+	toolNames := func(call int) []string {
+		var names []string
+		for _, definition := range fakeModel.Calls()[call].Settings.Tools {
+			names = append(names, definition.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"transfer_to_billing"}, toolNames(0))
+	assert.Equal(t, []string{handoff.ReturnToolName}, toolNames(1))
+	assert.Equal(t, []string{"transfer_to_billing"}, toolNames(2))
+}
+
+func TestHandoffInputFilter(t *testing.T) {
+	billing := agent.New("billing", "billing instructions")
+	triage := agent.New("triage", "triage instructions")
+	triage.AddTool(NewFunctionTool("lookup", "customer 42"))
+	triage.AddHandoff(handoff.NewHandoffWithOptions(billing, "Billing", handoff.Options{ToolName: "transfer_to_billing"}))
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("lookup", "{}")},
+		{GetFunctionToolCall("transfer_to_billing", "{}")},
+		{GetTextMessage("refund issued")},
+	})
+
+	var inputData *handoff.InputData
+	result, err := RunWithConfig(context.Background(), triage, "refund please", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		History:       []Message{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}},
+		HandoffInputFilter: handoff.Chain(func(ctx context.Context, data *handoff.InputData) (*handoff.InputData, error) {
+			inputData = data
+			return data, nil
+		}, handoff.RemoveAllTools),
+	})
+	assert.NoError(t, err)
+
+	// The filter sees the run's input, the earlier turns and the handoff turn
+	if !assert.NotNil(t, inputData) {
+		return
+	}
+	assert.Len(t, inputData.InputHistory, 3)
+	assert.Len(t, inputData.PreHandoffItems, 2)
+	assert.Len(t, inputData.NewItems, 2)
+	assert.Equal(t, "tool", inputData.NewItems[1]["role"])
+
+	// The next agent only receives the filtered history, while the result keeps everything
+	messages := fakeModel.Calls()[2].Messages
+	var roles []string
+	for _, message := range messages {
+		roles = append(roles, message.Role)
+	}
+	assert.Equal(t, []string{"system", "user", "assistant", "user"}, roles)
+	assert.Equal(t, "billing instructions", messages[0].Content)
+	assert.Len(t, result.History, 8)
+}
+
+func TestHandoffInputDataCustomToolName(t *testing.T) {
+	supervisor := agent.New("supervisor", "supervisor instructions")
+	triage := agent.New("triage", "triage instructions")
+	triage.AddHandoff(handoff.NewHandoffWithOptions(supervisor, "Supervisor", handoff.Options{ToolName: "escalate"}))
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("escalate", "{}")},
+		{GetTextMessage("I am the supervisor")},
+	})
+
+	var encoded []byte
+	_, err := RunWithConfig(context.Background(), triage, "I want a supervisor", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		HandoffInputFilter: func(ctx context.Context, data *handoff.InputData) (*handoff.InputData, error) {
+			var err error
+			encoded, err = handoff.EncodeInputData(data)
+			return data, err
+		},
+	})
+	assert.NoError(t, err)
+
+	// The handoff call is encoded as one although its name lacks the transfer_to_ prefix
+	var decoded struct {
+		NewItems []struct {
+			Type string `json:"type"`
+		} `json:"new_items"`
+	}
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	if !assert.Len(t, decoded.NewItems, 2) {
+		return
+	}
+	assert.Equal(t, handoff.RunItemHandoffCall, decoded.NewItems[0].Type)
+	assert.Equal(t, handoff.RunItemHandoffOutput, decoded.NewItems[1].Type)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+var (
+	// ErrToolTimeout is matched by errors.Is when a tool call exceeds its time limit
+	ErrToolTimeout = errors.New("tool call timed out")
+
+	// ErrToolPanicked is matched by errors.Is for every ToolPanicError
+	ErrToolPanicked = errors.New("tool panicked")
+)
+
+// ToolPanicError reports a panic recovered from a tool call
+type ToolPanicError struct {
+	// ToolName is the name of the tool that panicked
+	ToolName string
+
+	// Value is the value passed to panic
+	Value any
+
+	// Stack is the stack trace of the panic
+	Stack []byte
+}
+
+func (e *ToolPanicError) Error() string {
+	return fmt.Sprintf("tool '%s' panicked: %v", e.ToolName, e.Value)
+}
+
+// Is reports whether target is ErrToolPanicked
+func (e *ToolPanicError) Is(target error) bool {
+	return target == ErrToolPanicked
+}
+
+// toolTimeout returns the time limit of the tool's calls, or 0 for no limit
+func toolTimeout(config RunConfig, name string) time.Duration {
+	if timeout, ok := config.ToolTimeouts[name]; ok {
+		return timeout
+	}
+	return config.ToolTimeout
+}
+
+// invokeToolSandboxed runs the tool within its time limit, turning panics into ToolPanicErrors
+// and time limits into errors wrapping ErrToolTimeout. A cancelled run still returns ctx.Err().
+func invokeToolSandboxed(ctx context.Context, config RunConfig, t tool.Tool, args string) (*tool.ToolOutput, error) {
+	timeout := toolTimeout(config, t.Name())
+	if timeout <= 0 {
+		return invokeToolContext(ctx, t, args)
+	}
+
+	toolCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output, err := invokeToolContext(toolCtx, t, args)
+	if err != nil && ctx.Err() == nil && errors.Is(toolCtx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w: tool '%s' did not finish within %s", ErrToolTimeout, t.Name(), timeout)
+	}
+	return output, err
+}
+
+// invokeToolRecover runs the tool, recovering its panics
+func invokeToolRecover(ctx context.Context, t tool.Tool, args string) (output *tool.ToolOutput, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			output, err = nil, &ToolPanicError{ToolName: t.Name(), Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return tool.InvokeOutput(ctx, t, args)
+}
+
+// isToolFailure reports whether a tool error is reported to the model instead of failing the run
+func isToolFailure(err error) bool {
+	return errors.Is(err, ErrToolTimeout) || errors.Is(err, ErrToolPanicked)
+}
+
+// truncateToolOutput shortens a tool result to maxChars characters (0 means no limit),
+// appending a notice for the model
+func truncateToolOutput(result string, maxChars int) string {
+	if maxChars <= 0 {
+		return result
+	}
+	chars := utf8.RuneCountInString(result)
+	if chars <= maxChars {
+		return result
+	}
+	runes := []rune(result)
+	return string(runes[:maxChars]) + fmt.Sprintf("\n\n[Output truncated: showing the first %d of %d characters]", maxChars, chars)
+}
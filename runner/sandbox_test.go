@@ -0,0 +1,152 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+// toolResponses returns the contents of the tool messages of the history
+func toolResponses(history []Message) []string {
+	var contents []string
+	for _, msg := range history {
+		if msg.Role == "tool" {
+			contents = append(contents, msg.Content)
+		}
+	}
+	return contents
+}
+
+func TestToolPanicIsReportedToModel(t *testing.T) {
+	panicky, err := tool.NewFunctionToolWithName(func() string {
+		panic("boom")
+	}, "panicky", "Always panics")
+	require.NoError(t, err)
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("panicky", "{}")},
+		{GetTextMessage("recovered")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(panicky)
+
+	result, err := RunWithConfig(context.Background(), testAgent, "go", RunConfig{ModelProvider: fakeModel, MaxTurns: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "recovered", result.FinalOutput)
+	assert.Equal(t, []string{"Error: tool 'panicky' panicked: boom"}, toolResponses(result.History))
+
+	_, err = invokeToolRecover(context.Background(), panicky, "{}")
+	var panicErr *ToolPanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.ErrorIs(t, err, ErrToolPanicked)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+}
+
+func TestToolTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	slowTool, err := tool.NewFunctionToolWithName(func() string {
+		<-release
+		return "done"
+	}, "slow", "Never finishes in time")
+	require.NoError(t, err)
+	fastTool, err := tool.NewFunctionToolWithName(func() string {
+		time.Sleep(20 * time.Millisecond)
+		return "fast result"
+	}, "fast", "Finishes after its default time limit")
+	require.NoError(t, err)
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("slow", "{}"), GetFunctionToolCall("fast", "{}")},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(slowTool)
+	testAgent.AddTool(fastTool)
+
+	result, err := RunWithConfig(context.Background(), testAgent, "go", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      3,
+		ToolTimeout:   10 * time.Millisecond,
+		ToolTimeouts:  map[string]time.Duration{"fast": 0},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, []string{
+		"Error: tool call timed out: tool 'slow' did not finish within 10ms",
+		`"fast result"`,
+	}, toolResponses(result.History))
+}
+
+func TestMaxToolOutputChars(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("dump", "{}")},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("dump", strings.Repeat("é", 50)))
+
+	result, err := RunWithConfig(context.Background(), testAgent, "go", RunConfig{
+		ModelProvider:      fakeModel,
+		MaxTurns:           3,
+		MaxToolOutputChars: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{strings.Repeat("é", 10) + "\n\n[Output truncated: showing the first 10 of 50 characters]"}, toolResponses(result.History))
+}
+
+// nilOutputTool is a tool whose InvokeOutput returns no output and no error
+type nilOutputTool struct{}
+
+func (nilOutputTool) Name() string                     { return "nil_output" }
+func (nilOutputTool) Description() string              { return "Returns nothing" }
+func (nilOutputTool) ParamsJSONSchema() map[string]any { return map[string]any{"type": "object"} }
+func (nilOutputTool) Invoke(ctx context.Context, paramsJSON string) (string, error) {
+	return "", nil
+}
+func (nilOutputTool) InvokeOutput(ctx context.Context, paramsJSON string) (*tool.ToolOutput, error) {
+	return nil, nil
+}
+
+func TestToolWithNilOutput(t *testing.T) {
+	nilFunction, err := tool.NewFunctionToolWithName(func() *tool.ToolOutput {
+		return nil
+	}, "nil_function", "Returns a nil output")
+	require.NoError(t, err)
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("nil_output", "{}")},
+		{GetFunctionToolCall("nil_function", "{}")},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(nilOutputTool{})
+	testAgent.AddTool(nilFunction)
+
+	result, err := RunWithConfig(context.Background(), testAgent, "go", RunConfig{ModelProvider: fakeModel, MaxTurns: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, []string{"", ""}, toolResponses(result.History))
+}
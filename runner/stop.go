@@ -0,0 +1,85 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// DefaultStopReason is the Result.StopReason of runs stopped by a stop condition that gave no reason
+const DefaultStopReason = "stop condition met"
+
+// RunState is the state of a run seen by RunConfig.StopCondition after a turn
+type RunState struct {
+	// Step is the 1-based number of the turn that just ended
+	Step int
+
+	// Agent is the agent that runs the next turn, after any handoff of the turn
+	Agent *agent.Agent
+
+	// History are the messages of the run so far, starting with the user input
+	History []model.Message
+
+	// NewMessages are the messages the turn added to History
+	NewMessages []model.Message
+
+	// Usage is the usage of the run so far
+	Usage Usage
+}
+
+// ToolCalls returns the tool calls the model made in the turn, including handoff calls
+func (s RunState) ToolCalls() []model.ToolCall {
+	var calls []model.ToolCall
+	for _, message := range s.NewMessages {
+		calls = append(calls, message.ToolCalls...)
+	}
+	return calls
+}
+
+// CalledTool reports whether the model called the named tool in the turn
+func (s RunState) CalledTool(name string) bool {
+	for _, call := range s.ToolCalls() {
+		if call.Function.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// StopCondition decides after a turn without a final output whether the run stops there, and why
+type StopCondition func(state RunState) (stop bool, reason string)
+
+// checkStopCondition evaluates RunConfig.StopCondition after a turn that added the messages of
+// the result history from index start. When the run stops, the last assistant text of the run
+// becomes its final output.
+func checkStopCondition(state *executionState, start int) {
+	if state.config.StopCondition == nil || state.finalOutput != "" {
+		return
+	}
+
+	history := append([]model.Message(nil), state.resultMessages...)
+	stop, reason := state.config.StopCondition(RunState{
+		Step:        state.stepCounter + 1,
+		Agent:       state.currentAgent.Origin(),
+		History:     history,
+		NewMessages: history[start:],
+		Usage:       state.usage,
+	})
+	if !stop {
+		return
+	}
+
+	if reason == "" {
+		reason = DefaultStopReason
+	}
+	state.stopReason = reason
+	for i := len(state.resultMessages) - 1; i >= 0; i-- {
+		if message := state.resultMessages[i]; message.Role == "assistant" && message.Content != "" {
+			state.finalOutput = message.Content
+			break
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestStopCondition(t *testing.T) {
+	fakeModel := NewFakeModel()
+	searching := GetFunctionToolCall("search", "{}")
+	searching.Content = "Let me search for that."
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{searching},
+		{GetFunctionToolCall("escalate", "{}")},
+		{GetTextMessage("never reached")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("search", "nothing found"))
+	testAgent.AddTool(NewFunctionTool("escalate", "ticket created"))
+
+	var states []RunState
+	result, err := RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		StopCondition: func(state RunState) (bool, string) {
+			states = append(states, state)
+			return state.CalledTool("escalate"), "escalated to a human"
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "escalated to a human", result.StopReason)
+	assert.Equal(t, "Let me search for that.", result.FinalOutput)
+	assert.Len(t, fakeModel.Calls(), 2)
+
+	require.Len(t, states, 2)
+	assert.Equal(t, 1, states[0].Step)
+	assert.Same(t, testAgent, states[0].Agent)
+	assert.Len(t, states[0].NewMessages, 2)
+	assert.Len(t, states[1].History, 5)
+	assert.Equal(t, "escalate", states[1].ToolCalls()[0].Function.Name)
+
+	// The reason is part of the wire format
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	var decoded Result
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, result.StopReason, decoded.StopReason)
+
+	// Runs ending with a final output do not consult the condition
+	fakeModel = NewFakeModel()
+	fakeModel.AddTurn(GetTextMessage("done"))
+	result, err = RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		StopCondition: func(state RunState) (bool, string) {
+			t.Fatal("the condition should not be evaluated")
+			return true, ""
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Empty(t, result.StopReason)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import "github.com/ryichk/ai-agents-sdk-go/model"
+
+// prunedImageNotice replaces the images that RunConfig.MaxToolImages removes from the history
+const prunedImageNotice = "[An earlier image returned by a tool was removed from the history]"
+
+// isToolMediaMessage reports whether the message at index i carries the media returned by the
+// tool calls before it
+func isToolMediaMessage(messages []model.Message, i int) bool {
+	message := messages[i]
+	return i > 0 && messages[i-1].Role == "tool" && message.Role == "user" && message.Content == "" && len(message.ContentParts) > 0
+}
+
+// pruneToolImages keeps the last keep images returned by tools in the working history, replacing
+// the older ones with a notice, so loops taking a screenshot per turn do not fill the context window
+func pruneToolImages(messages []model.Message, keep int) []model.Message {
+	kept := 0
+	copied := false
+	for i := len(messages) - 1; i >= 0; i-- {
+		if !isToolMediaMessage(messages, i) {
+			continue
+		}
+
+		var parts []model.ContentPart
+		for j := len(messages[i].ContentParts) - 1; j >= 0; j-- {
+			part := messages[i].ContentParts[j]
+			if part.Type != model.ContentPartImageURL {
+				continue
+			}
+			if kept < keep {
+				kept++
+				continue
+			}
+			if parts == nil {
+				// Result.History and earlier model calls share the messages, so they are copied
+				// before they change
+				parts = append([]model.ContentPart(nil), messages[i].ContentParts...)
+			}
+			parts[j] = model.NewTextPart(prunedImageNotice)
+		}
+		if parts != nil {
+			if !copied {
+				messages = append([]model.Message(nil), messages...)
+				copied = true
+			}
+			messages[i].ContentParts = parts
+		}
+	}
+	return messages
+}
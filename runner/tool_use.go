@@ -0,0 +1,47 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// ErrToolUseRequired is returned when an agent with a required tool use policy keeps answering
+// without calling a tool
+var ErrToolUseRequired = errors.New("tool use required")
+
+// toolUseReminder is sent to the model when it answered without calling a required tool
+const toolUseReminder = "You must call one of your tools before answering. Call a tool now."
+
+// enforceToolUse rejects an answer of an agent whose tool use policy requires a tool call
+// before the final output. It returns nil when the answer can be the final output.
+func enforceToolUse(ctx context.Context, state *executionState, messages []model.Message, usage Usage) (*stepResult, error) {
+	a := state.currentAgent
+	if !a.ToolUse.Required || state.toolUsed {
+		return nil, nil
+	}
+	if state.toolUseRetries >= a.ToolUse.MaxRetries {
+		return nil, fmt.Errorf("%w: agent %s answered without calling a tool", ErrToolUseRequired, a.Name)
+	}
+
+	state.toolUseRetries++
+	state.forceToolUse = true
+	if span := tracing.GetActiveSpan(ctx); span != nil {
+		span.AddEvent("tool_use_retry", map[string]any{
+			"agent_name": a.Name,
+			"attempt":    state.toolUseRetries,
+		})
+	}
+
+	return &stepResult{
+		usage:    usage,
+		messages: append(messages, model.Message{Role: "user", Content: toolUseReminder}),
+	}, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestToolUseEnforcement(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("I think the answer is 42")},
+		{GetFunctionToolCall("search", "{}")},
+		{GetTextMessage("According to the docs, the answer is 42")},
+	})
+
+	testAgent := agent.New("rag", "Answer from the docs")
+	testAgent.AddTool(NewFunctionTool("search", "the answer is 42"))
+	testAgent.SetToolUse(agent.ToolUsePolicy{Required: true, MaxRetries: 1})
+
+	result, err := RunWithConfig(context.Background(), testAgent, "What is the answer?", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "According to the docs, the answer is 42", result.FinalOutput)
+
+	// The model is reminded and forced to call a tool, and then free to answer
+	calls := fakeModel.Calls()
+	require.Len(t, calls, 3)
+	assert.Empty(t, calls[0].Settings.ToolChoice)
+	assert.Equal(t, model.ToolChoiceRequired, calls[1].Settings.ToolChoice)
+	assert.Equal(t, toolUseReminder, calls[1].Messages[len(calls[1].Messages)-1].Content)
+	assert.Empty(t, calls[2].Settings.ToolChoice)
+
+	// The run fails once the retries are used up
+	fakeModel = NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("42")},
+		{GetTextMessage("42")},
+	})
+	_, err = RunWithConfig(context.Background(), testAgent, "What is the answer?", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+	})
+	assert.ErrorIs(t, err, ErrToolUseRequired)
+	assert.Len(t, fakeModel.Calls(), 2)
+}
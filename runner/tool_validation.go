@@ -0,0 +1,70 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// ToolArgumentValidation is how the runner checks the arguments of tool calls against the tools'
+// ParamsJSONSchema before invoking them
+type ToolArgumentValidation string
+
+const (
+	// ToolArgumentsUnchecked passes the arguments to the tools as the model wrote them (the default)
+	ToolArgumentsUnchecked ToolArgumentValidation = ""
+
+	// ToolArgumentsFeedback answers calls with invalid arguments with the validation error instead
+	// of invoking the tool, so the model can correct the call, up to MaxToolArgumentRetries times
+	// per tool (DefaultToolArgumentRetries if unset)
+	ToolArgumentsFeedback ToolArgumentValidation = "feedback"
+
+	// ToolArgumentsFail fails the run with an error wrapping ErrInvalidToolArguments
+	ToolArgumentsFail ToolArgumentValidation = "fail"
+)
+
+// DefaultToolArgumentRetries is the number of corrections per tool allowed with
+// ToolArgumentsFeedback when RunConfig.MaxToolArgumentRetries is not set
+const DefaultToolArgumentRetries = 3
+
+// maxToolArgumentRetries returns the number of times per tool that invalid arguments are reported
+// back to the model before the run fails
+func maxToolArgumentRetries(config RunConfig) int {
+	if config.MaxToolArgumentRetries == 0 && config.ToolArgumentValidation == ToolArgumentsFeedback {
+		return DefaultToolArgumentRetries
+	}
+	return config.MaxToolArgumentRetries
+}
+
+// validateToolArguments validates the arguments of a tool call according to
+// RunConfig.ToolArgumentValidation. With ToolArgumentsFeedback, invalid arguments are returned as
+// a *tool.ArgumentError, to be reported back to the model like the ones returned by tools.
+func validateToolArguments(ctx context.Context, state *executionState, t tool.Tool, args string) error {
+	policy := state.config.ToolArgumentValidation
+	if policy == ToolArgumentsUnchecked {
+		return nil
+	}
+
+	_, err := handoff.ValidateJSON(args, handoff.JSONSchema(t.ParamsJSONSchema()))
+	if err == nil {
+		return nil
+	}
+
+	if span := tracing.GetActiveSpan(ctx); span != nil {
+		span.AddEvent("tool_argument_validation", map[string]any{
+			"tool_name": t.Name(),
+			"error":     err.Error(),
+		})
+	}
+	if policy == ToolArgumentsFail {
+		return fmt.Errorf("%w for tool %s: %v", ErrInvalidToolArguments, t.Name(), err)
+	}
+	return &tool.ArgumentError{Err: err}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// forecastTool is a tool with a schema, recording the arguments it is invoked with
+type forecastTool struct {
+	invocations []string
+}
+
+func (t *forecastTool) Name() string        { return "forecast" }
+func (t *forecastTool) Description() string { return "Forecasts the weather" }
+
+func (t *forecastTool) ParamsJSONSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"city": map[string]any{"type": "string"},
+			"days": map[string]any{"type": "integer"},
+		},
+		"required": []string{"city", "days"},
+	}
+}
+
+func (t *forecastTool) Invoke(ctx context.Context, paramsJSON string) (string, error) {
+	t.invocations = append(t.invocations, paramsJSON)
+	return "sunny", nil
+}
+
+func forecastRun(t *testing.T, validation ToolArgumentValidation) (*forecastTool, *FakeModel, *Result, error) {
+	t.Helper()
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("forecast", `{"city":"Tokyo","days":1.5}`)},
+		{GetFunctionToolCall("forecast", `{"city":"Tokyo","days":2}`)},
+		{GetTextMessage("Sunny for two days")},
+	})
+
+	forecast := &forecastTool{}
+	testAgent := agent.New("test", "test instructions")
+	testAgent.AddTool(forecast)
+
+	result, err := RunWithConfig(context.Background(), testAgent, "weather in Tokyo?", RunConfig{
+		ModelProvider:          fakeModel,
+		MaxTurns:               5,
+		ToolArgumentValidation: validation,
+	})
+	return forecast, fakeModel, result, err
+}
+
+func TestToolArgumentValidation(t *testing.T) {
+	t.Run("unchecked", func(t *testing.T) {
+		forecast, _, _, err := forecastRun(t, ToolArgumentsUnchecked)
+		require.NoError(t, err)
+		assert.Len(t, forecast.invocations, 2)
+	})
+
+	t.Run("feedback", func(t *testing.T) {
+		forecast, fakeModel, result, err := forecastRun(t, ToolArgumentsFeedback)
+		require.NoError(t, err)
+		assert.Equal(t, "Sunny for two days", result.FinalOutput)
+
+		// Only the corrected call reaches the tool
+		assert.Equal(t, []string{`{"city":"Tokyo","days":2}`}, forecast.invocations)
+		messages := fakeModel.Calls()[1].Messages
+		assert.Equal(t, "Error: invalid arguments for tool 'forecast': invalid type for field days: expected integer. Please fix the arguments and call the tool again.",
+			messages[len(messages)-1].Content)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		forecast, _, _, err := forecastRun(t, ToolArgumentsFail)
+		require.ErrorIs(t, err, ErrInvalidToolArguments)
+		assert.Contains(t, err.Error(), "invalid type for field days")
+		assert.Empty(t, forecast.invocations)
+	})
+}
+
+func TestToolArgumentFeedbackLimit(t *testing.T) {
+	fakeModel := NewFakeModel()
+	invalid := []model.Message{GetFunctionToolCall("forecast", `{"city":"Tokyo"}`)}
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{invalid, invalid, invalid})
+
+	forecast := &forecastTool{}
+	testAgent := agent.New("test", "test instructions")
+	testAgent.AddTool(forecast)
+
+	// The model gets one correction, then the run fails instead of looping until MaxTurns
+	_, err := RunWithConfig(context.Background(), testAgent, "weather in Tokyo?", RunConfig{
+		ModelProvider:          fakeModel,
+		MaxTurns:               10,
+		ToolArgumentValidation: ToolArgumentsFeedback,
+		MaxToolArgumentRetries: 1,
+	})
+	require.ErrorIs(t, err, ErrInvalidToolArguments)
+	assert.Contains(t, err.Error(), "missing required field")
+	assert.Len(t, fakeModel.Calls(), 2)
+	assert.Empty(t, forecast.invocations)
+}
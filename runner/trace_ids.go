@@ -0,0 +1,73 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"errors"
+
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// RunError is returned when a traced run fails, other than by reaching MaxTurns or being
+// cancelled, so the failure can be looked up in the tracing backend. errors.Is and errors.As
+// see through it to the error that stopped the run.
+type RunError struct {
+	// Err is the error that stopped the run
+	Err error
+
+	// TraceID is the ID of the run's trace
+	TraceID string
+
+	// SpanID is the ID of the run's agent_run span
+	SpanID string
+}
+
+func (e *RunError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error that stopped the run
+func (e *RunError) Unwrap() error {
+	return e.Err
+}
+
+// TraceIDFromError returns the trace and span IDs of the run that returned err, for error
+// reports such as "see trace X". They are empty for untraced runs and for errors returned
+// before the run started.
+func TraceIDFromError(err error) (traceID string, spanID string) {
+	var runErr *RunError
+	var cancelErr *RunCancelledError
+	var maxErr *MaxTurnsExceededError
+	switch {
+	case errors.As(err, &runErr):
+		return runErr.TraceID, runErr.SpanID
+	case errors.As(err, &cancelErr):
+		return cancelErr.TraceID, cancelErr.SpanID
+	case errors.As(err, &maxErr) && maxErr.Partial != nil:
+		return maxErr.Partial.TraceID, maxErr.Partial.SpanID
+	}
+	return "", ""
+}
+
+// spanIDs returns the trace and span IDs of a span, which are empty when tracing is disabled
+func spanIDs(span tracing.Span) (traceID string, spanID string) {
+	if span == nil {
+		return "", ""
+	}
+	sc := span.Context()
+	return sc.TraceID, sc.SpanID
+}
+
+// wrapRunError adds the IDs of the run's span to an error, unless the error already carries them
+func wrapRunError(err error, span tracing.Span) error {
+	traceID, spanID := spanIDs(span)
+	var runErr *RunError
+	var cancelErr *RunCancelledError
+	var maxErr *MaxTurnsExceededError
+	if traceID == "" || errors.As(err, &runErr) || errors.As(err, &cancelErr) || errors.As(err, &maxErr) {
+		return err
+	}
+	return &RunError{Err: err, TraceID: traceID, SpanID: spanID}
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// spanRecorder is a span processor that records ended spans
+type spanRecorder struct {
+	mu      sync.Mutex
+	spans   []*tracing.StandardSpan
+	flushes int
+}
+
+func (r *spanRecorder) OnStart(span *tracing.StandardSpan) {}
+
+func (r *spanRecorder) OnEnd(span *tracing.StandardSpan) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+func (r *spanRecorder) ForceFlush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.flushes++
+}
+
+func (r *spanRecorder) Shutdown(ctx context.Context) error { return nil }
+
+func (r *spanRecorder) byName(name string) []*tracing.StandardSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var spans []*tracing.StandardSpan
+	for _, span := range r.spans {
+		if span.Context().Name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// useSpanRecorder installs a standard tracer recording ended spans for the duration of the test
+func useSpanRecorder(t *testing.T) *spanRecorder {
+	recorder := &spanRecorder{}
+	originalTracer := tracing.GetTracer()
+	tracing.SetTracer(tracing.NewStandardTracer(recorder))
+	t.Cleanup(func() { tracing.SetTracer(originalTracer) })
+	return recorder
+}
+
+func TestRunsGroupedInTrace(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	fakeModel := NewFakeModel()
+	testAgent := agent.New("test", "test instructions")
+	config := RunConfig{Model: "gpt-4o", ModelProvider: fakeModel}
+
+	trace, ctx := tracing.StartTrace(context.Background(), "support workflow", tracing.WithGroupID("thread_1"))
+	_, err := RunWithConfig(ctx, testAgent, "first", config)
+	require.NoError(t, err)
+	_, err = RunWithConfig(ctx, testAgent, "second", config)
+	require.NoError(t, err)
+	trace.End()
+
+	runs := recorder.byName("agent_run")
+	require.Len(t, runs, 2)
+	for _, run := range runs {
+		assert.Equal(t, trace.TraceID(), run.Context().TraceID)
+		assert.Equal(t, trace.TraceID(), run.Context().ParentSpanID)
+	}
+
+	roots := recorder.byName("support workflow")
+	require.Len(t, roots, 1)
+	assert.Equal(t, "thread_1", roots[0].Context().Attributes["group_id"])
+}
+
+func TestRunCreatesTrace(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("done")})
+	testAgent := agent.New("test", "test instructions")
+
+	_, err := RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		Model:         "gpt-4o",
+		ModelProvider: fakeModel,
+		WorkflowName:  "my workflow",
+		GroupID:       "conversation_1",
+		TraceMetadata: map[string]any{"user": "u1"},
+	})
+	require.NoError(t, err)
+
+	roots := recorder.byName("my workflow")
+	require.Len(t, roots, 1)
+	attributes := roots[0].Context().Attributes
+	assert.Equal(t, "trace", attributes["span_type"])
+	assert.Equal(t, "conversation_1", attributes["group_id"])
+	assert.Equal(t, map[string]any{"user": "u1"}, attributes["metadata"])
+
+	runs := recorder.byName("agent_run")
+	require.Len(t, runs, 1)
+	assert.Equal(t, roots[0].Context().TraceID, runs[0].Context().TraceID)
+}
+
+func TestTraceRedaction(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	fakeModel := NewFakeModel()
+	fakeModel.SetNextOutput([]model.Message{GetTextMessage("secret answer")})
+	testAgent := agent.New("test", "secret instructions")
+
+	_, err := RunWithConfig(context.Background(), testAgent, "secret question", RunConfig{
+		Model:          "gpt-4o",
+		ModelProvider:  fakeModel,
+		TraceRedaction: tracing.ExcludeSensitiveData(),
+	})
+	require.NoError(t, err)
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	require.NotEmpty(t, recorder.spans)
+	for _, span := range recorder.spans {
+		for key, value := range span.Context().Attributes {
+			if s, ok := value.(string); ok {
+				assert.NotContains(t, s, "secret", "attribute %q of span %q leaks sensitive data", key, span.Context().Name)
+			}
+		}
+	}
+}
+
+func TestRunFlushesOwnTrace(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	fakeModel := NewFakeModel()
+	testAgent := agent.New("test", "test instructions")
+	config := RunConfig{Model: "gpt-4o", ModelProvider: fakeModel}
+
+	_, err := RunWithConfig(context.Background(), testAgent, "hello", config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, recorder.flushes)
+
+	// Runs inside a caller's trace leave flushing to the caller
+	trace, ctx := tracing.StartTrace(context.Background(), "workflow")
+	_, err = RunWithConfig(ctx, testAgent, "hello", config)
+	require.NoError(t, err)
+	trace.End()
+	assert.Equal(t, 1, recorder.flushes)
+
+	config.SkipTraceFlush = true
+	_, err = RunWithConfig(context.Background(), testAgent, "hello", config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, recorder.flushes)
+}
+
+func TestTraceIDs(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddTurn(GetTextMessage("done"))
+	modelErr := errors.New("model unavailable")
+	fakeModel.AddError(modelErr)
+	testAgent := agent.New("test", "test instructions")
+	config := RunConfig{ModelProvider: fakeModel, MaxTurns: 1}
+
+	result, err := RunWithConfig(context.Background(), testAgent, "first", config)
+	require.NoError(t, err)
+	run := recorder.byName("agent_run")[0].Context()
+	assert.NotEmpty(t, result.TraceID)
+	assert.Equal(t, run.TraceID, result.TraceID)
+	assert.Equal(t, run.SpanID, result.SpanID)
+
+	// Errors carry the IDs of the failed run
+	_, err = RunWithConfig(context.Background(), testAgent, "second", config)
+	require.ErrorIs(t, err, modelErr)
+	var runErr *RunError
+	require.ErrorAs(t, err, &runErr)
+	run = recorder.byName("agent_run")[1].Context()
+	traceID, spanID := TraceIDFromError(err)
+	assert.Equal(t, run.TraceID, traceID)
+	assert.Equal(t, run.SpanID, spanID)
+	assert.NotEqual(t, result.TraceID, traceID)
+
+	// Errors returned before the run started have none
+	traceID, _ = TraceIDFromError(errors.New("unrelated"))
+	assert.Empty(t, traceID)
+}
+
+func TestTracingDisabled(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("lookup", `{}`)},
+		{GetTextMessage("done")},
+		{GetTextMessage("done again")},
+	})
+	testAgent := agent.New("test", "test instructions")
+	testAgent.AddTool(NewFunctionTool("lookup", "secret"))
+	config := RunConfig{ModelProvider: fakeModel, TracingDisabled: true}
+
+	result, err := RunWithConfig(context.Background(), testAgent, "confidential", config)
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Empty(t, result.TraceID)
+	assert.Empty(t, recorder.spans)
+	assert.Zero(t, recorder.flushes)
+
+	// The global kill switch disables the spans of every run
+	tracing.SetTracingDisabled(true)
+	t.Cleanup(func() { tracing.SetTracingDisabled(false) })
+	result, err = RunWithConfig(context.Background(), testAgent, "confidential", RunConfig{ModelProvider: fakeModel})
+	require.NoError(t, err)
+	assert.Empty(t, result.TraceID)
+	assert.Empty(t, recorder.spans)
+}
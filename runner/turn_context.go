@@ -0,0 +1,66 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// ContextProvider injects messages into a single turn, such as the current time, retrieved
+// documents or the state of an external system.
+// ProvideContext is called before every model call with the agent about to run and the messages
+// about to be sent, which must not be modified. The returned messages are appended to that turn
+// only: they are not kept in the working history nor in Result.History.
+type ContextProvider interface {
+	ProvideContext(ctx context.Context, a *agent.Agent, messages []model.Message) ([]model.Message, error)
+}
+
+// ContextProviderFunc adapts a function to a ContextProvider
+type ContextProviderFunc func(ctx context.Context, a *agent.Agent, messages []model.Message) ([]model.Message, error)
+
+// ProvideContext calls f(ctx, a, messages)
+func (f ContextProviderFunc) ProvideContext(ctx context.Context, a *agent.Agent, messages []model.Message) ([]model.Message, error) {
+	return f(ctx, a, messages)
+}
+
+// SystemContext returns a ContextProvider adding the text returned by fn as a system message.
+// Nothing is added when fn returns an empty string.
+func SystemContext(fn func(ctx context.Context, a *agent.Agent) (string, error)) ContextProvider {
+	return ContextProviderFunc(func(ctx context.Context, a *agent.Agent, messages []model.Message) ([]model.Message, error) {
+		text, err := fn(ctx, a)
+		if err != nil || text == "" {
+			return nil, err
+		}
+		return []model.Message{{Role: "system", Content: text}}, nil
+	})
+}
+
+// injectContext appends the messages of the run's ContextProviders to the messages of this turn.
+// The result never shares its backing array with messages.
+func injectContext(ctx context.Context, state *executionState, messages []model.Message) ([]model.Message, error) {
+	if len(state.config.ContextProviders) == 0 {
+		return messages, nil
+	}
+
+	var injected []model.Message
+	for _, provider := range state.config.ContextProviders {
+		extra, err := provider.ProvideContext(ctx, state.currentAgent.Origin(), messages)
+		if err != nil {
+			return nil, fmt.Errorf("context provider failed: %w", err)
+		}
+		injected = append(injected, extra...)
+	}
+	if len(injected) == 0 {
+		return messages, nil
+	}
+
+	turn := make([]model.Message, 0, len(messages)+len(injected))
+	turn = append(turn, messages...)
+	return append(turn, injected...), nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestRunWithContextProviders(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("lookup", `{}`)},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("lookup", "found"))
+
+	turn := 0
+	clock := SystemContext(func(ctx context.Context, a *agent.Agent) (string, error) {
+		turn++
+		if turn == 2 {
+			return "", nil
+		}
+		return "Current time: 12:00", nil
+	})
+	docs := ContextProviderFunc(func(ctx context.Context, a *agent.Agent, messages []model.Message) ([]model.Message, error) {
+		assert.Equal(t, "test", a.Name)
+		return []model.Message{{Role: "user", Content: "Documents for: " + messages[1].Content}}, nil
+	})
+
+	hooks := &llmHooksRecorder{}
+
+	result, err := RunWithConfig(context.Background(), testAgent, "question", RunConfig{
+		ModelProvider:    fakeModel,
+		MaxTurns:         5,
+		ContextProviders: []ContextProvider{clock, docs},
+		LLMHooks:         hooks,
+	})
+	require.NoError(t, err)
+
+	calls := fakeModel.Calls()
+	require.Len(t, calls, 2)
+
+	// OnLLMStart sees the messages sent to the provider, injected ones included
+	require.Len(t, hooks.startMessages, 2)
+	assert.Equal(t, calls[0].Messages, hooks.startMessages[0])
+	assert.Equal(t, calls[1].Messages, hooks.startMessages[1])
+
+	// The first turn gets both injected messages after the history
+	first := calls[0].Messages
+	require.Len(t, first, 4)
+	assert.Equal(t, model.Message{Role: "system", Content: "Current time: 12:00"}, first[2])
+	assert.Equal(t, model.Message{Role: "user", Content: "Documents for: question"}, first[3])
+
+	// The second turn gets fresh context, not the messages injected in the first one
+	second := calls[1].Messages
+	require.Len(t, second, 5)
+	assert.Equal(t, "tool", second[3].Role)
+	assert.Equal(t, "Documents for: question", second[4].Content)
+
+	// Injected messages are not kept in the history
+	assert.Len(t, result.History, 4)
+}
+
+func TestRunWithFailingContextProvider(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddTurn(GetTextMessage("done"))
+	errUnavailable := errors.New("unavailable")
+
+	_, err := RunWithConfig(context.Background(), agent.New("test", "Test agent"), "question", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		ContextProviders: []ContextProvider{ContextProviderFunc(func(ctx context.Context, a *agent.Agent, messages []model.Message) ([]model.Message, error) {
+			return nil, errUnavailable
+		})},
+	})
+	require.ErrorIs(t, err, errUnavailable)
+	assert.Empty(t, fakeModel.Calls())
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// TurnEvent describes a turn of the agent loop to the RunConfig.OnTurnStart and
+// RunConfig.OnTurnEnd callbacks
+type TurnEvent struct {
+	// Step is the 1-based number of the turn
+	Step int
+
+	// Agent is the agent running the turn. At the end of a handoff turn, it is the agent that
+	// handed off.
+	Agent *agent.Agent
+
+	// NewMessages are the messages the turn added to the history (empty at the start of the turn)
+	NewMessages []model.Message
+
+	// Usage is the usage of the run so far
+	Usage Usage
+}
+
+// TurnCallback is called at the start or the end of a turn. Returning an error stops the run
+// with that error, so callbacks can also enforce custom loop policies.
+type TurnCallback func(ctx context.Context, event TurnEvent) error
+
+// callTurnStart calls RunConfig.OnTurnStart before a turn
+func callTurnStart(state *executionState) error {
+	if state.config.OnTurnStart == nil {
+		return nil
+	}
+	event := TurnEvent{
+		Step:  state.stepCounter + 1,
+		Agent: state.currentAgent.Origin(),
+		Usage: state.usage,
+	}
+	if err := state.config.OnTurnStart(state.ctx, event); err != nil {
+		return fmt.Errorf("error in OnTurnStart callback: %w", err)
+	}
+	return nil
+}
+
+// callTurnEnd calls RunConfig.OnTurnEnd after a turn of the given agent, which added the
+// messages of the result history from index start
+func callTurnEnd(state *executionState, a *agent.Agent, start int) error {
+	if state.config.OnTurnEnd == nil {
+		return nil
+	}
+	event := TurnEvent{
+		Step:        state.stepCounter + 1,
+		Agent:       a.Origin(),
+		NewMessages: append([]model.Message(nil), state.resultMessages[start:]...),
+		Usage:       state.usage,
+	}
+	if err := state.config.OnTurnEnd(state.ctx, event); err != nil {
+		return fmt.Errorf("error in OnTurnEnd callback: %w", err)
+	}
+	return nil
+}
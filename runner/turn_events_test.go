@@ -0,0 +1,85 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestTurnCallbacks(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("foo", "{}")},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("foo", "result"))
+
+	var starts, ends []TurnEvent
+	result, err := RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		OnTurnStart: func(ctx context.Context, event TurnEvent) error {
+			starts = append(starts, event)
+			return nil
+		},
+		OnTurnEnd: func(ctx context.Context, event TurnEvent) error {
+			ends = append(ends, event)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, starts, 2)
+	require.Len(t, ends, 2)
+	assert.Equal(t, 1, starts[0].Step)
+	assert.Equal(t, 2, starts[1].Step)
+	assert.Same(t, testAgent, starts[0].Agent)
+	assert.Empty(t, starts[0].NewMessages)
+	assert.Zero(t, starts[0].Usage.Requests)
+
+	// The first turn added the tool call and its output, the second the final answer
+	assert.Equal(t, 1, ends[0].Step)
+	require.Len(t, ends[0].NewMessages, 2)
+	assert.Equal(t, "tool", ends[0].NewMessages[1].Role)
+	assert.Equal(t, 1, ends[0].Usage.Requests)
+	assert.Equal(t, 2, ends[1].Step)
+	assert.Equal(t, "done", ends[1].NewMessages[0].Content)
+	assert.Equal(t, result.Usage, ends[1].Usage)
+}
+
+func TestTurnCallbackStopsRun(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("foo", "{}")},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("foo", "result"))
+
+	errBudget := errors.New("over budget")
+	_, err := RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		OnTurnStart: func(ctx context.Context, event TurnEvent) error {
+			if event.Usage.Requests >= 1 {
+				return errBudget
+			}
+			return nil
+		},
+	})
+	assert.ErrorIs(t, err, errBudget)
+	assert.Len(t, fakeModel.Calls(), 1)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// applyTurnGuardrails checks an assistant message against the agent's turn guardrails
+func applyTurnGuardrails(ctx context.Context, a *agent.Agent, message model.Message) error {
+	for _, g := range a.TurnGuardrails {
+		result, err := g.Check(ctx, message)
+		if err != nil {
+			return fmt.Errorf("turn guardrail error: %w", err)
+		}
+
+		if !result.Allowed {
+			recordGuardrailTrip(ctx, g.Name(), result.Message)
+			return fmt.Errorf("%w: %s", ErrGuardrailTripwire, result.Message)
+		}
+	}
+
+	return nil
+}
+
+// applyToolInputGuardrails checks a tool call against the agent's tool input guardrails.
+// It returns true and the message to send to the model if a guardrail rejected the call.
+func applyToolInputGuardrails(ctx context.Context, a *agent.Agent, toolName string, arguments string) (bool, string, error) {
+	for _, g := range a.ToolInputGuardrails {
+		result, err := g.Check(ctx, toolName, arguments)
+		if err != nil {
+			return false, "", fmt.Errorf("tool input guardrail error: %w", err)
+		}
+
+		if !result.Allowed {
+			recordGuardrailTrip(ctx, g.Name(), result.Message)
+			if result.Reject {
+				return true, result.Message, nil
+			}
+			return false, "", fmt.Errorf("%w: %s", ErrGuardrailTripwire, result.Message)
+		}
+	}
+
+	return false, "", nil
+}
+
+// applyToolOutputGuardrails checks a tool output against the agent's tool output guardrails.
+// It returns the output to send to the model, and true if a guardrail rejected the output.
+func applyToolOutputGuardrails(ctx context.Context, a *agent.Agent, toolName string, arguments string, output string) (string, bool, error) {
+	for _, g := range a.ToolOutputGuardrails {
+		result, err := g.Check(ctx, toolName, arguments, output)
+		if err != nil {
+			return "", false, fmt.Errorf("tool output guardrail error: %w", err)
+		}
+
+		if !result.Allowed {
+			recordGuardrailTrip(ctx, g.Name(), result.Message)
+			if result.Reject {
+				return result.Message, true, nil
+			}
+			return "", false, fmt.Errorf("%w: %s", ErrGuardrailTripwire, result.Message)
+		}
+
+		if result.ModifiedOutput != "" {
+			output = result.ModifiedOutput
+		}
+	}
+
+	return output, false, nil
+}
+
+// recordGuardrailTrip records a tripped guardrail on the active span
+func recordGuardrailTrip(ctx context.Context, name string, message string) {
+	if span := tracing.GetActiveSpan(ctx); span != nil {
+		span.SetAttribute("guardrail_triggered", true)
+		span.SetAttribute("guardrail_name", name)
+		span.SetAttribute("guardrail_message", message)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/guardrail"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func newToolGuardrailAgent() (*agent.Agent, *FakeModel) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("delete_file", `{"a": "/etc/passwd"}`)},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("delete_file", "deleted"))
+	return testAgent, fakeModel
+}
+
+func TestToolInputGuardrail(t *testing.T) {
+	checkPath := func(reject bool) guardrail.ToolInputGuardrail {
+		return guardrail.NewToolInputGuardrail("paths", "Blocks system paths",
+			func(ctx context.Context, toolName string, arguments string) (guardrail.ToolGuardrailResult, error) {
+				if strings.Contains(arguments, "/etc") {
+					return guardrail.ToolGuardrailResult{Message: "system paths are off limits", Reject: reject}, nil
+				}
+				return guardrail.ToolGuardrailResult{Allowed: true}, nil
+			})
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		testAgent, fakeModel := newToolGuardrailAgent()
+		testAgent.AddToolInputGuardrail(checkPath(true))
+
+		result, err := RunWithConfig(context.Background(), testAgent, "clean up", RunConfig{ModelProvider: fakeModel, MaxTurns: 3})
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+		assert.Equal(t, "system paths are off limits", result.History[2].Content)
+	})
+
+	t.Run("tripwire", func(t *testing.T) {
+		testAgent, fakeModel := newToolGuardrailAgent()
+		testAgent.AddToolInputGuardrail(checkPath(false))
+
+		_, err := RunWithConfig(context.Background(), testAgent, "clean up", RunConfig{ModelProvider: fakeModel, MaxTurns: 3})
+		assert.ErrorIs(t, err, ErrGuardrailTripwire)
+		assert.Contains(t, err.Error(), "system paths are off limits")
+	})
+}
+
+func TestToolOutputGuardrail(t *testing.T) {
+	testAgent, fakeModel := newToolGuardrailAgent()
+	testAgent.AddToolOutputGuardrail(guardrail.NewToolOutputGuardrail("redact", "Redacts tool output",
+		func(ctx context.Context, toolName string, arguments string, output string) (guardrail.ToolGuardrailResult, error) {
+			return guardrail.ToolGuardrailResult{Allowed: true, ModifiedOutput: toolName + ": [redacted]"}, nil
+		}))
+
+	result, err := RunWithConfig(context.Background(), testAgent, "clean up", RunConfig{ModelProvider: fakeModel, MaxTurns: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "delete_file: [redacted]", result.History[2].Content)
+}
+
+func TestTurnGuardrail(t *testing.T) {
+	var checked []model.Message
+	testAgent, fakeModel := newToolGuardrailAgent()
+	testAgent.AddTurnGuardrail(guardrail.NewTurnGuardrail("no deletes", "Blocks file deletion",
+		func(ctx context.Context, message model.Message) (guardrail.TurnGuardrailResult, error) {
+			checked = append(checked, message)
+			for _, call := range message.ToolCalls {
+				if call.Function.Name == "delete_file" {
+					return guardrail.TurnGuardrailResult{Message: "deleting files is not allowed"}, nil
+				}
+			}
+			return guardrail.TurnGuardrailResult{Allowed: true}, nil
+		}))
+
+	_, err := RunWithConfig(context.Background(), testAgent, "clean up", RunConfig{ModelProvider: fakeModel, MaxTurns: 3})
+	assert.ErrorIs(t, err, ErrGuardrailTripwire)
+	require.Len(t, checked, 1)
+	assert.Len(t, checked[0].ToolCalls, 1)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// UnknownToolPolicy is how the runner answers a call of a tool the agent does not have
+type UnknownToolPolicy string
+
+const (
+	// UnknownToolCorrect answers the call with an error listing the agent's tools, so the model
+	// can call a valid one. It is the default.
+	UnknownToolCorrect UnknownToolPolicy = "correct"
+
+	// UnknownToolError fails the run with a *ModelBehaviorError
+	UnknownToolError UnknownToolPolicy = "error"
+
+	// UnknownToolFallback answers the call with RunConfig.UnknownToolHandler
+	UnknownToolFallback UnknownToolPolicy = "fallback"
+)
+
+// UnknownToolHandler answers a call of an unknown tool, given the names of the tools and handoffs
+// the agent offers. Its result is sent to the model as the output of the call; an error fails the run.
+type UnknownToolHandler func(ctx context.Context, call model.ToolCall, available []string) (string, error)
+
+// ModelBehaviorError reports an unexpected action of the model, such as a call of a tool the agent
+// does not have. Every one is kept in Result.ModelBehaviorErrors and listed by Result.Items; with
+// UnknownToolError, the run fails with it.
+type ModelBehaviorError struct {
+	// Step is the turn in which the model made the call
+	Step int `json:"step"`
+
+	// AgentName is the name of the agent that made the call
+	AgentName string `json:"agent_name"`
+
+	// ToolName is the name of the unknown tool
+	ToolName string `json:"tool_name"`
+
+	// ToolCallID is the ID of the call
+	ToolCallID string `json:"tool_call_id"`
+
+	// Message describes the error
+	Message string `json:"message"`
+}
+
+// Error returns the message of the error
+func (e *ModelBehaviorError) Error() string {
+	return "model behavior error: " + e.Message
+}
+
+// handleUnknownTool records a call of an unknown tool and answers it according to
+// RunConfig.UnknownToolPolicy
+func handleUnknownTool(ctx context.Context, state *executionState, a *agent.Agent, call model.ToolCall) (string, error) {
+	behaviorErr := ModelBehaviorError{
+		Step:       state.stepCounter + 1,
+		AgentName:  a.Name,
+		ToolName:   call.Function.Name,
+		ToolCallID: call.ID,
+		Message:    fmt.Sprintf("agent %s called tool %q, which does not exist", a.Name, call.Function.Name),
+	}
+	state.modelBehaviorErrors = append(state.modelBehaviorErrors, behaviorErr)
+
+	policy := state.config.UnknownToolPolicy
+	if span := tracing.GetActiveSpan(ctx); span != nil {
+		span.AddEvent("unknown_tool", map[string]any{
+			"tool_name":    call.Function.Name,
+			"tool_call_id": call.ID,
+			"policy":       string(policy),
+		})
+	}
+
+	var available []string
+	for _, definition := range buildToolDefinitions(a, state.previousAgent() != nil) {
+		available = append(available, definition.Name)
+	}
+
+	switch {
+	case policy == UnknownToolError:
+		return "", &behaviorErr
+	case policy == UnknownToolFallback && state.config.UnknownToolHandler != nil:
+		output, err := state.config.UnknownToolHandler(ctx, call, available)
+		if err != nil {
+			return "", fmt.Errorf("unknown tool handler: %w", err)
+		}
+		return output, nil
+	}
+
+	if len(available) == 0 {
+		return fmt.Sprintf("Error: Tool '%s' not found. No tools are available; answer without calling a tool.", call.Function.Name), nil
+	}
+	return fmt.Sprintf("Error: Tool '%s' not found. Available tools: %s. Call one of these tools instead.",
+		call.Function.Name, strings.Join(available, ", ")), nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/items"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func unknownToolRun(t *testing.T, config RunConfig) (*Result, *FakeModel, error) {
+	t.Helper()
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("search", `{"q":"refunds"}`)},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "test instructions")
+	testAgent.AddTool(NewFunctionTool("lookup", "found"))
+	testAgent.AddTool(NewFunctionTool("cancel", "cancelled"))
+
+	config.ModelProvider = fakeModel
+	config.MaxTurns = 5
+	result, err := RunWithConfig(context.Background(), testAgent, "find refunds", config)
+	return result, fakeModel, err
+}
+
+func TestUnknownToolCorrect(t *testing.T) {
+	result, fakeModel, err := unknownToolRun(t, RunConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	// The model is told which tools it can call
+	messages := fakeModel.Calls()[1].Messages
+	assert.Equal(t, "Error: Tool 'search' not found. Available tools: lookup, cancel. Call one of these tools instead.",
+		messages[len(messages)-1].Content)
+
+	require.Len(t, result.ModelBehaviorErrors, 1)
+	behaviorErr := result.ModelBehaviorErrors[0]
+	assert.Equal(t, 1, behaviorErr.Step)
+	assert.Equal(t, "test", behaviorErr.AgentName)
+	assert.Equal(t, "search", behaviorErr.ToolName)
+	assert.Equal(t, "call_search", behaviorErr.ToolCallID)
+
+	// The error follows the output of the call in the items
+	list := result.Items()
+	for i, item := range list {
+		if _, ok := item.(*items.FunctionCallOutput); ok {
+			require.Greater(t, len(list), i+1)
+			assert.Equal(t, &items.ModelBehaviorError{CallID: "call_search", Message: behaviorErr.Message}, list[i+1])
+		}
+	}
+}
+
+func TestUnknownToolError(t *testing.T) {
+	_, _, err := unknownToolRun(t, RunConfig{UnknownToolPolicy: UnknownToolError})
+
+	var behaviorErr *ModelBehaviorError
+	require.ErrorAs(t, err, &behaviorErr)
+	assert.Equal(t, "search", behaviorErr.ToolName)
+	assert.Equal(t, "call_search", behaviorErr.ToolCallID)
+}
+
+func TestUnknownToolFallback(t *testing.T) {
+	var available []string
+	result, fakeModel, err := unknownToolRun(t, RunConfig{
+		UnknownToolPolicy: UnknownToolFallback,
+		UnknownToolHandler: func(ctx context.Context, call model.ToolCall, tools []string) (string, error) {
+			available = tools
+			return "search is retired, use lookup", nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lookup", "cancel"}, available)
+	assert.Len(t, result.ModelBehaviorErrors, 1)
+
+	messages := fakeModel.Calls()[1].Messages
+	assert.Equal(t, "search is retired, use lookup", messages[len(messages)-1].Content)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import "time"
+
+// StepUsage represents the token usage of a single model call
+type StepUsage struct {
+	// Step is the 1-based turn number in which the call was made
+	Step int `json:"step"`
+
+	// AgentName is the name of the agent that made the call
+	AgentName string `json:"agent_name"`
+
+	// Model is the name of the model that was called
+	Model string `json:"model"`
+
+	// Usage is the token usage of the call
+	Usage Usage `json:"usage"`
+
+	// DurationMS is the duration of the call in milliseconds
+	DurationMS int64 `json:"duration_ms,omitempty"`
+}
+
+// UsageReport is a detailed breakdown of the token usage of a run
+type UsageReport struct {
+	// Steps contains one entry per model call, in call order
+	Steps []StepUsage `json:"steps"`
+
+	// ByAgent aggregates usage per agent name
+	ByAgent map[string]Usage `json:"by_agent"`
+
+	// ByModel aggregates usage per model name
+	ByModel map[string]Usage `json:"by_model"`
+}
+
+// newUsageReport creates an empty usage report
+func newUsageReport() UsageReport {
+	return UsageReport{
+		Steps:   []StepUsage{},
+		ByAgent: make(map[string]Usage),
+		ByModel: make(map[string]Usage),
+	}
+}
+
+// record adds the usage of a model call to the report
+func (r *UsageReport) record(step int, agentName string, modelName string, usage Usage, duration time.Duration) {
+	r.Steps = append(r.Steps, StepUsage{
+		Step:       step,
+		AgentName:  agentName,
+		Model:      modelName,
+		Usage:      usage,
+		DurationMS: duration.Milliseconds(),
+	})
+
+	agentUsage := r.ByAgent[agentName]
+	accumulateUsage(&agentUsage, usage)
+	r.ByAgent[agentName] = agentUsage
+
+	modelUsage := r.ByModel[modelName]
+	accumulateUsage(&modelUsage, usage)
+	r.ByModel[modelName] = modelUsage
+}
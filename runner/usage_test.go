@@ -0,0 +1,111 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/pricing"
+)
+
+func TestUsageReport(t *testing.T) {
+	ctx := context.Background()
+
+	fakeModel := NewFakeModel()
+
+	agent1 := agent.New("agent1", "agent1 instructions")
+	agent1.SetModel("gpt-4o-mini")
+	agent2 := agent.New("agent2", "agent2 instructions")
+	agent2.AddTool(NewFunctionTool("foo", "result"))
+
+	handoff1 := handoff.NewHandoff(agent1, "Handoff to agent1")
+	agent2.AddHandoff(handoff1)
+
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("foo", `{"a":"b"}`)},
+		{model.Message{
+			Role: "assistant",
+			ToolCalls: []model.ToolCall{{
+				ID:       "handoff_call",
+				Type:     "function",
+				Function: model.FunctionCall{Name: handoff1.ToolName(), Arguments: "{}"},
+			}},
+		}},
+		{GetTextMessage("done")},
+	})
+
+	config := RunConfig{
+		Model:         "gpt-4o",
+		ModelProvider: fakeModel,
+		MaxTurns:      10,
+	}
+
+	result, err := RunWithConfig(ctx, agent2, "user_message", config)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Usage.Requests)
+	assert.Equal(t, 450, result.Usage.TotalTokens)
+
+	report := result.UsageReport
+	require.Len(t, report.Steps, 3)
+	assert.Equal(t, 1, report.Steps[0].Step)
+	assert.Equal(t, "agent2", report.Steps[0].AgentName)
+	assert.Equal(t, "gpt-4o", report.Steps[0].Model)
+	assert.Equal(t, "agent1", report.Steps[2].AgentName)
+	assert.Equal(t, "gpt-4o-mini", report.Steps[2].Model)
+
+	assert.Equal(t, 2, report.ByAgent["agent2"].Requests)
+	assert.Equal(t, 300, report.ByAgent["agent2"].TotalTokens)
+	assert.Equal(t, 1, report.ByAgent["agent1"].Requests)
+	assert.Equal(t, 150, report.ByModel["gpt-4o-mini"].TotalTokens)
+}
+
+func TestUsageCost(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.SetUsage(model.Usage{PromptTokens: 1_000_000, CachedPromptTokens: 400_000, CompletionTokens: 100_000, TotalTokens: 1_100_000})
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("foo", "{}")},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "Test agent")
+	testAgent.AddTool(NewFunctionTool("foo", "result"))
+
+	recorder := useSpanRecorder(t)
+	result, err := RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		Model:         "my-model-2025-01-01",
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+		Pricing:       pricing.Table{"my-model": {Input: 1, CachedInput: 0.5, Output: 10}},
+	})
+	require.NoError(t, err)
+
+	// 600k uncached and 400k cached prompt tokens, and 100k completion tokens per call
+	assert.InDelta(t, 1.8, result.UsageReport.Steps[0].Usage.Cost, 1e-9)
+	assert.InDelta(t, 3.6, result.Usage.Cost, 1e-9)
+	assert.InDelta(t, 3.6, result.UsageReport.ByAgent["test"].Cost, 1e-9)
+	assert.InDelta(t, 3.6, result.UsageReport.ByModel["my-model-2025-01-01"].Cost, 1e-9)
+
+	assert.InDelta(t, 1.8, recorder.byName("llm_call")[0].Context().Attributes["cost"], 1e-9)
+	assert.InDelta(t, 3.6, recorder.byName("agent_run")[0].Context().Attributes["total_cost"], 1e-9)
+
+	// Models without a price cost nothing
+	fakeModel = NewFakeModel()
+	fakeModel.AddTurn(GetTextMessage("done"))
+	result, err = RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		Model:         "unknown-model",
+		ModelProvider: fakeModel,
+		MaxTurns:      5,
+	})
+	require.NoError(t, err)
+	assert.Zero(t, result.Usage.Cost)
+}
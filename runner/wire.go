@@ -0,0 +1,135 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// WireFormatVersion is the version of the JSON wire format of Result
+const WireFormatVersion = "v1"
+
+//go:embed schema/v1.schema.json
+var wireSchema []byte
+
+// JSONSchema returns the JSON Schema describing the wire format of Result produced by json.Marshal.
+// The schema also defines the message, usage and tool progress event objects under $defs, as well
+// as run_item, the JSON of the conversation items of Result.Items, and stream_event, the
+// server-sent events of a streamed agenthttp run.
+func JSONSchema() []byte {
+	schema := make([]byte, len(wireSchema))
+	copy(schema, wireSchema)
+	return schema
+}
+
+// wireResult is the JSON representation of Result
+type wireResult struct {
+	SchemaVersion    string      `json:"schema_version"`
+	RunID            string      `json:"run_id,omitempty"`
+	TraceID          string      `json:"trace_id,omitempty"`
+	SpanID           string      `json:"span_id,omitempty"`
+	FinalOutput      string      `json:"final_output"`
+	StructuredOutput any         `json:"structured_output,omitempty"`
+	LastAgent        string      `json:"last_agent"`
+	History          []Message   `json:"history"`
+	Usage            Usage       `json:"usage"`
+	UsageReport      UsageReport `json:"usage_report"`
+	Cached           bool        `json:"cached,omitempty"`
+	LastResponseID   string      `json:"last_response_id,omitempty"`
+
+	InputGuardrailResults  []GuardrailResult    `json:"input_guardrail_results,omitempty"`
+	OutputGuardrailResults []GuardrailResult    `json:"output_guardrail_results,omitempty"`
+	Handoffs               []HandoffRecord      `json:"handoffs,omitempty"`
+	HandoffDecisions       []HandoffDecision    `json:"handoff_decisions,omitempty"`
+	ModelBehaviorErrors    []ModelBehaviorError `json:"model_behavior_errors,omitempty"`
+	StartedAt              *time.Time           `json:"started_at,omitempty"`
+	DurationMS             int64                `json:"duration_ms,omitempty"`
+	ContextRecoveries      []ContextRecovery    `json:"context_recoveries,omitempty"`
+	StopReason             string               `json:"stop_reason,omitempty"`
+}
+
+// MarshalJSON encodes the result in the versioned wire format described by JSONSchema
+func (r Result) MarshalJSON() ([]byte, error) {
+	wire := wireResult{
+		SchemaVersion:    WireFormatVersion,
+		RunID:            r.RunID,
+		TraceID:          r.TraceID,
+		SpanID:           r.SpanID,
+		FinalOutput:      r.FinalOutput,
+		StructuredOutput: r.StructuredOutput,
+		History:          r.History,
+		Usage:            r.Usage,
+		UsageReport:      r.UsageReport,
+		Cached:           r.Cached,
+		LastResponseID:   r.LastResponseID,
+
+		InputGuardrailResults:  r.InputGuardrailResults,
+		OutputGuardrailResults: r.OutputGuardrailResults,
+		Handoffs:               r.Handoffs,
+		HandoffDecisions:       r.HandoffDecisions,
+		ModelBehaviorErrors:    r.ModelBehaviorErrors,
+		DurationMS:             r.Duration.Milliseconds(),
+		ContextRecoveries:      r.ContextRecoveries,
+		StopReason:             r.StopReason,
+	}
+
+	if r.LastAgent != nil {
+		wire.LastAgent = r.LastAgent.Name
+	}
+	if !r.StartedAt.IsZero() {
+		wire.StartedAt = &r.StartedAt
+	}
+	if wire.History == nil {
+		wire.History = []Message{}
+	}
+	if wire.UsageReport.Steps == nil {
+		wire.UsageReport = newUsageReport()
+	}
+
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON decodes a result in the wire format.
+// LastAgent cannot be restored and is left nil; StructuredOutput is decoded into generic JSON values.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var wire wireResult
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	if wire.SchemaVersion != "" && wire.SchemaVersion != WireFormatVersion {
+		return fmt.Errorf("unsupported result schema version: %s", wire.SchemaVersion)
+	}
+
+	*r = Result{
+		RunID:            wire.RunID,
+		TraceID:          wire.TraceID,
+		SpanID:           wire.SpanID,
+		FinalOutput:      wire.FinalOutput,
+		StructuredOutput: wire.StructuredOutput,
+		History:          wire.History,
+		Usage:            wire.Usage,
+		UsageReport:      wire.UsageReport,
+		Cached:           wire.Cached,
+		LastResponseID:   wire.LastResponseID,
+
+		InputGuardrailResults:  wire.InputGuardrailResults,
+		OutputGuardrailResults: wire.OutputGuardrailResults,
+		Handoffs:               wire.Handoffs,
+		HandoffDecisions:       wire.HandoffDecisions,
+		ModelBehaviorErrors:    wire.ModelBehaviorErrors,
+		Duration:               time.Duration(wire.DurationMS) * time.Millisecond,
+		ContextRecoveries:      wire.ContextRecoveries,
+		StopReason:             wire.StopReason,
+	}
+	if wire.StartedAt != nil {
+		r.StartedAt = *wire.StartedAt
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/items"
+	"github.com/ryichk/ai-agents-sdk-go/jsonschema"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+func TestResultWireFormat(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetFunctionToolCall("foo", `{"a":"b"}`)},
+		{GetTextMessage("done")},
+	})
+
+	testAgent := agent.New("test", "test instructions")
+	testAgent.AddTool(NewFunctionTool("foo", "result"))
+
+	result, err := RunWithConfig(context.Background(), testAgent, "hello", RunConfig{
+		Model:         "gpt-4o",
+		ModelProvider: fakeModel,
+	})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(JSONSchema(), &schema))
+	for _, key := range schema["required"].([]any) {
+		assert.Contains(t, decoded, key)
+	}
+	assert.Equal(t, WireFormatVersion, decoded["schema_version"])
+	assert.Equal(t, "test", decoded["last_agent"])
+
+	history := decoded["history"].([]any)
+	toolCall := history[1].(map[string]any)["tool_calls"].([]any)[0].(map[string]any)
+	assert.Equal(t, "foo", toolCall["function"].(map[string]any)["name"])
+
+	// Round trip
+	var roundTrip Result
+	require.NoError(t, json.Unmarshal(data, &roundTrip))
+	assert.Equal(t, result.FinalOutput, roundTrip.FinalOutput)
+	require.Len(t, roundTrip.History, len(result.History))
+	assert.Equal(t, result.History[1], roundTrip.History[1])
+	assert.Equal(t, result.Usage, roundTrip.Usage)
+	assert.Nil(t, roundTrip.LastAgent)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"schema_version":"v0"}`), &roundTrip))
+}
+
+// TestJSONSchemaInSync checks that the schema documents exactly the JSON fields of the Go types
+func TestJSONSchemaInSync(t *testing.T) {
+	var schema struct {
+		Defs map[string]struct {
+			Properties map[string]any `json:"properties"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(JSONSchema(), &schema))
+
+	types := map[string]reflect.Type{
+		"message":             reflect.TypeOf(Message{}),
+		"content_part":        reflect.TypeOf(model.ContentPart{}),
+		"tool_call":           reflect.TypeOf(model.ToolCall{}),
+		"usage":               reflect.TypeOf(Usage{}),
+		"step_usage":          reflect.TypeOf(StepUsage{}),
+		"usage_report":        reflect.TypeOf(UsageReport{}),
+		"guardrail_result":    reflect.TypeOf(GuardrailResult{}),
+		"handoff_record":      reflect.TypeOf(HandoffRecord{}),
+		"context_recovery":    reflect.TypeOf(ContextRecovery{}),
+		"tool_progress_event": reflect.TypeOf(tool.ProgressEvent{}),
+	}
+
+	for name, typ := range types {
+		def, ok := schema.Defs[name]
+		require.True(t, ok, "schema is missing definition %s", name)
+
+		var fields, properties []string
+		for i := 0; i < typ.NumField(); i++ {
+			fields = append(fields, strings.Split(typ.Field(i).Tag.Get("json"), ",")[0])
+		}
+		for property := range def.Properties {
+			properties = append(properties, property)
+		}
+		sort.Strings(fields)
+		sort.Strings(properties)
+
+		assert.Equal(t, fields, properties, "schema definition %s is out of sync with %s", name, typ)
+	}
+}
+
+// schemaDefinition returns a schema validating values against one of the wire schema's $defs
+func schemaDefinition(t *testing.T, name string) map[string]any {
+	t.Helper()
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(JSONSchema(), &schema))
+	return map[string]any{"$ref": "#/$defs/" + name, "$defs": schema["$defs"]}
+}
+
+func TestJSONSchemaRunItems(t *testing.T) {
+	list := []items.Item{
+		&items.Message{Role: "user", Content: "Describe this image"},
+		&items.Message{Role: "user", Content: "Describe this image", Parts: []model.ContentPart{model.NewImagePart("https://example.com/cat.png", "low")}},
+		&items.Message{Role: "assistant", Content: "No", Refusal: "I can't"},
+		&items.Reasoning{Summary: "The user wants the weather"},
+		&items.FunctionCall{CallID: "call_1", Name: "weather", Arguments: `{"city":"Tokyo"}`},
+		&items.FunctionCallOutput{CallID: "call_1", Output: "sunny"},
+		&items.FileSearchCall{ID: "fs_1", Status: "completed", Queries: []string{"weather"}},
+		&items.WebSearchCall{ID: "ws_1", Status: "completed"},
+		&items.ModelBehaviorError{CallID: "call_2", Message: "unknown tool"},
+	}
+	schema := schemaDefinition(t, "run_item")
+	for _, item := range list {
+		data, err := json.Marshal(item)
+		require.NoError(t, err)
+		_, err = jsonschema.ValidateJSON(schema, string(data))
+		assert.NoError(t, err, "%s does not match the schema", data)
+	}
+
+	_, err := jsonschema.ValidateJSON(schema, `{"type":"function_call","call_id":"call_1"}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+var (
+	// ErrEmptyChain is returned when a chain has no steps
+	ErrEmptyChain = errors.New("chain has no steps")
+
+	// ErrJudgeRequired is returned when a judge loop has no generator, judge or verdict function
+	ErrJudgeRequired = errors.New("judge loop requires a generator, a judge and a verdict function")
+)
+
+// ChainStep is one agent run of a Chain
+type ChainStep struct {
+	// Agent is the agent to run
+	Agent *agent.Agent
+
+	// Input builds the agent's input from the previous step's result (optional, defaults to the
+	// previous FinalOutput). It is not called for the first step, which receives the chain input.
+	Input func(ctx context.Context, previous *Result) (string, error)
+
+	// Gate decides whether the chain continues after this step (optional)
+	Gate func(ctx context.Context, result *Result) (bool, error)
+}
+
+// ChainResult is the result of a Chain run
+type ChainResult struct {
+	// Results contains the result of every step that ran, in order
+	Results []*Result
+
+	// Stopped is true if a gate stopped the chain before its last step
+	Stopped bool
+}
+
+// Final returns the result of the last step that ran
+func (r *ChainResult) Final() *Result {
+	if len(r.Results) == 0 {
+		return nil
+	}
+	return r.Results[len(r.Results)-1]
+}
+
+// Chain is a deterministic multi-agent pipeline: each step's output, optionally transformed,
+// becomes the next step's input, and gates can stop the pipeline between steps.
+type Chain struct {
+	steps  []ChainStep
+	config RunConfig
+}
+
+// NewChain creates a chain whose steps run with config
+func NewChain(config RunConfig, steps ...ChainStep) *Chain {
+	return &Chain{
+		steps:  steps,
+		config: config,
+	}
+}
+
+// Then appends a step to the chain
+func (c *Chain) Then(step ChainStep) *Chain {
+	c.steps = append(c.steps, step)
+	return c
+}
+
+// Run runs the steps in order. All steps belong to one trace named after config.WorkflowName,
+// unless ctx already carries a trace. Each step runs with its own idempotency key derived
+// from config.IdempotencyKey.
+func (c *Chain) Run(ctx context.Context, input string) (*ChainResult, error) {
+	if len(c.steps) == 0 {
+		return nil, ErrEmptyChain
+	}
+	for i, step := range c.steps {
+		if step.Agent == nil {
+			return nil, fmt.Errorf("chain step %d: %w", i+1, ErrAgentRequired)
+		}
+	}
+
+	ctx, trace := startWorkflowTrace(ctx, c.config)
+	if trace != nil {
+		defer trace.End()
+	}
+
+	chainResult := &ChainResult{}
+	var previous *Result
+
+	for i, step := range c.steps {
+		stepInput := input
+		if previous != nil {
+			stepInput = previous.FinalOutput
+		}
+		if step.Input != nil && previous != nil {
+			var err error
+			if stepInput, err = step.Input(ctx, previous); err != nil {
+				return chainResult, fmt.Errorf("chain step %d input: %w", i+1, err)
+			}
+		}
+
+		result, err := RunWithConfig(ctx, step.Agent, stepInput, innerRunConfig(c.config, fmt.Sprintf("step %d", i+1)))
+		if err != nil {
+			return chainResult, fmt.Errorf("chain step %d (%s): %w", i+1, step.Agent.Name, err)
+		}
+		chainResult.Results = append(chainResult.Results, result)
+		previous = result
+
+		if step.Gate != nil {
+			pass, err := step.Gate(ctx, result)
+			if err != nil {
+				return chainResult, fmt.Errorf("chain step %d gate: %w", i+1, err)
+			}
+			if !pass {
+				chainResult.Stopped = i < len(c.steps)-1
+				break
+			}
+		}
+	}
+
+	return chainResult, nil
+}
+
+// JudgeLoop implements the LLM-as-a-judge pattern: the generator produces an output, the judge
+// evaluates it, and the generator retries with the judge's feedback until the output passes.
+type JudgeLoop struct {
+	// Generator produces the output
+	Generator *agent.Agent
+
+	// Judge evaluates the generator's output, which it receives as input
+	Judge *agent.Agent
+
+	// Verdict interprets the judge's result: whether the output passes and the feedback for the next round
+	Verdict func(judgement *Result) (pass bool, feedback string, err error)
+
+	// MaxRounds is the maximum number of generator runs (defaults to 3)
+	MaxRounds int
+}
+
+// JudgeResult is the result of a JudgeLoop run
+type JudgeResult struct {
+	// Output is the generator's last result
+	Output *Result
+
+	// Judgement is the judge's last result
+	Judgement *Result
+
+	// Passed is true if the judge accepted Output
+	Passed bool
+
+	// Rounds is the number of generator runs
+	Rounds int
+}
+
+// Run runs the loop. If no output passes within MaxRounds, the last output is returned with Passed false.
+// Each generator and judge run uses its own idempotency key derived from config.IdempotencyKey.
+func (l JudgeLoop) Run(ctx context.Context, input string, config RunConfig) (*JudgeResult, error) {
+	if l.Generator == nil || l.Judge == nil || l.Verdict == nil {
+		return nil, ErrJudgeRequired
+	}
+
+	maxRounds := l.MaxRounds
+	if maxRounds <= 0 {
+		maxRounds = 3
+	}
+
+	ctx, trace := startWorkflowTrace(ctx, config)
+	if trace != nil {
+		defer trace.End()
+	}
+
+	judgeResult := &JudgeResult{}
+	generatorInput := input
+
+	for judgeResult.Rounds < maxRounds {
+		judgeResult.Rounds++
+
+		output, err := RunWithConfig(ctx, l.Generator, generatorInput, innerRunConfig(config, fmt.Sprintf("round %d generator", judgeResult.Rounds)))
+		if err != nil {
+			return judgeResult, fmt.Errorf("judge loop round %d generator: %w", judgeResult.Rounds, err)
+		}
+		judgeResult.Output = output
+
+		judgement, err := RunWithConfig(ctx, l.Judge, output.FinalOutput, innerRunConfig(config, fmt.Sprintf("round %d judge", judgeResult.Rounds)))
+		if err != nil {
+			return judgeResult, fmt.Errorf("judge loop round %d judge: %w", judgeResult.Rounds, err)
+		}
+		judgeResult.Judgement = judgement
+
+		pass, feedback, err := l.Verdict(judgement)
+		if err != nil {
+			return judgeResult, fmt.Errorf("judge loop round %d verdict: %w", judgeResult.Rounds, err)
+		}
+		if pass {
+			judgeResult.Passed = true
+			break
+		}
+
+		generatorInput = fmt.Sprintf("%s\n\nPrevious attempt:\n%s\n\nFeedback:\n%s", input, output.FinalOutput, feedback)
+	}
+
+	return judgeResult, nil
+}
+
+// startWorkflowTrace starts a trace for a run or a multi-run workflow unless ctx already belongs to
+// one. With RunConfig.TracingDisabled, the returned context disables the spans of the run instead.
+func startWorkflowTrace(ctx context.Context, config RunConfig) (context.Context, *tracing.Trace) {
+	if config.TracingDisabled {
+		ctx = tracing.ContextWithTracingDisabled(ctx)
+	}
+	if tracing.IsTracingDisabled(ctx) || tracing.TraceFromContext(ctx) != nil || tracing.GetActiveSpan(ctx) != nil {
+		return ctx, nil
+	}
+
+	trace, ctx := tracing.StartTrace(ctx, config.WorkflowName,
+		tracing.WithGroupID(config.GroupID),
+		tracing.WithMetadata(config.TraceMetadata),
+	)
+	return ctx, trace
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestChain(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("outline")},
+		{GetTextMessage("story")},
+	})
+	hooks := &llmHooksRecorder{}
+	config := RunConfig{ModelProvider: fakeModel, MaxTurns: 3, LLMHooks: hooks, WorkflowName: "story"}
+
+	chain := NewChain(config, ChainStep{
+		Agent: agent.New("outliner", "Write an outline"),
+		Gate: func(ctx context.Context, result *Result) (bool, error) {
+			return result.FinalOutput == "outline", nil
+		},
+	}).Then(ChainStep{
+		Agent: agent.New("writer", "Write a story"),
+		Input: func(ctx context.Context, previous *Result) (string, error) {
+			return "Write a story from this outline: " + previous.FinalOutput, nil
+		},
+	})
+
+	result, err := chain.Run(context.Background(), "a cat")
+	require.NoError(t, err)
+	assert.False(t, result.Stopped)
+	assert.Len(t, result.Results, 2)
+	assert.Equal(t, "story", result.Final().FinalOutput)
+
+	lastInput := hooks.startMessages[1][len(hooks.startMessages[1])-1]
+	assert.Equal(t, "Write a story from this outline: outline", lastInput.Content)
+
+	// Both runs belong to one trace
+	runs := recorder.byName("agent_run")
+	require.Len(t, runs, 2)
+	assert.Equal(t, runs[0].Context().TraceID, runs[1].Context().TraceID)
+
+	// A failing gate stops the chain
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{{GetTextMessage("bad outline")}})
+	result, err = chain.Run(context.Background(), "a dog")
+	require.NoError(t, err)
+	assert.True(t, result.Stopped)
+	assert.Len(t, result.Results, 1)
+
+	_, err = NewChain(config).Run(context.Background(), "input")
+	assert.ErrorIs(t, err, ErrEmptyChain)
+
+	// Steps without an agent are rejected before any step runs
+	calls := len(fakeModel.Calls())
+	_, err = NewChain(config, ChainStep{Agent: agent.New("outliner", "Outline")}, ChainStep{}).Run(context.Background(), "input")
+	assert.ErrorIs(t, err, ErrAgentRequired)
+	assert.EqualError(t, err, "chain step 2: agent is required")
+	assert.Len(t, fakeModel.Calls(), calls)
+}
+
+func TestJudgeLoop(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("draft 1")},
+		{GetTextMessage("fail: too short")},
+		{GetTextMessage("draft 2")},
+		{GetTextMessage("pass")},
+	})
+	hooks := &llmHooksRecorder{}
+	config := RunConfig{ModelProvider: fakeModel, MaxTurns: 3, LLMHooks: hooks}
+
+	loop := JudgeLoop{
+		Generator: agent.New("generator", "Write a story"),
+		Judge:     agent.New("judge", "Judge the story"),
+		Verdict: func(judgement *Result) (bool, string, error) {
+			feedback, failed := strings.CutPrefix(judgement.FinalOutput, "fail: ")
+			return !failed, feedback, nil
+		},
+	}
+
+	result, err := loop.Run(context.Background(), "a cat", config)
+	require.NoError(t, err)
+	assert.True(t, result.Passed)
+	assert.Equal(t, 2, result.Rounds)
+	assert.Equal(t, "draft 2", result.Output.FinalOutput)
+
+	// The second generator run receives the feedback
+	retryInput := hooks.startMessages[2][len(hooks.startMessages[2])-1].Content
+	assert.Contains(t, retryInput, "draft 1")
+	assert.Contains(t, retryInput, "too short")
+
+	// Without a passing verdict the last output is returned
+	loop.MaxRounds = 1
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("draft")},
+		{GetTextMessage("fail: no")},
+	})
+	result, err = loop.Run(context.Background(), "a cat", config)
+	require.NoError(t, err)
+	assert.False(t, result.Passed)
+	assert.Equal(t, "draft", result.Output.FinalOutput)
+
+	_, err = JudgeLoop{}.Run(context.Background(), "a cat", config)
+	assert.ErrorIs(t, err, ErrJudgeRequired)
+	_, err = JudgeLoop{Generator: loop.Generator, Verdict: loop.Verdict}.Run(context.Background(), "a cat", config)
+	assert.ErrorIs(t, err, ErrJudgeRequired)
+}
+
+func TestWorkflowIdempotencyKey(t *testing.T) {
+	fakeModel := NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("outline")},
+		{GetTextMessage("story")},
+	})
+	config := RunConfig{
+		ModelProvider:  fakeModel,
+		MaxTurns:       3,
+		IdempotencyKey: NewIdempotencyKey("session", "a cat"),
+		ResultStore:    NewMemoryResultStore(0),
+	}
+	chain := NewChain(config,
+		ChainStep{Agent: agent.New("outliner", "Write an outline")},
+		ChainStep{Agent: agent.New("writer", "Write a story")},
+	)
+
+	// Each step runs its own agent instead of getting the first step's stored result
+	result, err := chain.Run(context.Background(), "a cat")
+	require.NoError(t, err)
+	require.Len(t, result.Results, 2)
+	assert.Equal(t, "outline", result.Results[0].FinalOutput)
+	assert.Equal(t, "story", result.Final().FinalOutput)
+	assert.False(t, result.Final().Cached)
+
+	// A redelivery of the chain gets every step's stored result
+	result, err = chain.Run(context.Background(), "a cat")
+	require.NoError(t, err)
+	assert.Equal(t, "story", result.Final().FinalOutput)
+	assert.True(t, result.Results[0].Cached)
+	assert.True(t, result.Final().Cached)
+	assert.Len(t, fakeModel.Calls(), 2)
+
+	// The generator and the judge of a loop do not share a stored result either
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{GetTextMessage("draft")},
+		{GetTextMessage("pass")},
+	})
+	config.IdempotencyKey = NewIdempotencyKey("session", "a dog")
+	loop := JudgeLoop{
+		Generator: agent.New("generator", "Write a story"),
+		Judge:     agent.New("judge", "Judge the story"),
+		Verdict: func(judgement *Result) (bool, string, error) {
+			return judgement.FinalOutput == "pass", "", nil
+		},
+	}
+	judged, err := loop.Run(context.Background(), "a dog", config)
+	require.NoError(t, err)
+	assert.True(t, judged.Passed)
+	assert.Equal(t, "draft", judged.Output.FinalOutput)
+}
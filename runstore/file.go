@@ -0,0 +1,95 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package runstore provides runner.RunStore implementations that keep run checkpoints in files
+// or in a Postgres database.
+package runstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+)
+
+// ErrInvalidRunID is returned by FileStore for run IDs that are not usable as file names
+var ErrInvalidRunID = errors.New("invalid run ID")
+
+// FileStore keeps the checkpoint of each run in a JSON file named after the run ID
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore creates a file store in dir, creating the directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create run store directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// SaveCheckpoint writes the checkpoint of a run. The file is replaced atomically, so a crash
+// leaves the previous checkpoint intact.
+func (s *FileStore) SaveCheckpoint(ctx context.Context, checkpoint *runner.RunCheckpoint) error {
+	path, err := s.path(checkpoint.RunID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(s.dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadCheckpoint reads the checkpoint of a run
+func (s *FileStore) LoadCheckpoint(ctx context.Context, runID string) (*runner.RunCheckpoint, error) {
+	path, err := s.path(runID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", runner.ErrRunNotFound, runID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint runner.RunCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint of run %s: %w", runID, err)
+	}
+	return &checkpoint, nil
+}
+
+// path returns the file of a run
+func (s *FileStore) path(runID string) (string, error) {
+	if runID == "" || runID != filepath.Base(runID) || runID == "." || runID == ".." {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRunID, runID)
+	}
+	return filepath.Join(s.dir, runID+".json"), nil
+}
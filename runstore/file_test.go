@@ -0,0 +1,54 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+)
+
+func testCheckpoint(status runner.RunStatus, step int) *runner.RunCheckpoint {
+	return &runner.RunCheckpoint{
+		RunID:  "run-1",
+		Status: status,
+		Agent:  "billing",
+		Input:  "refund my order",
+		Step:   step,
+		Result: &runner.Result{
+			History: []runner.Message{{Role: "user", Content: "refund my order"}},
+			Usage:   runner.Usage{Requests: step},
+		},
+		UpdatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = store.LoadCheckpoint(ctx, "run-1")
+	assert.ErrorIs(t, err, runner.ErrRunNotFound)
+
+	require.NoError(t, store.SaveCheckpoint(ctx, testCheckpoint(runner.RunStatusRunning, 1)))
+	require.NoError(t, store.SaveCheckpoint(ctx, testCheckpoint(runner.RunStatusCompleted, 2)))
+
+	checkpoint, err := store.LoadCheckpoint(ctx, "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, runner.RunStatusCompleted, checkpoint.Status)
+	assert.Equal(t, 2, checkpoint.Step)
+	assert.Equal(t, "refund my order", checkpoint.Result.History[0].Content)
+	assert.Equal(t, 2, checkpoint.Result.Usage.Requests)
+
+	// Run IDs cannot escape the directory
+	_, err = store.LoadCheckpoint(ctx, "../run-1")
+	assert.ErrorIs(t, err, ErrInvalidRunID)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+)
+
+// DefaultTable is the table used by PostgresStore
+const DefaultTable = "agent_runs"
+
+// PostgresStore keeps run checkpoints in a Postgres table, one row per run. The status, agent,
+// step and timestamps have columns of their own for queries; the whole checkpoint is kept as
+// JSONB. Open db with the driver of your choice, such as pgx's stdlib or lib/pq.
+type PostgresStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewPostgresStore creates a store using DefaultTable in db
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db, table: DefaultTable}
+}
+
+// WithTable returns a copy of the store using another table, which may be schema-qualified.
+// The name is used in queries as is and must not come from user input.
+func (s *PostgresStore) WithTable(table string) *PostgresStore {
+	return &PostgresStore{db: s.db, table: table}
+}
+
+// CreateTable creates the table if it does not exist
+func (s *PostgresStore) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	run_id TEXT PRIMARY KEY,
+	status TEXT NOT NULL,
+	agent TEXT NOT NULL,
+	step INTEGER NOT NULL,
+	checkpoint JSONB NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL
+)`, s.table))
+	if err != nil {
+		return fmt.Errorf("failed to create run store table: %w", err)
+	}
+	return nil
+}
+
+// SaveCheckpoint inserts or replaces the checkpoint of a run
+func (s *PostgresStore) SaveCheckpoint(ctx context.Context, checkpoint *runner.RunCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (run_id, status, agent, step, checkpoint, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (run_id) DO UPDATE SET status = EXCLUDED.status, agent = EXCLUDED.agent,
+	step = EXCLUDED.step, checkpoint = EXCLUDED.checkpoint, updated_at = EXCLUDED.updated_at`, s.table),
+		checkpoint.RunID, string(checkpoint.Status), checkpoint.Agent, checkpoint.Step, string(data), checkpoint.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint of run %s: %w", checkpoint.RunID, err)
+	}
+	return nil
+}
+
+// LoadCheckpoint reads the checkpoint of a run
+func (s *PostgresStore) LoadCheckpoint(ctx context.Context, runID string) (*runner.RunCheckpoint, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf(`SELECT checkpoint FROM %s WHERE run_id = $1`, s.table), runID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", runner.ErrRunNotFound, runID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint of run %s: %w", runID, err)
+	}
+
+	var checkpoint runner.RunCheckpoint
+	if err := json.Unmarshal([]byte(data), &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint of run %s: %w", runID, err)
+	}
+	return &checkpoint, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package runstore
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+)
+
+func TestPostgresStore(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeDB{rows: make(map[string]string)}
+	sql.Register("runstore_fake", fake)
+	db, err := sql.Open("runstore_fake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := NewPostgresStore(db).WithTable("audit.runs")
+	require.NoError(t, store.CreateTable(ctx))
+	assert.Contains(t, fake.queries[0], "CREATE TABLE IF NOT EXISTS audit.runs")
+
+	_, err = store.LoadCheckpoint(ctx, "run-1")
+	assert.ErrorIs(t, err, runner.ErrRunNotFound)
+
+	require.NoError(t, store.SaveCheckpoint(ctx, testCheckpoint(runner.RunStatusRunning, 1)))
+	require.NoError(t, store.SaveCheckpoint(ctx, testCheckpoint(runner.RunStatusCompleted, 2)))
+	assert.Contains(t, fake.queries[2], "ON CONFLICT (run_id) DO UPDATE")
+	assert.Equal(t, []any{"run-1", "completed", "billing", int64(2)}, fake.args[:4])
+
+	checkpoint, err := store.LoadCheckpoint(ctx, "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, runner.RunStatusCompleted, checkpoint.Status)
+	assert.Equal(t, "refund my order", checkpoint.Result.History[0].Content)
+}
+
+// fakeDB is a database/sql driver standing in for Postgres. It keeps the checkpoint column of
+// the inserted rows by run ID.
+type fakeDB struct {
+	mu      sync.Mutex
+	rows    map[string]string
+	queries []string
+	args    []any
+}
+
+func (d *fakeDB) Open(name string) (driver.Conn, error) {
+	return fakeConn{d}, nil
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{db: c.db, query: query}, nil
+}
+
+func (c fakeConn) Close() error { return nil }
+
+func (c fakeConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error { return nil }
+
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.queries = append(s.db.queries, s.query)
+	s.db.args = nil
+	for _, arg := range args {
+		s.db.args = append(s.db.args, arg)
+	}
+	if strings.HasPrefix(s.query, "INSERT") {
+		s.db.rows[args[0].(string)] = args[4].(string)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.queries = append(s.db.queries, s.query)
+	rows := &fakeRows{}
+	if data, ok := s.db.rows[args[0].(string)]; ok {
+		rows.values = []string{data}
+	}
+	return rows, nil
+}
+
+type fakeRows struct{ values []string }
+
+func (r *fakeRows) Columns() []string { return []string{"checkpoint"} }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
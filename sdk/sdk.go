@@ -0,0 +1,59 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package sdk ties together the process-wide state of the SDK, so that a program can release
+// it in one call when it exits:
+//
+//	defer sdk.Shutdown(context.Background())
+package sdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+var (
+	hooksMu sync.Mutex
+	hooks   []func(ctx context.Context) error
+)
+
+// OnShutdown registers a function run by Shutdown, e.g. to close a session store or a
+// provider that is not the default one. Functions run in the order they were registered.
+func OnShutdown(fn func(ctx context.Context) error) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = append(hooks, fn)
+}
+
+// Shutdown flushes the pending spans, shuts down the global tracer and its processors, closes
+// the connections of the default model provider and runs the OnShutdown functions.
+// Every step runs even if an earlier one fails; the errors are joined.
+// The registered functions are cleared, so Shutdown can be called again after a new setup.
+func Shutdown(ctx context.Context) error {
+	var errs []error
+
+	tracing.ForceFlush()
+	if err := tracing.ShutdownTracing(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := model.CloseProvider(runner.DefaultProvider); err != nil {
+		errs = append(errs, err)
+	}
+
+	hooksMu.Lock()
+	registered := hooks
+	hooks = nil
+	hooksMu.Unlock()
+	for _, fn := range registered {
+		if err := fn(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
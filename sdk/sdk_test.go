@@ -0,0 +1,58 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// shutdownRecorder is a span processor recording flushes and shutdowns
+type shutdownRecorder struct {
+	flushed  bool
+	shutdown bool
+}
+
+func (r *shutdownRecorder) OnStart(span *tracing.StandardSpan) {}
+
+func (r *shutdownRecorder) OnEnd(span *tracing.StandardSpan) {}
+
+func (r *shutdownRecorder) ForceFlush() { r.flushed = true }
+
+func (r *shutdownRecorder) Shutdown(ctx context.Context) error {
+	r.shutdown = true
+	return nil
+}
+
+func TestShutdown(t *testing.T) {
+	processor := &shutdownRecorder{}
+	tracing.SetTraceProcessors(processor)
+
+	var calls []string
+	OnShutdown(func(ctx context.Context) error {
+		calls = append(calls, "store")
+		return errors.New("store failed")
+	})
+	OnShutdown(func(ctx context.Context) error {
+		calls = append(calls, "cache")
+		return nil
+	})
+
+	err := Shutdown(context.Background())
+	assert.EqualError(t, err, "store failed")
+	assert.Equal(t, []string{"store", "cache"}, calls)
+	assert.True(t, processor.flushed)
+	assert.True(t, processor.shutdown)
+	assert.IsType(t, &tracing.NoopTracer{}, tracing.GetTracer())
+
+	// The functions run once
+	assert.NoError(t, Shutdown(context.Background()))
+	assert.Len(t, calls, 2)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tokens
+
+import (
+	"encoding/json"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+const (
+	// tokensPerMessage is the formatting every chat message adds around its content
+	tokensPerMessage = 3
+
+	// tokensPerName is the extra token of a message with a name
+	tokensPerName = 1
+
+	// tokensPerReply primes the assistant's reply after the last message
+	tokensPerReply = 3
+
+	// tokensPerToolCall is the formatting of every tool call of an assistant message
+	tokensPerToolCall = 3
+
+	// tokensPerTool is the formatting of every tool definition
+	tokensPerTool = 7
+
+	// Images are counted as a 512px tile at low detail, and as a 1024x1024 image otherwise,
+	// since the image's size is not known
+	tokensPerLowDetailImage = 85
+	tokensPerImage          = 765
+)
+
+// CountText counts the tokens of a text with the encoding of a model
+func CountText(text string, modelName string) int {
+	return ForModel(modelName).Count(text)
+}
+
+// CountMessage counts the tokens of a message with the encoding of a model, including the
+// formatting the chat format adds around it. Images are estimated from their detail level, and
+// files and audio are not counted.
+func CountMessage(msg model.Message, modelName string) int {
+	return countMessage(ForModel(modelName), msg)
+}
+
+// CountMessages counts the tokens of a prompt, including the tokens that prime the reply
+func CountMessages(messages []model.Message, modelName string) int {
+	enc := ForModel(modelName)
+	count := tokensPerReply
+	for _, msg := range messages {
+		count += countMessage(enc, msg)
+	}
+	return count
+}
+
+// CountTool counts the tokens of a tool definition
+func CountTool(definition model.ToolDefinition, modelName string) int {
+	return countTool(ForModel(modelName), definition)
+}
+
+// CountTools counts the tokens of the tool definitions of a request
+func CountTools(definitions []model.ToolDefinition, modelName string) int {
+	enc := ForModel(modelName)
+	count := 0
+	for _, definition := range definitions {
+		count += countTool(enc, definition)
+	}
+	return count
+}
+
+// CountRequest counts the prompt tokens of a request: its messages and tool definitions
+func CountRequest(messages []model.Message, settings model.Settings, modelName string) int {
+	return CountMessages(messages, modelName) + CountTools(settings.Tools, modelName)
+}
+
+// MessageCounter returns a function that counts the tokens of messages with the encoding of a
+// model, for example as runner.TokenWindowTrimmer.CountTokens
+func MessageCounter(modelName string) func(msg model.Message) int {
+	enc := ForModel(modelName)
+	return func(msg model.Message) int {
+		return countMessage(enc, msg)
+	}
+}
+
+func countMessage(enc Encoding, msg model.Message) int {
+	count := tokensPerMessage + enc.Count(msg.Role) + enc.Count(msg.Content)
+	if msg.Name != "" {
+		count += tokensPerName + enc.Count(msg.Name)
+	}
+	for _, call := range msg.ToolCalls {
+		count += tokensPerToolCall + enc.Count(call.Function.Name) + enc.Count(call.Function.Arguments)
+	}
+	for _, part := range msg.ContentParts {
+		switch part.Type {
+		case model.ContentPartText:
+			count += enc.Count(part.Text)
+		case model.ContentPartImageURL:
+			if part.ImageURL != nil && part.ImageURL.Detail == "low" {
+				count += tokensPerLowDetailImage
+			} else {
+				count += tokensPerImage
+			}
+		}
+	}
+	return count
+}
+
+func countTool(enc Encoding, definition model.ToolDefinition) int {
+	count := tokensPerTool + enc.Count(definition.Name) + enc.Count(definition.Description)
+	if definition.Parameters != nil {
+		if encoded, err := json.Marshal(definition.Parameters); err == nil {
+			count += enc.Count(string(encoded))
+		}
+	}
+	return count
+}
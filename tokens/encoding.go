@@ -0,0 +1,220 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package tokens estimates the token counts of prompts before they are sent, for trimming
+// history, enforcing budgets and rate limiting. Counts use the tiktoken encoding of each model:
+// the built-in encodings are approximations, and exact implementations can be registered with
+// RegisterEncoding.
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Names of the tiktoken encodings used by OpenAI models
+const (
+	// CL100KBase is the encoding of gpt-4, gpt-3.5-turbo and the text-embedding-3 models
+	CL100KBase = "cl100k_base"
+
+	// O200KBase is the encoding of gpt-4o, gpt-4.1, gpt-5 and the o-series models
+	O200KBase = "o200k_base"
+)
+
+// ErrUnknownEncoding is returned when no encoding is registered under a name
+var ErrUnknownEncoding = errors.New("unknown encoding")
+
+// Encoding counts the tokens of a text
+type Encoding interface {
+	// Name returns the tiktoken name of the encoding, such as "o200k_base"
+	Name() string
+
+	// Count returns the number of tokens of the text
+	Count(text string) int
+}
+
+var (
+	encodings = map[string]Encoding{
+		CL100KBase: &approximateEncoding{name: CL100KBase, cjkRunesPerToken: 1},
+		O200KBase:  &approximateEncoding{name: O200KBase, cjkRunesPerToken: 1.5},
+	}
+	encodingsMu sync.RWMutex
+)
+
+// modelPrefixes maps model name prefixes to encodings, longest prefixes first
+var modelPrefixes = []struct {
+	prefix   string
+	encoding string
+}{
+	{"gpt-4o", O200KBase},
+	{"gpt-4.1", O200KBase},
+	{"gpt-4.5", O200KBase},
+	{"gpt-5", O200KBase},
+	{"chatgpt-4o", O200KBase},
+	{"o1", O200KBase},
+	{"o3", O200KBase},
+	{"o4", O200KBase},
+	{"gpt-4", CL100KBase},
+	{"gpt-3.5", CL100KBase},
+	{"gpt-35", CL100KBase},
+	{"text-embedding-3", CL100KBase},
+	{"text-embedding-ada-002", CL100KBase},
+}
+
+// RegisterEncoding registers an encoding under its name, replacing the encoding registered before.
+// The built-in encodings approximate the tiktoken encodings without their vocabularies; register
+// an exact BPE implementation under the same name for exact counts.
+func RegisterEncoding(enc Encoding) {
+	encodingsMu.Lock()
+	defer encodingsMu.Unlock()
+	encodings[enc.Name()] = enc
+}
+
+// GetEncoding returns the encoding registered under the name
+func GetEncoding(name string) (Encoding, error) {
+	encodingsMu.RLock()
+	defer encodingsMu.RUnlock()
+	enc, ok := encodings[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEncoding, name)
+	}
+	return enc, nil
+}
+
+// EncodingNameForModel returns the name of the encoding of a model. Provider prefixes such as
+// "openai/" are ignored, and unknown models use o200k_base.
+func EncodingNameForModel(modelName string) string {
+	if i := strings.LastIndex(modelName, "/"); i >= 0 {
+		modelName = modelName[i+1:]
+	}
+	for _, p := range modelPrefixes {
+		if strings.HasPrefix(modelName, p.prefix) {
+			return p.encoding
+		}
+	}
+	return O200KBase
+}
+
+// ForModel returns the encoding of a model
+func ForModel(modelName string) Encoding {
+	enc, err := GetEncoding(EncodingNameForModel(modelName))
+	if err != nil {
+		// The built-in encodings cannot be unregistered
+		panic(err)
+	}
+	return enc
+}
+
+// approximateEncoding estimates token counts by splitting text the way tiktoken's pre-tokenizer does
+// (words with their leading space, numbers in groups of three digits, punctuation runs and
+// whitespace) and estimating the tokens of each piece. Counts of English text and code are
+// usually within about 10% of the exact count.
+type approximateEncoding struct {
+	name string
+
+	// cjkRunesPerToken is the average number of Chinese, Japanese or Korean characters per token
+	cjkRunesPerToken float64
+}
+
+func (e *approximateEncoding) Name() string {
+	return e.name
+}
+
+func (e *approximateEncoding) Count(text string) int {
+	count := 0
+	cjk := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case isCJK(r):
+			cjk++
+			i += size
+			continue
+		case unicode.IsLetter(r) || unicode.IsMark(r):
+			n, ascii := 0, true
+			for i < len(text) {
+				r, size = utf8.DecodeRuneInString(text[i:])
+				if isCJK(r) || !(unicode.IsLetter(r) || unicode.IsMark(r)) {
+					break
+				}
+				if r >= utf8.RuneSelf {
+					ascii = false
+				}
+				n++
+				i += size
+			}
+			count += wordTokens(n, ascii)
+		case unicode.IsDigit(r):
+			n := 0
+			for i < len(text) {
+				r, size = utf8.DecodeRuneInString(text[i:])
+				if !unicode.IsDigit(r) {
+					break
+				}
+				n++
+				i += size
+			}
+			count += (n + 2) / 3
+		case unicode.IsSpace(r):
+			n := 0
+			for i < len(text) {
+				r, size = utf8.DecodeRuneInString(text[i:])
+				if !unicode.IsSpace(r) {
+					break
+				}
+				n++
+				i += size
+			}
+			// A single space is merged into the following word
+			if n > 1 || r == '\n' || i >= len(text) || !isWordStart(text[i:]) {
+				count++
+			}
+		default:
+			n := 0
+			for i < len(text) {
+				r, size = utf8.DecodeRuneInString(text[i:])
+				if unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r) || unicode.IsSpace(r) || isCJK(r) {
+					break
+				}
+				n++
+				i += size
+			}
+			count += (n + 1) / 2
+		}
+	}
+	if cjk > 0 {
+		count += int(float64(cjk)/e.cjkRunesPerToken + 0.5)
+		if count == 0 {
+			count = 1
+		}
+	}
+	return count
+}
+
+// wordTokens estimates the tokens of a word of n letters. Common English words are single
+// tokens, and long or non-ASCII words split into several.
+func wordTokens(n int, ascii bool) int {
+	if !ascii {
+		return (n + 2) / 3
+	}
+	if n <= 8 {
+		return 1
+	}
+	return 1 + (n-8+5)/6
+}
+
+// isWordStart reports whether text starts with a word, which a preceding space joins
+func isWordStart(text string) bool {
+	r, _ := utf8.DecodeRuneInString(text)
+	return unicode.IsLetter(r) && !isCJK(r)
+}
+
+// isCJK reports whether r is a Chinese, Japanese or Korean character
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tokens
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func TestEncodingNameForModel(t *testing.T) {
+	assert.Equal(t, O200KBase, EncodingNameForModel("gpt-4o-mini"))
+	assert.Equal(t, O200KBase, EncodingNameForModel("openai/gpt-4.1"))
+	assert.Equal(t, O200KBase, EncodingNameForModel("o3-mini"))
+	assert.Equal(t, CL100KBase, EncodingNameForModel("gpt-4-turbo"))
+	assert.Equal(t, CL100KBase, EncodingNameForModel("gpt-3.5-turbo"))
+	assert.Equal(t, O200KBase, EncodingNameForModel("some-local-model"))
+}
+
+func TestApproximateCount(t *testing.T) {
+	enc, err := GetEncoding(CL100KBase)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, enc.Count(""))
+	assert.Equal(t, 2, enc.Count("hello world"))
+	assert.Equal(t, 10, enc.Count("The quick brown fox jumps over the lazy dog."))
+	assert.Equal(t, 2, enc.Count("123456"))
+	assert.Equal(t, 6, enc.Count(`{"a": 1}`))
+	assert.Equal(t, 3, enc.Count("internationalization"))
+	assert.Equal(t, 5, enc.Count("こんにちは"))
+
+	enc, err = GetEncoding(O200KBase)
+	require.NoError(t, err)
+	assert.Equal(t, 3, enc.Count("こんにちは"))
+}
+
+func TestRegisterEncoding(t *testing.T) {
+	original, err := GetEncoding(O200KBase)
+	require.NoError(t, err)
+	t.Cleanup(func() { RegisterEncoding(original) })
+
+	RegisterEncoding(runeEncoding{})
+	assert.Equal(t, 5, CountText("hello", "gpt-4o"))
+
+	_, err = GetEncoding("p50k_base")
+	assert.ErrorIs(t, err, ErrUnknownEncoding)
+}
+
+func TestCountMessages(t *testing.T) {
+	original, err := GetEncoding(O200KBase)
+	require.NoError(t, err)
+	t.Cleanup(func() { RegisterEncoding(original) })
+	RegisterEncoding(runeEncoding{})
+
+	messages := []model.Message{
+		{Role: "user", Content: "hi", Name: "bob"},
+		{Role: "assistant", ToolCalls: []model.ToolCall{{Function: model.FunctionCall{Name: "f", Arguments: "{}"}}}},
+		{Role: "user", ContentParts: []model.ContentPart{model.NewImagePart("https://example.com/a.png", "low")}},
+	}
+
+	assert.Equal(t, 3+4+2+1+3, CountMessage(messages[0], "gpt-4o"))
+	assert.Equal(t, 3+9+3+1+2, CountMessage(messages[1], "gpt-4o"))
+	assert.Equal(t, 3+4+85, CountMessage(messages[2], "gpt-4o"))
+	assert.Equal(t, 3+13+18+92, CountMessages(messages, "gpt-4o"))
+	assert.Equal(t, CountMessage(messages[0], "gpt-4o"), MessageCounter("gpt-4o")(messages[0]))
+}
+
+func TestCountTools(t *testing.T) {
+	original, err := GetEncoding(O200KBase)
+	require.NoError(t, err)
+	t.Cleanup(func() { RegisterEncoding(original) })
+	RegisterEncoding(runeEncoding{})
+
+	definition := model.NewFunctionTool("lookup", "Looks up", map[string]any{"type": "object"})
+
+	assert.Equal(t, 7+6+8+17, CountTool(definition, "gpt-4o"))
+	assert.Equal(t, 2*CountTool(definition, "gpt-4o"), CountTools([]model.ToolDefinition{definition, definition}, "gpt-4o"))
+
+	settings := model.Settings{Tools: []model.ToolDefinition{definition}}
+	messages := []model.Message{{Role: "user", Content: "hi"}}
+	assert.Equal(t, CountMessages(messages, "gpt-4o")+CountTool(definition, "gpt-4o"), CountRequest(messages, settings, "gpt-4o"))
+}
+
+// runeEncoding counts one token per character, so counts can be checked exactly
+type runeEncoding struct{}
+
+func (runeEncoding) Name() string { return O200KBase }
+
+func (runeEncoding) Count(text string) int { return len([]rune(text)) }
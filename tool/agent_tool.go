@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/ryichk/ai-agents-sdk-go/interfaces"
+	"github.com/ryichk/ai-agents-sdk-go/model"
 )
 
 // AgentTool wraps an agent as a tool
@@ -20,6 +21,29 @@ type AgentTool struct {
 	runner      interfaces.Runner
 	// Optional custom output extractor function
 	outputExtractor func(result any) (string, error)
+	// Overrides of the configuration of the agent's runs
+	runOptions AgentRunOptions
+}
+
+// AgentRunOptions overrides the configuration of the runs of an agent used as a tool
+type AgentRunOptions struct {
+	// MaxTurns limits the turns of the run (optional)
+	MaxTurns int
+
+	// Model replaces the model of the agent (optional)
+	Model string
+
+	// ModelSettings override the model settings of the agent; their zero fields keep the agent's values (optional)
+	ModelSettings *model.Settings
+}
+
+// OptionsRunner is a Runner that can override the configuration of a run, required by agent tools
+// with AgentToolOption.MaxTurns, Model or ModelSettings
+type OptionsRunner interface {
+	interfaces.Runner
+
+	// RunWithOptions executes the agent with the given input, overriding the configuration of the run
+	RunWithOptions(ctx context.Context, agent any, input string, options AgentRunOptions) (any, error)
 }
 
 func (t *AgentTool) Name() string {
@@ -52,11 +76,17 @@ func (t *AgentTool) Invoke(ctx context.Context, paramsJSON string) (string, erro
 		Input string `json:"input"`
 	}
 	if err := json.Unmarshal([]byte(paramsJSON), &params); err != nil {
-		return "", fmt.Errorf("failed to parse parameters: %w", err)
+		return "", &ArgumentError{Err: fmt.Errorf("failed to parse parameters: %w", err)}
 	}
 
 	// Execute the agent
-	result, err := t.runner.Run(ctx, t.agent, params.Input)
+	var result any
+	var err error
+	if t.runOptions != (AgentRunOptions{}) {
+		result, err = t.runner.(OptionsRunner).RunWithOptions(ctx, t.agent, params.Input, t.runOptions)
+	} else {
+		result, err = t.runner.Run(ctx, t.agent, params.Input)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to run agent: %w", err)
 	}
@@ -82,6 +112,13 @@ type AgentToolOption struct {
 	Description string
 	// Custom function to extract output from agent result (optional)
 	OutputExtractor func(result any) (string, error)
+	// MaxTurns limits the turns of the agent's runs, e.g. to keep a sub-task short (optional).
+	// MaxTurns, Model and ModelSettings require a runner implementing OptionsRunner.
+	MaxTurns int
+	// Model replaces the model of the agent in its runs as a tool, e.g. a cheaper model for sub-tasks (optional)
+	Model string
+	// ModelSettings override the model settings of the agent in its runs as a tool (optional)
+	ModelSettings *model.Settings
 }
 
 // NewAgentTool converts an agent to a tool
@@ -128,6 +165,17 @@ func NewAgentTool(a interfaces.Agent, r interfaces.Runner, options ...AgentToolO
 		if option.OutputExtractor != nil {
 			agentTool.outputExtractor = option.OutputExtractor
 		}
+		agentTool.runOptions = AgentRunOptions{
+			MaxTurns:      option.MaxTurns,
+			Model:         option.Model,
+			ModelSettings: option.ModelSettings,
+		}
+	}
+
+	if agentTool.runOptions != (AgentRunOptions{}) {
+		if _, ok := r.(OptionsRunner); !ok {
+			return nil, fmt.Errorf("runner %T cannot override MaxTurns, Model or ModelSettings", r)
+		}
 	}
 
 	return agentTool, nil
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
 )
 
 // MockAgent implements interfaces.Agent interface for testing
@@ -113,3 +115,32 @@ func TestAgentToolInvoke(t *testing.T) {
 	assert.NoError(t, err, "Tool invocation should not return an error")
 	assert.Equal(t, "Custom extracted output", customResult, "Tool result should match custom output")
 }
+
+// MockOptionsRunner implements OptionsRunner for testing
+type MockOptionsRunner struct {
+	MockRunner
+	options AgentRunOptions
+}
+
+func (r *MockOptionsRunner) RunWithOptions(ctx context.Context, agent any, input string, options AgentRunOptions) (any, error) {
+	r.options = options
+	return r.result, r.err
+}
+
+func TestAgentToolRunOptions(t *testing.T) {
+	mockAgent := &MockAgent{name: "TestAgent"}
+
+	// Overrides need a runner that supports them
+	_, err := NewAgentTool(mockAgent, &MockRunner{}, AgentToolOption{MaxTurns: 2})
+	assert.Error(t, err)
+
+	settings := &model.Settings{Temperature: 0.1}
+	mockRunner := &MockOptionsRunner{MockRunner: MockRunner{result: &MockResult{output: "Mock result"}}}
+	agentTool, err := NewAgentTool(mockAgent, mockRunner, AgentToolOption{MaxTurns: 2, Model: "gpt-4o-mini", ModelSettings: settings})
+	assert.NoError(t, err)
+
+	result, err := agentTool.Invoke(context.Background(), `{"input":"test"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Mock result", result)
+	assert.Equal(t, AgentRunOptions{MaxTurns: 2, Model: "gpt-4o-mini", ModelSettings: settings}, mockRunner.options)
+}
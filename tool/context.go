@@ -0,0 +1,43 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import "context"
+
+// Context describes the call of a tool. The runner adds it to the context of every tool call, so
+// tools can log, rate-limit or behave differently depending on the caller without global state.
+type Context struct {
+	// AgentName is the name of the agent that called the tool
+	AgentName string
+
+	// ToolName is the name of the called tool
+	ToolName string
+
+	// ToolCallID is the ID of the model's tool call
+	ToolCallID string
+
+	// Turn is the turn of the run in which the model called the tool, starting at 1
+	Turn int
+
+	// RunID is the ID of the run, if it has one (see runner.RunConfig.RunID)
+	RunID string
+
+	// RunContext is the application value given to the run (see runner.RunConfig.RunContext)
+	RunContext any
+}
+
+type toolContextKey struct{}
+
+// ContextWithToolContext returns a context carrying the description of a tool call.
+// The runner installs it for every tool call; tool authors normally only call FromContext.
+func ContextWithToolContext(ctx context.Context, toolContext *Context) context.Context {
+	return context.WithValue(ctx, toolContextKey{}, toolContext)
+}
+
+// FromContext returns the description of the tool call being executed, from inside a tool's Invoke
+func FromContext(ctx context.Context) (*Context, bool) {
+	toolContext, ok := ctx.Value(toolContextKey{}).(*Context)
+	return toolContext, ok && toolContext != nil
+}
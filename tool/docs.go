@@ -0,0 +1,85 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// FunctionDoc documents a Go function used as a function tool. The tooldoc command generates
+// the registration of FunctionDoc and TypeDoc values from doc comments:
+//
+//	//go:generate go run github.com/ryichk/ai-agents-sdk-go/cmd/tooldoc -func getWeather -type Forecast
+type FunctionDoc struct {
+	// Description is the description of the tool, used unless FunctionToolOption.DescriptionOverride is set
+	Description string
+
+	// Params are the parameters of the function in order, context.Context included.
+	// Their names replace the generated param0, param1, ... names.
+	Params []ParamDoc
+}
+
+// ParamDoc documents a parameter of a function used as a tool
+type ParamDoc struct {
+	// Name is the name of the parameter in the tool's JSON schema
+	Name string
+
+	// Description is the description of the parameter (optional)
+	Description string
+}
+
+// TypeDoc documents the fields of a struct used in the parameters of function tools, by JSON name
+type TypeDoc map[string]string
+
+var (
+	functionDocs = make(map[string]FunctionDoc)
+	typeDocs     = make(map[reflect.Type]TypeDoc)
+	docsMu       sync.RWMutex
+)
+
+// RegisterFunctionDoc documents a function for NewFunctionTool. It must be called before the tool
+// is created, usually from an init function.
+func RegisterFunctionDoc(function any, doc FunctionDoc) {
+	name := functionName(reflect.ValueOf(function))
+	docsMu.Lock()
+	defer docsMu.Unlock()
+	functionDocs[name] = doc
+}
+
+// RegisterTypeDoc documents the fields of the struct type of value for the schemas of function tools
+func RegisterTypeDoc(value any, doc TypeDoc) {
+	t := reflect.TypeOf(value)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	docsMu.Lock()
+	defer docsMu.Unlock()
+	typeDocs[t] = doc
+}
+
+// functionName returns the qualified name of a function, which identifies its documentation
+func functionName(function reflect.Value) string {
+	if function.Kind() != reflect.Func {
+		return ""
+	}
+	return runtime.FuncForPC(function.Pointer()).Name()
+}
+
+// lookupFunctionDoc returns the documentation registered for a function
+func lookupFunctionDoc(function reflect.Value) (FunctionDoc, bool) {
+	docsMu.RLock()
+	defer docsMu.RUnlock()
+	doc, ok := functionDocs[functionName(function)]
+	return doc, ok
+}
+
+// lookupTypeDoc returns the documentation registered for a struct type
+func lookupTypeDoc(t reflect.Type) TypeDoc {
+	docsMu.RLock()
+	defer docsMu.RUnlock()
+	return typeDocs[t]
+}
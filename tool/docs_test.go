@@ -0,0 +1,101 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type forecastRequest struct {
+	City  string `json:"city"`
+	Units string `json:"units"`
+}
+
+func forecast(ctx context.Context, city string, days int) string {
+	return fmt.Sprintf("%s: sunny for %d days", city, days)
+}
+
+func forecastFor(request forecastRequest) string {
+	return request.City + " in " + request.Units
+}
+
+func TestFunctionDoc(t *testing.T) {
+	RegisterFunctionDoc(forecast, FunctionDoc{
+		Description: "Returns the weather forecast of a city.",
+		Params: []ParamDoc{
+			{Name: "ctx"},
+			{Name: "city", Description: "the name of the city"},
+			{Name: "days", Description: "the number of days to forecast"},
+		},
+	})
+
+	forecastTool, err := NewFunctionTool(forecast)
+	require.NoError(t, err)
+	assert.Equal(t, "Returns the weather forecast of a city.", forecastTool.Description())
+
+	properties := forecastTool.ParamsJSONSchema()["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "description": "the name of the city"}, properties["city"])
+	assert.Equal(t, map[string]any{"type": "integer", "description": "the number of days to forecast"}, properties["days"])
+	assert.ElementsMatch(t, []string{"city", "days"}, forecastTool.ParamsJSONSchema()["required"])
+
+	result, err := forecastTool.Invoke(context.Background(), `{"city":"Tokyo","days":3}`)
+	require.NoError(t, err)
+	assert.Equal(t, `"Tokyo: sunny for 3 days"`, result)
+
+	overridden, err := NewFunctionTool(forecast, FunctionToolOption{DescriptionOverride: "Forecast"})
+	require.NoError(t, err)
+	assert.Equal(t, "Forecast", overridden.Description())
+}
+
+func TestTypeDoc(t *testing.T) {
+	RegisterTypeDoc(&forecastRequest{}, TypeDoc{"city": "the name of the city"})
+
+	forecastTool, err := NewFunctionTool(forecastFor)
+	require.NoError(t, err)
+
+	properties := forecastTool.ParamsJSONSchema()["properties"].(map[string]any)
+	request := properties["param0"].(map[string]any)
+	fields := request["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "description": "the name of the city"}, fields["city"])
+	assert.Equal(t, map[string]any{"type": "string"}, fields["units"])
+
+	result, err := forecastTool.Invoke(context.Background(), `{"param0":{"city":"Tokyo","units":"metric"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, `"Tokyo in metric"`, result)
+}
+
+type ticketRequest struct {
+	Title    string   `json:"title" description:"A one-line summary"`
+	Priority string   `json:"priority" enum:"low,high"`
+	Assignee *string  `json:"assignee"`
+	Labels   []string `json:"labels,omitempty"`
+}
+
+func createTicket(request ticketRequest) string {
+	return request.Title + " (" + request.Priority + ")"
+}
+
+func TestStructTags(t *testing.T) {
+	RegisterTypeDoc(ticketRequest{}, TypeDoc{"title": "ignored, the tag wins", "labels": "the labels of the ticket"})
+
+	ticketTool, err := NewFunctionTool(createTicket)
+	require.NoError(t, err)
+
+	request := ticketTool.ParamsJSONSchema()["properties"].(map[string]any)["param0"].(map[string]any)
+	assert.Equal(t, []string{"title", "priority"}, request["required"])
+	properties := request["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "description": "A one-line summary"}, properties["title"])
+	assert.Equal(t, map[string]any{"type": "string", "enum": []any{"low", "high"}}, properties["priority"])
+	assert.Equal(t, "the labels of the ticket", properties["labels"].(map[string]any)["description"])
+
+	result, err := ticketTool.Invoke(context.Background(), `{"param0":{"title":"Login fails","priority":"high"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, `"Login fails (high)"`, result)
+}
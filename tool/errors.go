@@ -0,0 +1,38 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidArguments is matched by errors.Is for every ArgumentError
+var ErrInvalidArguments = errors.New("invalid tool arguments")
+
+// ArgumentError reports that the arguments generated by the model for a tool call are invalid.
+// Tools return it so the runner can ask the model to correct the call instead of failing the run.
+type ArgumentError struct {
+	// Err describes what is wrong with the arguments
+	Err error
+}
+
+// NewArgumentError creates an ArgumentError with a formatted message
+func NewArgumentError(format string, args ...any) *ArgumentError {
+	return &ArgumentError{Err: fmt.Errorf(format, args...)}
+}
+
+func (e *ArgumentError) Error() string {
+	return fmt.Sprintf("invalid tool arguments: %v", e.Err)
+}
+
+func (e *ArgumentError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidArguments
+func (e *ArgumentError) Is(target error) bool {
+	return target == ErrInvalidArguments
+}
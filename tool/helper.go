@@ -0,0 +1,249 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+const (
+	// DefaultHelperMaxRetries is the default number of retries performed by Helper
+	DefaultHelperMaxRetries = 2
+
+	// DefaultHelperRetryDelay is the default initial delay between retries
+	DefaultHelperRetryDelay = 500 * time.Millisecond
+)
+
+// ProgressEvent represents a progress notification emitted by a tool during Invoke
+type ProgressEvent struct {
+	// ToolName is the name of the tool reporting progress (may be empty)
+	ToolName string `json:"tool_name"`
+
+	// Message is a human-readable progress message
+	Message string `json:"message"`
+
+	// Progress is the completion ratio between 0.0 and 1.0 (negative if unknown)
+	Progress float64 `json:"progress"`
+
+	// Timestamp is when the event was emitted
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ProgressHandler receives progress events emitted by tools
+type ProgressHandler func(ctx context.Context, event ProgressEvent)
+
+type progressKey struct{}
+
+type progressTarget struct {
+	toolName string
+	handler  ProgressHandler
+}
+
+// ContextWithProgressHandler returns a context that delivers progress events of the named tool to handler.
+// The runner installs a handler for every tool invocation; tool authors normally only call ReportProgress.
+func ContextWithProgressHandler(ctx context.Context, toolName string, handler ProgressHandler) context.Context {
+	return context.WithValue(ctx, progressKey{}, progressTarget{toolName: toolName, handler: handler})
+}
+
+// ReportProgress emits a progress event from inside a tool's Invoke.
+// The event is recorded on the active tracing span and delivered to the handler installed in ctx, if any.
+func ReportProgress(ctx context.Context, message string, progress float64) {
+	event := ProgressEvent{
+		Message:   message,
+		Progress:  progress,
+		Timestamp: time.Now(),
+	}
+
+	target, _ := ctx.Value(progressKey{}).(progressTarget)
+	event.ToolName = target.toolName
+
+	if span := tracing.GetActiveSpan(ctx); span != nil {
+		span.AddEvent("tool_progress", map[string]any{
+			"tool_name": event.ToolName,
+			"message":   event.Message,
+			"progress":  event.Progress,
+		})
+	}
+
+	if target.handler != nil {
+		target.handler(ctx, event)
+	}
+}
+
+// Helper bundles common utilities for tool implementations:
+// context-aware HTTP calls, bounded retries and progress reporting.
+type Helper struct {
+	// HTTPClient is the client used by Do (defaults to http.DefaultClient)
+	HTTPClient *http.Client
+
+	// MaxRetries is the maximum number of retries after the first attempt (negative values mean none)
+	MaxRetries int
+
+	// RetryDelay is the initial delay between attempts; it doubles after each retry
+	RetryDelay time.Duration
+}
+
+// NewHelper creates a Helper with default settings
+func NewHelper() *Helper {
+	return &Helper{
+		HTTPClient: http.DefaultClient,
+		MaxRetries: DefaultHelperMaxRetries,
+		RetryDelay: DefaultHelperRetryDelay,
+	}
+}
+
+// Retry calls fn until it succeeds, the retries are exhausted, or ctx is cancelled.
+// Errors wrapped with Permanent are returned immediately without retrying.
+func (h *Helper) Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	delay := h.RetryDelay
+	maxRetries := max(h.MaxRetries, 0)
+	var err error
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+
+		if attempt == maxRetries {
+			break
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+		delay *= 2
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", maxRetries+1, err)
+}
+
+// Do sends an HTTP request bound to ctx, retrying on network errors, 429 and 5xx responses.
+// The request body must be nil or support GetBody so it can be replayed between attempts.
+func (h *Helper) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	client := h.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var resp *http.Response
+	err := h.Retry(ctx, func(ctx context.Context) error {
+		attemptReq := req.Clone(ctx)
+		if req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return Permanent(fmt.Errorf("failed to rewind request body: %w", err))
+			}
+			attemptReq.Body = body
+		}
+
+		r, err := client.Do(attemptReq)
+		if err != nil {
+			if ctx.Err() != nil {
+				return Permanent(ctx.Err())
+			}
+			return err
+		}
+
+		if r.StatusCode == http.StatusTooManyRequests || r.StatusCode >= http.StatusInternalServerError {
+			_, _ = io.Copy(io.Discard, r.Body)
+			r.Body.Close()
+			return fmt.Errorf("request failed with status %d", r.StatusCode)
+		}
+
+		resp = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Get is a convenience wrapper around Do that performs a GET request and returns the body.
+// Bodies larger than maxBytes are truncated (maxBytes <= 0 means no limit).
+func (h *Helper) Get(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := h.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var reader io.Reader = resp.Body
+	if maxBytes > 0 {
+		reader = io.LimitReader(resp.Body, maxBytes)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return body, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	return body, nil
+}
+
+// permanentError marks an error that should not be retried
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent wraps err so that Helper.Retry stops immediately and returns it
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelperRetry(t *testing.T) {
+	h := &Helper{MaxRetries: 2, RetryDelay: time.Millisecond}
+
+	// Succeeds on the third attempt
+	attempts := 0
+	err := h.Retry(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("temporary")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	// Gives up after MaxRetries
+	attempts = 0
+	err = h.Retry(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return errors.New("always fails")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Contains(t, err.Error(), "always fails")
+
+	// Permanent errors are not retried
+	attempts = 0
+	permanent := errors.New("bad request")
+	err = h.Retry(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return Permanent(permanent)
+	})
+	assert.ErrorIs(t, err, permanent)
+	assert.Equal(t, 1, attempts)
+
+	// A negative MaxRetries still makes the first attempt
+	h.MaxRetries = -1
+	attempts = 0
+	failure := errors.New("always fails")
+	err = h.Retry(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 1, attempts)
+	assert.Contains(t, err.Error(), "giving up after 1 attempts")
+}
+
+func TestHelperRetryCancelled(t *testing.T) {
+	h := &Helper{MaxRetries: 5, RetryDelay: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	err := h.Retry(ctx, func(ctx context.Context) error {
+		attempts++
+		return errors.New("temporary")
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestHelperDo(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("hello world"))
+	}))
+	defer server.Close()
+
+	h := NewHelper()
+	h.RetryDelay = time.Millisecond
+
+	body, err := h.Get(context.Background(), server.URL, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestReportProgress(t *testing.T) {
+	var events []ProgressEvent
+	ctx := ContextWithProgressHandler(context.Background(), "search", func(ctx context.Context, event ProgressEvent) {
+		events = append(events, event)
+	})
+
+	ReportProgress(ctx, "fetching", 0.5)
+	ReportProgress(context.Background(), "ignored", 1.0)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, "search", events[0].ToolName)
+	assert.Equal(t, "fetching", events[0].Message)
+	assert.Equal(t, 0.5, events[0].Progress)
+}
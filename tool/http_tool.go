@@ -0,0 +1,272 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultHTTPToolTimeout is the default time limit of a request made by an HTTPTool
+	DefaultHTTPToolTimeout = 30 * time.Second
+
+	// DefaultHTTPToolMaxResponseBytes is the default limit of the response body returned to the model
+	DefaultHTTPToolMaxResponseBytes = 64 * 1024
+)
+
+// ErrInvalidHTTPToolConfig is returned by NewHTTPTool for an incomplete configuration
+var ErrInvalidHTTPToolConfig = errors.New("invalid HTTP tool configuration")
+
+// HTTPToolConfig declares the request an HTTPTool sends
+type HTTPToolConfig struct {
+	// Name is the name of the tool
+	Name string
+
+	// Description tells the model what the tool returns
+	Description string
+
+	// Method is the HTTP method (defaults to GET)
+	Method string
+
+	// URL is the URL template. Placeholders such as {city} are replaced with the path-escaped
+	// argument of the same name, e.g. "https://api.example.com/weather/{city}".
+	URL string
+
+	// Headers are sent with every request; placeholders are replaced with the arguments
+	Headers map[string]string
+
+	// Params is the JSON schema of the arguments (optional). It defaults to one required
+	// string argument per URL placeholder.
+	Params map[string]any
+
+	// Auth adds credentials to the request (optional)
+	Auth func(ctx context.Context, req *http.Request) error
+
+	// Helper sends the request with its client and retries (optional, defaults to NewHelper())
+	Helper *Helper
+
+	// Timeout limits each invocation, including retries (defaults to DefaultHTTPToolTimeout)
+	Timeout time.Duration
+
+	// MaxResponseBytes limits the response body returned to the model (defaults to DefaultHTTPToolMaxResponseBytes)
+	MaxResponseBytes int64
+}
+
+// HTTPTool is a tool that renders an HTTP request from the model's arguments and returns the
+// response body. Arguments that are not URL or header placeholders are sent as query parameters for GET,
+// HEAD and DELETE requests, and as a JSON object body otherwise.
+type HTTPTool struct {
+	config       HTTPToolConfig
+	placeholders []string
+}
+
+var placeholderPattern = regexp.MustCompile(`\{([a-zA-Z0-9_]+)\}`)
+
+// NewHTTPTool creates an HTTP tool from the configuration
+func NewHTTPTool(config HTTPToolConfig) (*HTTPTool, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidHTTPToolConfig)
+	}
+	if config.URL == "" {
+		return nil, fmt.Errorf("%w: URL is required", ErrInvalidHTTPToolConfig)
+	}
+	if config.Method == "" {
+		config.Method = http.MethodGet
+	}
+	config.Method = strings.ToUpper(config.Method)
+	if config.Helper == nil {
+		config.Helper = NewHelper()
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultHTTPToolTimeout
+	}
+	if config.MaxResponseBytes <= 0 {
+		config.MaxResponseBytes = DefaultHTTPToolMaxResponseBytes
+	}
+
+	t := &HTTPTool{config: config, placeholders: []string{}}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(config.URL, -1) {
+		t.placeholders = append(t.placeholders, match[1])
+	}
+
+	if t.config.Params == nil {
+		properties := map[string]any{}
+		for _, name := range t.placeholders {
+			properties[name] = map[string]any{"type": "string"}
+		}
+		t.config.Params = map[string]any{
+			"type":       "object",
+			"properties": properties,
+			"required":   t.placeholders,
+		}
+	}
+
+	properties, _ := t.config.Params["properties"].(map[string]any)
+	for _, name := range t.placeholders {
+		if _, ok := properties[name]; !ok {
+			return nil, fmt.Errorf("%w: URL placeholder {%s} is not a parameter", ErrInvalidHTTPToolConfig, name)
+		}
+	}
+	return t, nil
+}
+
+func (t *HTTPTool) Name() string {
+	return t.config.Name
+}
+
+func (t *HTTPTool) Description() string {
+	return t.config.Description
+}
+
+func (t *HTTPTool) ParamsJSONSchema() map[string]any {
+	return t.config.Params
+}
+
+// Invoke sends the request and returns the response body. Responses with an error status are
+// returned to the model with the status, so it can correct the call or explain the failure.
+func (t *HTTPTool) Invoke(ctx context.Context, paramsJSON string) (string, error) {
+	args := map[string]any{}
+	if strings.TrimSpace(paramsJSON) != "" {
+		if err := json.Unmarshal([]byte(paramsJSON), &args); err != nil {
+			return "", &ArgumentError{Err: fmt.Errorf("failed to parse parameters: %w", err)}
+		}
+	}
+	for _, name := range requiredParams(t.config.Params) {
+		if v, ok := args[name]; !ok || v == nil {
+			return "", NewArgumentError("parameter '%s' is required", name)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.config.Timeout)
+	defer cancel()
+
+	req, err := t.newRequest(ctx, args)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := t.config.Helper.Do(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", t.config.Method, req.URL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.config.MaxResponseBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Sprintf("Error: HTTP %d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body), nil
+	}
+	return string(body), nil
+}
+
+// newRequest renders the request from the arguments
+func (t *HTTPTool) newRequest(ctx context.Context, args map[string]any) (*http.Request, error) {
+	rest := make(map[string]any, len(args))
+	for name, value := range args {
+		rest[name] = value
+	}
+
+	render := func(template string, escape func(string) string) string {
+		return placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+			name := placeholder[1 : len(placeholder)-1]
+			delete(rest, name)
+			return escape(argString(args[name]))
+		})
+	}
+	target := render(t.config.URL, url.PathEscape)
+	headers := make(map[string]string, len(t.config.Headers))
+	for name, value := range t.config.Headers {
+		headers[name] = render(value, func(s string) string { return s })
+	}
+
+	var body io.Reader
+	switch t.config.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		if len(rest) > 0 {
+			u, err := url.Parse(target)
+			if err != nil {
+				return nil, fmt.Errorf("invalid URL: %w", err)
+			}
+			query := u.Query()
+			for name, value := range rest {
+				if values, ok := value.([]any); ok {
+					for _, v := range values {
+						query.Add(name, argString(v))
+					}
+					continue
+				}
+				query.Set(name, argString(value))
+			}
+			u.RawQuery = query.Encode()
+			target = u.String()
+		}
+	default:
+		encoded, err := json.Marshal(rest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, t.config.Method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if t.config.Auth != nil {
+		if err := t.config.Auth(ctx, req); err != nil {
+			return nil, fmt.Errorf("failed to authenticate request: %w", err)
+		}
+	}
+	return req, nil
+}
+
+// requiredParams returns the names listed as required by the schema
+func requiredParams(schema map[string]any) []string {
+	switch required := schema["required"].(type) {
+	case []string:
+		return required
+	case []any:
+		names := make([]string, 0, len(required))
+		for _, name := range required {
+			if s, ok := name.(string); ok {
+				names = append(names, s)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// argString formats an argument for a URL or header; strings are used as they are
+func argString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPToolGet(t *testing.T) {
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		if strings.HasSuffix(r.URL.Path, "/Atlantis") {
+			http.Error(w, "unknown city", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"forecast":"sunny"}`))
+	}))
+	defer server.Close()
+
+	weather, err := NewHTTPTool(HTTPToolConfig{
+		Name:        "get_weather",
+		Description: "Get the weather forecast of a city",
+		URL:         server.URL + "/weather/{city}",
+		Headers:     map[string]string{"X-Units": "{units}"},
+		Params: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"city":  map[string]any{"type": "string"},
+				"units": map[string]any{"type": "string"},
+				"days":  map[string]any{"type": "integer"},
+			},
+			"required": []string{"city"},
+		},
+		Auth: func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer secret")
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	output, err := weather.Invoke(context.Background(), `{"city": "San Francisco", "units": "metric", "days": 3}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"forecast":"sunny"}`, output)
+	assert.Equal(t, http.MethodGet, got.Method)
+	assert.Equal(t, "/weather/San%20Francisco", got.URL.EscapedPath())
+	assert.Equal(t, "days=3", got.URL.RawQuery)
+	assert.Equal(t, "metric", got.Header.Get("X-Units"))
+	assert.Equal(t, "Bearer secret", got.Header.Get("Authorization"))
+
+	// Error statuses are returned to the model
+	output, err = weather.Invoke(context.Background(), `{"city": "Atlantis"}`)
+	require.NoError(t, err)
+	assert.Contains(t, output, "HTTP 404")
+	assert.Contains(t, output, "unknown city")
+
+	_, err = weather.Invoke(context.Background(), `{}`)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+}
+
+func TestHTTPToolPostAndLimits(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer server.Close()
+
+	create, err := NewHTTPTool(HTTPToolConfig{
+		Name:             "create_ticket",
+		Method:           "post",
+		URL:              server.URL + "/projects/{project}/tickets",
+		MaxResponseBytes: 10,
+		Params: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"project": map[string]any{"type": "string"},
+				"title":   map[string]any{"type": "string"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	output, err := create.Invoke(context.Background(), `{"project": "web", "title": "Broken link"}`)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("x", 10), output)
+	assert.JSONEq(t, `{"title": "Broken link"}`, body)
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer slow.Close()
+
+	timeout, err := NewHTTPTool(HTTPToolConfig{
+		Name:    "slow",
+		URL:     slow.URL,
+		Timeout: 20 * time.Millisecond,
+		Helper:  &Helper{MaxRetries: 0},
+	})
+	require.NoError(t, err)
+	_, err = timeout.Invoke(context.Background(), `{}`)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNewHTTPToolConfig(t *testing.T) {
+	lookup, err := NewHTTPTool(HTTPToolConfig{Name: "lookup", URL: "https://example.com/users/{id}"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"id": map[string]any{"type": "string"}},
+		"required":   []string{"id"},
+	}, lookup.ParamsJSONSchema())
+
+	// A URL without placeholders takes no required parameters, not a null list
+	status, err := NewHTTPTool(HTTPToolConfig{Name: "status", URL: "https://example.com/status"})
+	require.NoError(t, err)
+	schema, err := json.Marshal(status.ParamsJSONSchema())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","properties":{},"required":[]}`, string(schema))
+
+	_, err = NewHTTPTool(HTTPToolConfig{URL: "https://example.com"})
+	assert.ErrorIs(t, err, ErrInvalidHTTPToolConfig)
+
+	_, err = NewHTTPTool(HTTPToolConfig{
+		Name:   "lookup",
+		URL:    "https://example.com/users/{id}",
+		Params: map[string]any{"type": "object", "properties": map[string]any{}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidHTTPToolConfig)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package openapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// Auth authenticates the requests of the generated tools
+type Auth interface {
+	// Apply adds the credentials to the request
+	Apply(ctx context.Context, req *http.Request) error
+}
+
+// AuthFunc is a function implementing Auth, such as one fetching a fresh OAuth token
+type AuthFunc func(ctx context.Context, req *http.Request) error
+
+// Apply calls the function
+func (f AuthFunc) Apply(ctx context.Context, req *http.Request) error {
+	return f(ctx, req)
+}
+
+// BearerToken authenticates with an "Authorization: Bearer" header
+func BearerToken(token string) Auth {
+	return AuthFunc(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// BasicAuth authenticates with HTTP basic authentication
+func BasicAuth(username, password string) Auth {
+	return AuthFunc(func(ctx context.Context, req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	})
+}
+
+// APIKeyHeader sends an API key in a header
+func APIKeyHeader(name, key string) Auth {
+	return AuthFunc(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set(name, key)
+		return nil
+	})
+}
+
+// APIKeyQuery sends an API key as a query parameter
+func APIKeyQuery(name, key string) Auth {
+	return AuthFunc(func(ctx context.Context, req *http.Request) error {
+		query := req.URL.Query()
+		query.Set(name, key)
+		req.URL.RawQuery = query.Encode()
+		return nil
+	})
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+const petStore = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pet Store", "version": "1.0.0"},
+  "servers": [{"url": "https://petstore.example.com/v1"}],
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "summary": "List pets",
+        "parameters": [
+          {"name": "limit", "in": "query", "description": "Maximum number of pets", "schema": {"type": "integer"}},
+          {"name": "tag", "in": "query", "schema": {"type": "array", "items": {"type": "string"}}}
+        ]
+      },
+      "post": {
+        "operationId": "createPet",
+        "summary": "Create a pet",
+        "requestBody": {"$ref": "#/components/requestBodies/NewPet"}
+      }
+    },
+    "/pets/{petId}": {
+      "parameters": [{"$ref": "#/components/parameters/PetID"}],
+      "get": {"summary": "Get a pet"},
+      "delete": {"operationId": "deletePet", "deprecated": true}
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "required": ["name"],
+        "properties": {
+          "name": {"type": "string"},
+          "parent": {"$ref": "#/components/schemas/Pet"}
+        }
+      }
+    },
+    "parameters": {
+      "PetID": {"name": "petId", "in": "path", "required": true, "schema": {"type": "string"}}
+    },
+    "requestBodies": {
+      "NewPet": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+    }
+  }
+}`
+
+func newPetStoreTools(t *testing.T, opts ...Option) map[string]tool.Tool {
+	t.Helper()
+	spec, err := Parse([]byte(petStore))
+	require.NoError(t, err)
+	tools, err := NewTools(spec, opts...)
+	require.NoError(t, err)
+	index, err := tool.Index(tools)
+	require.NoError(t, err)
+	return index
+}
+
+func TestNewToolsSchemas(t *testing.T) {
+	tools := newPetStoreTools(t)
+	require.Len(t, tools, 3)
+
+	list := tools["listPets"]
+	require.NotNil(t, list)
+	assert.Equal(t, "List pets", list.Description())
+	schema, err := json.Marshal(list.ParamsJSONSchema())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"limit": {"type": "integer", "description": "Maximum number of pets"},
+			"tag": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": []
+	}`, string(schema))
+
+	// Refs are inlined, cutting recursive schemas
+	create := tools["createPet"]
+	require.NotNil(t, create)
+	schema, err = json.Marshal(create.ParamsJSONSchema())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"body": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {"name": {"type": "string"}, "parent": {}}
+			}
+		},
+		"required": ["body"]
+	}`, string(schema))
+
+	// Operations without an ID are named after their method and path
+	get := tools["get_pets_petId"]
+	require.NotNil(t, get)
+	assert.Equal(t, []string{"petId"}, get.ParamsJSONSchema()["required"])
+}
+
+func TestOperationToolInvoke(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, string(body))
+		if r.URL.Path == "/v1/pets/missing" {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	tools := newPetStoreTools(t, WithBaseURL(server.URL+"/v1"), WithAuth(BearerToken("secret")), WithHeader("X-Client", "agents"))
+	ctx := context.Background()
+
+	output, err := tools["listPets"].Invoke(ctx, `{"limit": 10, "tag": ["cat", "dog"]}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"ok":true}`, output)
+	assert.Equal(t, "GET", requests[0].Method)
+	assert.Equal(t, "/v1/pets", requests[0].URL.Path)
+	assert.Equal(t, "limit=10&tag=cat&tag=dog", requests[0].URL.RawQuery)
+	assert.Equal(t, "Bearer secret", requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "agents", requests[0].Header.Get("X-Client"))
+
+	_, err = tools["createPet"].Invoke(ctx, `{"body": {"name": "Tama"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "POST", requests[1].Method)
+	assert.Equal(t, "application/json", requests[1].Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"name": "Tama"}`, bodies[1])
+
+	// Error statuses are returned to the model
+	output, err = tools["get_pets_petId"].Invoke(ctx, `{"petId": "missing"}`)
+	require.NoError(t, err)
+	assert.Contains(t, output, "HTTP 404")
+	assert.Contains(t, output, "not found")
+
+	// Missing required parameters ask the model to correct the call
+	_, err = tools["get_pets_petId"].Invoke(ctx, `{}`)
+	assert.ErrorIs(t, err, tool.ErrInvalidArguments)
+	_, err = tools["createPet"].Invoke(ctx, `{}`)
+	assert.ErrorIs(t, err, tool.ErrInvalidArguments)
+}
+
+func TestNewToolsOptions(t *testing.T) {
+	tools := newPetStoreTools(t, WithOperations("listPets", "deletePet"))
+	assert.Len(t, tools, 2)
+
+	_, err := Parse([]byte(`{"swagger": "2.0"}`))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	spec, err := Parse([]byte(`{"openapi": "3.1.0", "paths": {}}`))
+	require.NoError(t, err)
+	_, err = NewTools(spec)
+	assert.ErrorIs(t, err, ErrNoServer)
+
+	spec, err = Parse([]byte(`{"openapi": "3.1.0", "servers": [{"url": "http://x"}], "paths": {"/a": {"get": {"parameters": [{"$ref": "#/components/parameters/Missing"}]}}}}`))
+	require.NoError(t, err)
+	_, err = NewTools(spec)
+	assert.ErrorIs(t, err, ErrUnresolvedRef)
+}
+
+const mixedBodies = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Files", "version": "1.0.0"},
+  "servers": [{"url": "https://files.example.com"}],
+  "paths": {
+    "/files": {
+      "post": {
+        "operationId": "uploadFile",
+        "requestBody": {"content": {"multipart/form-data": {"schema": {"type": "object"}}}}
+      },
+      "put": {
+        "operationId": "replaceFile",
+        "requestBody": {"content": {"application/json; charset=utf-8": {"schema": {"type": "object"}}}}
+      }
+    },
+    "/files/{id}": {
+      "patch": {
+        "operationId": "patchFile",
+        "parameters": [{"name": "id", "in": "path", "required": true}],
+        "requestBody": {"content": {"application/merge-patch+json": {"schema": {"type": "object"}}}}
+      }
+    }
+  }
+}`
+
+func TestNewToolsRequestBodyContentTypes(t *testing.T) {
+	var contentTypes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	spec, err := Parse([]byte(mixedBodies))
+	require.NoError(t, err)
+
+	// The multipart upload is skipped instead of failing the whole document
+	tools, err := NewTools(spec, WithBaseURL(server.URL))
+	require.NoError(t, err)
+	index, err := tool.Index(tools)
+	require.NoError(t, err)
+	assert.Len(t, index, 2)
+	assert.NotContains(t, index, "uploadFile")
+
+	// JSON bodies are sent with the content type of the document
+	_, err = index["replaceFile"].Invoke(context.Background(), `{"body": {"name": "a.txt"}}`)
+	require.NoError(t, err)
+	_, err = index["patchFile"].Invoke(context.Background(), `{"id": "1", "body": {"name": "b.txt"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"application/json; charset=utf-8", "application/merge-patch+json"}, contentTypes)
+
+	path := spec.Paths["/files"]
+	_, err = newOperationTool(spec, server.URL, "post", "/files", path, path.Operations["post"], &Options{})
+	assert.ErrorIs(t, err, ErrUnsupportedRequestBody)
+}
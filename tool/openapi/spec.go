@@ -0,0 +1,212 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package openapi turns the operations of an OpenAPI 3 document into tools, so agents can call
+// existing REST APIs. Each operation becomes a tool.Tool whose parameters schema is derived from
+// the operation's parameters and JSON request body, and whose invocation sends the HTTP request.
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Errors returned when loading a document
+var (
+	// ErrUnsupportedVersion is returned for documents that are not OpenAPI 3
+	ErrUnsupportedVersion = errors.New("unsupported OpenAPI version")
+
+	// ErrUnresolvedRef is returned for a $ref that does not point into the document's components
+	ErrUnresolvedRef = errors.New("unresolved $ref")
+)
+
+// Spec is the part of an OpenAPI 3 document needed to call its operations
+type Spec struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components,omitempty"`
+}
+
+// Info is the metadata of the API
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Server is a base URL of the API
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem holds the operations of a path by lowercase HTTP method, and the parameters shared by them
+type PathItem struct {
+	Parameters []Parameter
+	Operations map[string]*Operation
+}
+
+// httpMethods are the keys of a path item that hold operations
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// UnmarshalJSON splits the path item into its shared parameters and its operations
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if params, ok := raw["parameters"]; ok {
+		if err := json.Unmarshal(params, &p.Parameters); err != nil {
+			return err
+		}
+	}
+	p.Operations = map[string]*Operation{}
+	for _, method := range httpMethods {
+		if op, ok := raw[method]; ok {
+			var operation Operation
+			if err := json.Unmarshal(op, &operation); err != nil {
+				return fmt.Errorf("%s: %w", method, err)
+			}
+			p.Operations[method] = &operation
+		}
+	}
+	return nil
+}
+
+// Operation is an API operation
+type Operation struct {
+	OperationID string       `json:"operationId,omitempty"`
+	Summary     string       `json:"summary,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	Parameters  []Parameter  `json:"parameters,omitempty"`
+	RequestBody *RequestBody `json:"requestBody,omitempty"`
+	Deprecated  bool         `json:"deprecated,omitempty"`
+}
+
+// Parameter is a path, query, header or cookie parameter of an operation
+type Parameter struct {
+	Ref         string         `json:"$ref,omitempty"`
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      map[string]any `json:"schema,omitempty"`
+}
+
+// RequestBody is the request body of an operation
+type RequestBody struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema of a request body for a content type
+type MediaType struct {
+	Schema map[string]any `json:"schema,omitempty"`
+}
+
+// Components holds the reusable objects referenced with $ref
+type Components struct {
+	Schemas         map[string]map[string]any `json:"schemas,omitempty"`
+	Parameters      map[string]Parameter      `json:"parameters,omitempty"`
+	RequestBodies   map[string]RequestBody    `json:"requestBodies,omitempty"`
+	SecuritySchemes map[string]map[string]any `json:"securitySchemes,omitempty"`
+}
+
+// Parse parses an OpenAPI 3 document in JSON. YAML documents must be converted to JSON first.
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedVersion, spec.OpenAPI)
+	}
+	return &spec, nil
+}
+
+// Load reads and parses an OpenAPI 3 document in JSON from a file
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// parameter resolves a parameter that may be a $ref to the components
+func (s *Spec) parameter(p Parameter) (Parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/")
+	if resolved, found := s.Components.Parameters[name]; ok && found {
+		return resolved, nil
+	}
+	return Parameter{}, fmt.Errorf("%w: %s", ErrUnresolvedRef, p.Ref)
+}
+
+// requestBody resolves a request body that may be a $ref to the components
+func (s *Spec) requestBody(b *RequestBody) (*RequestBody, error) {
+	if b == nil || b.Ref == "" {
+		return b, nil
+	}
+	name, ok := strings.CutPrefix(b.Ref, "#/components/requestBodies/")
+	if resolved, found := s.Components.RequestBodies[name]; ok && found {
+		return &resolved, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnresolvedRef, b.Ref)
+}
+
+// resolveSchema returns a copy of the schema with the $refs to component schemas inlined.
+// Recursive schemas are cut at the first repetition with an unconstrained schema.
+func (s *Spec) resolveSchema(schema map[string]any, seen map[string]bool) (map[string]any, error) {
+	if ref, ok := schema["$ref"].(string); ok {
+		name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+		target, found := s.Components.Schemas[name]
+		if !ok || !found {
+			return nil, fmt.Errorf("%w: %s", ErrUnresolvedRef, ref)
+		}
+		if seen[name] {
+			return map[string]any{}, nil
+		}
+		seen[name] = true
+		defer delete(seen, name)
+		return s.resolveSchema(target, seen)
+	}
+
+	resolved := make(map[string]any, len(schema))
+	for key, value := range schema {
+		v, err := s.resolveValue(value, seen)
+		if err != nil {
+			return nil, err
+		}
+		resolved[key] = v
+	}
+	return resolved, nil
+}
+
+func (s *Spec) resolveValue(value any, seen map[string]bool) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		return s.resolveSchema(v, seen)
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := s.resolveValue(item, seen)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = resolved
+		}
+		return items, nil
+	default:
+		return value, nil
+	}
+}
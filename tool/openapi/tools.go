@@ -0,0 +1,432 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+// ErrNoServer is returned when neither the document nor the options give a base URL
+var ErrNoServer = errors.New("OpenAPI document has no server URL")
+
+// ErrUnsupportedRequestBody is returned for an operation whose request body has no JSON content type
+var ErrUnsupportedRequestBody = errors.New("unsupported request body content type")
+
+// DefaultMaxResponseBytes is the default limit of the response body returned to the model
+const DefaultMaxResponseBytes = 64 * 1024
+
+// bodyParam is the name of the tool parameter holding the JSON request body
+const bodyParam = "body"
+
+// Options configures the generated tools
+type Options struct {
+	// BaseURL overrides the first server URL of the document
+	BaseURL string
+
+	// HTTPClient sends the requests (optional, defaults to http.DefaultClient)
+	HTTPClient *http.Client
+
+	// Auth authenticates the requests (optional)
+	Auth Auth
+
+	// Headers are added to every request
+	Headers map[string]string
+
+	// Filter selects the operations to generate tools for (optional, defaults to all but deprecated ones)
+	Filter func(method, path string, op *Operation) bool
+
+	// MaxResponseBytes limits the response body returned to the model (defaults to DefaultMaxResponseBytes)
+	MaxResponseBytes int64
+}
+
+// Option configures the generated tools
+type Option func(*Options)
+
+// WithBaseURL overrides the server URL of the document
+func WithBaseURL(baseURL string) Option {
+	return func(o *Options) {
+		o.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the client sending the requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) {
+		o.HTTPClient = client
+	}
+}
+
+// WithAuth sets how requests are authenticated
+func WithAuth(auth Auth) Option {
+	return func(o *Options) {
+		o.Auth = auth
+	}
+}
+
+// WithHeader adds a header to every request
+func WithHeader(name, value string) Option {
+	return func(o *Options) {
+		if o.Headers == nil {
+			o.Headers = map[string]string{}
+		}
+		o.Headers[name] = value
+	}
+}
+
+// WithOperations generates tools only for the operations with the IDs
+func WithOperations(operationIDs ...string) Option {
+	return func(o *Options) {
+		o.Filter = func(method, path string, op *Operation) bool {
+			for _, id := range operationIDs {
+				if op.OperationID == id {
+					return true
+				}
+			}
+			return false
+		}
+	}
+}
+
+// WithFilter sets the function selecting the operations to generate tools for
+func WithFilter(filter func(method, path string, op *Operation) bool) Option {
+	return func(o *Options) {
+		o.Filter = filter
+	}
+}
+
+// WithMaxResponseBytes limits the response body returned to the model
+func WithMaxResponseBytes(n int64) Option {
+	return func(o *Options) {
+		o.MaxResponseBytes = n
+	}
+}
+
+// NewTools creates a tool for each operation of the document, sorted by name. A tool is named
+// after the operation's ID, or its method and path when it has none. Operations whose request
+// body has no JSON content type, such as file uploads, are skipped.
+func NewTools(spec *Spec, opts ...Option) ([]tool.Tool, error) {
+	options := Options{
+		HTTPClient:       http.DefaultClient,
+		MaxResponseBytes: DefaultMaxResponseBytes,
+		Filter: func(method, path string, op *Operation) bool {
+			return !op.Deprecated
+		},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	baseURL := options.BaseURL
+	if baseURL == "" && len(spec.Servers) > 0 {
+		baseURL = spec.Servers[0].URL
+	}
+	if baseURL == "" {
+		return nil, ErrNoServer
+	}
+
+	var tools []tool.Tool
+	for path, item := range spec.Paths {
+		for method, op := range item.Operations {
+			if !options.Filter(method, path, op) {
+				continue
+			}
+			t, err := newOperationTool(spec, strings.TrimSuffix(baseURL, "/"), method, path, item, op, &options)
+			if errors.Is(err, ErrUnsupportedRequestBody) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			tools = append(tools, t)
+		}
+	}
+
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].Name() < tools[j].Name()
+	})
+	if _, err := tool.Index(tools); err != nil {
+		return nil, err
+	}
+	return tools, nil
+}
+
+// OperationTool is a tool that calls an API operation
+type OperationTool struct {
+	name         string
+	description  string
+	method       string
+	url          string
+	params       []Parameter
+	hasBody      bool
+	bodyType     string
+	bodyRequired bool
+	schema       map[string]any
+	options      *Options
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// toolName returns a name accepted by the model APIs, at most 64 letters, digits, _ or -
+func toolName(method, path string, op *Operation) string {
+	name := op.OperationID
+	if name == "" {
+		name = method + "/" + path
+	}
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "_"), "_")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+func newOperationTool(spec *Spec, baseURL, method, path string, item PathItem, op *Operation, options *Options) (*OperationTool, error) {
+	t := &OperationTool{
+		name:    toolName(method, path, op),
+		method:  strings.ToUpper(method),
+		url:     baseURL + path,
+		options: options,
+	}
+
+	t.description = op.Summary
+	if op.Description != "" {
+		if t.description != "" {
+			t.description += "\n\n"
+		}
+		t.description += op.Description
+	}
+	if t.description == "" {
+		t.description = t.method + " " + path
+	}
+
+	// Operation parameters override the path item's ones with the same name and location
+	params := map[string]Parameter{}
+	var order []string
+	for _, p := range append(append([]Parameter(nil), item.Parameters...), op.Parameters...) {
+		resolved, err := spec.parameter(p)
+		if err != nil {
+			return nil, err
+		}
+		if resolved.In == "cookie" {
+			continue
+		}
+		key := resolved.In + ":" + resolved.Name
+		if _, ok := params[key]; !ok {
+			order = append(order, key)
+		}
+		params[key] = resolved
+	}
+
+	properties := map[string]any{}
+	required := []string{}
+	for _, key := range order {
+		p := params[key]
+		schema := map[string]any{"type": "string"}
+		if p.Schema != nil {
+			resolved, err := spec.resolveSchema(p.Schema, map[string]bool{})
+			if err != nil {
+				return nil, err
+			}
+			schema = resolved
+		}
+		if p.Description != "" {
+			schema["description"] = p.Description
+		}
+		properties[p.Name] = schema
+		if p.Required || p.In == "path" {
+			required = append(required, p.Name)
+		}
+		t.params = append(t.params, p)
+	}
+
+	body, err := spec.requestBody(op.RequestBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		contentType, media, ok := jsonMediaType(body.Content)
+		if !ok {
+			return nil, ErrUnsupportedRequestBody
+		}
+		schema := map[string]any{"type": "object"}
+		if media.Schema != nil {
+			if schema, err = spec.resolveSchema(media.Schema, map[string]bool{}); err != nil {
+				return nil, err
+			}
+		}
+		if body.Description != "" {
+			schema["description"] = body.Description
+		}
+		properties[bodyParam] = schema
+		t.hasBody = true
+		t.bodyType = contentType
+		t.bodyRequired = body.Required
+		if body.Required {
+			required = append(required, bodyParam)
+		}
+	}
+
+	t.schema = map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+	return t, nil
+}
+
+// jsonMediaType returns the JSON content type of a request body: application/json, with or
+// without parameters such as charset, or a +json type. application/json is preferred.
+func jsonMediaType(content map[string]MediaType) (string, MediaType, bool) {
+	var found []string
+	for contentType := range content {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			continue
+		}
+		if mediaType == "application/json" {
+			return contentType, content[contentType], true
+		}
+		if strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json") {
+			found = append(found, contentType)
+		}
+	}
+	if len(found) == 0 {
+		return "", MediaType{}, false
+	}
+	sort.Strings(found)
+	return found[0], content[found[0]], true
+}
+
+func (t *OperationTool) Name() string {
+	return t.name
+}
+
+func (t *OperationTool) Description() string {
+	return t.description
+}
+
+// ParamsJSONSchema returns the operation's parameters, plus "body" for its JSON request body
+func (t *OperationTool) ParamsJSONSchema() map[string]any {
+	return t.schema
+}
+
+// Invoke sends the request and returns the response body. Responses with an error status are
+// returned to the model with the status, so it can correct the call or explain the failure.
+func (t *OperationTool) Invoke(ctx context.Context, paramsJSON string) (string, error) {
+	args := map[string]json.RawMessage{}
+	if strings.TrimSpace(paramsJSON) != "" {
+		if err := json.Unmarshal([]byte(paramsJSON), &args); err != nil {
+			return "", &tool.ArgumentError{Err: fmt.Errorf("failed to parse parameters: %w", err)}
+		}
+	}
+
+	req, err := t.newRequest(ctx, args)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := t.options.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", t.method, req.URL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.options.MaxResponseBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Sprintf("Error: HTTP %d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body), nil
+	}
+	if len(body) == 0 {
+		return fmt.Sprintf("HTTP %d %s", resp.StatusCode, http.StatusText(resp.StatusCode)), nil
+	}
+	return string(body), nil
+}
+
+// newRequest builds the request from the arguments generated by the model
+func (t *OperationTool) newRequest(ctx context.Context, args map[string]json.RawMessage) (*http.Request, error) {
+	target := t.url
+	query := url.Values{}
+	headers := http.Header{}
+
+	for _, p := range t.params {
+		raw, ok := args[p.Name]
+		if !ok || string(raw) == "null" {
+			if p.Required || p.In == "path" {
+				return nil, tool.NewArgumentError("parameter '%s' is required", p.Name)
+			}
+			continue
+		}
+
+		switch p.In {
+		case "path":
+			target = strings.ReplaceAll(target, "{"+p.Name+"}", url.PathEscape(paramString(raw)))
+		case "query":
+			var values []json.RawMessage
+			if json.Unmarshal(raw, &values) == nil {
+				for _, v := range values {
+					query.Add(p.Name, paramString(v))
+				}
+			} else {
+				query.Set(p.Name, paramString(raw))
+			}
+		case "header":
+			headers.Set(p.Name, paramString(raw))
+		}
+	}
+
+	var body io.Reader
+	if raw, ok := args[bodyParam]; ok && t.hasBody && string(raw) != "null" {
+		body = bytes.NewReader(raw)
+	} else if t.bodyRequired {
+		return nil, tool.NewArgumentError("parameter '%s' is required", bodyParam)
+	}
+
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, t.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", t.bodyType)
+	}
+	for name, value := range t.options.Headers {
+		req.Header.Set(name, value)
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	if t.options.Auth != nil {
+		if err := t.options.Auth.Apply(ctx, req); err != nil {
+			return nil, fmt.Errorf("failed to authenticate request: %w", err)
+		}
+	}
+	return req, nil
+}
+
+// paramString formats a JSON argument as a parameter value; strings are sent without quotes
+func paramString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+// ToolOutput is the result of a tool call that can carry structured values and media
+// in addition to text
+type ToolOutput struct {
+	// Text is the textual result sent to the model
+	Text string
+
+	// Value is a structured result, sent to the model as JSON when Text is empty
+	Value any
+
+	// Parts are images or files returned by the tool. The runner shows them to the model
+	// in a message after the tool results, since tool messages can only contain text.
+	Parts []model.ContentPart
+}
+
+// OutputInvoker is implemented by tools that return a ToolOutput.
+// The runner calls InvokeOutput instead of Invoke for such tools.
+type OutputInvoker interface {
+	// InvokeOutput executes the tool
+	InvokeOutput(ctx context.Context, paramsJSON string) (*ToolOutput, error)
+}
+
+// NewTextOutput creates a text tool output
+func NewTextOutput(text string) *ToolOutput {
+	return &ToolOutput{Text: text}
+}
+
+// NewValueOutput creates a tool output with a JSON-marshalable value
+func NewValueOutput(value any) *ToolOutput {
+	return &ToolOutput{Value: value}
+}
+
+// NewImageOutput creates a tool output with an image (e.g. mimeType "image/png") and an optional caption
+func NewImageOutput(data []byte, mimeType string, caption string) *ToolOutput {
+	return &ToolOutput{
+		Text:  caption,
+		Parts: []model.ContentPart{model.NewImageDataPart(data, mimeType, "")},
+	}
+}
+
+// NewImageURLOutput creates a tool output with an image referenced by URL (an http(s) or data URL)
+// and an optional caption
+func NewImageURLOutput(url string, caption string) *ToolOutput {
+	return &ToolOutput{
+		Text:  caption,
+		Parts: []model.ContentPart{model.NewImagePart(url, "")},
+	}
+}
+
+// NewFileOutput creates a tool output with a file (e.g. mimeType "application/pdf") and an optional caption
+func NewFileOutput(fileName string, data []byte, mimeType string, caption string) *ToolOutput {
+	return &ToolOutput{
+		Text:  caption,
+		Parts: []model.ContentPart{model.NewFilePart(fileName, data, mimeType)},
+	}
+}
+
+// Content returns the text sent to the model as the tool result
+func (o *ToolOutput) Content() (string, error) {
+	if o == nil {
+		return "", nil
+	}
+	if o.Text != "" || o.Value == nil {
+		return o.Text, nil
+	}
+
+	if s, ok := o.Value.(string); ok {
+		return s, nil
+	}
+
+	data, err := json.Marshal(o.Value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal tool output: %w", err)
+	}
+	return string(data), nil
+}
+
+// InvokeOutput runs the tool and returns its result as a ToolOutput,
+// using InvokeOutput if the tool implements OutputInvoker. A nil output is an empty text output.
+func InvokeOutput(ctx context.Context, t Tool, paramsJSON string) (*ToolOutput, error) {
+	if invoker, ok := t.(OutputInvoker); ok {
+		output, err := invoker.InvokeOutput(ctx, paramsJSON)
+		if err == nil && output == nil {
+			output = NewTextOutput("")
+		}
+		return output, err
+	}
+
+	result, err := t.Invoke(ctx, paramsJSON)
+	if err != nil {
+		return nil, err
+	}
+	return NewTextOutput(result), nil
+}
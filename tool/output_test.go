@@ -0,0 +1,57 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+func screenshot(url string) *ToolOutput {
+	return NewImageOutput([]byte("png"), "image/png", "Screenshot of "+url)
+}
+
+func TestFunctionToolOutput(t *testing.T) {
+	screenshotTool, err := NewFunctionTool(screenshot)
+	require.NoError(t, err)
+
+	output, err := InvokeOutput(context.Background(), screenshotTool, `{"param0":"https://example.com"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Screenshot of https://example.com", output.Text)
+	require.Len(t, output.Parts, 1)
+	assert.Equal(t, model.ContentPartImageURL, output.Parts[0].Type)
+
+	text, err := screenshotTool.Invoke(context.Background(), `{"param0":"https://example.com"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Screenshot of https://example.com", text)
+
+	// Tools without InvokeOutput return their text result
+	output, err = InvokeOutput(context.Background(), &SimpleAddTool{}, `{"a": 5, "b": 7}`)
+	require.NoError(t, err)
+	assert.Equal(t, "12", output.Text)
+	assert.Empty(t, output.Parts)
+}
+
+func TestToolOutputContent(t *testing.T) {
+	content, err := NewValueOutput(map[string]any{"temperature": 21}).Content()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"temperature": 21}`, content)
+
+	content, err = NewValueOutput("sunny").Content()
+	require.NoError(t, err)
+	assert.Equal(t, "sunny", content)
+
+	content, err = (&ToolOutput{Text: "text wins", Value: 1}).Content()
+	require.NoError(t, err)
+	assert.Equal(t, "text wins", content)
+
+	_, err = NewValueOutput(make(chan int)).Content()
+	assert.Error(t, err)
+}
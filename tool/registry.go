@@ -0,0 +1,172 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrDuplicateTool is returned when two tools share a name
+var ErrDuplicateTool = errors.New("duplicate tool name")
+
+// RegisteredTool is a tool of a Registry with its namespace and tags
+type RegisteredTool struct {
+	// Tool is the registered tool
+	Tool Tool
+
+	// Namespace groups related tools, such as "finance" or "finance/reports" (may be empty)
+	Namespace string
+
+	// Tags label the tool for discovery
+	Tags []string
+}
+
+// Path returns the namespace and the name of the tool joined by a slash, such as "finance/get_balance"
+func (r RegisteredTool) Path() string {
+	if r.Namespace == "" {
+		return r.Tool.Name()
+	}
+	return r.Namespace + "/" + r.Tool.Name()
+}
+
+// RegisterOption configures how a tool is registered
+type RegisterOption func(*RegisteredTool)
+
+// WithNamespace sets the namespace of the tool
+func WithNamespace(namespace string) RegisterOption {
+	return func(r *RegisteredTool) {
+		r.Namespace = strings.Trim(namespace, "/")
+	}
+}
+
+// WithTags sets the tags of the tool
+func WithTags(tags ...string) RegisterOption {
+	return func(r *RegisteredTool) {
+		r.Tags = append(r.Tags, tags...)
+	}
+}
+
+// Registry is a catalog of tools that agents pick their tools from. Tools are registered under
+// a namespace and selected with path patterns, so agents can share tools without wiring each
+// one by hand. A Registry is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	tools map[string]RegisteredTool
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{tools: map[string]RegisteredTool{}}
+}
+
+// Register adds the tool. Tool names are sent to the model without their namespace, so a
+// name can only be registered once across all namespaces; a second one returns ErrDuplicateTool.
+func (r *Registry) Register(t Tool, opts ...RegisterOption) error {
+	entry := RegisteredTool{Tool: t}
+	for _, opt := range opts {
+		opt(&entry)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.tools[t.Name()]; ok {
+		return fmt.Errorf("%w: %s is already registered as %s", ErrDuplicateTool, t.Name(), existing.Path())
+	}
+	r.tools[t.Name()] = entry
+	return nil
+}
+
+// MustRegister is like Register but panics if the tool cannot be registered
+func (r *Registry) MustRegister(t Tool, opts ...RegisterOption) {
+	if err := r.Register(t, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Get returns the tool with the name
+func (r *Registry) Get(name string) (Tool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.tools[name]
+	return entry.Tool, ok
+}
+
+// List returns the registered tools sorted by path
+func (r *Registry) List() []RegisteredTool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]RegisteredTool, 0, len(r.tools))
+	for _, entry := range r.tools {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path() < entries[j].Path()
+	})
+	return entries
+}
+
+// Select returns the tools whose path matches any of the patterns, sorted by path. Patterns
+// use path.Match syntax, and a trailing "/**" matches a namespace with all of its children:
+//
+//	registry.Select("finance/*")          // tools directly in the finance namespace
+//	registry.Select("finance/**")         // tools in finance and its child namespaces
+//	registry.Select("search", "hr/*")     // a single tool and a namespace
+func (r *Registry) Select(patterns ...string) []Tool {
+	var tools []Tool
+	for _, entry := range r.List() {
+		if matchesAny(entry.Path(), patterns) {
+			tools = append(tools, entry.Tool)
+		}
+	}
+	return tools
+}
+
+// SelectTags returns the tools having any of the tags, sorted by path
+func (r *Registry) SelectTags(tags ...string) []Tool {
+	var tools []Tool
+	for _, entry := range r.List() {
+		for _, tag := range tags {
+			if slices.Contains(entry.Tags, tag) {
+				tools = append(tools, entry.Tool)
+				break
+			}
+		}
+	}
+	return tools
+}
+
+func matchesAny(toolPath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+			if strings.HasPrefix(toolPath, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if matched, err := path.Match(pattern, toolPath); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Index returns the tools by name, or ErrDuplicateTool if two of them share a name
+func Index(tools []Tool) (map[string]Tool, error) {
+	index := make(map[string]Tool, len(tools))
+	for _, t := range tools {
+		if _, ok := index[t.Name()]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateTool, t.Name())
+		}
+		index[t.Name()] = t
+	}
+	return index, nil
+}
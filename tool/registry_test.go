@@ -0,0 +1,62 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTool(t *testing.T, name string) Tool {
+	t.Helper()
+	tool, err := NewFunctionTool(add, FunctionToolOption{NameOverride: name, DescriptionOverride: name})
+	require.NoError(t, err)
+	return tool
+}
+
+func toolNames(tools []Tool) []string {
+	names := make([]string, 0, len(tools))
+	for _, t := range tools {
+		names = append(names, t.Name())
+	}
+	return names
+}
+
+func TestRegistrySelect(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister(newTestTool(t, "get_balance"), WithNamespace("finance"), WithTags("read"))
+	registry.MustRegister(newTestTool(t, "transfer"), WithNamespace("finance"), WithTags("write"))
+	registry.MustRegister(newTestTool(t, "quarterly_report"), WithNamespace("finance/reports"), WithTags("read"))
+	registry.MustRegister(newTestTool(t, "search"))
+
+	assert.Equal(t, []string{"get_balance", "transfer"}, toolNames(registry.Select("finance/*")))
+	assert.Equal(t, []string{"get_balance", "quarterly_report", "transfer"}, toolNames(registry.Select("finance/**")))
+	assert.Equal(t, []string{"get_balance", "search"}, toolNames(registry.Select("search", "finance/get_*")))
+	assert.Equal(t, []string{"get_balance", "quarterly_report"}, toolNames(registry.SelectTags("read")))
+	assert.Empty(t, registry.Select("hr/*"))
+
+	tool, ok := registry.Get("transfer")
+	require.True(t, ok)
+	assert.Equal(t, "transfer", tool.Name())
+
+	entries := registry.List()
+	require.Len(t, entries, 4)
+	assert.Equal(t, "finance/get_balance", entries[0].Path())
+	assert.Equal(t, "search", entries[3].Path())
+}
+
+func TestRegistryDuplicateNames(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(newTestTool(t, "search"), WithNamespace("web")))
+
+	err := registry.Register(newTestTool(t, "search"), WithNamespace("docs"))
+	assert.ErrorIs(t, err, ErrDuplicateTool)
+	assert.Contains(t, err.Error(), "web/search")
+
+	_, err = Index([]Tool{newTestTool(t, "search"), newTestTool(t, "search")})
+	assert.ErrorIs(t, err, ErrDuplicateTool)
+}
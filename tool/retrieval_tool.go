@@ -0,0 +1,127 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ryichk/ai-agents-sdk-go/retrieval"
+)
+
+const (
+	// DefaultRetrievalToolName is the default name of a retrieval tool
+	DefaultRetrievalToolName = "search_knowledge_base"
+
+	// DefaultRetrievalTopK is the default number of chunks a retrieval tool returns
+	DefaultRetrievalTopK = 5
+)
+
+// RetrievalToolOption customizes a retrieval tool
+type RetrievalToolOption struct {
+	// NameOverride replaces DefaultRetrievalToolName
+	NameOverride string
+
+	// DescriptionOverride tells the model what the knowledge base contains
+	DescriptionOverride string
+
+	// TopK is the number of chunks returned (defaults to DefaultRetrievalTopK)
+	TopK int
+
+	// MinScore drops the chunks less similar to the query than the score
+	MinScore float64
+}
+
+// RetrievalTool searches a vector store for the chunks most similar to the model's query
+type RetrievalTool struct {
+	name        string
+	description string
+	topK        int
+	minScore    float64
+	store       retrieval.VectorStore
+	embedder    retrieval.Embedder
+}
+
+// NewRetrievalTool creates a tool that embeds the model's query with the embedder and returns
+// the top-k chunks of the store
+func NewRetrievalTool(store retrieval.VectorStore, embedder retrieval.Embedder, options ...RetrievalToolOption) *RetrievalTool {
+	t := &RetrievalTool{
+		name:        DefaultRetrievalToolName,
+		description: "Search the knowledge base for passages relevant to a query",
+		topK:        DefaultRetrievalTopK,
+		store:       store,
+		embedder:    embedder,
+	}
+	for _, option := range options {
+		if option.NameOverride != "" {
+			t.name = option.NameOverride
+		}
+		if option.DescriptionOverride != "" {
+			t.description = option.DescriptionOverride
+		}
+		if option.TopK > 0 {
+			t.topK = option.TopK
+		}
+		if option.MinScore != 0 {
+			t.minScore = option.MinScore
+		}
+	}
+	return t
+}
+
+func (t *RetrievalTool) Name() string {
+	return t.name
+}
+
+func (t *RetrievalTool) Description() string {
+	return t.description
+}
+
+func (t *RetrievalTool) ParamsJSONSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{
+				"type":        "string",
+				"description": "What to search for",
+			},
+		},
+		"required": []string{"query"},
+	}
+}
+
+// Invoke returns the matching chunks, most similar first, with their IDs and scores
+func (t *RetrievalTool) Invoke(ctx context.Context, paramsJSON string) (string, error) {
+	var params struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal([]byte(paramsJSON), &params); err != nil {
+		return "", &ArgumentError{Err: fmt.Errorf("failed to parse parameters: %w", err)}
+	}
+	if strings.TrimSpace(params.Query) == "" {
+		return "", NewArgumentError("parameter 'query' is required")
+	}
+
+	matches, err := retrieval.Search(ctx, t.store, t.embedder, params.Query, t.topK)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, match := range matches {
+		if match.Score < t.minScore {
+			continue
+		}
+		n++
+		fmt.Fprintf(&b, "[%d] %s (score %.3f)\n%s\n\n", n, match.ID, match.Score, match.Content)
+	}
+	if n == 0 {
+		return "No relevant passages found.", nil
+	}
+	return strings.TrimSpace(b.String()), nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/retrieval"
+)
+
+func TestRetrievalTool(t *testing.T) {
+	ctx := context.Background()
+	embedder := retrieval.EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+		embeddings := map[string][]float32{
+			"refund policy": {1, 0},
+			"shipping":      {0, 1},
+		}
+		result := make([][]float32, len(texts))
+		for i, text := range texts {
+			result[i] = embeddings[text]
+		}
+		return result, nil
+	})
+
+	store := retrieval.NewMemoryStore()
+	require.NoError(t, store.Upsert(ctx, []retrieval.Document{
+		{ID: "refunds", Content: "Refunds take 5 days.", Embedding: []float32{1, 0.1}},
+		{ID: "shipping", Content: "Shipping is free over $50.", Embedding: []float32{0.1, 1}},
+	}))
+
+	search := NewRetrievalTool(store, embedder, RetrievalToolOption{TopK: 2, MinScore: 0.5})
+	assert.Equal(t, DefaultRetrievalToolName, search.Name())
+
+	output, err := search.Invoke(ctx, `{"query": "refund policy"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "[1] refunds (score 0.995)\nRefunds take 5 days.", output)
+
+	_, err = search.Invoke(ctx, `{"query": ""}`)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+}
@@ -11,6 +11,8 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+
+	"github.com/ryichk/ai-agents-sdk-go/jsonschema"
 )
 
 // Tool represents a tool that can be used by agents
@@ -30,6 +32,7 @@ type FunctionTool struct {
 	name          string
 	description   string
 	paramsSchema  map[string]any
+	paramNames    []string
 	function      any
 	reflectedFunc reflect.Value
 	functionType  reflect.Type
@@ -50,14 +53,24 @@ func (t *FunctionTool) ParamsJSONSchema() map[string]any {
 
 // Invoke executes the tool
 func (t *FunctionTool) Invoke(ctx context.Context, paramsJSON string) (string, error) {
+	output, err := t.InvokeOutput(ctx, paramsJSON)
+	if err != nil {
+		return "", err
+	}
+	return output.Content()
+}
+
+// InvokeOutput executes the tool. A function returning ToolOutput or *ToolOutput is passed through;
+// any other result is marshaled to JSON.
+func (t *FunctionTool) InvokeOutput(ctx context.Context, paramsJSON string) (*ToolOutput, error) {
 	var params map[string]any
 	if err := json.Unmarshal([]byte(paramsJSON), &params); err != nil {
-		return "", fmt.Errorf("failed to parse parameters: %w", err)
+		return nil, &ArgumentError{Err: fmt.Errorf("failed to parse parameters: %w", err)}
 	}
 
-	args, err := t.prepareArgs(params)
+	args, err := t.prepareArgs(ctx, params)
 	if err != nil {
-		return "", err
+		return nil, &ArgumentError{Err: err}
 	}
 
 	// Call the function
@@ -65,29 +78,39 @@ func (t *FunctionTool) Invoke(ctx context.Context, paramsJSON string) (string, e
 
 	// Process the result
 	if len(results) == 0 {
-		return "", nil
+		return NewTextOutput(""), nil
 	}
 
 	// Error check (if the last return value is an error)
 	if t.functionType.NumOut() > 1 && t.functionType.Out(t.functionType.NumOut()-1).Implements(reflect.TypeOf((*error)(nil)).Elem()) {
 		lastResult := results[len(results)-1]
 		if !lastResult.IsNil() {
-			return "", lastResult.Interface().(error)
+			return nil, lastResult.Interface().(error)
 		}
 	}
 
-	// Convert result to JSON
 	result := results[0].Interface()
+	switch output := result.(type) {
+	case *ToolOutput:
+		if output == nil {
+			return NewTextOutput(""), nil
+		}
+		return output, nil
+	case ToolOutput:
+		return &output, nil
+	}
+
+	// Convert result to JSON
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal result: %w", err)
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
 	}
 
-	return string(resultJSON), nil
+	return NewTextOutput(string(resultJSON)), nil
 }
 
 // prepareArgs prepares the function arguments
-func (t *FunctionTool) prepareArgs(params map[string]any) ([]reflect.Value, error) {
+func (t *FunctionTool) prepareArgs(ctx context.Context, params map[string]any) ([]reflect.Value, error) {
 	// Implementation is simplified. In reality, more complex conversions might be needed
 	args := make([]reflect.Value, t.functionType.NumIn())
 
@@ -97,7 +120,7 @@ func (t *FunctionTool) prepareArgs(params map[string]any) ([]reflect.Value, erro
 
 		// Special handling for context
 		if paramType.Implements(reflect.TypeOf((*context.Context)(nil)).Elem()) {
-			args[i] = reflect.ValueOf(context.Background()) // Simplified
+			args[i] = reflect.ValueOf(ctx)
 			continue
 		}
 
@@ -119,9 +142,12 @@ func (t *FunctionTool) prepareArgs(params map[string]any) ([]reflect.Value, erro
 	return args, nil
 }
 
-// getParamName gets the function parameter name
+// getParamName gets the function parameter name: its documented name (see FunctionDoc), or
+// param0, param1, ... since Go does not keep parameter names at run time
 func (t *FunctionTool) getParamName(index int) string {
-	// Implementation is simplified. In reality, parameter names can be obtained using reflection
+	if index < len(t.paramNames) && t.paramNames[index] != "" {
+		return t.paramNames[index]
+	}
 	return fmt.Sprintf("param%d", index)
 }
 
@@ -144,8 +170,17 @@ func convertValue(value any, targetType reflect.Type) (reflect.Value, error) {
 		return reflect.ValueOf(string(stringValue)), nil
 	}
 
-	// Other conversions (in reality, more conversion logic would be needed)
-	return reflect.Value{}, fmt.Errorf("cannot convert %v to %v", reflectedValue.Type(), targetType)
+	// Other values, such as numbers for integer parameters or objects for structs, are decoded
+	// from their JSON
+	data, err := json.Marshal(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	converted := reflect.New(targetType)
+	if err := json.Unmarshal(data, converted.Interface()); err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot convert %v to %v: %w", reflectedValue.Type(), targetType, err)
+	}
+	return converted.Elem(), nil
 }
 
 // FunctionToolOption represents options for creating a function tool.
@@ -205,8 +240,12 @@ func NewFunctionTool(function any, options ...FunctionToolOption) (*FunctionTool
 	parts := strings.Split(funcName, ".")
 	name := parts[len(parts)-1]
 
-	// Default description
+	// Default description, from the function's documentation if it has one (see FunctionDoc)
 	description := "No description provided"
+	doc, documented := lookupFunctionDoc(reflectedFunc)
+	if documented && doc.Description != "" {
+		description = doc.Description
+	}
 
 	// Apply options
 	for _, option := range options {
@@ -218,21 +257,31 @@ func NewFunctionTool(function any, options ...FunctionToolOption) (*FunctionTool
 		}
 	}
 
-	// Generate JSON schema for parameters
-	paramsSchema := generateParamsSchema(functionType)
+	// Name and describe the parameters after the documentation, if it matches the signature
+	var params []ParamDoc
+	if documented && len(doc.Params) == functionType.NumIn() {
+		params = doc.Params
+	}
 
-	return &FunctionTool{
+	functionTool := &FunctionTool{
 		name:          name,
 		description:   description,
-		paramsSchema:  paramsSchema,
 		function:      function,
 		reflectedFunc: reflectedFunc,
 		functionType:  functionType,
-	}, nil
+	}
+	for _, param := range params {
+		functionTool.paramNames = append(functionTool.paramNames, param.Name)
+	}
+
+	// Generate JSON schema for parameters
+	functionTool.paramsSchema = functionTool.generateParamsSchema(params)
+	return functionTool, nil
 }
 
-// generateParamsSchema generates JSON schema from function parameters
-func generateParamsSchema(funcType reflect.Type) map[string]any {
+// generateParamsSchema generates JSON schema from function parameters, described by params if any
+func (t *FunctionTool) generateParamsSchema(params []ParamDoc) map[string]any {
+	funcType := t.functionType
 	schema := map[string]any{
 		"type":       "object",
 		"properties": map[string]any{},
@@ -250,9 +299,13 @@ func generateParamsSchema(funcType reflect.Type) map[string]any {
 			continue
 		}
 
-		paramName := fmt.Sprintf("param%d", i) // Simplified
+		paramName := t.getParamName(i)
 
-		properties[paramName] = generateTypeSchema(paramType)
+		paramSchema := generateTypeSchema(paramType)
+		if i < len(params) && params[i].Description != "" {
+			paramSchema["description"] = params[i].Description
+		}
+		properties[paramName] = paramSchema
 		required = append(required, paramName)
 	}
 
@@ -260,58 +313,14 @@ func generateParamsSchema(funcType reflect.Type) map[string]any {
 	return schema
 }
 
-// generateTypeSchema generates JSON schema from type
-func generateTypeSchema(t reflect.Type) map[string]any {
-	schema := map[string]any{}
-
-	switch t.Kind() {
-	case reflect.String:
-		schema["type"] = "string"
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		schema["type"] = "integer"
-	case reflect.Float32, reflect.Float64:
-		schema["type"] = "number"
-	case reflect.Bool:
-		schema["type"] = "boolean"
-	case reflect.Slice, reflect.Array:
-		schema["type"] = "array"
-		schema["items"] = generateTypeSchema(t.Elem())
-	case reflect.Map:
-		schema["type"] = "object"
-		// Map key/value type details are omitted for simplification
-	case reflect.Struct:
-		schema["type"] = "object"
-		schema["properties"] = map[string]any{}
-		required := []string{}
-
-		for _, i := range make([]int, t.NumField()) {
-			field := t.Field(i)
-			// Skip unexported fields
-			if field.PkgPath != "" {
-				continue
-			}
-
-			// Get field name from JSON tag
-			jsonTag := field.Tag.Get("json")
-			fieldName := field.Name
-			if jsonTag != "" {
-				parts := strings.Split(jsonTag, ",")
-				if parts[0] != "" && parts[0] != "-" {
-					fieldName = parts[0]
-				}
-			}
-
-			properties := schema["properties"].(map[string]any)
-			properties[fieldName] = generateTypeSchema(field.Type)
-			required = append(required, fieldName)
-		}
-
-		schema["required"] = required
-	default:
-		// Other types (interfaces, channels, etc.) are treated as strings
-		schema["type"] = "string"
-	}
+// schemaReflector generates the schemas of parameters, with the field descriptions of TypeDoc
+var schemaReflector = &jsonschema.Reflector{
+	FieldDescription: func(structType reflect.Type, name string) string {
+		return lookupTypeDoc(structType)[name]
+	},
+}
 
-	return schema
+// generateTypeSchema generates JSON schema from type (see jsonschema.Reflector)
+func generateTypeSchema(t reflect.Type) map[string]any {
+	return schemaReflector.Reflect(t)
 }
@@ -299,3 +299,33 @@ func TestCustomTool(t *testing.T) {
 	assert.NoError(t, err, "Invoke should not return an error")
 	assert.Equal(t, "Custom tool: hello", result, "Tool output is incorrect")
 }
+
+func TestFunctionToolArgumentError(t *testing.T) {
+	addTool, err := NewFunctionTool(add)
+	assert.NoError(t, err)
+
+	_, err = addTool.Invoke(context.Background(), "not a json")
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+
+	_, err = addTool.Invoke(context.Background(), `{"a": 5}`)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+
+	var argErr *ArgumentError
+	assert.ErrorAs(t, err, &argErr)
+	assert.Contains(t, argErr.Err.Error(), "missing parameter")
+}
+
+func TestFunctionToolReceivesContext(t *testing.T) {
+	type ctxKey struct{}
+
+	ctxTool, err := NewFunctionTool(func(ctx context.Context) string {
+		value, _ := ctx.Value(ctxKey{}).(string)
+		return value
+	})
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "from caller")
+	result, err := ctxTool.Invoke(ctx, `{}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `"from caller"`, result)
+}
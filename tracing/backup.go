@@ -0,0 +1,262 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultReplayRetries is the number of retries of a backup file that fails to export
+	DefaultReplayRetries = 3
+
+	// DefaultReplayBackoff is the delay before the first retry of a backup file, doubled at each retry
+	DefaultReplayBackoff = time.Second
+
+	// DefaultReplayMaxBackoff caps the delay between retries of a backup file
+	DefaultReplayMaxBackoff = 30 * time.Second
+)
+
+// backupSpan is a span as saved in a backup file
+type backupSpan struct {
+	TraceID    string         `json:"trace_id"`
+	SpanID     string         `json:"span_id"`
+	ParentID   string         `json:"parent_id,omitempty"`
+	Name       string         `json:"name"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+}
+
+// writeBackup saves spans to a new file of the backup directory, named after the kind of
+// backup, the trace of the first span and the time
+func writeBackup(dir string, kind string, spans []*StandardSpan) (string, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	records := make([]backupSpan, 0, len(spans))
+	for _, span := range spans {
+		sc := span.Context()
+		records = append(records, backupSpan{
+			TraceID:    sc.TraceID,
+			SpanID:     sc.SpanID,
+			ParentID:   sc.ParentSpanID,
+			Name:       sc.Name,
+			Attributes: sc.Attributes,
+			StartTime:  sc.StartTime,
+			EndTime:    sc.EndTime,
+		})
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spans for backup: %w", err)
+	}
+
+	// The random suffix keeps the backups of the same second apart
+	pattern := fmt.Sprintf("%s_backup_%s_%s_*.json", kind, spans[0].Context().TraceID, time.Now().Format("20060102_150405"))
+	file, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to write backup file: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write backup file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write backup file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// ReplayOption configures ReplayBackups
+type ReplayOption func(*replayOptions)
+
+// replayOptions are the retry settings of ReplayBackups
+type replayOptions struct {
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// WithReplayRetries sets how many times a backup file that fails to export is retried
+// (defaults to DefaultReplayRetries)
+func WithReplayRetries(retries int) ReplayOption {
+	return func(o *replayOptions) {
+		if retries >= 0 {
+			o.retries = retries
+		}
+	}
+}
+
+// WithReplayBackoff sets the delay before the first retry and the maximum delay between retries
+// (defaults to DefaultReplayBackoff and DefaultReplayMaxBackoff)
+func WithReplayBackoff(initial time.Duration, max time.Duration) ReplayOption {
+	return func(o *replayOptions) {
+		if initial > 0 {
+			o.backoff = initial
+		}
+		if max > 0 {
+			o.maxBackoff = max
+		}
+	}
+}
+
+// replayKey marks the contexts of replayed exports, so exporters do not back them up again
+type replayKey struct{}
+
+// isReplay reports whether an export replays a backup
+func isReplay(ctx context.Context) bool {
+	replay, _ := ctx.Value(replayKey{}).(bool)
+	return replay
+}
+
+// ReplayBackups resends the spans saved in the backup files of dir by the OpenAI exporter and
+// the batch span processor. Each file is exported with retries and exponential backoff, and
+// deleted once exported. Files that cannot be read or exported are kept for a later replay.
+// It returns the number of files replayed and the errors of the other files.
+func ReplayBackups(ctx context.Context, dir string, exporter SpanExporter, opts ...ReplayOption) (int, error) {
+	options := replayOptions{retries: DefaultReplayRetries, backoff: DefaultReplayBackoff, maxBackoff: DefaultReplayMaxBackoff}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*_backup_*.json"))
+	if err != nil {
+		return 0, err
+	}
+	sort.Strings(files)
+
+	ctx = context.WithValue(ctx, replayKey{}, true)
+	replayed := 0
+	var errs []error
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := replayFile(ctx, file, exporter, options); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(file), err))
+			continue
+		}
+		if err := os.Remove(file); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		replayed++
+	}
+	return replayed, errors.Join(errs...)
+}
+
+// RunBackupReplayer replays the backups of dir every interval until ctx is done, logging the
+// files that still fail. Run it in a goroutine next to a batch span processor with a backup
+// directory to resend the batches lost to outages.
+func RunBackupReplayer(ctx context.Context, dir string, exporter SpanExporter, interval time.Duration, opts ...ReplayOption) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			replayed, err := ReplayBackups(ctx, dir, exporter, opts...)
+			if replayed > 0 {
+				logger.Info("Replayed %d trace backup files", replayed)
+			}
+			if err != nil && ctx.Err() == nil {
+				logger.Warn("Failed to replay trace backups: %v", err)
+			}
+		}
+	}
+}
+
+// replayFile exports the spans of a backup file, retrying with backoff
+func replayFile(ctx context.Context, file string, exporter SpanExporter, options replayOptions) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	export, err := backupExport(data, exporter)
+	if err != nil {
+		return err
+	}
+
+	backoff := options.backoff
+	for attempt := 0; ; attempt++ {
+		err = export(ctx)
+		if err == nil || attempt >= options.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, options.maxBackoff)
+	}
+}
+
+// backupExport decodes a backup file into the function exporting it. Besides span lists, it
+// accepts the single spans of older batch processors and the OpenAI items of older exporters,
+// which only the OpenAI exporter can resend.
+func backupExport(data []byte, exporter SpanExporter) (func(ctx context.Context) error, error) {
+	var single SpanContext
+	if json.Unmarshal(data, &single) == nil && single.SpanID != "" {
+		spans := []*StandardSpan{{ctx: &single, completed: true, sampled: true}}
+		return func(ctx context.Context) error { return exporter.ExportSpans(ctx, spans) }, nil
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid backup file: %w", err)
+	}
+	if len(raw) > 0 {
+		var header struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(raw[0], &header); err == nil && header.Object != "" {
+			openAI, ok := exporter.(*OpenAIExporter)
+			if !ok {
+				return nil, errors.New("OpenAI trace items can only be replayed by an OpenAIExporter")
+			}
+			items := make([]any, len(raw))
+			for i, item := range raw {
+				items[i] = item
+			}
+			return func(ctx context.Context) error { return openAI.send(ctx, items) }, nil
+		}
+	}
+
+	var records []backupSpan
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("invalid backup file: %w", err)
+	}
+	spans := make([]*StandardSpan, 0, len(records))
+	for _, r := range records {
+		spans = append(spans, &StandardSpan{
+			ctx: &SpanContext{
+				TraceID:      r.TraceID,
+				SpanID:       r.SpanID,
+				ParentSpanID: r.ParentID,
+				Name:         r.Name,
+				StartTime:    r.StartTime,
+				EndTime:      r.EndTime,
+				Attributes:   r.Attributes,
+			},
+			completed: true,
+			sampled:   true,
+		})
+	}
+	return func(ctx context.Context) error { return exporter.ExportSpans(ctx, spans) }, nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyIngest is a trace ingest server failing its first requests, with a 503 unless status is set
+type flakyIngest struct {
+	mu         sync.Mutex
+	failures   int
+	status     int
+	retryAfter string
+	requests   int
+	items      []map[string]any
+}
+
+func (s *flakyIngest) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if s.failures > 0 {
+		s.failures--
+		if s.retryAfter != "" {
+			w.Header().Set("Retry-After", s.retryAfter)
+		}
+		if s.status != 0 {
+			w.WriteHeader(s.status)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		return
+	}
+	var body struct {
+		Data []map[string]any `json:"data"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	s.items = append(s.items, body.Data...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestReplayBackups(t *testing.T) {
+	ingest := &flakyIngest{failures: 2}
+	server := httptest.NewServer(ingest)
+	defer server.Close()
+
+	dir := t.TempDir()
+	exporter, err := NewOpenAIExporter(OpenAIExporterOptions{APIKey: "test-key", Endpoint: server.URL, BackupDir: dir, MaxRetries: -1})
+	require.NoError(t, err)
+
+	tracer := NewStandardTracer()
+	span, _ := tracer.StartSpan(context.Background(), "llm_call", map[string]any{"span_type": "generation", "model": "gpt-4o"})
+	span.End()
+
+	// The failed export is backed up
+	require.Error(t, exporter.ExportSpans(context.Background(), []*StandardSpan{span.(*StandardSpan)}))
+	files, _ := filepath.Glob(filepath.Join(dir, "trace_backup_*.json"))
+	require.Len(t, files, 1)
+
+	// The replay retries the failure and deletes the file
+	replayed, err := ReplayBackups(context.Background(), dir, exporter, WithReplayBackoff(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+	assert.Equal(t, 3, ingest.requests)
+	require.Len(t, ingest.items, 1)
+	assert.Equal(t, "span_"+span.Context().SpanID, ingest.items[0]["id"])
+	assert.Equal(t, "gpt-4o", ingest.items[0]["span_data"].(map[string]any)["model"])
+
+	files, _ = filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.Empty(t, files)
+}
+
+func TestReplayBackupsKeepsFailures(t *testing.T) {
+	ingest := &flakyIngest{failures: 100}
+	server := httptest.NewServer(ingest)
+	defer server.Close()
+
+	dir := t.TempDir()
+	exporter, err := NewOpenAIExporter(OpenAIExporterOptions{APIKey: "test-key", Endpoint: server.URL, BackupDir: dir, MaxRetries: -1})
+	require.NoError(t, err)
+
+	// A file of OpenAI items written by older exporters
+	legacy := `[{"object": "trace.span", "id": "span_1", "trace_id": "trace_1", "span_data": {"type": "custom"}}]`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "trace_backup_1_20250101_000000.json"), []byte(legacy), 0600))
+
+	replayed, err := ReplayBackups(context.Background(), dir, exporter, WithReplayRetries(1), WithReplayBackoff(time.Millisecond, time.Millisecond))
+	assert.Error(t, err)
+	assert.Equal(t, 0, replayed)
+	assert.Equal(t, 2, ingest.requests)
+
+	// Failed replays are not backed up again
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.Len(t, files, 1)
+
+	// Other exporters cannot resend OpenAI items
+	_, err = ReplayBackups(context.Background(), dir, NewOTLPExporter(OTLPExporterOptions{Endpoint: server.URL}))
+	assert.ErrorContains(t, err, "OpenAIExporter")
+
+	ingest.failures = 0
+	replayed, err = ReplayBackups(context.Background(), dir, exporter)
+	require.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+	require.Len(t, ingest.items, 1)
+	assert.Equal(t, "span_1", ingest.items[0]["id"])
+}
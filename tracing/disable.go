@@ -0,0 +1,51 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync/atomic"
+)
+
+// DisableTracingEnv is the environment variable disabling tracing for the whole process when set
+// to a true value such as "1" or "true", like in OpenAI's Agents SDK for Python
+const DisableTracingEnv = "OPENAI_AGENTS_DISABLE_TRACING"
+
+// tracingDisabled is the global kill switch, read from DisableTracingEnv at startup
+var tracingDisabled atomic.Bool
+
+func init() {
+	if disabled, _ := strconv.ParseBool(os.Getenv(DisableTracingEnv)); disabled {
+		tracingDisabled.Store(true)
+	}
+}
+
+// tracingDisabledKey is the context key of ContextWithTracingDisabled
+type tracingDisabledKey struct{}
+
+// SetTracingDisabled turns the global kill switch on or off. While it is on, StartSpan and
+// StartTrace return noop spans whatever the tracer, so no span, and no message content recorded
+// in spans, is created or buffered anywhere.
+func SetTracingDisabled(disabled bool) {
+	tracingDisabled.Store(disabled)
+}
+
+// ContextWithTracingDisabled returns a context in which no span is created, for runs handling
+// data that must not be retained
+func ContextWithTracingDisabled(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tracingDisabledKey{}, true)
+}
+
+// IsTracingDisabled reports whether spans are disabled for ctx, by the global kill switch or by
+// ContextWithTracingDisabled
+func IsTracingDisabled(ctx context.Context) bool {
+	if tracingDisabled.Load() {
+		return true
+	}
+	disabled, _ := ctx.Value(tracingDisabledKey{}).(bool)
+	return disabled
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracingDisabled(t *testing.T) {
+	processor := &recordingProcessor{}
+	tracer := NewStandardTracer(processor)
+	original := GetTracer()
+	SetTracer(tracer)
+	t.Cleanup(func() { SetTracer(original) })
+
+	ctx := ContextWithTracingDisabled(context.Background())
+	assert.True(t, IsTracingDisabled(ctx))
+	span, spanCtx := StartSpan(ctx, "agent_run", map[string]any{"input": "secret"})
+	assert.IsType(t, &NoopSpan{}, span)
+	assert.Nil(t, SpanFromContext(spanCtx))
+	span, _ = tracer.StartSpan(ctx, "agent_run", nil)
+	assert.IsType(t, &NoopSpan{}, span)
+	trace, _ := StartTrace(ctx, "workflow")
+	assert.Empty(t, trace.TraceID())
+
+	SetTracingDisabled(true)
+	t.Cleanup(func() { SetTracingDisabled(false) })
+	assert.True(t, IsTracingDisabled(context.Background()))
+	span, _ = StartSpan(context.Background(), "agent_run", nil)
+	assert.IsType(t, &NoopSpan{}, span)
+
+	SetTracingDisabled(false)
+	span, _ = StartSpan(context.Background(), "agent_run", nil)
+	span.End()
+	assert.IsType(t, &StandardSpan{}, span)
+	assert.Equal(t, []string{"agent_run"}, processor.started)
+}
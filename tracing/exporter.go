@@ -8,13 +8,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/version"
 )
 
 const (
@@ -26,6 +31,12 @@ const (
 
 	// MaxRetries is the maximum number of retries for failed requests
 	MaxRetries = 3
+
+	// DefaultRetryBackoff is the delay before the first retry of a failed request
+	DefaultRetryBackoff = 500 * time.Millisecond
+
+	// maxRetryBackoff caps the delay between retries, including the delays asked by Retry-After
+	maxRetryBackoff = time.Minute
 )
 
 // SpanExporter is an interface for exporting traces to external systems
@@ -51,9 +62,14 @@ type OpenAIExporterOptions struct {
 	// Timeout is the timeout for API requests (optional, defaults to DefaultTimeout)
 	Timeout time.Duration
 
-	// MaxRetries is the maximum number of retries for failed requests (optional, defaults to MaxRetries)
+	// MaxRetries is the maximum number of retries of requests failing with a rate limit, a server
+	// error or a network error (optional, defaults to MaxRetries; negative disables retries)
 	MaxRetries int
 
+	// RetryBackoff is the delay before the first retry, doubled at each retry with jitter
+	// (optional; zero or negative values use DefaultRetryBackoff). A Retry-After header takes precedence.
+	RetryBackoff time.Duration
+
 	// BackupDir is the directory to save traces if API requests fail (optional)
 	BackupDir string
 }
@@ -62,7 +78,6 @@ type OpenAIExporterOptions struct {
 type OpenAIExporter struct {
 	options OpenAIExporterOptions
 	client  *http.Client
-	mu      sync.Mutex
 }
 
 // OpenAISpanData is the structure of a span for OpenAI's tracing API
@@ -78,6 +93,15 @@ type OpenAISpanData struct {
 	Error     map[string]any `json:"error,omitempty"`
 }
 
+// OpenAITraceData is the structure of a trace for OpenAI's tracing API
+type OpenAITraceData struct {
+	Object       string         `json:"object"`
+	ID           string         `json:"id"`
+	WorkflowName string         `json:"workflow_name"`
+	GroupID      string         `json:"group_id,omitempty"`
+	Metadata     map[string]any `json:"metadata,omitempty"`
+}
+
 // NewOpenAIExporter creates a new OpenAI trace exporter
 func NewOpenAIExporter(options OpenAIExporterOptions) (*OpenAIExporter, error) {
 	if options.APIKey == "" {
@@ -100,6 +124,10 @@ func NewOpenAIExporter(options OpenAIExporterOptions) (*OpenAIExporter, error) {
 		options.MaxRetries = MaxRetries
 	}
 
+	if options.RetryBackoff <= 0 {
+		options.RetryBackoff = DefaultRetryBackoff
+	}
+
 	// Create backup directory if specified
 	if options.BackupDir != "" {
 		if err := os.MkdirAll(options.BackupDir, 0750); err != nil {
@@ -120,29 +148,89 @@ func (e *OpenAIExporter) ExportSpan(ctx context.Context, span *StandardSpan) err
 	return e.ExportSpans(ctx, []*StandardSpan{span})
 }
 
-// ExportSpans exports multiple spans to OpenAI
+// ExportSpans exports multiple spans to OpenAI. Rate limits, server errors and network errors
+// are retried with backoff. If the retries are exhausted and a backup directory is set, the
+// spans are saved there for ReplayBackups.
 func (e *OpenAIExporter) ExportSpans(ctx context.Context, spans []*StandardSpan) error {
 	if len(spans) == 0 {
 		return nil
 	}
 
-	// Convert spans to OpenAI format
-	openAISpans := make([]OpenAISpanData, 0, len(spans))
-	for _, span := range spans {
-		openAISpan := e.convertToOpenAISpan(span)
-		openAISpans = append(openAISpans, openAISpan)
+	// Send the spans in OpenAI's format
+	err := e.sendWithRetry(ctx, e.convertToOpenAIItems(spans))
+	if err != nil && e.options.BackupDir != "" && !isReplay(ctx) {
+		if _, backupErr := writeBackup(e.options.BackupDir, "trace", spans); backupErr != nil {
+			logger.Warn("Failed to save spans to backup: %v", backupErr)
+		}
 	}
+	return err
+}
+
+// ingestError is an error response of the ingest endpoint
+type ingestError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *ingestError) Error() string {
+	return fmt.Sprintf("API returned error %d: %s", e.StatusCode, e.Body)
+}
+
+// sendWithRetry sends items, retrying rate limits, server errors and network errors
+func (e *OpenAIExporter) sendWithRetry(ctx context.Context, items []any) error {
+	backoff := e.options.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := e.send(ctx, items)
+		if err == nil || attempt >= e.options.MaxRetries || ctx.Err() != nil {
+			return err
+		}
+
+		var delay time.Duration
+		var apiErr *ingestError
+		var netErr *url.Error
+		switch {
+		case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500):
+			delay = apiErr.RetryAfter
+		case errors.As(err, &netErr):
+		default:
+			return err
+		}
+		if delay <= 0 {
+			// Equal jitter: wait between half and all of the backoff
+			delay = backoff/2 + rand.N(backoff/2+1)
+			backoff = min(backoff*2, maxRetryBackoff)
+		}
 
-	// If backup directory is specified, always save a local copy first
-	if e.options.BackupDir != "" {
-		if err := e.saveToBackup(spans); err != nil {
-			fmt.Printf("[WARN] Failed to save spans to backup: %v\n", err)
+		timer := time.NewTimer(min(delay, maxRetryBackoff))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
 		}
 	}
+}
+
+// parseRetryAfter parses a Retry-After header, in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
 
+// send posts trace and span items to the ingest endpoint
+func (e *OpenAIExporter) send(ctx context.Context, items []any) error {
 	// Create request body with data as array
 	requestBody := map[string]any{
-		"data": openAISpans,
+		"data": items,
 	}
 
 	// Marshal request body
@@ -161,6 +249,7 @@ func (e *OpenAIExporter) ExportSpans(ctx context.Context, spans []*StandardSpan)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+e.options.APIKey)
 	req.Header.Set("OpenAI-Beta", "traces=v1")
+	req.Header.Set("User-Agent", version.UserAgent())
 
 	// Send request
 	resp, err := e.client.Do(req)
@@ -172,7 +261,7 @@ func (e *OpenAIExporter) ExportSpans(ctx context.Context, spans []*StandardSpan)
 	// Check response - Accept 200 OK and 204 No Content as success
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API returned error %d: %s", resp.StatusCode, string(body))
+		return &ingestError{StatusCode: resp.StatusCode, Body: string(body), RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
 	}
 
 	// Log success message
@@ -185,36 +274,37 @@ func (e *OpenAIExporter) ExportSpans(ctx context.Context, spans []*StandardSpan)
 	return nil
 }
 
-// saveToBackup saves traces to a backup file if sending fails
-func (e *OpenAIExporter) saveToBackup(spans []*StandardSpan) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-
-	// Create backup filename with timestamp
-	timestamp := time.Now().Format("20060102_150405")
-	filename := fmt.Sprintf("trace_backup_%s_%s.json", spans[0].Context().TraceID, timestamp)
-	filepath := fmt.Sprintf("%s/%s", e.options.BackupDir, filename)
+// backsUp reports whether the exporter saves the spans it fails to export
+func (e *OpenAIExporter) backsUp() bool {
+	return e.options.BackupDir != ""
+}
 
-	// Convert spans to OpenAI format
-	openAISpans := make([]OpenAISpanData, 0, len(spans))
+// convertToOpenAIItems converts spans to OpenAI's trace and span objects
+func (e *OpenAIExporter) convertToOpenAIItems(spans []*StandardSpan) []any {
+	items := make([]any, 0, len(spans))
 	for _, span := range spans {
-		openAISpan := e.convertToOpenAISpan(span)
-		openAISpans = append(openAISpans, openAISpan)
+		if isTraceRoot(span.Context()) {
+			items = append(items, e.convertToOpenAITrace(span))
+		} else {
+			items = append(items, e.convertToOpenAISpan(span))
+		}
 	}
+	return items
+}
 
-	// Marshal to JSON
-	jsonData, err := json.MarshalIndent(openAISpans, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal spans for backup: %w", err)
-	}
+// convertToOpenAITrace converts the root span of a trace to OpenAI's trace format
+func (e *OpenAIExporter) convertToOpenAITrace(span *StandardSpan) OpenAITraceData {
+	ctx := span.Context()
 
-	// Write to file
-	err = os.WriteFile(filepath, jsonData, 0600)
-	if err != nil {
-		return fmt.Errorf("failed to write backup file: %w", err)
+	trace := OpenAITraceData{
+		Object: "trace",
+		ID:     e.formatTraceID(ctx.TraceID),
 	}
+	trace.WorkflowName, _ = ctx.Attributes["workflow_name"].(string)
+	trace.GroupID, _ = ctx.Attributes["group_id"].(string)
+	trace.Metadata, _ = ctx.Attributes["metadata"].(map[string]any)
 
-	return nil
+	return trace
 }
 
 // convertToOpenAISpan converts a StandardSpan to OpenAI's span format
@@ -236,6 +326,11 @@ func (e *OpenAIExporter) convertToOpenAISpan(span *StandardSpan) OpenAISpanData
 	traceID := e.formatTraceID(ctx.TraceID)
 	parentID := e.formatParentID(ctx.ParentSpanID)
 
+	// Children of the trace root span are top-level spans in OpenAI's format
+	if ctx.ParentSpanID != "" && ctx.ParentSpanID == ctx.TraceID {
+		parentID = ""
+	}
+
 	// Create the span object following the Python spans.py export() format
 	openAISpan := OpenAISpanData{
 		Object:    "trace.span",
@@ -376,6 +471,9 @@ func (e *OpenAIExporter) processCustomSpan(ctx *SpanContext, spanData map[string
 // processGuardrailSpan populates span data for guardrail spans
 func (e *OpenAIExporter) processGuardrailSpan(ctx *SpanContext, spanData map[string]any) {
 	spanData["name"] = ctx.Name
+	if name, ok := ctx.Attributes["guardrail_name"]; ok {
+		spanData["name"] = name
+	}
 	e.addAttributeIfExists(ctx, spanData, "triggered")
 }
 
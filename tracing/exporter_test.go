@@ -0,0 +1,98 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIExporterRetry(t *testing.T) {
+	tracer := NewStandardTracer()
+	span, _ := tracer.StartSpan(context.Background(), "llm_call", map[string]any{"span_type": "generation"})
+	span.End()
+	spans := []*StandardSpan{span.(*StandardSpan)}
+
+	newExporter := func(t *testing.T, ingest *flakyIngest) (*OpenAIExporter, string) {
+		server := httptest.NewServer(ingest)
+		t.Cleanup(server.Close)
+		dir := t.TempDir()
+		exporter, err := NewOpenAIExporter(OpenAIExporterOptions{APIKey: "test-key", Endpoint: server.URL, BackupDir: dir, RetryBackoff: time.Millisecond})
+		require.NoError(t, err)
+		return exporter, dir
+	}
+	backups := func(dir string) []string {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		return files
+	}
+
+	t.Run("retries server errors and rate limits", func(t *testing.T) {
+		ingest := &flakyIngest{failures: 3, status: http.StatusTooManyRequests, retryAfter: "0"}
+		exporter, dir := newExporter(t, ingest)
+
+		require.NoError(t, exporter.ExportSpans(context.Background(), spans))
+		assert.Equal(t, 4, ingest.requests)
+		assert.Len(t, ingest.items, 1)
+		assert.Empty(t, backups(dir))
+	})
+
+	t.Run("backs up after the retries", func(t *testing.T) {
+		ingest := &flakyIngest{failures: 100}
+		exporter, dir := newExporter(t, ingest)
+
+		err := exporter.ExportSpans(context.Background(), spans)
+		assert.ErrorContains(t, err, "API returned error 503")
+		assert.Equal(t, 1+MaxRetries, ingest.requests)
+		assert.Len(t, backups(dir), 1)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		ingest := &flakyIngest{failures: 100, status: http.StatusBadRequest}
+		exporter, dir := newExporter(t, ingest)
+
+		assert.Error(t, exporter.ExportSpans(context.Background(), spans))
+		assert.Equal(t, 1, ingest.requests)
+		assert.Len(t, backups(dir), 1)
+	})
+
+	t.Run("defaults a negative backoff", func(t *testing.T) {
+		exporter, err := NewOpenAIExporter(OpenAIExporterOptions{APIKey: "test-key", RetryBackoff: -time.Second})
+		require.NoError(t, err)
+		assert.Equal(t, DefaultRetryBackoff, exporter.options.RetryBackoff)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2"))
+	assert.Zero(t, parseRetryAfter(""))
+	assert.Zero(t, parseRetryAfter("soon"))
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	assert.InDelta(t, time.Hour.Seconds(), parseRetryAfter(date).Seconds(), 2)
+}
+
+func TestOpenAIExporterGuardrailSpan(t *testing.T) {
+	exporter, err := NewOpenAIExporter(OpenAIExporterOptions{APIKey: "test-key", BackupDir: t.TempDir()})
+	require.NoError(t, err)
+
+	tracer := NewStandardTracer()
+	span, _ := tracer.StartSpan(context.Background(), "no_secrets", map[string]any{
+		"span_type":      string(SpanTypeGuardrail),
+		"guardrail_name": "no_secrets",
+		"guardrail_type": "input",
+	})
+	span.SetAttribute("triggered", true)
+	span.End()
+
+	data := exporter.createSpanDataByType(span.Context(), exporter.getSpanType(span.Context()))
+	assert.Equal(t, map[string]any{"type": "guardrail", "name": "no_secrets", "triggered": true}, data)
+}
@@ -97,6 +97,45 @@ func AddGlobalProcessor(processor SpanProcessor) {
 	spanProcessors = append(spanProcessors, processor)
 }
 
+// AddTraceProcessor registers a span processor that receives every span of the global tracer,
+// in addition to the processors already registered. If tracing has not been initialized,
+// a StandardTracer is installed so the processor starts receiving spans.
+func AddTraceProcessor(processor SpanProcessor) {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+	spanProcessors = append(spanProcessors, processor)
+	ensureStandardTracer()
+}
+
+// SetTraceProcessors replaces all registered span processors with the given ones.
+// Replaced processors are not shut down. If tracing has not been initialized,
+// a StandardTracer is installed so the processors start receiving spans.
+func SetTraceProcessors(processors ...SpanProcessor) {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+	spanProcessors = append([]SpanProcessor(nil), processors...)
+	if len(processors) > 0 {
+		ensureStandardTracer()
+	}
+}
+
+// ensureStandardTracer replaces a noop global tracer with a StandardTracer.
+// The caller must hold globalMutex.
+func ensureStandardTracer() {
+	if _, ok := globalTracer.(*NoopTracer); ok {
+		globalTracer = NewStandardTracer()
+	}
+}
+
+// NotifySpanStarted notifies all global processors that a span has started
+func NotifySpanStarted(span *StandardSpan) {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+	for _, processor := range spanProcessors {
+		processor.OnStart(span)
+	}
+}
+
 // NotifySpanEnded notifies all global processors that a span has ended
 func NotifySpanEnded(span *StandardSpan) {
 	globalMutex.RLock()
@@ -123,11 +162,24 @@ func SpanFromContext(ctx context.Context) Span {
 	return nil
 }
 
-// StartSpan starts a span with the global tracer
+// StartSpan starts a span with the global tracer. It returns a noop span when tracing is
+// disabled for ctx, see IsTracingDisabled.
 func StartSpan(ctx context.Context, name string, attributes map[string]any) (Span, context.Context) {
+	if IsTracingDisabled(ctx) {
+		return &NoopSpan{}, ctx
+	}
 	return GetTracer().StartSpan(ctx, name, attributes)
 }
 
+// CustomSpan starts a span of type "custom" carrying arbitrary data, for recording
+// application-specific operations alongside the spans created by the SDK
+func CustomSpan(ctx context.Context, name string, data map[string]any) (Span, context.Context) {
+	return StartSpan(ctx, name, map[string]any{
+		"span_type": "custom",
+		"data":      data,
+	})
+}
+
 // GetActiveSpan gets the active span from the context
 func GetActiveSpan(ctx context.Context) Span {
 	return SpanFromContext(ctx)
@@ -144,11 +196,20 @@ type Config struct {
 	// OpenAI contains configuration for OpenAI tracing
 	OpenAI *OpenAITracingConfig
 
+	// OTLP contains configuration for exporting to an OpenTelemetry collector
+	OTLP *OTLPTracingConfig
+
 	// BatchSize is the batch size for the processor
 	BatchSize int
 
 	// ExportInterval is the export interval for the processor
 	ExportInterval time.Duration
+
+	// Sampler decides which spans are exported (optional, defaults to every span)
+	Sampler Sampler
+
+	// IDGenerator generates the IDs of traces and spans (optional, defaults to UUIDGenerator)
+	IDGenerator IDGenerator
 }
 
 // OpenAITracingConfig contains configuration for OpenAI tracing
@@ -166,6 +227,21 @@ type OpenAITracingConfig struct {
 	BackupDir string
 }
 
+// OTLPTracingConfig contains configuration for OTLP tracing
+type OTLPTracingConfig struct {
+	// Enabled indicates whether OTLP tracing is enabled
+	Enabled bool
+
+	// Endpoint is the OTLP/HTTP traces endpoint
+	Endpoint string
+
+	// Headers are additional HTTP headers sent to the collector
+	Headers map[string]string
+
+	// ServiceName is the service.name resource attribute
+	ServiceName string
+}
+
 // DefaultConfig returns the default tracing configuration
 func DefaultConfig() *Config {
 	return &Config{
@@ -208,13 +284,46 @@ func InitTracing(config *Config) error {
 		processors = append(processors, processor)
 	}
 
+	// Add OTLP processor if enabled
+	if config.OTLP != nil && config.OTLP.Enabled {
+		exporter := NewOTLPExporter(OTLPExporterOptions{
+			Endpoint:    config.OTLP.Endpoint,
+			Headers:     config.OTLP.Headers,
+			ServiceName: config.OTLP.ServiceName,
+		})
+
+		processor := NewBatchSpanProcessor(exporter,
+			WithBatchSize(config.BatchSize),
+			WithExportInterval(config.ExportInterval),
+		)
+		processors = append(processors, processor)
+	}
+
 	// Create tracer with processors
 	tracer := NewStandardTracer(processors...)
+	tracer.SetSampler(config.Sampler)
+	tracer.SetIDGenerator(config.IDGenerator)
 	SetTracer(tracer)
 
 	return nil
 }
 
+// ForceFlush forces the global tracer and processors to process their pending spans,
+// e.g. before a process that was interrupted exits
+func ForceFlush() {
+	globalMutex.RLock()
+	tracer := globalTracer
+	processors := append([]SpanProcessor(nil), spanProcessors...)
+	globalMutex.RUnlock()
+
+	if standard, ok := tracer.(*StandardTracer); ok {
+		standard.ForceFlush()
+	}
+	for _, processor := range processors {
+		processor.ForceFlush()
+	}
+}
+
 // ShutdownTracing shuts down tracing
 func ShutdownTracing(ctx context.Context) error {
 	globalMutex.Lock()
@@ -267,6 +376,11 @@ func InitFromEnv() error {
 			}
 		}
 
+		// Check whether sensitive data should be recorded in spans
+		if policy := redactionPolicyFromEnv(); policy != nil {
+			SetRedactionPolicy(policy)
+		}
+
 		// Parse batch size
 		if batchSizeStr := os.Getenv("OPENAI_TRACE_BATCH_SIZE"); batchSizeStr != "" {
 			if batchSize, err := strconv.Atoi(batchSizeStr); err == nil && batchSize > 0 {
@@ -274,6 +388,13 @@ func InitFromEnv() error {
 			}
 		}
 
+		// Parse the fraction of traces to export
+		if rateStr := os.Getenv("OPENAI_TRACE_SAMPLE_RATE"); rateStr != "" {
+			if rate, err := strconv.ParseFloat(rateStr, 64); err == nil && rate >= 0 && rate <= 1 {
+				config.Sampler = ParentBasedSampler(ProbabilitySampler(rate))
+			}
+		}
+
 		// Parse export interval
 		if intervalStr := os.Getenv("OPENAI_TRACE_EXPORT_INTERVAL"); intervalStr != "" {
 			if interval, err := strconv.Atoi(intervalStr); err == nil && interval > 0 {
@@ -332,6 +453,13 @@ type MultiExporter struct {
 	exporters []SpanExporter
 }
 
+// NewMultiExporter creates an exporter that sends spans to all of the given exporters
+func NewMultiExporter(exporters ...SpanExporter) *MultiExporter {
+	return &MultiExporter{
+		exporters: exporters,
+	}
+}
+
 // ExportSpan exports a span to all configured exporters
 func (e *MultiExporter) ExportSpan(ctx context.Context, span *StandardSpan) error {
 	var firstError error
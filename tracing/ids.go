@@ -0,0 +1,110 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator generates the IDs of traces and spans for a StandardTracer.
+// Implementations must be safe for concurrent use.
+type IDGenerator interface {
+	// NewTraceID returns the ID of a new trace
+	NewTraceID() string
+
+	// NewSpanID returns the ID of a new span
+	NewSpanID() string
+}
+
+// UUIDGenerator generates random UUIDs. It is the default generator.
+type UUIDGenerator struct{}
+
+// NewTraceID returns a random UUID
+func (UUIDGenerator) NewTraceID() string {
+	return uuid.New().String()
+}
+
+// NewSpanID returns a random UUID
+func (UUIDGenerator) NewSpanID() string {
+	return uuid.New().String()
+}
+
+// W3CIDGenerator generates random IDs in the format of W3C trace context: 32 lowercase hex
+// characters for traces and 16 for spans, so they can be sent in traceparent headers as is
+type W3CIDGenerator struct{}
+
+// NewTraceID returns 16 random bytes in hex
+func (W3CIDGenerator) NewTraceID() string {
+	return randomHex(16)
+}
+
+// NewSpanID returns 8 random bytes in hex
+func (W3CIDGenerator) NewSpanID() string {
+	return randomHex(8)
+}
+
+// randomHex returns n random bytes in hex, never all zero, which W3C trace context forbids
+func randomHex(n int) string {
+	b := make([]byte, n)
+	for {
+		_, _ = rand.Read(b)
+		for _, c := range b {
+			if c != 0 {
+				return hex.EncodeToString(b)
+			}
+		}
+	}
+}
+
+// PrefixedIDGenerator adds a prefix, such as a tenant name, to the IDs of another generator
+// (UUIDGenerator if base is nil)
+func PrefixedIDGenerator(prefix string, base IDGenerator) IDGenerator {
+	if base == nil {
+		base = UUIDGenerator{}
+	}
+	return prefixedIDGenerator{prefix: prefix, base: base}
+}
+
+type prefixedIDGenerator struct {
+	prefix string
+	base   IDGenerator
+}
+
+func (g prefixedIDGenerator) NewTraceID() string {
+	return g.prefix + g.base.NewTraceID()
+}
+
+func (g prefixedIDGenerator) NewSpanID() string {
+	return g.prefix + g.base.NewSpanID()
+}
+
+// SequentialIDGenerator generates deterministic IDs ("trace_1", "trace_2", ... and "span_1",
+// "span_2", ...), for tests and golden files
+type SequentialIDGenerator struct {
+	mu     sync.Mutex
+	traces int
+	spans  int
+}
+
+// NewTraceID returns the next trace ID
+func (g *SequentialIDGenerator) NewTraceID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.traces++
+	return fmt.Sprintf("trace_%d", g.traces)
+}
+
+// NewSpanID returns the next span ID
+func (g *SequentialIDGenerator) NewSpanID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.spans++
+	return fmt.Sprintf("span_%d", g.spans)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDGenerators(t *testing.T) {
+	w3c := W3CIDGenerator{}
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{32}$`), w3c.NewTraceID())
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{16}$`), w3c.NewSpanID())
+	assert.NotEqual(t, w3c.NewTraceID(), w3c.NewTraceID())
+
+	prefixed := PrefixedIDGenerator("acme-", nil)
+	assert.True(t, strings.HasPrefix(prefixed.NewTraceID(), "acme-"))
+	assert.Len(t, prefixed.NewSpanID(), len("acme-")+36)
+}
+
+func TestStandardTracerIDGenerator(t *testing.T) {
+	tracer := NewStandardTracer()
+	tracer.SetIDGenerator(&SequentialIDGenerator{})
+	original := GetTracer()
+	SetTracer(tracer)
+	t.Cleanup(func() { SetTracer(original) })
+
+	trace, ctx := StartTrace(context.Background(), "workflow")
+	span, _ := StartSpan(ctx, "agent_run", nil)
+	assert.Equal(t, "trace_1", trace.TraceID())
+	assert.Equal(t, "trace_1", span.Context().TraceID)
+	assert.Equal(t, "span_2", span.Context().SpanID)
+
+	// Spans outside of a trace start a new one
+	span, _ = StartSpan(context.Background(), "agent_run", nil)
+	assert.Equal(t, "trace_2", span.Context().TraceID)
+}
@@ -0,0 +1,380 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultMetricsNamespace is the prefix of the metric names of a MetricsProcessor
+const DefaultMetricsNamespace = "agents"
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency histograms
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// DefaultTurnBuckets are the upper bounds of the turns-per-run histogram
+var DefaultTurnBuckets = []float64{1, 2, 3, 5, 8, 13, 21}
+
+// MetricType is the type of a metric family
+type MetricType string
+
+const (
+	// MetricCounter is a monotonically increasing value
+	MetricCounter MetricType = "counter"
+
+	// MetricHistogram is a distribution of observations in buckets
+	MetricHistogram MetricType = "histogram"
+)
+
+// MetricFamily is a metric and its samples, one per combination of label values
+type MetricFamily struct {
+	Name    string
+	Help    string
+	Type    MetricType
+	Samples []MetricSample
+}
+
+// MetricSample is the value of a metric for a combination of label values
+type MetricSample struct {
+	Labels map[string]string
+
+	// Value is the value of a counter
+	Value float64
+
+	// Count, Sum and Buckets describe a histogram
+	Count   uint64
+	Sum     float64
+	Buckets []MetricBucket
+}
+
+// MetricBucket is a cumulative histogram bucket
+type MetricBucket struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// MetricsOption configures a MetricsProcessor
+type MetricsOption func(*MetricsProcessor)
+
+// WithMetricsNamespace sets the prefix of the metric names (defaults to DefaultMetricsNamespace)
+func WithMetricsNamespace(namespace string) MetricsOption {
+	return func(p *MetricsProcessor) {
+		p.namespace = namespace
+	}
+}
+
+// WithLatencyBuckets sets the bucket upper bounds, in seconds, of the latency histograms
+func WithLatencyBuckets(buckets ...float64) MetricsOption {
+	return func(p *MetricsProcessor) {
+		p.latencyBuckets = buckets
+	}
+}
+
+// MetricsProcessor is a SpanProcessor that aggregates the spans of agent runs into metrics:
+// runs started, completed and failed, turns per run, model and tool call counts and latencies,
+// and tokens per model. It serves them in the Prometheus text format as an http.Handler, and
+// Metrics returns them for other metric systems.
+//
+//	metrics := tracing.NewMetricsProcessor()
+//	tracing.AddTraceProcessor(metrics)
+//	http.Handle("/metrics", metrics)
+type MetricsProcessor struct {
+	mu             sync.Mutex
+	namespace      string
+	latencyBuckets []float64
+	families       []*metricFamily
+
+	runsStarted   *metricFamily
+	runsCompleted *metricFamily
+	runsFailed    *metricFamily
+	runTurns      *metricFamily
+	runDuration   *metricFamily
+	llmCalls      *metricFamily
+	llmDuration   *metricFamily
+	tokens        *metricFamily
+	toolCalls     *metricFamily
+	toolDuration  *metricFamily
+}
+
+// NewMetricsProcessor creates a MetricsProcessor
+func NewMetricsProcessor(opts ...MetricsOption) *MetricsProcessor {
+	p := &MetricsProcessor{
+		namespace:      DefaultMetricsNamespace,
+		latencyBuckets: DefaultLatencyBuckets,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.runsStarted = p.counter("runs_started_total", "Agent runs started.", "agent")
+	p.runsCompleted = p.counter("runs_completed_total", "Agent runs that produced a final output.", "agent")
+	p.runsFailed = p.counter("runs_failed_total", "Agent runs that ended with an error.", "agent")
+	p.runTurns = p.histogram("run_turns", "Turns used by completed agent runs.", DefaultTurnBuckets, "agent")
+	p.runDuration = p.histogram("run_duration_seconds", "Duration of agent runs.", p.latencyBuckets, "agent")
+	p.llmCalls = p.counter("llm_calls_total", "Model calls.", "model", "status")
+	p.llmDuration = p.histogram("llm_call_duration_seconds", "Duration of model calls.", p.latencyBuckets, "model")
+	p.tokens = p.counter("tokens_total", "Tokens used per model.", "model", "type")
+	p.toolCalls = p.counter("tool_calls_total", "Tool calls.", "tool", "status")
+	p.toolDuration = p.histogram("tool_call_duration_seconds", "Duration of tool calls.", p.latencyBuckets, "tool")
+	return p
+}
+
+// OnStart counts started runs
+func (p *MetricsProcessor) OnStart(span *StandardSpan) {
+	sc := span.Context()
+	if sc.Name != "agent_run" {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.runsStarted.add(1, stringAttribute(sc.Attributes, "agent_name"))
+}
+
+// OnEnd aggregates the attributes of finished run, model call and tool call spans
+func (p *MetricsProcessor) OnEnd(span *StandardSpan) {
+	sc := span.Context()
+	attrs := sc.Attributes
+	seconds := sc.EndTime.Sub(sc.StartTime).Seconds()
+	_, failed := attrs["error"]
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch sc.Name {
+	case "agent_run":
+		agentName := stringAttribute(attrs, "agent_name")
+		p.runDuration.observe(seconds, agentName)
+		if failed {
+			p.runsFailed.add(1, agentName)
+			return
+		}
+		p.runsCompleted.add(1, agentName)
+		if turns, ok := numberAttribute(attrs, "turns_used"); ok {
+			// turns_used counts the turns before the final one
+			p.runTurns.observe(turns+1, agentName)
+		}
+	case "llm_call":
+		modelName := stringAttribute(attrs, "model")
+		p.llmCalls.add(1, modelName, status(failed))
+		p.llmDuration.observe(seconds, modelName)
+		if tokens, ok := numberAttribute(attrs, "prompt_tokens"); ok {
+			p.tokens.add(tokens, modelName, "prompt")
+		}
+		if tokens, ok := numberAttribute(attrs, "completion_tokens"); ok {
+			p.tokens.add(tokens, modelName, "completion")
+		}
+	case "tool_call":
+		toolName := stringAttribute(attrs, "tool_name")
+		p.toolCalls.add(1, toolName, status(failed))
+		p.toolDuration.observe(seconds, toolName)
+	}
+}
+
+// ForceFlush does nothing, since metrics are aggregated as spans end
+func (p *MetricsProcessor) ForceFlush() {}
+
+// Shutdown does nothing; the metrics remain readable
+func (p *MetricsProcessor) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// Metrics returns a snapshot of the metrics, sorted by name and label values
+func (p *MetricsProcessor) Metrics() []MetricFamily {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	families := make([]MetricFamily, 0, len(p.families))
+	for _, f := range p.families {
+		families = append(families, f.snapshot())
+	}
+	sort.Slice(families, func(i, j int) bool { return families[i].Name < families[j].Name })
+	return families
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format
+func (p *MetricsProcessor) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	for _, f := range p.Metrics() {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", f.Name, f.Help, f.Name, f.Type)
+		for _, s := range f.Samples {
+			if f.Type == MetricCounter {
+				fmt.Fprintf(&b, "%s%s %s\n", f.Name, formatLabels(s.Labels, ""), formatFloat(s.Value))
+				continue
+			}
+			for _, bucket := range s.Buckets {
+				fmt.Fprintf(&b, "%s_bucket%s %d\n", f.Name, formatLabels(s.Labels, formatFloat(bucket.UpperBound)), bucket.Count)
+			}
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", f.Name, formatLabels(s.Labels, "+Inf"), s.Count)
+			fmt.Fprintf(&b, "%s_sum%s %s\n", f.Name, formatLabels(s.Labels, ""), formatFloat(s.Sum))
+			fmt.Fprintf(&b, "%s_count%s %d\n", f.Name, formatLabels(s.Labels, ""), s.Count)
+		}
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format
+func (p *MetricsProcessor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = p.WriteTo(w)
+}
+
+// counter registers a counter family
+func (p *MetricsProcessor) counter(name string, help string, labels ...string) *metricFamily {
+	return p.register(&metricFamily{name: p.namespace + "_" + name, help: help, kind: MetricCounter, labels: labels})
+}
+
+// histogram registers a histogram family
+func (p *MetricsProcessor) histogram(name string, help string, buckets []float64, labels ...string) *metricFamily {
+	return p.register(&metricFamily{name: p.namespace + "_" + name, help: help, kind: MetricHistogram, labels: labels, buckets: buckets})
+}
+
+// register adds a metric family to the processor
+func (p *MetricsProcessor) register(f *metricFamily) *metricFamily {
+	f.series = make(map[string]*metricSeries)
+	p.families = append(p.families, f)
+	return f
+}
+
+// metricFamily is a metric with one series per combination of label values
+type metricFamily struct {
+	name    string
+	help    string
+	kind    MetricType
+	labels  []string
+	buckets []float64
+	series  map[string]*metricSeries
+}
+
+// metricSeries is the value of a metric for one combination of label values
+type metricSeries struct {
+	labelValues []string
+	value       float64
+	count       uint64
+	sum         float64
+	counts      []uint64
+}
+
+// get returns the series of the label values, creating it if needed
+func (f *metricFamily) get(labelValues []string) *metricSeries {
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &metricSeries{labelValues: labelValues, counts: make([]uint64, len(f.buckets))}
+		f.series[key] = s
+	}
+	return s
+}
+
+// add increments a counter
+func (f *metricFamily) add(value float64, labelValues ...string) {
+	f.get(labelValues).value += value
+}
+
+// observe records an observation in a histogram
+func (f *metricFamily) observe(value float64, labelValues ...string) {
+	s := f.get(labelValues)
+	s.count++
+	s.sum += value
+	for i, bound := range f.buckets {
+		if value <= bound {
+			s.counts[i]++
+		}
+	}
+}
+
+// snapshot copies the family's samples, sorted by label values
+func (f *metricFamily) snapshot() MetricFamily {
+	family := MetricFamily{Name: f.name, Help: f.help, Type: f.kind, Samples: make([]MetricSample, 0, len(f.series))}
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+		sample := MetricSample{Labels: make(map[string]string, len(f.labels)), Value: s.value, Count: s.count, Sum: s.sum}
+		for i, label := range f.labels {
+			sample.Labels[label] = s.labelValues[i]
+		}
+		for i, bound := range f.buckets {
+			sample.Buckets = append(sample.Buckets, MetricBucket{UpperBound: bound, Count: s.counts[i]})
+		}
+		family.Samples = append(family.Samples, sample)
+	}
+	return family
+}
+
+// status returns the status label of a call
+func status(failed bool) string {
+	if failed {
+		return "error"
+	}
+	return "ok"
+}
+
+// stringAttribute returns a string attribute, or "" if it is missing
+func stringAttribute(attrs map[string]any, key string) string {
+	value, _ := attrs[key].(string)
+	return value
+}
+
+// numberAttribute returns a numeric attribute as a float64
+func numberAttribute(attrs map[string]any, key string) (float64, bool) {
+	switch v := attrs[key].(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// labelEscaper escapes label values in the Prometheus text format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels formats labels in the Prometheus text format, adding the le label of histogram buckets
+func formatLabels(labels map[string]string, le string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		pairs = append(pairs, name+`="`+labelEscaper.Replace(labels[name])+`"`)
+	}
+	if le != "" {
+		pairs = append(pairs, `le="`+le+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// formatFloat formats a sample value in the Prometheus text format
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
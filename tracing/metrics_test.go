@@ -0,0 +1,83 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsProcessor(t *testing.T) {
+	metrics := NewMetricsProcessor(WithLatencyBuckets(1, 10))
+	tracer := NewStandardTracer(metrics)
+	ctx := context.Background()
+
+	// A completed run with one model call and one tool call
+	run, runCtx := tracer.StartSpan(ctx, "agent_run", map[string]any{"agent_name": "triage"})
+	llm, _ := tracer.StartSpan(runCtx, "llm_call", map[string]any{"model": "gpt-4o"})
+	llm.SetAttributes(map[string]any{"prompt_tokens": 100, "completion_tokens": 20})
+	llm.End()
+	toolSpan, _ := tracer.StartSpan(runCtx, "tool_call", map[string]any{"tool_name": "lookup"})
+	toolSpan.SetAttribute("error", "timeout")
+	toolSpan.End()
+	run.SetAttribute("turns_used", 1)
+	run.End()
+
+	// A failed run
+	failed, _ := tracer.StartSpan(ctx, "agent_run", map[string]any{"agent_name": "triage"})
+	failed.SetAttribute("error", "maximum turns exceeded")
+	failed.End()
+
+	families := make(map[string]MetricFamily)
+	for _, f := range metrics.Metrics() {
+		families[f.Name] = f
+	}
+
+	assert.Equal(t, float64(2), families["agents_runs_started_total"].Samples[0].Value)
+	assert.Equal(t, float64(1), families["agents_runs_completed_total"].Samples[0].Value)
+	assert.Equal(t, float64(1), families["agents_runs_failed_total"].Samples[0].Value)
+
+	turns := families["agents_run_turns"].Samples[0]
+	assert.Equal(t, uint64(1), turns.Count)
+	assert.Equal(t, float64(2), turns.Sum)
+
+	tokens := families["agents_tokens_total"].Samples
+	require.Len(t, tokens, 2)
+	assert.Equal(t, map[string]string{"model": "gpt-4o", "type": "completion"}, tokens[0].Labels)
+	assert.Equal(t, float64(20), tokens[0].Value)
+	assert.Equal(t, float64(100), tokens[1].Value)
+
+	toolCalls := families["agents_tool_calls_total"].Samples
+	require.Len(t, toolCalls, 1)
+	assert.Equal(t, map[string]string{"tool": "lookup", "status": "error"}, toolCalls[0].Labels)
+
+	latency := families["agents_tool_call_duration_seconds"].Samples[0]
+	assert.Equal(t, []MetricBucket{{UpperBound: 1, Count: 1}, {UpperBound: 10, Count: 1}}, latency.Buckets)
+}
+
+func TestMetricsProcessorPrometheusFormat(t *testing.T) {
+	metrics := NewMetricsProcessor(WithMetricsNamespace("app"), WithLatencyBuckets(1))
+	tracer := NewStandardTracer(metrics)
+
+	span, _ := tracer.StartSpan(context.Background(), "llm_call", map[string]any{"model": `say "hi"`})
+	span.End()
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := recorder.Body.String()
+	assert.True(t, strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain; version=0.0.4"))
+	assert.Contains(t, body, "# HELP app_llm_calls_total Model calls.\n# TYPE app_llm_calls_total counter\n")
+	assert.Contains(t, body, `app_llm_calls_total{model="say \"hi\"",status="ok"} 1`+"\n")
+	assert.Contains(t, body, `app_llm_call_duration_seconds_bucket{model="say \"hi\"",le="1"} 1`+"\n")
+	assert.Contains(t, body, `app_llm_call_duration_seconds_bucket{model="say \"hi\"",le="+Inf"} 1`+"\n")
+	assert.Contains(t, body, `app_llm_call_duration_seconds_count{model="say \"hi\""} 1`+"\n")
+	assert.Contains(t, body, "# TYPE app_run_turns histogram\n")
+}
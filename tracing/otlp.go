@@ -0,0 +1,276 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ryichk/ai-agents-sdk-go/version"
+)
+
+const (
+	// DefaultOTLPEndpoint is the default OTLP/HTTP traces endpoint of a local collector
+	DefaultOTLPEndpoint = "http://localhost:4318/v1/traces"
+
+	// DefaultOTLPServiceName is the default service.name resource attribute
+	DefaultOTLPServiceName = "ai-agents-sdk-go"
+)
+
+// OTLPExporterOptions configures the OTLP trace exporter
+type OTLPExporterOptions struct {
+	// Endpoint is the OTLP/HTTP traces endpoint
+	// (optional, falls back to OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, then OTEL_EXPORTER_OTLP_ENDPOINT, then DefaultOTLPEndpoint)
+	Endpoint string
+
+	// Headers are additional HTTP headers sent with every request (e.g. authentication)
+	Headers map[string]string
+
+	// ServiceName is the service.name resource attribute (optional, falls back to OTEL_SERVICE_NAME)
+	ServiceName string
+
+	// ResourceAttributes are additional resource attributes attached to every span
+	ResourceAttributes map[string]any
+
+	// Timeout is the timeout for API requests (optional, defaults to DefaultTimeout)
+	Timeout time.Duration
+}
+
+// OTLPExporter exports spans to an OpenTelemetry collector using OTLP/HTTP with JSON encoding,
+// so traces can flow into Jaeger, Tempo, Datadog and other OpenTelemetry backends
+type OTLPExporter struct {
+	options OTLPExporterOptions
+	client  *http.Client
+}
+
+// NewOTLPExporter creates a new OTLP trace exporter
+func NewOTLPExporter(options OTLPExporterOptions) *OTLPExporter {
+	if options.Endpoint == "" {
+		options.Endpoint = os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	}
+	if options.Endpoint == "" {
+		if base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); base != "" {
+			options.Endpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+		}
+	}
+	if options.Endpoint == "" {
+		options.Endpoint = DefaultOTLPEndpoint
+	}
+
+	if options.ServiceName == "" {
+		options.ServiceName = os.Getenv("OTEL_SERVICE_NAME")
+	}
+	if options.ServiceName == "" {
+		options.ServiceName = DefaultOTLPServiceName
+	}
+
+	if options.Timeout == 0 {
+		options.Timeout = DefaultTimeout
+	}
+
+	return &OTLPExporter{
+		options: options,
+		client: &http.Client{
+			Timeout: options.Timeout,
+		},
+	}
+}
+
+// ExportSpan exports a single span to the collector
+func (e *OTLPExporter) ExportSpan(ctx context.Context, span *StandardSpan) error {
+	return e.ExportSpans(ctx, []*StandardSpan{span})
+}
+
+// ExportSpans exports multiple spans to the collector in a single request
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []*StandardSpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	jsonData, err := json.Marshal(e.buildRequest(spans))
+	if err != nil {
+		return fmt.Errorf("failed to marshal OTLP request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.options.Endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", version.UserAgent())
+	for k, v := range e.options.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send OTLP traces: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("OTLP collector returned error %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Shutdown gracefully shuts down the exporter
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// buildRequest converts spans to an OTLP ExportTraceServiceRequest in JSON form
+func (e *OTLPExporter) buildRequest(spans []*StandardSpan) map[string]any {
+	resourceAttributes := map[string]any{
+		"service.name":           e.options.ServiceName,
+		"telemetry.sdk.name":     version.SDKName,
+		"telemetry.sdk.version":  version.SDKVersion(),
+		"telemetry.sdk.language": "go",
+	}
+	for k, v := range e.options.ResourceAttributes {
+		resourceAttributes[k] = v
+	}
+
+	otlpSpans := make([]map[string]any, 0, len(spans))
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, convertToOTLPSpan(span))
+	}
+
+	return map[string]any{
+		"resourceSpans": []any{
+			map[string]any{
+				"resource": map[string]any{
+					"attributes": toOTLPAttributes(resourceAttributes),
+				},
+				"scopeSpans": []any{
+					map[string]any{
+						"scope": map[string]any{
+							"name":    version.ModulePath + "/tracing",
+							"version": version.SDKVersion(),
+						},
+						"spans": otlpSpans,
+					},
+				},
+			},
+		},
+	}
+}
+
+// convertToOTLPSpan converts a StandardSpan to an OTLP span
+func convertToOTLPSpan(span *StandardSpan) map[string]any {
+	span.mu.Lock()
+	ctx := *span.ctx
+	events := make([]SpanEvent, len(span.events))
+	copy(events, span.events)
+	span.mu.Unlock()
+
+	otlpSpan := map[string]any{
+		"traceId":           toOTLPID(ctx.TraceID, 16),
+		"spanId":            toOTLPID(ctx.SpanID, 8),
+		"name":              ctx.Name,
+		"kind":              1, // SPAN_KIND_INTERNAL
+		"startTimeUnixNano": strconv.FormatInt(ctx.StartTime.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(ctx.EndTime.UnixNano(), 10),
+		"attributes":        toOTLPAttributes(ctx.Attributes),
+	}
+
+	if ctx.ParentSpanID != "" {
+		otlpSpan["parentSpanId"] = toOTLPID(ctx.ParentSpanID, 8)
+	}
+
+	if len(events) > 0 {
+		otlpEvents := make([]map[string]any, 0, len(events))
+		for _, event := range events {
+			otlpEvents = append(otlpEvents, map[string]any{
+				"name":         event.Name,
+				"timeUnixNano": strconv.FormatInt(event.Timestamp.UnixNano(), 10),
+				"attributes":   toOTLPAttributes(event.Attributes),
+			})
+		}
+		otlpSpan["events"] = otlpEvents
+	}
+
+	// STATUS_CODE_ERROR is 2, STATUS_CODE_OK is 1
+	if errAttr, ok := ctx.Attributes["error"]; ok && errAttr != nil && errAttr != "" {
+		otlpSpan["status"] = map[string]any{
+			"code":    2,
+			"message": fmt.Sprint(errAttr),
+		}
+	} else if success, ok := ctx.Attributes["success"].(bool); ok && success {
+		otlpSpan["status"] = map[string]any{"code": 1}
+	}
+
+	return otlpSpan
+}
+
+// toOTLPID converts an SDK ID (e.g. a UUID, optionally prefixed with "trace_" or "span_")
+// to a lowercase hex OTLP ID of the given byte length
+func toOTLPID(id string, byteLen int) string {
+	id = strings.TrimPrefix(strings.TrimPrefix(id, "trace_"), "span_")
+	id = strings.ToLower(strings.ReplaceAll(id, "-", ""))
+
+	if _, err := hex.DecodeString(id); err != nil || len(id) < byteLen*2 {
+		// Fall back to a hex encoding of the raw ID, padded to the required length
+		id = hex.EncodeToString([]byte(id))
+		for len(id) < byteLen*2 {
+			id = "0" + id
+		}
+	}
+
+	return id[len(id)-byteLen*2:]
+}
+
+// toOTLPAttributes converts a map of attributes to OTLP KeyValue pairs
+func toOTLPAttributes(attributes map[string]any) []map[string]any {
+	result := make([]map[string]any, 0, len(attributes))
+	for k, v := range attributes {
+		result = append(result, map[string]any{
+			"key":   k,
+			"value": toOTLPValue(v),
+		})
+	}
+	return result
+}
+
+// toOTLPValue converts a Go value to an OTLP AnyValue
+func toOTLPValue(value any) map[string]any {
+	switch v := value.(type) {
+	case string:
+		return map[string]any{"stringValue": v}
+	case bool:
+		return map[string]any{"boolValue": v}
+	case int:
+		return map[string]any{"intValue": strconv.FormatInt(int64(v), 10)}
+	case int32:
+		return map[string]any{"intValue": strconv.FormatInt(int64(v), 10)}
+	case int64:
+		return map[string]any{"intValue": strconv.FormatInt(v, 10)}
+	case float32:
+		return map[string]any{"doubleValue": float64(v)}
+	case float64:
+		return map[string]any{"doubleValue": v}
+	case nil:
+		return map[string]any{"stringValue": ""}
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return map[string]any{"stringValue": fmt.Sprint(v)}
+		}
+		return map[string]any{"stringValue": string(data)}
+	}
+}
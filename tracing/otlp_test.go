@@ -0,0 +1,82 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	var received map[string]any
+	var authHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(OTLPExporterOptions{
+		Endpoint:    server.URL,
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		ServiceName: "test-service",
+	})
+
+	tracer := NewStandardTracer()
+	parent, ctx := tracer.StartSpan(context.Background(), "parent", map[string]any{"span_type": "agent"})
+	child, _ := tracer.StartSpan(ctx, "child", map[string]any{"count": 3, "error": "boom"})
+	child.AddEvent("progress", map[string]any{"done": true})
+	child.End()
+	parent.End()
+
+	err := exporter.ExportSpans(context.Background(), []*StandardSpan{parent.(*StandardSpan), child.(*StandardSpan)})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", authHeader)
+
+	resourceSpans := received["resourceSpans"].([]any)
+	require.Len(t, resourceSpans, 1)
+	scopeSpans := resourceSpans[0].(map[string]any)["scopeSpans"].([]any)
+	spans := scopeSpans[0].(map[string]any)["spans"].([]any)
+	require.Len(t, spans, 2)
+
+	parentSpan := spans[0].(map[string]any)
+	childSpan := spans[1].(map[string]any)
+
+	assert.Len(t, parentSpan["traceId"], 32)
+	assert.Len(t, parentSpan["spanId"], 16)
+	assert.Equal(t, parentSpan["traceId"], childSpan["traceId"])
+	assert.Equal(t, parentSpan["spanId"], childSpan["parentSpanId"])
+	assert.Equal(t, float64(2), childSpan["status"].(map[string]any)["code"])
+	assert.Len(t, childSpan["events"], 1)
+}
+
+func TestOTLPExporterError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(OTLPExporterOptions{Endpoint: server.URL})
+
+	tracer := NewStandardTracer()
+	span, _ := tracer.StartSpan(context.Background(), "span", nil)
+	span.End()
+
+	err := exporter.ExportSpan(context.Background(), span.(*StandardSpan))
+	assert.Error(t, err)
+}
+
+func TestToOTLPID(t *testing.T) {
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", toOTLPID("trace_01234567-89ab-cdef-0123-456789abcdef", 16))
+	assert.Equal(t, "0123456789abcdef", toOTLPID("span_01234567-89ab-cdef-0123-456789abcdef", 8))
+	assert.Len(t, toOTLPID("custom", 8), 16)
+}
@@ -6,9 +6,7 @@ package tracing
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"sync"
 	"time"
 )
@@ -220,28 +218,11 @@ func (p *BatchSpanProcessor) saveSpanToBackup(span *StandardSpan) error {
 		return nil
 	}
 
-	// Ensure the backup directory exists
-	if err := os.MkdirAll(p.options.BackupDir, 0750); err != nil {
-		return fmt.Errorf("failed to create backup directory: %w", err)
-	}
-
-	// Create backup filename with timestamp
-	timestamp := time.Now().Format("20060102_150405")
-	filename := fmt.Sprintf("span_backup_%s_%s.json", span.Context().TraceID, timestamp)
-	filepath := fmt.Sprintf("%s/%s", p.options.BackupDir, filename)
-
-	// Convert the span to JSON
-	spanJSON, err := json.MarshalIndent(span.Context(), "", "  ")
+	file, err := writeBackup(p.options.BackupDir, "span", []*StandardSpan{span})
 	if err != nil {
-		return fmt.Errorf("failed to marshal span for backup: %w", err)
+		return err
 	}
-
-	// Write to file
-	if err := os.WriteFile(filepath, spanJSON, 0600); err != nil {
-		return fmt.Errorf("failed to write backup file: %w", err)
-	}
-
-	logger.Info("Saved span to backup file: %s", filepath)
+	logger.Info("Saved span to backup file: %s", file)
 	return nil
 }
 
@@ -261,46 +242,13 @@ func (p *BatchSpanProcessor) export(batch []*StandardSpan) {
 	if err != nil {
 		logger.Error("Failed to export spans: %v", err)
 
-		// If export fails and backup directory is specified, save to backup
-		if p.options.BackupDir != "" {
-			batchBackupFile := fmt.Sprintf("%s/batch_backup_%s.json",
-				p.options.BackupDir,
-				time.Now().Format("20060102_150405"),
-			)
-
-			// Convert all spans to JSON
-			spansJSON := make([]map[string]any, 0, len(batch))
-			for _, span := range batch {
-				spanMap := map[string]any{
-					"trace_id":   span.Context().TraceID,
-					"span_id":    span.Context().SpanID,
-					"parent_id":  span.Context().ParentSpanID,
-					"name":       span.Context().Name,
-					"attributes": span.Context().Attributes,
-					"start_time": span.Context().StartTime.Format(time.RFC3339Nano),
-					"end_time":   span.Context().EndTime.Format(time.RFC3339Nano),
-				}
-				spansJSON = append(spansJSON, spanMap)
-			}
-
-			// Marshal to JSON
-			jsonData, err := json.MarshalIndent(spansJSON, "", "  ")
-			if err != nil {
-				logger.Error("Failed to marshal batch spans for backup: %v", err)
-				return
-			}
-
-			// Ensure the backup directory exists
-			if err := os.MkdirAll(p.options.BackupDir, 0750); err != nil {
-				logger.Error("Failed to create backup directory: %v", err)
-				return
-			}
-
-			// Write to file
-			if err := os.WriteFile(batchBackupFile, jsonData, 0600); err != nil {
-				logger.Error("Failed to write batch backup file: %v", err)
+		// If export fails and backup directory is specified, save to backup, unless the
+		// exporter already did
+		if b, ok := p.exporter.(interface{ backsUp() bool }); p.options.BackupDir != "" && (!ok || !b.backsUp()) {
+			if file, err := writeBackup(p.options.BackupDir, "batch", batch); err != nil {
+				logger.Error("Failed to save batch to backup: %v", err)
 			} else {
-				logger.Info("Saved batch to backup file: %s", batchBackupFile)
+				logger.Info("Saved batch to backup file: %s", file)
 			}
 		}
 	} else {
@@ -0,0 +1,223 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// TraceParentHeader is the W3C trace context header carrying the trace and parent span IDs
+	TraceParentHeader = "traceparent"
+
+	// TraceStateHeader is the W3C trace context header carrying vendor-specific trace data
+	TraceStateHeader = "tracestate"
+)
+
+// ErrInvalidTraceParent is returned by ParseTraceParent for malformed traceparent headers
+var ErrInvalidTraceParent = errors.New("invalid traceparent")
+
+// TraceParent is a W3C trace context: the trace and span of a caller in a distributed trace
+type TraceParent struct {
+	// TraceID is the trace ID, 32 lowercase hex characters
+	TraceID string
+
+	// ParentID is the ID of the caller's span, 16 lowercase hex characters
+	ParentID string
+
+	// Sampled reports whether the caller records the trace
+	Sampled bool
+
+	// TraceState is the tracestate header, passed on as is (optional)
+	TraceState string
+}
+
+// String formats the trace context as a version 00 traceparent header
+func (p TraceParent) String() string {
+	flags := "00"
+	if p.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", p.TraceID, p.ParentID, flags)
+}
+
+// ParseTraceParent parses a traceparent header
+func ParseTraceParent(header string) (TraceParent, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return TraceParent{}, fmt.Errorf("%w: %q", ErrInvalidTraceParent, header)
+	}
+	if !isHexID(parts[1], 16) || !isHexID(parts[2], 8) || !isHexID(parts[3], 1) {
+		return TraceParent{}, fmt.Errorf("%w: %q", ErrInvalidTraceParent, header)
+	}
+
+	flags, _ := hex.DecodeString(parts[3])
+	return TraceParent{
+		TraceID:  parts[1],
+		ParentID: parts[2],
+		Sampled:  flags[0]&1 == 1,
+	}, nil
+}
+
+// isHexID reports whether id is byteLen bytes of lowercase hex, and not all zero
+func isHexID(id string, byteLen int) bool {
+	if len(id) != byteLen*2 || strings.ToLower(id) != id {
+		return false
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return false
+	}
+	return byteLen == 1 || strings.Trim(id, "0") != ""
+}
+
+// traceStateKey is the context key of the tracestate received with a remote parent
+type traceStateKey struct{}
+
+// remoteParentKey is the context key of the caller's span received with a remote parent. It is
+// kept apart from the active span, so traces started under local spans still join the caller.
+type remoteParentKey struct{}
+
+// ContextWithTraceParent returns a context whose spans join the caller's distributed trace:
+// they take its trace ID, and spans without a parent in this process become children of the
+// caller's span. The caller's sampling decision is passed to ParentBasedSampler.
+func ContextWithTraceParent(ctx context.Context, parent TraceParent) context.Context {
+	span := &remoteSpan{parent: parent}
+	ctx = context.WithValue(ContextWithSpan(ctx, span), remoteParentKey{}, span)
+	if parent.TraceState != "" {
+		ctx = context.WithValue(ctx, traceStateKey{}, parent.TraceState)
+	}
+	return ctx
+}
+
+// TraceParentFromContext returns the trace context of the span active in ctx, to send to
+// downstream services. IDs that are not hex, such as the default UUIDs, are converted the same
+// way as for OTLP export. It returns false when there is no traced span.
+func TraceParentFromContext(ctx context.Context) (TraceParent, bool) {
+	span := SpanFromContext(ctx)
+	if span == nil {
+		return TraceParent{}, false
+	}
+	sc := span.Context()
+	if sc == nil || sc.TraceID == "" || sc.SpanID == "" {
+		return TraceParent{}, false
+	}
+
+	parent := TraceParent{
+		TraceID:  toOTLPID(sc.TraceID, 16),
+		ParentID: toOTLPID(sc.SpanID, 8),
+		Sampled:  isSampled(span),
+	}
+	parent.TraceState, _ = ctx.Value(traceStateKey{}).(string)
+	return parent, true
+}
+
+// ExtractTraceContext reads the traceparent and tracestate headers of an incoming request and
+// returns a context joining the caller's trace. ctx is returned as is when there is no valid
+// traceparent header.
+func ExtractTraceContext(ctx context.Context, header http.Header) context.Context {
+	parent, err := ParseTraceParent(header.Get(TraceParentHeader))
+	if err != nil {
+		return ctx
+	}
+	parent.TraceState = header.Get(TraceStateHeader)
+	return ContextWithTraceParent(ctx, parent)
+}
+
+// InjectTraceContext sets the traceparent and tracestate headers of an outgoing request from
+// the span active in ctx. It does nothing when there is no traced span.
+func InjectTraceContext(ctx context.Context, header http.Header) {
+	parent, ok := TraceParentFromContext(ctx)
+	if !ok {
+		return
+	}
+	header.Set(TraceParentHeader, parent.String())
+	if parent.TraceState != "" {
+		header.Set(TraceStateHeader, parent.TraceState)
+	}
+}
+
+// HTTPMiddleware makes the spans of the requests handled by next, such as agent runs, join
+// the distributed trace of the caller, see ExtractTraceContext
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(ExtractTraceContext(r.Context(), r.Header)))
+	})
+}
+
+// Transport is an http.RoundTripper that propagates the trace context of each request's context
+// to the server, so calls made by tools continue the run's trace. Use it in the HTTP client of
+// tools: &http.Client{Transport: tracing.NewTransport(nil)}.
+type Transport struct {
+	// Base sends the requests (http.DefaultTransport if nil)
+	Base http.RoundTripper
+}
+
+// NewTransport creates a Transport sending the requests with base (http.DefaultTransport if nil)
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+// RoundTrip adds the trace context headers to a copy of the request and sends it
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if _, ok := TraceParentFromContext(req.Context()); ok {
+		req = req.Clone(req.Context())
+		InjectTraceContext(req.Context(), req.Header)
+	}
+	return base.RoundTrip(req)
+}
+
+// remoteSpan stands for the span of a caller in another process. It only carries the IDs that
+// the spans started under it need.
+type remoteSpan struct {
+	parent TraceParent
+}
+
+func (s *remoteSpan) Start() Span { return s }
+
+func (s *remoteSpan) End() {}
+
+func (s *remoteSpan) AddEvent(name string, attributes map[string]any) {}
+
+func (s *remoteSpan) SetAttribute(key string, value any) {}
+
+func (s *remoteSpan) SetAttributes(attributes map[string]any) {}
+
+func (s *remoteSpan) Context() *SpanContext {
+	return &SpanContext{
+		TraceID:    s.parent.TraceID,
+		SpanID:     s.parent.ParentID,
+		StartTime:  time.Time{},
+		Attributes: map[string]any{},
+	}
+}
+
+// remoteParentFromContext returns the caller's span received with ContextWithTraceParent, or nil
+func remoteParentFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(remoteParentKey{}).(*remoteSpan); ok {
+		return span
+	}
+	return nil
+}
+
+// isSampled reports whether a span is recorded
+func isSampled(span Span) bool {
+	switch s := span.(type) {
+	case *StandardSpan:
+		return s.sampled
+	case *remoteSpan:
+		return s.parent.Sampled
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	remoteTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	remoteSpanID  = "00f067aa0ba902b7"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	parent, err := ParseTraceParent("00-" + remoteTraceID + "-" + remoteSpanID + "-01")
+	require.NoError(t, err)
+	assert.Equal(t, TraceParent{TraceID: remoteTraceID, ParentID: remoteSpanID, Sampled: true}, parent)
+	assert.Equal(t, "00-"+remoteTraceID+"-"+remoteSpanID+"-01", parent.String())
+
+	// Later versions may add fields
+	parent, err = ParseTraceParent("01-" + remoteTraceID + "-" + remoteSpanID + "-00-extra")
+	require.NoError(t, err)
+	assert.False(t, parent.Sampled)
+
+	for _, header := range []string{
+		"",
+		"00-" + remoteTraceID + "-" + remoteSpanID,
+		"00-" + remoteTraceID + "-" + remoteSpanID + "-01-extra",
+		"ff-" + remoteTraceID + "-" + remoteSpanID + "-01",
+		"00-00000000000000000000000000000000-" + remoteSpanID + "-01",
+		"00-" + remoteTraceID + "-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-" + remoteSpanID + "-01",
+		"00-" + remoteTraceID + "-" + remoteSpanID + "-0x",
+	} {
+		_, err := ParseTraceParent(header)
+		assert.ErrorIs(t, err, ErrInvalidTraceParent, header)
+	}
+}
+
+func TestTraceContextPropagation(t *testing.T) {
+	processor := &recordingProcessor{}
+	tracer := NewStandardTracer(processor)
+	tracer.SetIDGenerator(W3CIDGenerator{})
+	tracer.SetSampler(ParentBasedSampler(AlwaysSample()))
+
+	header := http.Header{}
+	header.Set(TraceParentHeader, "00-"+remoteTraceID+"-"+remoteSpanID+"-01")
+	header.Set(TraceStateHeader, "vendor=value")
+	ctx := ExtractTraceContext(context.Background(), header)
+
+	// Spans join the caller's trace under its span
+	span, spanCtx := tracer.StartSpan(ctx, "agent_run", nil)
+	assert.Equal(t, remoteTraceID, span.Context().TraceID)
+	assert.Equal(t, remoteSpanID, span.Context().ParentSpanID)
+	assert.True(t, span.(*StandardSpan).IsSampled())
+
+	// and pass their own span on
+	outgoing := http.Header{}
+	InjectTraceContext(spanCtx, outgoing)
+	assert.Equal(t, "00-"+remoteTraceID+"-"+span.Context().SpanID+"-01", outgoing.Get(TraceParentHeader))
+	assert.Equal(t, "vendor=value", outgoing.Get(TraceStateHeader))
+
+	// The caller's sampling decision is followed
+	header.Set(TraceParentHeader, "00-"+remoteTraceID+"-"+remoteSpanID+"-00")
+	span, spanCtx = tracer.StartSpan(ExtractTraceContext(context.Background(), header), "agent_run", nil)
+	assert.False(t, span.(*StandardSpan).IsSampled())
+	parent, ok := TraceParentFromContext(spanCtx)
+	require.True(t, ok)
+	assert.False(t, parent.Sampled)
+
+	// Without a valid header or a span there is nothing to propagate
+	assert.Equal(t, context.Background(), ExtractTraceContext(context.Background(), http.Header{}))
+	InjectTraceContext(context.Background(), outgoing)
+	_, ok = TraceParentFromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestStartTraceJoinsRemoteParent(t *testing.T) {
+	tracer := NewStandardTracer()
+	tracer.SetIDGenerator(W3CIDGenerator{})
+	original := GetTracer()
+	SetTracer(tracer)
+	t.Cleanup(func() { SetTracer(original) })
+
+	header := http.Header{}
+	header.Set(TraceParentHeader, "00-"+remoteTraceID+"-"+remoteSpanID+"-01")
+	ctx := ExtractTraceContext(context.Background(), header)
+
+	// The trace joins the caller's trace under its span
+	trace, traceCtx := StartTrace(ctx, "workflow")
+	root := trace.Span().Context()
+	assert.Equal(t, remoteTraceID, root.TraceID)
+	assert.Equal(t, remoteSpanID, root.ParentSpanID)
+	assert.NotEqual(t, root.TraceID, root.SpanID)
+
+	span, _ := StartSpan(traceCtx, "agent_run", nil)
+	assert.Equal(t, remoteTraceID, span.Context().TraceID)
+	assert.Equal(t, root.SpanID, span.Context().ParentSpanID)
+
+	// Local spans active in ctx are left, but the caller is kept
+	local, localCtx := StartSpan(ctx, "request", nil)
+	trace, _ = StartTrace(localCtx, "workflow")
+	assert.Equal(t, remoteTraceID, trace.TraceID())
+	assert.Equal(t, remoteSpanID, trace.Span().Context().ParentSpanID)
+	assert.NotEqual(t, local.Context().SpanID, trace.Span().Context().ParentSpanID)
+
+	// Without a caller the trace is a root whose span has the ID of the trace
+	trace, _ = StartTrace(context.Background(), "workflow")
+	assert.NotEqual(t, remoteTraceID, trace.TraceID())
+	assert.Empty(t, trace.Span().Context().ParentSpanID)
+	assert.Equal(t, trace.TraceID(), trace.Span().Context().SpanID)
+}
+
+func TestTraceContextHTTP(t *testing.T) {
+	tracer := NewStandardTracer()
+	tracer.SetIDGenerator(W3CIDGenerator{})
+
+	var received string
+	server := httptest.NewServer(HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if parent, ok := TraceParentFromContext(r.Context()); ok {
+			received = parent.String()
+		}
+	})))
+	defer server.Close()
+
+	span, ctx := tracer.StartSpan(context.Background(), "tool_call", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "00-"+span.Context().TraceID+"-"+span.Context().SpanID+"-01", received)
+	// The caller's request is not modified
+	assert.Empty(t, req.Header.Get(TraceParentHeader))
+}
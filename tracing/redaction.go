@@ -0,0 +1,147 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"os"
+	"strconv"
+)
+
+const (
+	// redactionKey is the key for the redaction policy in the context
+	redactionKey contextKey = "openai-trace-redaction"
+
+	// RedactedValue replaces sensitive attribute values that are not included in traces
+	RedactedValue = "[REDACTED]"
+)
+
+// DefaultSensitiveKeys are the span and event attributes that may contain
+// message contents, system prompts or tool inputs and outputs
+var DefaultSensitiveKeys = []string{
+	"input",
+	"output",
+	"messages",
+	"reasoning",
+	"instructions",
+	"system_prompt",
+	"tool_args",
+	"arguments",
+	"result",
+	"response_preview",
+	"guardrail_message",
+	"handoff_reason",
+	"filtered_metadata",
+}
+
+// RedactionPolicy controls whether sensitive data is recorded into spans
+type RedactionPolicy struct {
+	// IncludeSensitiveData records sensitive attributes as-is when Redactor is nil.
+	// When false they are replaced with RedactedValue.
+	IncludeSensitiveData bool
+
+	// SensitiveKeys are the attribute keys treated as sensitive (defaults to DefaultSensitiveKeys)
+	SensitiveKeys []string
+
+	// Redactor, if set, is called for every sensitive attribute and returns the value to record,
+	// which allows masking individual fields instead of dropping them
+	Redactor func(key string, value any) any
+}
+
+var (
+	// globalRedaction is the policy used when the context does not carry one
+	globalRedaction *RedactionPolicy
+)
+
+// ExcludeSensitiveData returns a policy that replaces all sensitive attributes with RedactedValue
+func ExcludeSensitiveData() *RedactionPolicy {
+	return &RedactionPolicy{IncludeSensitiveData: false}
+}
+
+// SetRedactionPolicy sets the global redaction policy (nil records everything)
+func SetRedactionPolicy(policy *RedactionPolicy) {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+	globalRedaction = policy
+}
+
+// ContextWithRedactionPolicy returns a context whose spans are recorded with the given policy,
+// overriding the global policy
+func ContextWithRedactionPolicy(ctx context.Context, policy *RedactionPolicy) context.Context {
+	return context.WithValue(ctx, redactionKey, policy)
+}
+
+// RedactionPolicyFromContext returns the redaction policy in effect for ctx (nil records everything)
+func RedactionPolicyFromContext(ctx context.Context) *RedactionPolicy {
+	if policy, ok := ctx.Value(redactionKey).(*RedactionPolicy); ok {
+		return policy
+	}
+
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+	return globalRedaction
+}
+
+// isSensitive reports whether key is a sensitive attribute under the policy
+func (p *RedactionPolicy) isSensitive(key string) bool {
+	keys := p.SensitiveKeys
+	if keys == nil {
+		keys = DefaultSensitiveKeys
+	}
+
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns the value to record for the attribute under the policy. Spans apply it to their
+// attributes; call it for sensitive values nested in other attributes, such as the data of a
+// custom span.
+func (p *RedactionPolicy) Redact(key string, value any) any {
+	if p == nil || !p.isSensitive(key) {
+		return value
+	}
+
+	if p.Redactor != nil {
+		return p.Redactor(key, value)
+	}
+
+	if p.IncludeSensitiveData {
+		return value
+	}
+
+	return RedactedValue
+}
+
+// redactAttributes returns a copy of attributes with the policy applied
+func (p *RedactionPolicy) redactAttributes(attributes map[string]any) map[string]any {
+	if p == nil || attributes == nil {
+		return attributes
+	}
+
+	redacted := make(map[string]any, len(attributes))
+	for k, v := range attributes {
+		redacted[k] = p.Redact(k, v)
+	}
+	return redacted
+}
+
+// redactionPolicyFromEnv reads OPENAI_AGENTS_TRACE_INCLUDE_SENSITIVE_DATA
+func redactionPolicyFromEnv() *RedactionPolicy {
+	value := os.Getenv("OPENAI_AGENTS_TRACE_INCLUDE_SENSITIVE_DATA")
+	if value == "" {
+		return nil
+	}
+
+	include, err := strconv.ParseBool(value)
+	if err != nil || include {
+		return nil
+	}
+
+	return ExcludeSensitiveData()
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactionPolicy(t *testing.T) {
+	tracer := NewStandardTracer()
+
+	ctx := ContextWithRedactionPolicy(context.Background(), ExcludeSensitiveData())
+	span, _ := tracer.StartSpan(ctx, "span", map[string]any{
+		"input":      "secret input",
+		"agent_name": "agent",
+	})
+	span.SetAttribute("output", "secret output")
+	span.AddEvent("event", map[string]any{"tool_args": "secret args"})
+	span.End()
+
+	stdSpan := span.(*StandardSpan)
+	attributes := stdSpan.Context().Attributes
+	assert.Equal(t, RedactedValue, attributes["input"])
+	assert.Equal(t, RedactedValue, attributes["output"])
+	assert.Equal(t, "agent", attributes["agent_name"])
+	assert.Equal(t, RedactedValue, stdSpan.events[0].Attributes["tool_args"])
+}
+
+func TestRedactionPolicyRedactor(t *testing.T) {
+	tracer := NewStandardTracer()
+
+	policy := &RedactionPolicy{
+		SensitiveKeys: []string{"email"},
+		Redactor: func(key string, value any) any {
+			email, _ := value.(string)
+			return strings.Repeat("*", strings.Index(email, "@")) + email[strings.Index(email, "@"):]
+		},
+	}
+
+	ctx := ContextWithRedactionPolicy(context.Background(), policy)
+	span, _ := tracer.StartSpan(ctx, "span", map[string]any{
+		"email": "user@example.com",
+		"input": "not sensitive under this policy",
+	})
+	span.End()
+
+	attributes := span.Context().Attributes
+	assert.Equal(t, "****@example.com", attributes["email"])
+	assert.Equal(t, "not sensitive under this policy", attributes["input"])
+}
+
+func TestGlobalRedactionPolicy(t *testing.T) {
+	SetRedactionPolicy(ExcludeSensitiveData())
+	defer SetRedactionPolicy(nil)
+
+	tracer := NewStandardTracer()
+	span, _ := tracer.StartSpan(context.Background(), "span", map[string]any{"messages": "[]"})
+	span.End()
+	assert.Equal(t, RedactedValue, span.Context().Attributes["messages"])
+
+	// A policy in the context takes precedence
+	ctx := ContextWithRedactionPolicy(context.Background(), &RedactionPolicy{IncludeSensitiveData: true})
+	span, _ = tracer.StartSpan(ctx, "span", map[string]any{"messages": "[]"})
+	span.End()
+	assert.Equal(t, "[]", span.Context().Attributes["messages"])
+}
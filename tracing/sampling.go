@@ -0,0 +1,161 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+)
+
+// SamplingParameters describe a span about to start, for a Sampler to decide whether to record it
+type SamplingParameters struct {
+	// TraceID is the ID of the span's trace
+	TraceID string
+
+	// Name is the name of the span, such as "llm_call"
+	Name string
+
+	// SpanType is the span's "span_type" attribute, such as "guardrails" or "tool"
+	SpanType string
+
+	// Attributes are the attributes the span starts with
+	Attributes map[string]any
+
+	// HasParent reports whether the span has a parent span
+	HasParent bool
+
+	// ParentSampled reports whether the parent span is recorded
+	ParentSampled bool
+}
+
+// Sampler decides which spans a StandardTracer records. Spans that are not sampled still carry
+// their context to child spans, but are never passed to span processors.
+type Sampler interface {
+	ShouldSample(params SamplingParameters) bool
+}
+
+// SamplerFunc adapts a function to a Sampler
+type SamplerFunc func(params SamplingParameters) bool
+
+// ShouldSample calls f(params)
+func (f SamplerFunc) ShouldSample(params SamplingParameters) bool {
+	return f(params)
+}
+
+// AlwaysSample returns a Sampler recording every span
+func AlwaysSample() Sampler {
+	return SamplerFunc(func(SamplingParameters) bool { return true })
+}
+
+// NeverSample returns a Sampler recording no span
+func NeverSample() Sampler {
+	return SamplerFunc(func(SamplingParameters) bool { return false })
+}
+
+// ProbabilitySampler returns a Sampler recording a fraction p of the traces (0 to 1).
+// The decision is derived from the trace ID, so the spans of a trace are all recorded or all dropped.
+func ProbabilitySampler(p float64) Sampler {
+	switch {
+	case p >= 1:
+		return AlwaysSample()
+	case p <= 0:
+		return NeverSample()
+	}
+
+	bound := uint64(p * math.MaxUint64)
+	return SamplerFunc(func(params SamplingParameters) bool {
+		return traceHash(params.TraceID) < bound
+	})
+}
+
+// traceHash hashes a trace ID uniformly over the uint64 range
+func traceHash(traceID string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(traceID))
+
+	// FNV spreads similar IDs poorly over the high bits; finish with the splitmix64 mixer
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// RateLimitingSampler returns a Sampler recording at most perSecond spans per second on average,
+// with bursts of up to one second's worth of spans
+func RateLimitingSampler(perSecond float64) Sampler {
+	return newRateLimiter(perSecond, time.Now)
+}
+
+// rateLimiter is a token bucket refilled at perSecond tokens per second
+type rateLimiter struct {
+	mu        sync.Mutex
+	perSecond float64
+	tokens    float64
+	last      time.Time
+	now       func() time.Time
+}
+
+// newRateLimiter creates a full rate limiter using the given clock
+func newRateLimiter(perSecond float64, now func() time.Time) *rateLimiter {
+	return &rateLimiter{perSecond: perSecond, tokens: math.Max(perSecond, 1), last: now(), now: now}
+}
+
+// ShouldSample takes a token from the bucket if one is available
+func (r *rateLimiter) ShouldSample(SamplingParameters) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.tokens = math.Min(math.Max(r.perSecond, 1), r.tokens+now.Sub(r.last).Seconds()*r.perSecond)
+	r.last = now
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+// ParentBasedSampler returns a Sampler that follows the decision of the parent span, so traces
+// are kept or dropped as a whole, and uses root for spans without a parent
+func ParentBasedSampler(root Sampler) Sampler {
+	return SamplerFunc(func(params SamplingParameters) bool {
+		if params.HasParent {
+			return params.ParentSampled
+		}
+		return root.ShouldSample(params)
+	})
+}
+
+// SpanTypeSampler picks a Sampler per span, by span name first and then by span type, e.g. to
+// keep every guardrail span while sampling llm_call spans
+type SpanTypeSampler struct {
+	// ByName are the samplers of spans with the given names
+	ByName map[string]Sampler
+
+	// ByType are the samplers of spans with the given "span_type" attributes
+	ByType map[string]Sampler
+
+	// Default samples the other spans (optional, defaults to recording them)
+	Default Sampler
+}
+
+// ShouldSample applies the sampler matching the span
+func (s SpanTypeSampler) ShouldSample(params SamplingParameters) bool {
+	if sampler, ok := s.ByName[params.Name]; ok {
+		return sampler.ShouldSample(params)
+	}
+	if sampler, ok := s.ByType[params.SpanType]; ok {
+		return sampler.ShouldSample(params)
+	}
+	if s.Default != nil {
+		return s.Default.ShouldSample(params)
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// endedNames returns the names of the spans ended in the processor
+func endedNames(p *recordingProcessor) []string {
+	names := make([]string, len(p.ended))
+	for i, span := range p.ended {
+		names[i] = span.Context().Name
+	}
+	return names
+}
+
+func TestSpanTypeSampler(t *testing.T) {
+	processor := &recordingProcessor{}
+	tracer := NewStandardTracer(processor)
+	tracer.SetSampler(SpanTypeSampler{
+		ByName: map[string]Sampler{"llm_call": NeverSample()},
+		ByType: map[string]Sampler{"guardrails": AlwaysSample()},
+	})
+
+	run, ctx := tracer.StartSpan(context.Background(), "agent_run", map[string]any{"span_type": "agent"})
+	llm, llmCtx := tracer.StartSpan(ctx, "llm_call", map[string]any{"span_type": "agent"})
+	guardrails, _ := tracer.StartSpan(ctx, "output_guardrails", map[string]any{"span_type": "guardrails"})
+	guardrails.End()
+	llm.End()
+	run.End()
+
+	assert.Equal(t, []string{"agent_run", "output_guardrails"}, processor.started)
+	assert.Equal(t, []string{"output_guardrails", "agent_run"}, endedNames(processor))
+
+	// Unsampled spans still propagate their context
+	assert.Equal(t, llm, SpanFromContext(llmCtx))
+	assert.False(t, llm.(*StandardSpan).IsSampled())
+	assert.Equal(t, run.Context().TraceID, llm.Context().TraceID)
+}
+
+func TestParentBasedSampler(t *testing.T) {
+	processor := &recordingProcessor{}
+	tracer := NewStandardTracer(processor)
+
+	roots := 0
+	tracer.SetSampler(ParentBasedSampler(SamplerFunc(func(params SamplingParameters) bool {
+		roots++
+		return params.Name == "kept"
+	})))
+
+	for _, name := range []string{"kept", "dropped"} {
+		root, ctx := tracer.StartSpan(context.Background(), name, nil)
+		child, _ := tracer.StartSpan(ctx, name+"_child", nil)
+		child.End()
+		root.End()
+	}
+
+	assert.Equal(t, 2, roots)
+	assert.Equal(t, []string{"kept_child", "kept"}, endedNames(processor))
+}
+
+func TestProbabilitySampler(t *testing.T) {
+	sampler := ProbabilitySampler(0.25)
+
+	kept := 0
+	for i := range 10000 {
+		params := SamplingParameters{TraceID: fmt.Sprintf("trace-%d", i)}
+		decision := sampler.ShouldSample(params)
+		if decision {
+			kept++
+		}
+		// The decision only depends on the trace
+		params.Name = "other"
+		assert.Equal(t, decision, sampler.ShouldSample(params))
+	}
+	assert.InDelta(t, 2500, kept, 250)
+
+	assert.True(t, ProbabilitySampler(1).ShouldSample(SamplingParameters{}))
+	assert.False(t, ProbabilitySampler(0).ShouldSample(SamplingParameters{}))
+}
+
+func TestRateLimitingSampler(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newRateLimiter(2, func() time.Time { return now })
+
+	assert.True(t, limiter.ShouldSample(SamplingParameters{}))
+	assert.True(t, limiter.ShouldSample(SamplingParameters{}))
+	assert.False(t, limiter.ShouldSample(SamplingParameters{}))
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, limiter.ShouldSample(SamplingParameters{}))
+	assert.False(t, limiter.ShouldSample(SamplingParameters{}))
+
+	// Idle time does not accumulate more than a second's worth of spans
+	now = now.Add(time.Minute)
+	assert.True(t, limiter.ShouldSample(SamplingParameters{}))
+	assert.True(t, limiter.ShouldSample(SamplingParameters{}))
+	assert.False(t, limiter.ShouldSample(SamplingParameters{}))
+}
@@ -9,14 +9,14 @@ import (
 	"fmt"
 	"sync"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 // StandardTracer is the standard implementation of a Tracer
 type StandardTracer struct {
-	processors []SpanProcessor
-	mu         sync.Mutex
+	processors  []SpanProcessor
+	sampler     Sampler
+	idGenerator IDGenerator
+	mu          sync.Mutex
 }
 
 // StandardSpan is the standard implementation of a Span
@@ -25,8 +25,10 @@ type StandardSpan struct {
 	ctx        *SpanContext
 	events     []SpanEvent
 	attributes map[string]any
+	redaction  *RedactionPolicy
 	mu         sync.Mutex
 	completed  bool
+	sampled    bool
 }
 
 // NewStandardTracer creates a new StandardTracer
@@ -43,18 +45,48 @@ func (t *StandardTracer) AddProcessor(processor SpanProcessor) {
 	t.processors = append(t.processors, processor)
 }
 
+// SetSampler sets the Sampler deciding which spans are passed to the processors.
+// A nil sampler records every span, which is the default.
+func (t *StandardTracer) SetSampler(sampler Sampler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sampler = sampler
+}
+
+// SetIDGenerator sets the generator of trace and span IDs (nil restores UUIDGenerator)
+func (t *StandardTracer) SetIDGenerator(generator IDGenerator) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.idGenerator = generator
+}
+
+// ids returns the tracer's ID generator
+func (t *StandardTracer) ids() IDGenerator {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.idGenerator == nil {
+		return UUIDGenerator{}
+	}
+	return t.idGenerator
+}
+
 // StartSpan starts a new span
 func (t *StandardTracer) StartSpan(ctx context.Context, name string, attributes map[string]any) (Span, context.Context) {
+	if IsTracingDisabled(ctx) {
+		return &NoopSpan{}, ctx
+	}
+
 	// Get parent span from context if available
 	var parentSpanID string
-	if parentSpan := SpanFromContext(ctx); parentSpan != nil {
+	parentSpan := SpanFromContext(ctx)
+	if parentSpan != nil {
 		parentSpanID = parentSpan.Context().SpanID
 	}
 
 	// Create new span context
 	spanContext := &SpanContext{
-		TraceID:      getTraceIDFromContext(ctx),
-		SpanID:       uuid.New().String(),
+		TraceID:      t.traceID(ctx),
+		SpanID:       t.ids().NewSpanID(),
 		ParentSpanID: parentSpanID,
 		Name:         name,
 		StartTime:    time.Now().UTC(),
@@ -67,10 +99,12 @@ func (t *StandardTracer) StartSpan(ctx context.Context, name string, attributes
 		ctx:        spanContext,
 		events:     make([]SpanEvent, 0),
 		attributes: make(map[string]any),
+		redaction:  RedactionPolicyFromContext(ctx),
 	}
 
 	// Add attributes
 	if attributes != nil {
+		attributes = span.redaction.redactAttributes(attributes)
 		// コンテキストの属性にも直接設定
 		for k, v := range attributes {
 			spanContext.Attributes[k] = v
@@ -78,9 +112,21 @@ func (t *StandardTracer) StartSpan(ctx context.Context, name string, attributes
 		span.SetAttributes(attributes)
 	}
 
-	// Notify processors
-	for _, processor := range t.processors {
-		processor.OnStart(span)
+	// The root span of a trace shares its ID with the trace, so exporters can recognize
+	// top-level spans. It is set before the processors see the span.
+	if parentSpan == nil && isTraceRoot(spanContext) {
+		spanContext.SpanID = spanContext.TraceID
+	}
+
+	span.sampled = t.shouldSample(spanContext, parentSpan)
+	if span.sampled {
+		// Notify processors
+		for _, processor := range t.processors {
+			processor.OnStart(span)
+		}
+
+		// Notify global processors
+		NotifySpanStarted(span)
 	}
 
 	// Add span to context
@@ -89,6 +135,17 @@ func (t *StandardTracer) StartSpan(ctx context.Context, name string, attributes
 	return span, newCtx
 }
 
+// ForceFlush forces all processors of the tracer to process their pending spans
+func (t *StandardTracer) ForceFlush() {
+	t.mu.Lock()
+	processors := append([]SpanProcessor(nil), t.processors...)
+	t.mu.Unlock()
+
+	for _, processor := range processors {
+		processor.ForceFlush()
+	}
+}
+
 // Close cleans up the tracer
 func (t *StandardTracer) Close(ctx context.Context) error {
 	t.mu.Lock()
@@ -109,15 +166,38 @@ func (t *StandardTracer) Close(ctx context.Context) error {
 	return firstErr
 }
 
-// getTraceIDFromContext gets the trace ID from the context or creates a new one
-func getTraceIDFromContext(ctx context.Context) string {
+// shouldSample applies the tracer's sampler to a new span
+func (t *StandardTracer) shouldSample(sc *SpanContext, parent Span) bool {
+	t.mu.Lock()
+	sampler := t.sampler
+	t.mu.Unlock()
+	if sampler == nil {
+		return true
+	}
+
+	params := SamplingParameters{
+		TraceID:    sc.TraceID,
+		Name:       sc.Name,
+		Attributes: sc.Attributes,
+		HasParent:  parent != nil,
+	}
+	params.SpanType, _ = sc.Attributes["span_type"].(string)
+	if parent != nil {
+		// Spans of other tracers are considered recorded
+		params.ParentSampled = isSampled(parent)
+	}
+	return sampler.ShouldSample(params)
+}
+
+// traceID gets the trace ID from the context or creates a new one
+func (t *StandardTracer) traceID(ctx context.Context) string {
 	// Try to get trace ID from parent span
 	if parentSpan := SpanFromContext(ctx); parentSpan != nil {
 		return parentSpan.Context().TraceID
 	}
 
 	// Create new trace ID
-	return uuid.New().String()
+	return t.ids().NewTraceID()
 }
 
 // Start starts the span
@@ -150,6 +230,10 @@ func (s *StandardSpan) End() {
 	s.completed = true
 	s.mu.Unlock()
 
+	if !s.sampled {
+		return
+	}
+
 	// Notify processors
 	for _, processor := range s.tracer.processors {
 		processor.OnEnd(s)
@@ -159,6 +243,11 @@ func (s *StandardSpan) End() {
 	NotifySpanEnded(s)
 }
 
+// IsSampled reports whether the span is recorded, i.e. passed to the span processors
+func (s *StandardSpan) IsSampled() bool {
+	return s.sampled
+}
+
 // AddEvent adds an event to the span
 func (s *StandardSpan) AddEvent(name string, attributes map[string]any) {
 	s.mu.Lock()
@@ -171,7 +260,7 @@ func (s *StandardSpan) AddEvent(name string, attributes map[string]any) {
 	event := SpanEvent{
 		Name:       name,
 		Timestamp:  time.Now().UTC(),
-		Attributes: attributes,
+		Attributes: s.redaction.redactAttributes(attributes),
 	}
 	s.events = append(s.events, event)
 }
@@ -185,7 +274,7 @@ func (s *StandardSpan) SetAttribute(key string, value any) {
 		return
 	}
 
-	s.attributes[key] = value
+	s.attributes[key] = s.redaction.Redact(key, value)
 }
 
 // SetAttributes sets multiple attributes on the span
@@ -202,7 +291,7 @@ func (s *StandardSpan) SetAttributes(attributes map[string]any) {
 	}
 
 	for k, v := range attributes {
-		s.attributes[k] = v
+		s.attributes[k] = s.redaction.Redact(k, v)
 	}
 }
 
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+)
+
+const (
+	// traceKey is the key for the trace in the context
+	traceKey contextKey = "openai-trace"
+
+	// DefaultWorkflowName is the workflow name used for traces created automatically by the runner
+	DefaultWorkflowName = "Agent workflow"
+)
+
+// Trace groups the spans of one logical workflow, which may span multiple agent runs
+type Trace struct {
+	// WorkflowName is the name of the workflow
+	WorkflowName string
+
+	// GroupID links multiple traces together (e.g. a chat thread ID)
+	GroupID string
+
+	// Metadata is arbitrary metadata attached to the trace
+	Metadata map[string]any
+
+	span Span
+}
+
+// TraceOptions contains optional settings for a trace
+type TraceOptions struct {
+	// GroupID links multiple traces together
+	GroupID string
+
+	// Metadata is arbitrary metadata attached to the trace
+	Metadata map[string]any
+}
+
+// TraceOption is a function that sets options for a trace
+type TraceOption func(*TraceOptions)
+
+// WithGroupID sets the group ID of the trace
+func WithGroupID(groupID string) TraceOption {
+	return func(o *TraceOptions) {
+		o.GroupID = groupID
+	}
+}
+
+// WithMetadata sets metadata on the trace
+func WithMetadata(metadata map[string]any) TraceOption {
+	return func(o *TraceOptions) {
+		o.Metadata = metadata
+	}
+}
+
+// StartTrace starts a new trace and returns a context carrying it. When ctx carries the trace
+// context of a caller (see ExtractTraceContext), the trace joins the caller's distributed trace.
+// Spans started from the returned context, including those of every runner.Run call,
+// belong to the trace until End is called.
+func StartTrace(ctx context.Context, workflowName string, opts ...TraceOption) (*Trace, context.Context) {
+	options := &TraceOptions{}
+	for _, o := range opts {
+		o(options)
+	}
+
+	if workflowName == "" {
+		workflowName = DefaultWorkflowName
+	}
+
+	attributes := map[string]any{
+		"span_type":     string(SpanTypeTrace),
+		"workflow_name": workflowName,
+	}
+	if options.GroupID != "" {
+		attributes["group_id"] = options.GroupID
+	}
+	if len(options.Metadata) > 0 {
+		attributes["metadata"] = options.Metadata
+	}
+
+	// A trace is a root in this process: detach from any local span active in ctx, but stay
+	// under the caller's span of a distributed trace. Without a caller, the tracer gives the
+	// root span the ID of the trace.
+	span, ctx := StartSpan(ContextWithSpan(ctx, remoteParentFromContext(ctx)), workflowName, attributes)
+
+	trace := &Trace{
+		WorkflowName: workflowName,
+		GroupID:      options.GroupID,
+		Metadata:     options.Metadata,
+		span:         span,
+	}
+
+	return trace, context.WithValue(ctx, traceKey, trace)
+}
+
+// TraceID returns the ID of the trace (empty when tracing is disabled)
+func (t *Trace) TraceID() string {
+	return t.span.Context().TraceID
+}
+
+// Span returns the root span of the trace
+func (t *Trace) Span() Span {
+	return t.span
+}
+
+// End ends the trace
+func (t *Trace) End() {
+	t.span.End()
+}
+
+// TraceFromContext gets the current trace from the context
+func TraceFromContext(ctx context.Context) *Trace {
+	if trace, ok := ctx.Value(traceKey).(*Trace); ok {
+		return trace
+	}
+	return nil
+}
+
+// isTraceRoot reports whether the span is the root span of a trace
+func isTraceRoot(ctx *SpanContext) bool {
+	spanType, _ := ctx.Attributes["span_type"].(string)
+	return spanType == string(SpanTypeTrace)
+}
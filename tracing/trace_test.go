@@ -0,0 +1,93 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartTrace(t *testing.T) {
+	originalTracer := GetTracer()
+	defer SetTracer(originalTracer)
+	SetTracer(NewStandardTracer())
+
+	// A trace is a root even when started inside another span
+	outer, ctx := StartSpan(context.Background(), "outer", nil)
+	defer outer.End()
+
+	trace, ctx := StartTrace(ctx, "my workflow", WithGroupID("thread_1"), WithMetadata(map[string]any{"user": "u1"}))
+	defer trace.End()
+
+	assert.Same(t, trace, TraceFromContext(ctx))
+	assert.NotEqual(t, outer.Context().TraceID, trace.TraceID())
+	assert.Equal(t, "thread_1", trace.GroupID)
+
+	span1, _ := StartSpan(ctx, "run1", nil)
+	span2, _ := StartSpan(ctx, "run2", nil)
+	assert.Equal(t, trace.TraceID(), span1.Context().TraceID)
+	assert.Equal(t, trace.TraceID(), span2.Context().TraceID)
+	assert.Equal(t, trace.TraceID(), span1.Context().ParentSpanID)
+
+	assert.Nil(t, TraceFromContext(context.Background()))
+}
+
+// spanIDProcessor records the span IDs the processors see when spans start and end
+type spanIDProcessor struct {
+	started []string
+	ended   []string
+}
+
+func (p *spanIDProcessor) OnStart(span *StandardSpan) {
+	p.started = append(p.started, span.Context().SpanID)
+}
+func (p *spanIDProcessor) OnEnd(span *StandardSpan)           { p.ended = append(p.ended, span.Context().SpanID) }
+func (p *spanIDProcessor) ForceFlush()                        {}
+func (p *spanIDProcessor) Shutdown(ctx context.Context) error { return nil }
+
+func TestStartTraceRootSpanID(t *testing.T) {
+	originalTracer := GetTracer()
+	defer SetTracer(originalTracer)
+	processor := &spanIDProcessor{}
+	SetTracer(NewStandardTracer(processor))
+
+	trace, _ := StartTrace(context.Background(), "my workflow")
+	trace.End()
+
+	// The processors see the root span with the trace's ID from the start
+	assert.Equal(t, []string{trace.TraceID()}, processor.started)
+	assert.Equal(t, processor.started, processor.ended)
+	assert.Equal(t, trace.TraceID(), trace.Span().Context().SpanID)
+}
+
+func TestOpenAIExporterTraceConversion(t *testing.T) {
+	exporter := &OpenAIExporter{}
+	tracer := NewStandardTracer()
+	originalTracer := GetTracer()
+	defer SetTracer(originalTracer)
+	SetTracer(tracer)
+
+	trace, ctx := StartTrace(context.Background(), "my workflow", WithGroupID("thread_1"))
+	span, _ := StartSpan(ctx, "agent_run", map[string]any{"span_type": "agent"})
+	span.End()
+	trace.End()
+
+	items := exporter.convertToOpenAIItems([]*StandardSpan{trace.Span().(*StandardSpan), span.(*StandardSpan)})
+	require.Len(t, items, 2)
+
+	traceData, ok := items[0].(OpenAITraceData)
+	require.True(t, ok)
+	assert.Equal(t, "trace", traceData.Object)
+	assert.Equal(t, "my workflow", traceData.WorkflowName)
+	assert.Equal(t, "thread_1", traceData.GroupID)
+
+	spanData, ok := items[1].(OpenAISpanData)
+	require.True(t, ok)
+	assert.Equal(t, traceData.ID, spanData.TraceID)
+	assert.Empty(t, spanData.ParentID)
+}
@@ -492,3 +492,59 @@ func TraceHandoffEnd(ctx context.Context, success bool, err error) {
 		span.End()
 	}
 }
+
+// recordingProcessor records started and ended spans
+type recordingProcessor struct {
+	mu      sync.Mutex
+	started []string
+	ended   []*StandardSpan
+}
+
+func (p *recordingProcessor) OnStart(span *StandardSpan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started = append(p.started, span.Context().Name)
+}
+
+func (p *recordingProcessor) OnEnd(span *StandardSpan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ended = append(p.ended, span)
+}
+
+func (p *recordingProcessor) ForceFlush() {}
+
+func (p *recordingProcessor) Shutdown(ctx context.Context) error { return nil }
+
+func TestTraceProcessors(t *testing.T) {
+	SetTracer(&NoopTracer{})
+	defer func() {
+		SetTraceProcessors()
+		SetTracer(&NoopTracer{})
+	}()
+
+	first := &recordingProcessor{}
+	AddTraceProcessor(first)
+
+	// Registering a processor enables the standard tracer
+	_, ok := GetTracer().(*StandardTracer)
+	assert.True(t, ok)
+
+	span, _ := CustomSpan(context.Background(), "fetch_user", map[string]any{"user_id": "u1"})
+	span.End()
+
+	assert.Equal(t, []string{"fetch_user"}, first.started)
+	require.Len(t, first.ended, 1)
+	assert.Equal(t, "custom", first.ended[0].Context().Attributes["span_type"])
+	assert.Equal(t, map[string]any{"user_id": "u1"}, first.ended[0].Context().Attributes["data"])
+
+	// SetTraceProcessors replaces the registered processors
+	second := &recordingProcessor{}
+	SetTraceProcessors(second)
+
+	span, _ = CustomSpan(context.Background(), "another", nil)
+	span.End()
+
+	assert.Len(t, first.ended, 1)
+	assert.Len(t, second.ended, 1)
+}
@@ -44,6 +44,12 @@ const (
 
 	// SpanTypeRunner represents a runner span
 	SpanTypeRunner OpenAISpanType = "runner"
+
+	// SpanTypeTrace represents the root span of a trace
+	SpanTypeTrace OpenAISpanType = "trace"
+
+	// SpanTypeGuardrail represents the check of a single guardrail
+	SpanTypeGuardrail OpenAISpanType = "guardrail"
 )
 
 // SpanContext contains the context of a span
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+const (
+	// SDKName is the name reported to providers and trace backends
+	SDKName = "ai-agents-sdk-go"
+
+	// ModulePath is the Go module path of the SDK
+	ModulePath = "github.com/ryichk/ai-agents-sdk-go"
+
+	// DevelVersion is reported when the SDK version cannot be determined from build info
+	DevelVersion = "devel"
+)
+
+var (
+	sdkVersion     string
+	sdkVersionOnce sync.Once
+)
+
+// SDKVersion returns the version of the SDK module linked into the current binary.
+// The version is read from the build info, so it matches the version in the caller's go.mod.
+func SDKVersion() string {
+	sdkVersionOnce.Do(func() {
+		sdkVersion = DevelVersion
+
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+
+		if info.Main.Path == ModulePath {
+			if info.Main.Version != "" && info.Main.Version != "(devel)" {
+				sdkVersion = info.Main.Version
+			}
+			return
+		}
+
+		for _, dep := range info.Deps {
+			if dep.Path == ModulePath {
+				if dep.Replace != nil && dep.Replace.Version != "" {
+					sdkVersion = dep.Replace.Version
+				} else if dep.Version != "" {
+					sdkVersion = dep.Version
+				}
+				return
+			}
+		}
+	})
+
+	return sdkVersion
+}
+
+// GoVersion returns the Go runtime version (e.g. "go1.24.1")
+func GoVersion() string {
+	return runtime.Version()
+}
+
+// UserAgent returns the User-Agent header value sent with API requests
+func UserAgent() string {
+	return fmt.Sprintf("%s/%s (%s; %s/%s)", SDKName, SDKVersion(), GoVersion(), runtime.GOOS, runtime.GOARCH)
+}
+
+// Attributes returns the SDK metadata as span attributes
+func Attributes() map[string]any {
+	return map[string]any{
+		"sdk_name":    SDKName,
+		"sdk_version": SDKVersion(),
+		"go_version":  GoVersion(),
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package version
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserAgent(t *testing.T) {
+	ua := UserAgent()
+	assert.True(t, strings.HasPrefix(ua, SDKName+"/"), "User-Agent should start with the SDK name")
+	assert.Contains(t, ua, runtime.Version())
+	assert.NotEmpty(t, SDKVersion())
+}
+
+func TestAttributes(t *testing.T) {
+	attrs := Attributes()
+	assert.Equal(t, SDKName, attrs["sdk_name"])
+	assert.Equal(t, SDKVersion(), attrs["sdk_version"])
+	assert.Equal(t, runtime.Version(), attrs["go_version"])
+}
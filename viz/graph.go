@@ -0,0 +1,169 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package viz draws the topology of a multi-agent workflow: the agents reachable through
+// handoffs and the tools they call, as Graphviz DOT or Mermaid diagrams for documentation and
+// debugging. It is the counterpart of the Python SDK's draw_graph extension.
+package viz
+
+import (
+	"fmt"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+)
+
+// NodeKind is the kind of a graph node
+type NodeKind string
+
+const (
+	// NodeStart is the entry point of the workflow
+	NodeStart NodeKind = "start"
+
+	// NodeEnd is reached by the agents that cannot hand off
+	NodeEnd NodeKind = "end"
+
+	// NodeAgent is an agent
+	NodeAgent NodeKind = "agent"
+
+	// NodeTool is a tool
+	NodeTool NodeKind = "tool"
+)
+
+// EdgeKind is the kind of a graph edge
+type EdgeKind string
+
+const (
+	// EdgeFlow links the start and end nodes to the agents
+	EdgeFlow EdgeKind = "flow"
+
+	// EdgeHandoff is a handoff from an agent to another
+	EdgeHandoff EdgeKind = "handoff"
+
+	// EdgeReturn is a return handoff, back to an agent that hands off to the agent
+	EdgeReturn EdgeKind = "return"
+
+	// EdgeToolCall is an agent calling a tool
+	EdgeToolCall EdgeKind = "tool_call"
+
+	// EdgeToolResult is a tool returning its result to an agent
+	EdgeToolResult EdgeKind = "tool_result"
+)
+
+// Node is an agent, a tool, or the start or end of the workflow
+type Node struct {
+	// ID identifies the node in the graph
+	ID string
+
+	// Kind is the kind of node
+	Kind NodeKind
+
+	// Label is the name of the agent or tool
+	Label string
+}
+
+// Edge links two nodes by ID
+type Edge struct {
+	From string
+	To   string
+	Kind EdgeKind
+}
+
+// Graph is the topology of a workflow
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// Build walks the handoffs and tools of the root agent recursively. Every agent appears once, even
+// in cyclic handoff graphs, and tools are shared by name between agents.
+func Build(root *agent.Agent) *Graph {
+	b := &builder{
+		graph:  &Graph{},
+		agents: make(map[*agent.Agent]string),
+		tools:  make(map[string]string),
+	}
+	b.graph.Nodes = append(b.graph.Nodes, Node{ID: "__start__", Kind: NodeStart, Label: "__start__"})
+	if root == nil {
+		return b.graph
+	}
+
+	rootID := b.walk(root)
+	b.graph.Edges = append(b.graph.Edges, Edge{From: "__start__", To: rootID, Kind: EdgeFlow})
+	b.addReturns()
+	if b.ends {
+		b.graph.Nodes = append(b.graph.Nodes, Node{ID: "__end__", Kind: NodeEnd, Label: "__end__"})
+	}
+	return b.graph
+}
+
+// builder accumulates the nodes and edges of a graph
+type builder struct {
+	graph *Graph
+
+	// agents and tools map the visited agents and tool names to their node IDs
+	agents map[*agent.Agent]string
+	tools  map[string]string
+
+	// returners are the agents with a return handoff
+	returners []*agent.Agent
+
+	// ends is set when an agent links to the end node
+	ends bool
+}
+
+// walk adds the agent, its tools and the agents it hands off to, and returns the agent's node ID
+func (b *builder) walk(a *agent.Agent) string {
+	if id, ok := b.agents[a.Origin()]; ok {
+		return id
+	}
+	a = a.Snapshot()
+	id := fmt.Sprintf("agent_%d", len(b.agents))
+	b.agents[a.Origin()] = id
+	b.graph.Nodes = append(b.graph.Nodes, Node{ID: id, Kind: NodeAgent, Label: a.Name})
+
+	for _, t := range a.Tools {
+		toolID, ok := b.tools[t.Name()]
+		if !ok {
+			toolID = fmt.Sprintf("tool_%d", len(b.tools))
+			b.tools[t.Name()] = toolID
+			b.graph.Nodes = append(b.graph.Nodes, Node{ID: toolID, Kind: NodeTool, Label: t.Name()})
+		}
+		b.graph.Edges = append(b.graph.Edges,
+			Edge{From: id, To: toolID, Kind: EdgeToolCall},
+			Edge{From: toolID, To: id, Kind: EdgeToolResult},
+		)
+	}
+
+	handsOff := false
+	for _, h := range a.Handoffs {
+		if handoff.IsReturnHandoff(h) {
+			b.returners = append(b.returners, a.Origin())
+			continue
+		}
+		target, ok := h.TargetAgent().(*agent.Agent)
+		if !ok || target == nil {
+			continue
+		}
+		handsOff = true
+		b.graph.Edges = append(b.graph.Edges, Edge{From: id, To: b.walk(target), Kind: EdgeHandoff})
+	}
+	if !handsOff {
+		b.ends = true
+		b.graph.Edges = append(b.graph.Edges, Edge{From: id, To: "__end__", Kind: EdgeFlow})
+	}
+	return id
+}
+
+// addReturns links the agents with a return handoff back to the agents that hand off to them
+func (b *builder) addReturns() {
+	for _, returner := range b.returners {
+		id := b.agents[returner]
+		for _, edge := range b.graph.Edges {
+			if edge.Kind == EdgeHandoff && edge.To == id {
+				b.graph.Edges = append(b.graph.Edges, Edge{From: id, To: edge.From, Kind: EdgeReturn})
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package viz
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+)
+
+// DOT returns the Graphviz DOT diagram of the workflow starting at the root agent
+func DOT(root *agent.Agent) string {
+	return Build(root).DOT()
+}
+
+// Mermaid returns the Mermaid flowchart of the workflow starting at the root agent
+func Mermaid(root *agent.Agent) string {
+	return Build(root).Mermaid()
+}
+
+// dotNodeStyles are the DOT attributes of each node kind, matching the Python SDK's graphs
+var dotNodeStyles = map[NodeKind]string{
+	NodeStart: "shape=ellipse, style=filled, fillcolor=lightblue, width=0.5, height=0.3",
+	NodeEnd:   "shape=ellipse, style=filled, fillcolor=lightblue, width=0.5, height=0.3",
+	NodeAgent: "shape=box, style=filled, fillcolor=lightyellow, width=1.5, height=0.8",
+	NodeTool:  "shape=ellipse, style=filled, fillcolor=lightgreen, width=0.5, height=0.3",
+}
+
+// dotEdgeStyles are the DOT attributes of each edge kind
+var dotEdgeStyles = map[EdgeKind]string{
+	EdgeFlow:       "",
+	EdgeHandoff:    "",
+	EdgeReturn:     "style=dashed",
+	EdgeToolCall:   "style=dotted, penwidth=1.5",
+	EdgeToolResult: "style=dotted, penwidth=1.5",
+}
+
+// DOT renders the graph in the Graphviz DOT language
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph G {\n")
+	b.WriteString("    graph [splines=true];\n")
+	b.WriteString("    node [fontname=\"Arial\"];\n")
+	b.WriteString("    edge [penwidth=1.5];\n")
+
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "    %s [label=%s, %s];\n", dotQuote(n.ID), dotQuote(n.Label), dotNodeStyles[n.Kind])
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "    %s -> %s", dotQuote(e.From), dotQuote(e.To))
+		if style := dotEdgeStyles[e.Kind]; style != "" {
+			fmt.Fprintf(&b, " [%s]", style)
+		}
+		b.WriteString(";\n")
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote quotes a DOT identifier
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// Mermaid renders the graph as a Mermaid flowchart
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+
+	for _, n := range g.Nodes {
+		label := mermaidQuote(n.Label)
+		switch n.Kind {
+		case NodeAgent:
+			fmt.Fprintf(&b, "    %s[%s]:::agent\n", n.ID, label)
+		case NodeTool:
+			fmt.Fprintf(&b, "    %s([%s]):::tool\n", n.ID, label)
+		default:
+			fmt.Fprintf(&b, "    %s([%s]):::terminal\n", n.ID, label)
+		}
+	}
+	for _, e := range g.Edges {
+		switch e.Kind {
+		case EdgeToolCall, EdgeToolResult:
+			fmt.Fprintf(&b, "    %s -.-> %s\n", e.From, e.To)
+		case EdgeReturn:
+			fmt.Fprintf(&b, "    %s -. return .-> %s\n", e.From, e.To)
+		default:
+			fmt.Fprintf(&b, "    %s --> %s\n", e.From, e.To)
+		}
+	}
+
+	b.WriteString("    classDef agent fill:#ffffe0,stroke:#333\n")
+	b.WriteString("    classDef tool fill:#90ee90,stroke:#333\n")
+	b.WriteString("    classDef terminal fill:#add8e6,stroke:#333\n")
+	return b.String()
+}
+
+// mermaidQuote quotes a Mermaid label
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", "<br>").Replace(s) + `"`
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package viz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/handoff"
+	"github.com/ryichk/ai-agents-sdk-go/tool"
+)
+
+func lookupTool(t *testing.T) tool.Tool {
+	t.Helper()
+	lookup, err := tool.NewFunctionToolWithName(func(ctx context.Context) string { return "found" }, "lookup", "Look up an order")
+	require.NoError(t, err)
+	return lookup
+}
+
+func TestBuild(t *testing.T) {
+	lookup := lookupTool(t)
+	triage := agent.New("triage", "Route the request")
+	billing := agent.New("billing", "Handle payments")
+	shipping := agent.New("shipping", "Handle deliveries")
+
+	triage.AddTool(lookup)
+	triage.AddHandoffs(
+		handoff.NewHandoff(billing, "Payments"),
+		handoff.NewHandoffWithOptions(shipping, "Deliveries", handoff.Options{ToolName: "ask_shipping"}),
+	)
+	billing.AddTool(lookup)
+	billing.AddHandoff(handoff.NewReturnHandoff())
+	shipping.AddHandoff(handoff.NewHandoff(triage, "Back to triage"))
+
+	g := Build(triage)
+
+	assert.Equal(t, []Node{
+		{ID: "__start__", Kind: NodeStart, Label: "__start__"},
+		{ID: "agent_0", Kind: NodeAgent, Label: "triage"},
+		{ID: "tool_0", Kind: NodeTool, Label: "lookup"},
+		{ID: "agent_1", Kind: NodeAgent, Label: "billing"},
+		{ID: "agent_2", Kind: NodeAgent, Label: "shipping"},
+		{ID: "__end__", Kind: NodeEnd, Label: "__end__"},
+	}, g.Nodes)
+	assert.Equal(t, []Edge{
+		{From: "agent_0", To: "tool_0", Kind: EdgeToolCall},
+		{From: "tool_0", To: "agent_0", Kind: EdgeToolResult},
+		{From: "agent_1", To: "tool_0", Kind: EdgeToolCall},
+		{From: "tool_0", To: "agent_1", Kind: EdgeToolResult},
+		{From: "agent_1", To: "__end__", Kind: EdgeFlow},
+		{From: "agent_0", To: "agent_1", Kind: EdgeHandoff},
+		{From: "agent_2", To: "agent_0", Kind: EdgeHandoff},
+		{From: "agent_0", To: "agent_2", Kind: EdgeHandoff},
+		{From: "__start__", To: "agent_0", Kind: EdgeFlow},
+		{From: "agent_1", To: "agent_0", Kind: EdgeReturn},
+	}, g.Edges)
+}
+
+func TestDOT(t *testing.T) {
+	researcher := agent.New(`say "hi"`, "Research")
+	researcher.AddTool(lookupTool(t))
+
+	assert.Equal(t, `digraph G {
+    graph [splines=true];
+    node [fontname="Arial"];
+    edge [penwidth=1.5];
+    "__start__" [label="__start__", shape=ellipse, style=filled, fillcolor=lightblue, width=0.5, height=0.3];
+    "agent_0" [label="say \"hi\"", shape=box, style=filled, fillcolor=lightyellow, width=1.5, height=0.8];
+    "tool_0" [label="lookup", shape=ellipse, style=filled, fillcolor=lightgreen, width=0.5, height=0.3];
+    "__end__" [label="__end__", shape=ellipse, style=filled, fillcolor=lightblue, width=0.5, height=0.3];
+    "agent_0" -> "tool_0" [style=dotted, penwidth=1.5];
+    "tool_0" -> "agent_0" [style=dotted, penwidth=1.5];
+    "agent_0" -> "__end__";
+    "__start__" -> "agent_0";
+}
+`, DOT(researcher))
+}
+
+func TestMermaid(t *testing.T) {
+	specialist := agent.New("specialist", "Answer")
+	specialist.AddHandoff(handoff.NewReturnHandoff())
+	triage := agent.New("triage", "Route the request")
+	triage.AddHandoff(handoff.NewHandoffWithOptions(specialist, "", handoff.Options{ToolName: "ask_specialist"}))
+
+	assert.Equal(t, `flowchart TD
+    __start__(["__start__"]):::terminal
+    agent_0["triage"]:::agent
+    agent_1["specialist"]:::agent
+    __end__(["__end__"]):::terminal
+    agent_1 --> __end__
+    agent_0 --> agent_1
+    __start__ --> agent_0
+    agent_1 -. return .-> agent_0
+    classDef agent fill:#ffffe0,stroke:#333
+    classDef tool fill:#90ee90,stroke:#333
+    classDef terminal fill:#add8e6,stroke:#333
+`, Mermaid(triage))
+}
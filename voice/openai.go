@@ -0,0 +1,144 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package voice
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/ryichk/ai-agents-sdk-go/model"
+)
+
+const (
+	// DefaultOpenAISTTModel is the default OpenAI transcription model
+	DefaultOpenAISTTModel = "gpt-4o-transcribe"
+
+	// DefaultOpenAITTSModel is the default OpenAI speech model
+	DefaultOpenAITTSModel = "gpt-4o-mini-tts"
+
+	// DefaultOpenAIVoice is the default OpenAI voice
+	DefaultOpenAIVoice = "alloy"
+)
+
+// OpenAISTTConfig configures the OpenAI speech-to-text model
+type OpenAISTTConfig struct {
+	// Model is the transcription model (optional, defaults to DefaultOpenAISTTModel)
+	Model string
+
+	// Language is the ISO-639-1 language of the audio (optional)
+	Language string
+
+	// Prompt guides the transcription style or vocabulary (optional)
+	Prompt string
+}
+
+// OpenAISTTModel transcribes audio with the OpenAI transcription API
+type OpenAISTTModel struct {
+	client *openai.Client
+	config OpenAISTTConfig
+}
+
+// NewOpenAISTTModel creates an OpenAI speech-to-text model
+func NewOpenAISTTModel(clientConfig model.OpenAIConfig, config OpenAISTTConfig) (*OpenAISTTModel, error) {
+	client, err := model.NewOpenAIClient(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Model == "" {
+		config.Model = DefaultOpenAISTTModel
+	}
+
+	return &OpenAISTTModel{
+		client: client,
+		config: config,
+	}, nil
+}
+
+// Transcribe returns the transcription of the audio input
+func (m *OpenAISTTModel) Transcribe(ctx context.Context, input AudioInput) (string, error) {
+	fileName := input.FileName
+	if fileName == "" {
+		fileName = "input.wav"
+	}
+
+	response, err := m.client.CreateTranscription(ctx, openai.AudioRequest{
+		Model:    m.config.Model,
+		FilePath: fileName,
+		Reader:   bytes.NewReader(input.Data),
+		Language: m.config.Language,
+		Prompt:   m.config.Prompt,
+		Format:   openai.AudioResponseFormatJSON,
+	})
+	if err != nil {
+		return "", fmt.Errorf("OpenAI transcription error: %w", err)
+	}
+
+	return response.Text, nil
+}
+
+// OpenAITTSConfig configures the OpenAI text-to-speech model
+type OpenAITTSConfig struct {
+	// Model is the speech model (optional, defaults to DefaultOpenAITTSModel)
+	Model string
+
+	// Voice is the voice to use (optional, defaults to DefaultOpenAIVoice)
+	Voice string
+
+	// Format is the audio format (optional, defaults to 24kHz 16-bit mono "pcm")
+	Format string
+
+	// Speed is the speed of the generated audio from 0.25 to 4.0 (optional, defaults to 1.0)
+	Speed float64
+}
+
+// OpenAITTSModel synthesizes speech with the OpenAI speech API
+type OpenAITTSModel struct {
+	client *openai.Client
+	config OpenAITTSConfig
+}
+
+// NewOpenAITTSModel creates an OpenAI text-to-speech model
+func NewOpenAITTSModel(clientConfig model.OpenAIConfig, config OpenAITTSConfig) (*OpenAITTSModel, error) {
+	client, err := model.NewOpenAIClient(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Model == "" {
+		config.Model = DefaultOpenAITTSModel
+	}
+	if config.Voice == "" {
+		config.Voice = DefaultOpenAIVoice
+	}
+	if config.Format == "" {
+		config.Format = string(openai.SpeechResponseFormatPcm)
+	}
+
+	return &OpenAITTSModel{
+		client: client,
+		config: config,
+	}, nil
+}
+
+// Synthesize returns a stream of audio for the text
+func (m *OpenAITTSModel) Synthesize(ctx context.Context, text string) (io.ReadCloser, error) {
+	response, err := m.client.CreateSpeech(ctx, openai.CreateSpeechRequest{
+		Model:          openai.SpeechModel(m.config.Model),
+		Input:          text,
+		Voice:          openai.SpeechVoice(m.config.Voice),
+		ResponseFormat: openai.SpeechResponseFormat(m.config.Format),
+		Speed:          m.config.Speed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI speech error: %w", err)
+	}
+
+	return response, nil
+}
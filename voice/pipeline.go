@@ -0,0 +1,252 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ryichk/ai-agents-sdk-go/tracing"
+)
+
+// DefaultWorkflowName is the name of the trace created for a pipeline run
+const DefaultWorkflowName = "Voice Agent"
+
+// PipelineConfig configures a Pipeline
+type PipelineConfig struct {
+	// WorkflowName is the name of the trace created for each run (defaults to DefaultWorkflowName)
+	WorkflowName string
+
+	// SplitText splits buffered workflow output into pieces that are ready to be synthesized
+	// and the remaining text (defaults to SplitSentences)
+	SplitText func(buffer string) (chunks []string, rest string)
+}
+
+// Pipeline transcribes audio, runs a workflow on the transcription and synthesizes the response
+type Pipeline struct {
+	workflow Workflow
+	stt      STTModel
+	tts      TTSModel
+	config   PipelineConfig
+}
+
+// NewPipeline creates a voice pipeline
+func NewPipeline(workflow Workflow, stt STTModel, tts TTSModel, config PipelineConfig) *Pipeline {
+	if config.WorkflowName == "" {
+		config.WorkflowName = DefaultWorkflowName
+	}
+	if config.SplitText == nil {
+		config.SplitText = SplitSentences
+	}
+
+	return &Pipeline{
+		workflow: workflow,
+		stt:      stt,
+		tts:      tts,
+		config:   config,
+	}
+}
+
+// Run processes a single audio clip as one turn
+func (p *Pipeline) Run(ctx context.Context, input AudioInput) *StreamedAudioResult {
+	turns := make(chan AudioInput, 1)
+	turns <- input
+	close(turns)
+
+	return p.run(ctx, turns)
+}
+
+// RunStreamed processes every turn of a streamed input until it is closed
+func (p *Pipeline) RunStreamed(ctx context.Context, input *StreamedAudioInput) *StreamedAudioResult {
+	return p.run(ctx, input.turns)
+}
+
+// run processes turns in the background and returns their events
+func (p *Pipeline) run(ctx context.Context, turns <-chan AudioInput) *StreamedAudioResult {
+	result := &StreamedAudioResult{
+		events: make(chan Event, 16),
+	}
+
+	go func() {
+		defer close(result.events)
+
+		trace, traceCtx := tracing.StartTrace(ctx, p.config.WorkflowName)
+		defer trace.End()
+
+		emit := func(event Event) error {
+			select {
+			case result.events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		for {
+			var input AudioInput
+			var ok bool
+			select {
+			case input, ok = <-turns:
+			case <-ctx.Done():
+				ok = false
+			}
+			if !ok {
+				break
+			}
+
+			if err := p.processTurn(traceCtx, input, emit); err != nil {
+				trace.Span().SetAttribute("error", err.Error())
+				_ = emit(Event{Type: EventError, Err: err})
+				break
+			}
+		}
+
+		// Once ctx is done the consumer may have stopped reading, so only deliver what fits the buffer
+		final := func(event Event) {
+			if ctx.Err() == nil {
+				result.events <- event
+				return
+			}
+			select {
+			case result.events <- event:
+			default:
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			final(Event{Type: EventError, Err: err})
+		}
+		final(Event{Type: EventSessionEnded})
+	}()
+
+	return result
+}
+
+// processTurn transcribes one turn, runs the workflow and streams the synthesized response
+func (p *Pipeline) processTurn(ctx context.Context, input AudioInput, emit func(Event) error) error {
+	transcription, err := p.transcribe(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if err := emit(Event{Type: EventTranscription, Text: transcription}); err != nil {
+		return err
+	}
+	if err := emit(Event{Type: EventTurnStarted}); err != nil {
+		return err
+	}
+
+	var buffer strings.Builder
+	err = p.workflow.Run(ctx, transcription, func(text string) error {
+		buffer.WriteString(text)
+
+		chunks, rest := p.config.SplitText(buffer.String())
+		buffer.Reset()
+		buffer.WriteString(rest)
+
+		for _, chunk := range chunks {
+			if err := p.synthesize(ctx, chunk, emit); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("workflow failed: %w", err)
+	}
+
+	// Speak whatever text is left
+	if rest := strings.TrimSpace(buffer.String()); rest != "" {
+		if err := p.synthesize(ctx, rest, emit); err != nil {
+			return err
+		}
+	}
+
+	return emit(Event{Type: EventTurnEnded})
+}
+
+// transcribe converts a turn to text
+func (p *Pipeline) transcribe(ctx context.Context, input AudioInput) (string, error) {
+	span, spanCtx := tracing.StartSpan(ctx, "transcription", map[string]any{
+		"span_type": "transcription",
+	})
+	defer span.End()
+
+	transcription, err := p.stt.Transcribe(spanCtx, input)
+	if err != nil {
+		span.SetAttribute("error", err.Error())
+		return "", fmt.Errorf("transcription failed: %w", err)
+	}
+
+	span.SetAttribute("output", transcription)
+	return transcription, nil
+}
+
+// synthesize converts text to speech and emits the audio in chunks
+func (p *Pipeline) synthesize(ctx context.Context, text string, emit func(Event) error) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	span, spanCtx := tracing.StartSpan(ctx, "speech", map[string]any{
+		"span_type": "speech",
+		"input":     text,
+	})
+	defer span.End()
+
+	audio, err := p.tts.Synthesize(spanCtx, text)
+	if err != nil {
+		span.SetAttribute("error", err.Error())
+		return fmt.Errorf("speech synthesis failed: %w", err)
+	}
+	defer audio.Close()
+
+	buf := make([]byte, audioChunkSize)
+	for {
+		n, err := audio.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			if err := emit(Event{Type: EventAudio, Audio: chunk}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			span.SetAttribute("error", err.Error())
+			return fmt.Errorf("failed to read synthesized audio: %w", err)
+		}
+	}
+}
+
+// SplitSentences splits text after sentence-ending punctuation followed by whitespace.
+// The trailing text without a sentence end is returned as rest.
+func SplitSentences(buffer string) ([]string, string) {
+	var chunks []string
+	start := 0
+	runes := []rune(buffer)
+
+	for i, r := range runes {
+		if !strings.ContainsRune(".!?。！？", r) {
+			continue
+		}
+		if i+1 < len(runes) && !strings.ContainsRune(" \n\t", runes[i+1]) && r <= '?' {
+			// Not the end of a sentence (e.g. "3.14" or "e.g.")
+			continue
+		}
+		if sentence := strings.TrimSpace(string(runes[start : i+1])); sentence != "" {
+			chunks = append(chunks, sentence)
+		}
+		start = i + 1
+	}
+
+	return chunks, string(runes[start:])
+}
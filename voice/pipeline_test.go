@@ -0,0 +1,224 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package voice
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/agentstest"
+	"github.com/ryichk/ai-agents-sdk-go/model"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+)
+
+// fakeSTT returns the audio data as the transcription
+type fakeSTT struct{}
+
+func (s *fakeSTT) Transcribe(ctx context.Context, input AudioInput) (string, error) {
+	if strings.HasSuffix(input.FileName, ".wav") && len(input.Data) > 44 {
+		return string(input.Data[44:]), nil
+	}
+	return string(input.Data), nil
+}
+
+// fakeTTS returns the text wrapped in brackets as audio
+type fakeTTS struct {
+	texts []string
+}
+
+func (s *fakeTTS) Synthesize(ctx context.Context, text string) (io.ReadCloser, error) {
+	s.texts = append(s.texts, text)
+	return io.NopCloser(strings.NewReader("[" + text + "]")), nil
+}
+
+func echoWorkflow() Workflow {
+	return WorkflowFunc(func(ctx context.Context, transcription string, output func(text string) error) error {
+		for _, part := range []string{"You said ", transcription, ". Anything ", "else?"} {
+			if err := output(part); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func TestPipelineRun(t *testing.T) {
+	tts := &fakeTTS{}
+	pipeline := NewPipeline(echoWorkflow(), &fakeSTT{}, tts, PipelineConfig{})
+
+	result := pipeline.Run(context.Background(), AudioInput{Data: []byte("hello"), FileName: "input.mp3"})
+
+	var types []EventType
+	var audio strings.Builder
+	for event := range result.Events() {
+		types = append(types, event.Type)
+		if event.Type == EventTranscription {
+			assert.Equal(t, "hello", event.Text)
+		}
+		if event.Type == EventAudio {
+			audio.Write(event.Audio)
+		}
+	}
+
+	assert.Equal(t, []EventType{EventTranscription, EventTurnStarted, EventAudio, EventAudio, EventTurnEnded, EventSessionEnded}, types)
+	assert.Equal(t, "[You said hello.][Anything else?]", audio.String())
+	assert.Equal(t, []string{"You said hello.", "Anything else?"}, tts.texts)
+}
+
+func TestPipelineRunStreamed(t *testing.T) {
+	pipeline := NewPipeline(echoWorkflow(), &fakeSTT{}, &fakeTTS{}, PipelineConfig{})
+
+	input := NewStreamedAudioInput(DefaultSampleRate)
+	result := pipeline.RunStreamed(context.Background(), input)
+
+	input.AddAudio([]byte("first "))
+	input.AddAudio([]byte("turn"))
+	input.EndTurn()
+	input.AddAudio([]byte("second"))
+	input.Close()
+
+	var transcriptions []string
+	turns := 0
+	for event := range result.Events() {
+		switch event.Type {
+		case EventTranscription:
+			transcriptions = append(transcriptions, event.Text)
+		case EventTurnEnded:
+			turns++
+		}
+	}
+
+	assert.Equal(t, []string{"first turn", "second"}, transcriptions)
+	assert.Equal(t, 2, turns)
+}
+
+func TestStreamedAudioInputClose(t *testing.T) {
+	input := NewStreamedAudioInput(DefaultSampleRate)
+	for range cap(input.turns) {
+		input.AddAudio([]byte("turn"))
+		input.EndTurn()
+	}
+
+	// Nothing reads the turns: EndTurn waits without blocking the other methods, and Close releases it
+	ended := make(chan struct{})
+	input.AddAudio([]byte("waiting"))
+	go func() {
+		input.EndTurn()
+		close(ended)
+	}()
+
+	closed := make(chan struct{})
+	go func() {
+		input.AddAudio([]byte("more"))
+		input.Close()
+		close(closed)
+	}()
+
+	for _, done := range []chan struct{}{ended, closed} {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("StreamedAudioInput deadlocked")
+		}
+	}
+
+	turns := 0
+	for range input.turns {
+		turns++
+	}
+	assert.Equal(t, cap(input.turns), turns)
+}
+
+func TestSingleAgentWorkflowHistory(t *testing.T) {
+	fakeModel := agentstest.NewFakeModel()
+	fakeModel.AddMultipleTurnOutputs([][]model.Message{
+		{agentstest.GetTextMessage("Nice to meet you, Alice.")},
+		{agentstest.GetTextMessage("Your name is Alice.")},
+	})
+	workflow := NewSingleAgentWorkflow(agent.New("Assistant", "Be helpful"), runner.RunConfig{ModelProvider: fakeModel})
+
+	var outputs []string
+	output := func(text string) error {
+		outputs = append(outputs, text)
+		return nil
+	}
+	require.NoError(t, workflow.Run(context.Background(), "My name is Alice.", output))
+	require.NoError(t, workflow.Run(context.Background(), "What is my name?", output))
+	assert.Equal(t, []string{"Nice to meet you, Alice.", "Your name is Alice."}, outputs)
+
+	// The second turn continues the conversation of the first
+	var sent []string
+	for _, message := range fakeModel.Calls()[1].Messages {
+		if message.Role != "system" {
+			sent = append(sent, message.Content)
+		}
+	}
+	assert.Equal(t, []string{"My name is Alice.", "Nice to meet you, Alice.", "What is my name?"}, sent)
+	assert.Len(t, workflow.History(), 4)
+}
+
+func TestPipelineWorkflowError(t *testing.T) {
+	workflow := WorkflowFunc(func(ctx context.Context, transcription string, output func(text string) error) error {
+		return errors.New("boom")
+	})
+	pipeline := NewPipeline(workflow, &fakeSTT{}, &fakeTTS{}, PipelineConfig{})
+
+	_, err := pipeline.Run(context.Background(), AudioInput{Data: []byte("hello")}).Audio()
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestSplitSentences(t *testing.T) {
+	chunks, rest := SplitSentences("Pi is 3.14. Is it? Yes! And")
+	assert.Equal(t, []string{"Pi is 3.14.", "Is it?", "Yes!"}, chunks)
+	assert.Equal(t, " And", rest)
+
+	chunks, rest = SplitSentences("こんにちは。元気")
+	assert.Equal(t, []string{"こんにちは。"}, chunks)
+	assert.Equal(t, "元気", rest)
+}
+
+func TestOpenAIModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/audio/transcriptions":
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			assert.Equal(t, DefaultOpenAISTTModel, r.FormValue("model"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"text":"hello"}`))
+		case "/audio/speech":
+			_, _ = w.Write([]byte("audio"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	clientConfig := model.OpenAIConfig{APIKey: "test", BaseURL: server.URL}
+
+	stt, err := NewOpenAISTTModel(clientConfig, OpenAISTTConfig{})
+	require.NoError(t, err)
+	text, err := stt.Transcribe(context.Background(), NewPCMAudioInput([]byte{0, 0}, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", text)
+
+	tts, err := NewOpenAITTSModel(clientConfig, OpenAITTSConfig{})
+	require.NoError(t, err)
+	audio, err := tts.Synthesize(context.Background(), "hello")
+	require.NoError(t, err)
+	defer audio.Close()
+	data, err := io.ReadAll(audio)
+	require.NoError(t, err)
+	assert.Equal(t, "audio", string(data))
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+// Package voice turns agent workflows into voice applications:
+// audio is transcribed (speech-to-text), the text is sent to a workflow such as an agent,
+// and the workflow's output is synthesized (text-to-speech) and streamed back as audio.
+package voice
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"sync"
+)
+
+const (
+	// DefaultSampleRate is the sample rate of PCM audio handled by the pipeline
+	DefaultSampleRate = 24000
+
+	// audioChunkSize is the size of audio chunks emitted by the pipeline
+	audioChunkSize = 4096
+)
+
+// STTModel transcribes speech to text
+type STTModel interface {
+	// Transcribe returns the transcription of the audio input
+	Transcribe(ctx context.Context, input AudioInput) (string, error)
+}
+
+// TTSModel synthesizes speech from text
+type TTSModel interface {
+	// Synthesize returns a stream of audio for the text. The caller closes the stream.
+	Synthesize(ctx context.Context, text string) (io.ReadCloser, error)
+}
+
+// AudioInput is a complete audio clip, such as a recorded voice message
+type AudioInput struct {
+	// Data is the encoded audio
+	Data []byte
+
+	// FileName is the name of the clip; its extension tells the STT model the audio format (e.g. "input.wav")
+	FileName string
+}
+
+// NewPCMAudioInput creates an AudioInput from 16-bit little-endian mono PCM samples by wrapping them in a WAV container
+func NewPCMAudioInput(pcm []byte, sampleRate int) AudioInput {
+	if sampleRate <= 0 {
+		sampleRate = DefaultSampleRate
+	}
+
+	var buf bytes.Buffer
+	const channels, bitsPerSample = 1, 16
+	byteRate := sampleRate * channels * bitsPerSample / 8
+
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(36+len(pcm)))
+	buf.WriteString("WAVEfmt ")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(16))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(1)) // PCM
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(channels))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(byteRate))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(channels*bitsPerSample/8))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(bitsPerSample))
+	buf.WriteString("data")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(pcm)))
+	buf.Write(pcm)
+
+	return AudioInput{
+		Data:     buf.Bytes(),
+		FileName: "input.wav",
+	}
+}
+
+// StreamedAudioInput is audio pushed incrementally, such as from a microphone.
+// Audio is buffered until EndTurn marks the end of what the user said; each turn is
+// transcribed and answered separately. Close ends the session.
+type StreamedAudioInput struct {
+	sampleRate int
+	mu         sync.Mutex
+	buffer     bytes.Buffer
+	turns      chan AudioInput
+	closed     bool
+
+	// done is closed by Close to release EndTurn calls waiting for the pipeline
+	done chan struct{}
+	// sending counts the EndTurn calls sending a turn, so Close closes turns after them
+	sending sync.WaitGroup
+}
+
+// NewStreamedAudioInput creates a streamed input of 16-bit little-endian mono PCM at the given sample rate
+func NewStreamedAudioInput(sampleRate int) *StreamedAudioInput {
+	return &StreamedAudioInput{
+		sampleRate: sampleRate,
+		turns:      make(chan AudioInput, 16),
+		done:       make(chan struct{}),
+	}
+}
+
+// AddAudio appends PCM samples to the current turn
+func (s *StreamedAudioInput) AddAudio(pcm []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.buffer.Write(pcm)
+}
+
+// EndTurn marks the end of the current turn so it is transcribed and answered.
+// When the pipeline has not taken the previous turns yet, it waits for it, or for Close;
+// audio can still be added meanwhile.
+func (s *StreamedAudioInput) EndTurn() {
+	s.mu.Lock()
+	if s.closed || s.buffer.Len() == 0 {
+		s.mu.Unlock()
+		return
+	}
+
+	pcm := bytes.Clone(s.buffer.Bytes())
+	s.buffer.Reset()
+	s.sending.Add(1)
+	defer s.sending.Done()
+	s.mu.Unlock()
+
+	select {
+	case s.turns <- NewPCMAudioInput(pcm, s.sampleRate):
+	case <-s.done:
+	}
+}
+
+// Close ends the session without waiting for the pipeline: EndTurn calls waiting for it return,
+// and the current turn, if any, is queued only when there is room. Call EndTurn before Close to
+// wait for the last turn to be queued.
+func (s *StreamedAudioInput) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.done)
+	pcm := bytes.Clone(s.buffer.Bytes())
+	s.buffer.Reset()
+	s.mu.Unlock()
+
+	s.sending.Wait()
+	if len(pcm) > 0 {
+		select {
+		case s.turns <- NewPCMAudioInput(pcm, s.sampleRate):
+		default:
+		}
+	}
+	close(s.turns)
+}
+
+// EventType is the type of a pipeline event
+type EventType string
+
+const (
+	// EventTranscription is emitted with the transcription of a user turn
+	EventTranscription EventType = "transcription"
+
+	// EventTurnStarted is emitted before the audio of a turn
+	EventTurnStarted EventType = "turn_started"
+
+	// EventAudio carries a chunk of synthesized audio
+	EventAudio EventType = "audio"
+
+	// EventTurnEnded is emitted after the audio of a turn
+	EventTurnEnded EventType = "turn_ended"
+
+	// EventError is emitted when the pipeline fails; it is followed by EventSessionEnded
+	EventError EventType = "error"
+
+	// EventSessionEnded is the last event of a pipeline run
+	EventSessionEnded EventType = "session_ended"
+)
+
+// Event is emitted by a pipeline run
+type Event struct {
+	// Type is the type of the event
+	Type EventType
+
+	// Text is the transcription for EventTranscription
+	Text string
+
+	// Audio is the audio chunk for EventAudio
+	Audio []byte
+
+	// Err is the error for EventError
+	Err error
+}
+
+// StreamedAudioResult is the output of a pipeline run
+type StreamedAudioResult struct {
+	events chan Event
+}
+
+// Events returns the channel of pipeline events. It is closed after EventSessionEnded.
+func (r *StreamedAudioResult) Events() <-chan Event {
+	return r.events
+}
+
+// Audio reads all events and returns the concatenated audio, or the first error
+func (r *StreamedAudioResult) Audio() ([]byte, error) {
+	var audio bytes.Buffer
+	var firstErr error
+
+	for event := range r.events {
+		switch event.Type {
+		case EventAudio:
+			audio.Write(event.Audio)
+		case EventError:
+			if firstErr == nil {
+				firstErr = event.Err
+			}
+		}
+	}
+
+	return audio.Bytes(), firstErr
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 ryichk
+// Licensed under the MIT License.
+// This is a Go implementation inspired by OpenAI's Agents SDK for Python.
+
+package voice
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ryichk/ai-agents-sdk-go/agent"
+	"github.com/ryichk/ai-agents-sdk-go/runner"
+)
+
+// Workflow produces the text response to a transcribed user turn
+type Workflow interface {
+	// Run handles one turn. It calls output with each piece of text to speak, in order;
+	// text can be emitted incrementally so synthesis starts before the whole response is ready.
+	Run(ctx context.Context, transcription string, output func(text string) error) error
+}
+
+// WorkflowFunc adapts a function to the Workflow interface
+type WorkflowFunc func(ctx context.Context, transcription string, output func(text string) error) error
+
+// Run calls f
+func (f WorkflowFunc) Run(ctx context.Context, transcription string, output func(text string) error) error {
+	return f(ctx, transcription, output)
+}
+
+// SingleAgentWorkflow runs an agent for every turn, continuing the conversation of the
+// previous turns. After a handoff, subsequent turns continue with the agent that produced
+// the last response.
+type SingleAgentWorkflow struct {
+	mu           sync.Mutex
+	currentAgent *agent.Agent
+	config       runner.RunConfig
+	history      []runner.Message
+}
+
+// NewSingleAgentWorkflow creates a workflow that answers every turn with the agent.
+// The conversation starts from config.History.
+func NewSingleAgentWorkflow(a *agent.Agent, config runner.RunConfig) *SingleAgentWorkflow {
+	return &SingleAgentWorkflow{
+		currentAgent: a,
+		config:       config,
+		history:      config.History,
+	}
+}
+
+// History returns the conversation so far
+func (w *SingleAgentWorkflow) History() []runner.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]runner.Message(nil), w.history...)
+}
+
+// Run runs the current agent with the transcription and outputs its final output
+func (w *SingleAgentWorkflow) Run(ctx context.Context, transcription string, output func(text string) error) error {
+	w.mu.Lock()
+	a := w.currentAgent
+	config := w.config
+	config.History = w.history
+	w.mu.Unlock()
+
+	result, err := runner.RunWithConfig(ctx, a, transcription, config)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	if result.LastAgent != nil {
+		w.currentAgent = result.LastAgent
+	}
+	w.history = result.History
+	w.mu.Unlock()
+
+	return output(result.FinalOutput)
+}